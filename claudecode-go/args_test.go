@@ -0,0 +1,159 @@
+package claudecode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildArgs_CLIOptions(t *testing.T) {
+	client := &Client{claudePath: "claude"}
+
+	args, err := client.buildArgs(SessionConfig{
+		Query:          "hello",
+		SessionID:      "sess-1",
+		ForkSession:    true,
+		Model:          ModelOpus,
+		FallbackModel:  ModelSonnet,
+		PermissionMode: PermissionModeAcceptEdits,
+		Settings:       "/etc/claude/settings.json",
+		SettingSources: []SettingSource{SettingSourceUser, SettingSourceProject},
+		ExtraArgs:      []string{"--betas", "beta-a", "beta-b"},
+		OutputFormat:   OutputStreamJSON,
+
+		IncludePartialMessages: true,
+	})
+	if err != nil {
+		t.Fatalf("buildArgs failed: %v", err)
+	}
+
+	joined := strings.Join(args, " ")
+	for _, want := range []string{
+		"--resume sess-1 --fork-session",
+		"--model opus --fallback-model sonnet",
+		"--permission-mode acceptEdits",
+		"--settings /etc/claude/settings.json",
+		"--setting-sources user,project",
+		"--include-partial-messages",
+		"hello --betas beta-a beta-b",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("expected %q in args: %s", want, joined)
+		}
+	}
+}
+
+func TestBuildArgs_Continue(t *testing.T) {
+	client := &Client{claudePath: "claude"}
+
+	args, err := client.buildArgs(SessionConfig{Query: "next", Continue: true})
+	if err != nil {
+		t.Fatalf("buildArgs failed: %v", err)
+	}
+	if !strings.Contains(strings.Join(args, " "), "--continue") {
+		t.Errorf("expected --continue in args: %v", args)
+	}
+}
+
+func TestBuildArgs_OutputSchema(t *testing.T) {
+	client := &Client{claudePath: "claude"}
+
+	args, err := client.buildArgs(SessionConfig{
+		Query:              "list files",
+		AppendSystemPrompt: "Be brief.",
+		OutputSchema:       []byte(`{"type":"object","required":["files"]}`),
+	})
+	if err != nil {
+		t.Fatalf("buildArgs failed: %v", err)
+	}
+
+	var prompt string
+	for i, arg := range args {
+		if arg == "--append-system-prompt" && i+1 < len(args) {
+			prompt = args[i+1]
+		}
+	}
+	if !strings.HasPrefix(prompt, "Be brief.\n\n") {
+		t.Errorf("expected the caller's prompt first, got %q", prompt)
+	}
+	if !strings.Contains(prompt, "JSON Schema") || !strings.Contains(prompt, `"required": [`) {
+		t.Errorf("expected schema instructions in prompt, got %q", prompt)
+	}
+}
+
+func TestBuildArgs_InvalidOptions(t *testing.T) {
+	client := &Client{claudePath: "claude"}
+
+	tests := []struct {
+		name   string
+		config SessionConfig
+		errMsg string
+	}{
+		{
+			name:   "continue with session ID",
+			config: SessionConfig{Query: "q", SessionID: "sess-1", Continue: true},
+			errMsg: "continue cannot be combined",
+		},
+		{
+			name:   "fork without resume",
+			config: SessionConfig{Query: "q", ForkSession: true},
+			errMsg: "fork session requires",
+		},
+		{
+			name:   "fallback same as model",
+			config: SessionConfig{Query: "q", Model: ModelSonnet, FallbackModel: ModelSonnet},
+			errMsg: "fallback model must differ",
+		},
+		{
+			name:   "unknown permission mode",
+			config: SessionConfig{Query: "q", PermissionMode: "yolo"},
+			errMsg: "invalid permission mode",
+		},
+		{
+			name:   "unknown setting source",
+			config: SessionConfig{Query: "q", SettingSources: []SettingSource{"global"}},
+			errMsg: "invalid setting source",
+		},
+		{
+			name:   "partial messages without stream-json",
+			config: SessionConfig{Query: "q", OutputFormat: OutputJSON, IncludePartialMessages: true},
+			errMsg: "partial messages require",
+		},
+		{
+			name:   "output schema not an object",
+			config: SessionConfig{Query: "q", OutputSchema: []byte(`"string"`)},
+			errMsg: "invalid output schema",
+		},
+		{
+			name:   "output schema with $ref",
+			config: SessionConfig{Query: "q", OutputSchema: []byte(`{"$ref":"#/$defs/item"}`)},
+			errMsg: "$ref is not supported",
+		},
+		{
+			name:   "repair turns without output schema",
+			config: SessionConfig{Query: "q", OutputSchemaRepairTurns: 1},
+			errMsg: "require an output schema",
+		},
+		{
+			name:   "managed extra flag",
+			config: SessionConfig{Query: "q", ExtraArgs: []string{"--output-format=json"}},
+			errMsg: "managed by SessionConfig",
+		},
+		{
+			name:   "permission bypass extra flag",
+			config: SessionConfig{Query: "q", ExtraArgs: []string{"--dangerously-skip-permissions"}},
+			errMsg: "is not allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.buildArgs(tt.config)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
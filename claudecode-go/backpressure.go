@@ -0,0 +1,241 @@
+package claudecode
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultEventBufferSize is the capacity of Session.Events unless set with WithEventBuffer
+const DefaultEventBufferSize = 100
+
+// OverflowPolicy decides what happens to stream events when Session.Events is full
+type OverflowPolicy string
+
+const (
+	// OverflowBlock waits for the consumer, which stops reading claude's output
+	// and eventually stalls the claude process itself
+	OverflowBlock OverflowPolicy = "block"
+
+	// OverflowSpill writes events to a temporary file and delivers them, in
+	// order, as the consumer catches up
+	OverflowSpill OverflowPolicy = "spill"
+
+	// OverflowDrop discards events, counting them in Session.DroppedEvents.
+	// The result is still returned by Wait.
+	OverflowDrop OverflowPolicy = "drop"
+)
+
+// WithEventBuffer sets the capacity of each session's Events channel
+func WithEventBuffer(size int) ClientOption {
+	return func(c *Client) {
+		c.eventBuffer = size
+	}
+}
+
+// WithOverflowPolicy sets what happens when a session's Events channel is full.
+// The default is OverflowBlock.
+func WithOverflowPolicy(policy OverflowPolicy) ClientOption {
+	return func(c *Client) {
+		c.overflow = policy
+	}
+}
+
+// WithSpillDir sets the directory for OverflowSpill files. The default is os.TempDir().
+func WithSpillDir(dir string) ClientOption {
+	return func(c *Client) {
+		c.spillDir = dir
+	}
+}
+
+// validateEventOptions checks the client's event delivery options
+func (c *Client) validateEventOptions() error {
+	if c.eventBuffer < 0 {
+		return fmt.Errorf("event buffer size must not be negative")
+	}
+	switch c.overflow {
+	case "", OverflowBlock, OverflowSpill, OverflowDrop:
+		return nil
+	default:
+		return fmt.Errorf("invalid overflow policy %q", c.overflow)
+	}
+}
+
+// DroppedEvents returns how many events were discarded under OverflowDrop
+func (s *Session) DroppedEvents() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// deliver hands a parsed event line to the consumer according to the session's
+// overflow policy
+func (s *Session) deliver(event StreamEvent, line []byte) {
+	switch s.overflow {
+	case OverflowDrop:
+		select {
+		case s.Events <- event:
+		default:
+			if atomic.AddUint64(&s.dropped, 1) == 1 {
+				s.log().Warn("event consumer is falling behind, dropping events")
+			}
+		}
+	case OverflowSpill:
+		if err := s.spill.push(event, line); err != nil {
+			// Fall back to blocking rather than lose the event
+			s.log().Warn("failed to spill event, blocking instead", "error", err)
+			s.spill.waitDrained()
+			s.Events <- event
+		}
+	default:
+		s.Events <- event
+	}
+}
+
+// closeEvents delivers any spilled events and then closes Events
+func (s *Session) closeEvents() {
+	if s.spill != nil {
+		s.spill.close()
+	}
+	close(s.Events)
+}
+
+// spillQueue buffers events in a file while the consumer is behind. Events
+// bypass the file only when it holds nothing, so delivery stays in order.
+type spillQueue struct {
+	session *Session
+	dir     string
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	writer  *os.File
+	reader  *bufio.Reader
+	readFD  *os.File
+	pending int // Events in the file not yet delivered
+	closed  bool
+	done    chan struct{}
+}
+
+func newSpillQueue(s *Session, dir string) *spillQueue {
+	q := &spillQueue{session: s, dir: dir, done: make(chan struct{})}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// push delivers event directly when possible and appends it to the file otherwise
+func (q *spillQueue) push(event StreamEvent, line []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.pending == 0 {
+		select {
+		case q.session.Events <- event:
+			return nil
+		default:
+		}
+	}
+
+	if q.writer == nil {
+		if err := q.open(); err != nil {
+			return err
+		}
+	}
+	if _, err := q.writer.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write spill file: %w", err)
+	}
+	q.pending++
+	q.cond.Broadcast()
+	return nil
+}
+
+// open creates the spill file and starts delivering from it
+func (q *spillQueue) open() error {
+	writer, err := os.CreateTemp(q.dir, "claude-events-*.jsonl")
+	if err != nil {
+		return fmt.Errorf("failed to create spill file: %w", err)
+	}
+	readFD, err := os.Open(writer.Name())
+	if err != nil {
+		_ = writer.Close()
+		_ = os.Remove(writer.Name())
+		return fmt.Errorf("failed to open spill file: %w", err)
+	}
+	q.writer = writer
+	q.readFD = readFD
+	q.reader = bufio.NewReader(readFD)
+	q.session.log().Warn("event consumer is falling behind, spilling events to disk", "path", writer.Name())
+
+	go q.forward()
+	return nil
+}
+
+// forward delivers spilled events in order until the queue is closed and empty
+func (q *spillQueue) forward() {
+	defer close(q.done)
+	defer q.remove()
+
+	for {
+		q.mu.Lock()
+		for q.pending == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if q.pending == 0 {
+			q.mu.Unlock()
+			return
+		}
+		q.mu.Unlock()
+
+		line, err := q.reader.ReadBytes('\n')
+		if err != nil && !(errors.Is(err, io.EOF) && len(line) > 0) {
+			q.session.log().Error("failed to read spill file, dropping spilled events", "error", err)
+			q.mu.Lock()
+			atomic.AddUint64(&q.session.dropped, uint64(q.pending))
+			q.pending = 0
+			q.cond.Broadcast()
+			q.mu.Unlock()
+			continue
+		}
+
+		// Lines were parsed once already, so this only fails on corruption
+		if event, err := parseEventLine(line); err == nil {
+			q.session.Events <- event
+		}
+
+		q.mu.Lock()
+		q.pending--
+		q.cond.Broadcast()
+		q.mu.Unlock()
+	}
+}
+
+// waitDrained blocks until every spilled event has been delivered
+func (q *spillQueue) waitDrained() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.pending > 0 {
+		q.cond.Wait()
+	}
+}
+
+// close waits for spilled events to be delivered and stops the forwarder
+func (q *spillQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	started := q.writer != nil
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	if started {
+		<-q.done
+	}
+}
+
+func (q *spillQueue) remove() {
+	_ = q.readFD.Close()
+	_ = q.writer.Close()
+	if err := os.Remove(q.writer.Name()); err != nil && !os.IsNotExist(err) {
+		q.session.log().Warn("failed to remove spill file", "path", q.writer.Name(), "error", err)
+	}
+}
@@ -0,0 +1,129 @@
+package claudecode_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/humanlayer/humanlayer/claudecode-go"
+)
+
+// floodScript writes more events than fit in a pipe buffer, then touches marker
+func floodScript(count int, marker string) string {
+	return fmt.Sprintf(`i=0
+while [ $i -lt %d ]; do
+  i=$((i+1))
+  echo '{"type":"assistant","session_id":"fake","message":{"id":"m'$i'","role":"assistant","content":[{"type":"text","text":"padding padding padding padding padding padding padding padding"}]}}'
+done
+echo '{"type":"result","subtype":"success","session_id":"fake","num_turns":'$i'}'
+touch %s`, count, marker)
+}
+
+func waitForFile(t *testing.T, path string) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(path); err == nil {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", path)
+}
+
+func TestOverflowSpill_DeliversInOrder(t *testing.T) {
+	const count = 2000
+	marker := filepath.Join(t.TempDir(), "done")
+	spillDir := t.TempDir()
+
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, floodScript(count, marker)),
+		claudecode.WithEventBuffer(4),
+		claudecode.WithOverflowPolicy(claudecode.OverflowSpill),
+		claudecode.WithSpillDir(spillDir),
+	)
+	session, err := client.Launch(claudecode.SessionConfig{Query: "flood", OutputFormat: claudecode.OutputStreamJSON})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	// The process finishes writing even though nothing is consuming events
+	waitForFile(t, marker)
+
+	var received int
+	for event := range session.Events {
+		received++
+		if received <= count {
+			msg, ok := event.Typed.(*claudecode.AssistantMessage)
+			if !ok || event.Message == nil || event.Message.ID != fmt.Sprintf("m%d", received) {
+				t.Fatalf("event %d out of order: %+v", received, msg)
+			}
+		} else if event.Type != "result" {
+			t.Fatalf("expected result event last, got %q", event.Type)
+		}
+	}
+	if received != count+1 {
+		t.Errorf("expected %d events, got %d", count+1, received)
+	}
+
+	result, err := session.Wait()
+	if err != nil {
+		t.Fatalf("session failed: %v", err)
+	}
+	if result.NumTurns != count {
+		t.Errorf("expected %d turns, got %d", count, result.NumTurns)
+	}
+	if session.DroppedEvents() != 0 {
+		t.Errorf("expected no dropped events, got %d", session.DroppedEvents())
+	}
+
+	entries, err := os.ReadDir(spillDir)
+	if err != nil {
+		t.Fatalf("failed to read spill dir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected spill file to be removed, found %d entries", len(entries))
+	}
+}
+
+func TestOverflowDrop_CountsDroppedEvents(t *testing.T) {
+	const count = 2000
+	marker := filepath.Join(t.TempDir(), "done")
+
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, floodScript(count, marker)),
+		claudecode.WithEventBuffer(4),
+		claudecode.WithOverflowPolicy(claudecode.OverflowDrop),
+	)
+	session, err := client.Launch(claudecode.SessionConfig{Query: "flood", OutputFormat: claudecode.OutputStreamJSON})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	// Nothing consumes events until the session is over
+	result, err := session.Wait()
+	if err != nil {
+		t.Fatalf("session failed: %v", err)
+	}
+	if result.NumTurns != count {
+		t.Errorf("expected result to survive dropped events, got %d turns", result.NumTurns)
+	}
+
+	var received uint64
+	for range session.Events {
+		received++
+	}
+	if received != 4 {
+		t.Errorf("expected a full buffer of 4 events, got %d", received)
+	}
+	if dropped := session.DroppedEvents(); received+dropped != count+1 {
+		t.Errorf("expected %d delivered and dropped events, got %d + %d", count+1, received, dropped)
+	}
+}
+
+func TestOverflowPolicy_Invalid(t *testing.T) {
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, "exit 0"), claudecode.WithOverflowPolicy("discard"))
+	if _, err := client.Launch(claudecode.SessionConfig{Query: "hello"}); err == nil {
+		t.Error("expected error for unknown overflow policy")
+	}
+}
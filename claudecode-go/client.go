@@ -2,19 +2,29 @@ package claudecode
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 )
 
+const (
+	// outputDrainTimeout bounds how long output is read after claude exits
+	outputDrainTimeout = 5 * time.Second
+
+	// groupPollInterval is how often leftover processes are checked after claude exits
+	groupPollInterval = 50 * time.Millisecond
+)
+
 // isClosedPipeError checks if an error is due to a closed pipe (expected when process exits)
 func isClosedPipeError(err error) bool {
 	if err == nil {
@@ -41,45 +51,106 @@ func isClosedPipeError(err error) bool {
 
 // Client provides methods to interact with the Claude Code SDK
 type Client struct {
-	claudePath string
+	claudePath  string
+	logger      *slog.Logger
+	redact      bool
+	eventBuffer int
+	overflow    OverflowPolicy
+	spillDir    string
+
+	probeOnce    sync.Once
+	capabilities Capabilities
 }
 
 // NewClient creates a new Claude Code client
-func NewClient() (*Client, error) {
+func NewClient(opts ...ClientOption) (*Client, error) {
 	// Find claude binary in PATH
 	path, err := exec.LookPath("claude")
 	if err != nil {
 		return nil, fmt.Errorf("claude binary not found in PATH: %w", err)
 	}
 
-	return &Client{
-		claudePath: path,
-	}, nil
+	c := NewClientWithPath(path, opts...)
+	// Probe the version up front so a stale binary is reported at startup
+	c.Capabilities()
+	return c, nil
 }
 
-// NewClientWithPath creates a new client with a specific claude binary path
-func NewClientWithPath(claudePath string) *Client {
-	return &Client{
-		claudePath: claudePath,
+// NewClientWithPath creates a new client with a specific claude binary path.
+// The binary's version is probed the first time an option that depends on it
+// is used, or when Capabilities is called.
+func NewClientWithPath(claudePath string, opts ...ClientOption) *Client {
+	c := &Client{
+		claudePath:  claudePath,
+		redact:      true,
+		eventBuffer: DefaultEventBufferSize,
+		overflow:    OverflowBlock,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // buildArgs converts SessionConfig into command line arguments
 func (c *Client) buildArgs(config SessionConfig) ([]string, error) {
+	if err := validateOptions(config); err != nil {
+		return nil, err
+	}
+	if flags := gatedFlags(config); len(flags) > 0 {
+		if err := c.Capabilities().require(flags); err != nil {
+			return nil, err
+		}
+	}
+
 	args := []string{}
 
 	// Always use print mode for SDK
-	args = append(args, "--print", config.Query)
+	if config.InputFormat == InputStreamJSON {
+		// Stream-json input requires stream-json output; the query is sent over stdin
+		if config.OutputFormat != OutputStreamJSON {
+			return nil, fmt.Errorf("input format %q requires output format %q", InputStreamJSON, OutputStreamJSON)
+		}
+		args = append(args, "--print", "--input-format", string(InputStreamJSON))
+	} else {
+		args = append(args, "--print", config.Query)
+	}
 
 	// Session management
 	if config.SessionID != "" {
 		args = append(args, "--resume", config.SessionID)
 	}
+	if config.Continue {
+		args = append(args, "--continue")
+	}
+	if config.ForkSession {
+		args = append(args, "--fork-session")
+	}
 
 	// Model
 	if config.Model != "" {
 		args = append(args, "--model", string(config.Model))
 	}
+	if config.FallbackModel != "" {
+		args = append(args, "--fallback-model", string(config.FallbackModel))
+	}
+
+	// Permission mode
+	if config.PermissionMode != "" {
+		args = append(args, "--permission-mode", string(config.PermissionMode))
+	}
+
+	// Settings
+	if config.Settings != "" {
+		args = append(args, "--settings", config.Settings)
+	}
+	if len(config.SettingSources) > 0 {
+		sources := make([]string, len(config.SettingSources))
+		for i, source := range config.SettingSources {
+			sources[i] = string(source)
+		}
+		args = append(args, "--setting-sources", strings.Join(sources, ","))
+	}
 
 	// Output format
 	if config.OutputFormat != "" {
@@ -98,8 +169,12 @@ func (c *Client) buildArgs(config SessionConfig) ([]string, error) {
 			return nil, fmt.Errorf("failed to marshal MCP config: %w", err)
 		}
 
-		// Log MCP config for debugging
-		log.Printf("MCP config JSON: %s", string(mcpJSON))
+		// Log MCP config for debugging, without the secrets env and headers tend to hold
+		logged := config.MCPConfig
+		if c.redact {
+			logged = redactMCPConfig(logged)
+		}
+		c.log().Debug("MCP config", "mcp_config", logged)
 
 		// Create a temp file for MCP config
 		tmpFile, err := os.CreateTemp("", "mcp-config-*.json")
@@ -113,7 +188,7 @@ func (c *Client) buildArgs(config SessionConfig) ([]string, error) {
 		}
 		_ = tmpFile.Close()
 
-		log.Printf("MCP config written to: %s", tmpFile.Name())
+		c.log().Debug("MCP config written", "path", tmpFile.Name())
 
 		args = append(args, "--mcp-config", tmpFile.Name())
 		// Note: temp file will be cleaned up when process exits
@@ -133,8 +208,15 @@ func (c *Client) buildArgs(config SessionConfig) ([]string, error) {
 	if config.SystemPrompt != "" {
 		args = append(args, "--system-prompt", config.SystemPrompt)
 	}
-	if config.AppendSystemPrompt != "" {
-		args = append(args, "--append-system-prompt", config.AppendSystemPrompt)
+	appendSystemPrompt := config.AppendSystemPrompt
+	if len(config.OutputSchema) > 0 {
+		if appendSystemPrompt != "" {
+			appendSystemPrompt += "\n\n"
+		}
+		appendSystemPrompt += outputSchemaPrompt(config.OutputSchema)
+	}
+	if appendSystemPrompt != "" {
+		args = append(args, "--append-system-prompt", appendSystemPrompt)
 	}
 
 	// Tools
@@ -147,7 +229,7 @@ func (c *Client) buildArgs(config SessionConfig) ([]string, error) {
 
 	// Additional directories
 	if len(config.AdditionalDirectories) > 0 {
-		log.Printf("Processing %d additional directories", len(config.AdditionalDirectories))
+		c.log().Debug("processing additional directories", "count", len(config.AdditionalDirectories))
 		for _, dir := range config.AdditionalDirectories {
 			// Expand tilde if present
 			expandedDir := dir
@@ -164,16 +246,19 @@ func (c *Client) buildArgs(config SessionConfig) ([]string, error) {
 			// Convert to absolute path
 			absPath, err := filepath.Abs(expandedDir)
 			if err == nil {
-				log.Printf("Adding directory (expanded): %s -> %s", dir, absPath)
+				c.log().Debug("adding directory", "dir", dir, "path", absPath)
 				args = append(args, "--add-dir", absPath)
 			} else {
 				// Fallback to original if absolute path conversion fails
-				log.Printf("Adding directory (original, expansion failed): %s", dir)
+				c.log().Debug("adding directory without expansion", "dir", dir, "error", err)
 				args = append(args, "--add-dir", dir)
 			}
 		}
-	} else {
-		log.Printf("No additional directories to add")
+	}
+
+	// Partial messages
+	if config.IncludePartialMessages {
+		args = append(args, "--include-partial-messages")
 	}
 
 	// Verbose
@@ -182,25 +267,143 @@ func (c *Client) buildArgs(config SessionConfig) ([]string, error) {
 	}
 
 	// Query handling:
-	// When --add-dir is present, query must be passed via stdin
+	// With stream-json input, or when --add-dir is present, query must be passed via stdin
 	// Otherwise, pass as a positional argument
-	if config.Query != "" && len(config.AdditionalDirectories) == 0 {
+	if config.Query != "" && len(config.AdditionalDirectories) == 0 && config.InputFormat != InputStreamJSON {
 		args = append(args, config.Query)
 	}
 
+	// Extra flags, validated above. They follow the query so that a variadic
+	// flag such as --betas cannot consume it as one of its values
+	args = append(args, config.ExtraArgs...)
+
 	return args, nil
 }
 
+// isManagedFlag reports whether flag is set from a SessionConfig field and so
+// may not appear in ExtraArgs
+func isManagedFlag(flag string) bool {
+	switch flag {
+	case "-p", "--print", "--output-format", "--input-format",
+		"-r", "--resume", "-c", "--continue", "--fork-session",
+		"--model", "--fallback-model", "--permission-mode", "--permission-prompt-tool",
+		"--settings", "--setting-sources", "--mcp-config", "--max-turns",
+		"--system-prompt", "--append-system-prompt",
+		"--allowedTools", "--allowed-tools", "--disallowedTools", "--disallowed-tools",
+		"--add-dir", "--include-partial-messages":
+		return true
+	}
+	return false
+}
+
+// isBypassFlag reports whether flag skips claude's permission checks, which
+// must be requested through PermissionMode so that callers can see it
+func isBypassFlag(flag string) bool {
+	switch flag {
+	case "--dangerously-skip-permissions", "--allow-dangerously-skip-permissions":
+		return true
+	}
+	return false
+}
+
+// validateOptions checks CLI options that claude would otherwise reject at startup
+func validateOptions(config SessionConfig) error {
+	if config.Continue && config.SessionID != "" {
+		return fmt.Errorf("continue cannot be combined with a session ID to resume")
+	}
+	if config.ForkSession && config.SessionID == "" && !config.Continue {
+		return fmt.Errorf("fork session requires a session ID to resume or continue")
+	}
+	if config.IncludePartialMessages && config.OutputFormat != OutputStreamJSON {
+		return fmt.Errorf("partial messages require output format %q", OutputStreamJSON)
+	}
+	if len(config.OutputSchema) > 0 {
+		if _, err := parseSchema(config.OutputSchema); err != nil {
+			return err
+		}
+	}
+	if config.OutputSchemaRepairTurns < 0 {
+		return fmt.Errorf("output schema repair turns must not be negative")
+	}
+	if config.OutputSchemaRepairTurns > 0 && len(config.OutputSchema) == 0 {
+		return fmt.Errorf("output schema repair turns require an output schema")
+	}
+	if config.FallbackModel != "" && config.FallbackModel == config.Model {
+		return fmt.Errorf("fallback model must differ from the main model %q", config.Model)
+	}
+
+	switch config.PermissionMode {
+	case "", PermissionModeDefault, PermissionModeAcceptEdits, PermissionModePlan, PermissionModeBypassPermissions:
+	default:
+		return fmt.Errorf("invalid permission mode %q", config.PermissionMode)
+	}
+
+	for _, source := range config.SettingSources {
+		switch source {
+		case SettingSourceUser, SettingSourceProject, SettingSourceLocal:
+		default:
+			return fmt.Errorf("invalid setting source %q (must be %q, %q or %q)",
+				source, SettingSourceUser, SettingSourceProject, SettingSourceLocal)
+		}
+	}
+
+	for _, arg := range config.ExtraArgs {
+		flag, _, _ := strings.Cut(arg, "=")
+		if isManagedFlag(flag) {
+			return fmt.Errorf("extra argument %q is managed by SessionConfig", flag)
+		}
+		if isBypassFlag(flag) {
+			return fmt.Errorf("extra argument %q is not allowed; use PermissionMode %q", flag, PermissionModeBypassPermissions)
+		}
+	}
+	return nil
+}
+
 // Launch starts a new Claude session and returns immediately
 func (c *Client) Launch(config SessionConfig) (*Session, error) {
+	return c.LaunchContext(context.Background(), config)
+}
+
+// LaunchContext starts a new Claude session bound to ctx and returns immediately.
+// When ctx is cancelled or its deadline passes before the process exits, the
+// process is sent SIGINT and killed if it is still running after the
+// session's interrupt grace period. The session then reports a *ContextError.
+func (c *Client) LaunchContext(ctx context.Context, config SessionConfig) (*Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, &ContextError{Err: err}
+	}
+
 	args, err := c.buildArgs(config)
 	if err != nil {
 		return nil, err
 	}
+	if err := config.Limits.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid resource limits: %w", err)
+	}
+	if err := c.validateEventOptions(); err != nil {
+		return nil, err
+	}
 
-	log.Printf("Executing Claude command: %s %v", c.claudePath, args)
+	loggedArgs := args
+	if c.redact {
+		loggedArgs = redactArgs(args)
+	}
+	c.log().Info("executing claude command", "path", c.claudePath, "args", loggedArgs, "env", envKeys(config.Env))
 	cmd := exec.Command(c.claudePath, args...)
 
+	// Run claude in its own process group so that interrupting or killing the
+	// session also reaches the MCP servers and tool processes it spawns
+	setProcessGroup(cmd)
+
+	var limits *limiter
+	if !config.Limits.IsZero() {
+		limits, err = newLimiter(*config.Limits, c.log())
+		if err != nil {
+			return nil, err
+		}
+		limits.prepare(cmd)
+	}
+
 	// Set environment variables if specified
 	if len(config.Env) > 0 {
 		cmd.Env = os.Environ() // Start with current environment
@@ -233,19 +436,47 @@ func (c *Client) Launch(config SessionConfig) (*Session, error) {
 		}
 	}
 
-	// Set up pipes for stdout/stderr
-	stdout, err := cmd.StdoutPipe()
+	// Set up pipes for stdout/stderr. Unlike cmd.StdoutPipe, these are not closed
+	// by cmd.Wait, so output is read until every process holding them has exited.
+	stdout, stdoutWriter, err := os.Pipe()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
 	}
-
-	stderr, err := cmd.StderrPipe()
+	stderr, stderrWriter, err := os.Pipe()
 	if err != nil {
+		_ = stdout.Close()
+		_ = stdoutWriter.Close()
 		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
 	}
+	cmd.Stdout = stdoutWriter
+	cmd.Stderr = stderrWriter
 
-	// If we have additional directories, we need to pass the query via stdin
-	if config.Query != "" && len(config.AdditionalDirectories) > 0 {
+	started := false
+	defer func() {
+		// The child holds its own copies of the write ends once started
+		_ = stdoutWriter.Close()
+		_ = stderrWriter.Close()
+		if !started {
+			_ = stdout.Close()
+			_ = stderr.Close()
+			if limits != nil {
+				limits.close()
+			}
+		}
+	}()
+
+	// Stream-json input keeps stdin open for the lifetime of the session
+	var input io.WriteCloser
+	if config.InputFormat == InputStreamJSON {
+		input, err = cmd.StdinPipe()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdin pipe: %w", err)
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start claude: %w", err)
+		}
+	} else if config.Query != "" && len(config.AdditionalDirectories) > 0 {
+		// If we have additional directories, we need to pass the query via stdin
 		stdin, err := cmd.StdinPipe()
 		if err != nil {
 			return nil, fmt.Errorf("failed to create stdin pipe: %w", err)
@@ -260,11 +491,11 @@ func (c *Client) Launch(config SessionConfig) (*Session, error) {
 		go func() {
 			defer func() {
 				if err := stdin.Close(); err != nil {
-					log.Printf("WARNING: Failed to close stdin: %v", err)
+					c.log().Warn("failed to close stdin", "error", err)
 				}
 			}()
 			if _, err := stdin.Write([]byte(config.Query)); err != nil {
-				log.Printf("WARNING: Failed to write query to stdin: %v", err)
+				c.log().Warn("failed to write query to stdin", "error", err)
 			}
 		}()
 	} else {
@@ -274,12 +505,27 @@ func (c *Client) Launch(config SessionConfig) (*Session, error) {
 		}
 	}
 
+	if limits != nil {
+		if err := limits.start(cmd.Process.Pid); err != nil {
+			_ = signalGroup(cmd.Process.Pid, syscall.SIGKILL)
+			_ = cmd.Wait()
+			return nil, err
+		}
+	}
+	started = true
+
 	session := &Session{
 		Config:    config,
 		StartTime: time.Now(),
 		cmd:       cmd,
 		done:      make(chan struct{}),
-		Events:    make(chan StreamEvent, 100),
+		Events:    make(chan StreamEvent, c.eventBuffer),
+		stdin:     input,
+		logger:    c.log().With("pid", cmd.Process.Pid),
+		overflow:  c.overflow,
+	}
+	if c.overflow == OverflowSpill {
+		session.spill = newSpillQueue(session, c.spillDir)
 	}
 
 	// Create a channel to signal parsing completion
@@ -307,37 +553,125 @@ func (c *Client) Launch(config SessionConfig) (*Session, error) {
 		}()
 	}
 
+	// Enforce resource limits while the process runs
+	exited := make(chan struct{})
+	if limits != nil {
+		go session.enforceLimits(limits, exited)
+	}
+
 	// Wait for process to complete in background
 	go func() {
 		// Wait for the command to exit
-		session.SetError(cmd.Wait())
+		waitErr := cmd.Wait()
+		close(exited)
+
+		// A limit breach takes precedence over the exit error it caused
+		if limits != nil {
+			if breach := limits.exited(cmd.ProcessState); breach != nil {
+				session.SetError(breach)
+			}
+		}
+		session.SetError(waitErr)
+
+		// Stop anything claude left running in its process group, so orphaned
+		// MCP servers or tool processes neither leak nor hold the output open
+		session.reapGroup()
 
 		// IMPORTANT: Wait for parsing to complete before signaling done.
 		// This ensures that all output has been read and processed before
 		// the session is considered complete. Without this synchronization,
 		// Wait() might return before the result is available.
-		<-parseDone
+		select {
+		case <-parseDone:
+		case <-time.After(outputDrainTimeout):
+			// A process outside the group still holds the pipes open
+			session.log().Warn("claude output still open after exit, closing it", "timeout", outputDrainTimeout)
+			_ = stdout.Close()
+			_ = stderr.Close()
+			<-parseDone
+		}
+		_ = stdout.Close()
+		_ = stderr.Close()
+
+		if limits != nil {
+			limits.close()
+		}
 
 		close(session.done)
 	}()
 
+	// Stop the process if the context ends first
+	if ctx.Done() != nil {
+		go session.watchContext(ctx)
+	}
+
+	// With stream-json input, the initial query is the first user turn
+	if input != nil && config.Query != "" {
+		if err := session.writeMessage(config.Query); err != nil {
+			_ = session.Kill()
+			return nil, fmt.Errorf("failed to send initial query: %w", err)
+		}
+	}
+
 	return session, nil
 }
 
-// LaunchAndWait starts a Claude session and waits for it to complete
+// LaunchAndWait starts a Claude session and waits for it to complete.
+//
+// With an OutputSchema, the result is validated and its JSON stored in
+// Result.StructuredOutput. An invalid result is repaired by resuming the
+// session up to OutputSchemaRepairTurns times; if it still does not match,
+// the last result is returned together with an *OutputValidationError.
 func (c *Client) LaunchAndWait(config SessionConfig) (*Result, error) {
+	result, err := c.launchAndWait(config)
+	if err != nil || len(config.OutputSchema) == 0 {
+		return result, err
+	}
+
+	// Validate the result, resuming the session to repair it while turns remain
+	for {
+		if result == nil || result.IsError {
+			return result, nil
+		}
+		structured, err := ValidateOutput(config.OutputSchema, result.Result)
+		if err == nil {
+			result.StructuredOutput = structured
+			return result, nil
+		}
+		var validationErr *OutputValidationError
+		if !errors.As(err, &validationErr) || config.OutputSchemaRepairTurns == 0 || result.SessionID == "" {
+			return result, err
+		}
+
+		c.log().Debug("result does not match output schema, requesting repair",
+			"session_id", result.SessionID,
+			"problems", validationErr.Problems,
+			"repair_turns_left", config.OutputSchemaRepairTurns)
+		config = RepairConfig(config, result.SessionID, validationErr)
+		if result, err = c.launchAndWait(config); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// launchAndWait runs a single claude process to completion
+func (c *Client) launchAndWait(config SessionConfig) (*Result, error) {
 	session, err := c.Launch(config)
 	if err != nil {
 		return nil, err
 	}
 
+	// No further turns will be sent, so let claude exit after the initial query
+	if config.InputFormat == InputStreamJSON {
+		if err := session.CloseInput(); err != nil {
+			session.log().Warn("failed to close stdin", "error", err)
+		}
+	}
+
 	return session.Wait()
 }
 
 // Wait blocks until the session completes and returns the result
-// TODO: Add context support to allow cancellation/timeout. This would help prevent
-// indefinite blocking when waiting for interrupted sessions or hanging processes.
-// Consider adding WaitContext(ctx context.Context) method or updating Wait() signature.
 func (s *Session) Wait() (*Result, error) {
 	<-s.done
 
@@ -348,19 +682,230 @@ func (s *Session) Wait() (*Result, error) {
 	return s.result, nil
 }
 
-// Kill terminates the session
+// WaitContext blocks until the session completes or ctx ends, whichever comes first.
+// If ctx ends first, the process is interrupted and killed after the grace period,
+// and a *ContextError is returned.
+func (s *Session) WaitContext(ctx context.Context) (*Result, error) {
+	select {
+	case <-s.done:
+		return s.Wait()
+	case <-ctx.Done():
+		err := &ContextError{Err: ctx.Err()}
+		s.SetError(err)
+		if termErr := s.Terminate(); termErr != nil {
+			s.log().Warn("failed to terminate claude process", "error", termErr)
+		}
+		return nil, err
+	}
+}
+
+// watchContext stops the process when ctx ends before the session completes
+func (s *Session) watchContext(ctx context.Context) {
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		// Record the cause first so it takes precedence over the exit error
+		s.SetError(&ContextError{Err: ctx.Err()})
+		if err := s.Terminate(); err != nil {
+			s.log().Warn("failed to terminate claude process", "error", err)
+		}
+	}
+}
+
+// Terminate stops the session's process group, escalating from SIGINT to SIGTERM
+// after InterruptGracePeriod and to SIGKILL after TerminateGracePeriod, and
+// returns once the session has exited. If Interrupt was already called, the
+// escalation continues from that interrupt instead of starting over.
+func (s *Session) Terminate() error {
+	if s.cmd.Process == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	interruptedAt := s.interruptedAt
+	s.mu.RUnlock()
+
+	if interruptedAt.IsZero() {
+		if err := s.Interrupt(); err != nil {
+			return fmt.Errorf("failed to interrupt claude process: %w", err)
+		}
+		interruptedAt = time.Now()
+	}
+
+	interruptGrace := s.Config.InterruptGracePeriod
+	if interruptGrace <= 0 {
+		interruptGrace = DefaultInterruptGracePeriod
+	}
+	if s.waitDone(time.Until(interruptedAt.Add(interruptGrace))) {
+		return nil
+	}
+
+	s.log().Warn("claude process did not exit after SIGINT, sending SIGTERM", "grace_period", interruptGrace)
+	if err := signalGroup(s.cmd.Process.Pid, syscall.SIGTERM); err != nil {
+		return fmt.Errorf("failed to send SIGTERM to claude process: %w", err)
+	}
+	if s.waitDone(s.terminateGracePeriod()) {
+		return nil
+	}
+
+	s.log().Warn("claude process did not exit after SIGTERM, killing", "grace_period", s.terminateGracePeriod())
+	if err := s.Kill(); err != nil {
+		return fmt.Errorf("failed to kill claude process: %w", err)
+	}
+	<-s.done
+	return nil
+}
+
+// waitDone reports whether the session exits within d
+func (s *Session) waitDone(d time.Duration) bool {
+	if d <= 0 {
+		select {
+		case <-s.done:
+			return true
+		default:
+			return false
+		}
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-s.done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// terminateGracePeriod returns the configured SIGTERM grace period or the default
+func (s *Session) terminateGracePeriod() time.Duration {
+	if s.Config.TerminateGracePeriod > 0 {
+		return s.Config.TerminateGracePeriod
+	}
+	return DefaultTerminateGracePeriod
+}
+
+// reapGroup stops processes left in the session's process group after claude
+// itself has exited, sending SIGTERM and then SIGKILL after the grace period
+func (s *Session) reapGroup() {
+	pid := s.cmd.Process.Pid
+	if !groupAlive(pid) {
+		return
+	}
+
+	s.log().Warn("processes still running in claude's process group after exit, terminating them")
+	if err := signalGroup(pid, syscall.SIGTERM); err != nil {
+		s.log().Warn("failed to send SIGTERM to claude process group", "error", err)
+	}
+
+	deadline := time.Now().Add(s.terminateGracePeriod())
+	for groupAlive(pid) && time.Now().Before(deadline) {
+		time.Sleep(groupPollInterval)
+	}
+	if groupAlive(pid) {
+		if err := signalGroup(pid, syscall.SIGKILL); err != nil {
+			s.log().Warn("failed to kill claude process group", "error", err)
+		}
+	}
+}
+
+// SendMessage sends an additional user turn to a session launched with InputStreamJSON.
+// The message is processed after any turn already in progress.
+func (s *Session) SendMessage(ctx context.Context, text string) error {
+	if s.stdin == nil {
+		return ErrStreamingInputDisabled
+	}
+
+	select {
+	case <-s.done:
+		return ErrInputClosed
+	default:
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.writeMessage(text)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CloseInput closes stdin of a session launched with InputStreamJSON.
+// Claude finishes the current turn and exits.
+func (s *Session) CloseInput() error {
+	if s.stdin == nil {
+		return ErrStreamingInputDisabled
+	}
+
+	s.stdinMu.Lock()
+	defer s.stdinMu.Unlock()
+
+	if s.inputClosed {
+		return nil
+	}
+	s.inputClosed = true
+
+	if err := s.stdin.Close(); err != nil && !isClosedPipeError(err) {
+		return fmt.Errorf("failed to close stdin: %w", err)
+	}
+	return nil
+}
+
+// writeMessage writes a single stream-json user message to stdin
+func (s *Session) writeMessage(text string) error {
+	line, err := json.Marshal(userInputMessage{
+		Type: "user",
+		Message: userInputContent{
+			Role:    "user",
+			Content: []userInputText{{Type: "text", Text: text}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	s.stdinMu.Lock()
+	defer s.stdinMu.Unlock()
+
+	if s.inputClosed {
+		return ErrInputClosed
+	}
+
+	if _, err := s.stdin.Write(append(line, '\n')); err != nil {
+		if isClosedPipeError(err) {
+			return ErrInputClosed
+		}
+		return fmt.Errorf("failed to write message to stdin: %w", err)
+	}
+	return nil
+}
+
+// Kill sends SIGKILL to the session's process group
 func (s *Session) Kill() error {
 	if s.cmd.Process != nil {
-		return s.cmd.Process.Kill()
+		return signalGroup(s.cmd.Process.Pid, syscall.SIGKILL)
 	}
 	return nil
 }
 
-// Interrupt sends a SIGINT signal to the session process
+// Interrupt sends a SIGINT signal to the session's process group
 func (s *Session) Interrupt() error {
-	if s.cmd.Process != nil {
-		return s.cmd.Process.Signal(syscall.SIGINT)
+	if s.cmd.Process == nil {
+		return nil
 	}
+	if err := signalGroup(s.cmd.Process.Pid, syscall.SIGINT); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.interruptedAt = time.Now()
+	s.mu.Unlock()
 	return nil
 }
 
@@ -376,14 +921,7 @@ func (s *Session) parseStreamingJSON(stdout, stderr io.Reader) {
 	// Capture stderr in background
 	go func() {
 		defer close(stderrDone)
-		buf := make([]byte, 1024)
-		for {
-			n, err := stderr.Read(buf)
-			if err != nil {
-				break
-			}
-			stderrBuf.Write(buf[:n])
-		}
+		_ = s.readStderr(stderr, &stderrBuf)
 	}()
 
 	for scanner.Scan() {
@@ -392,10 +930,10 @@ func (s *Session) parseStreamingJSON(stdout, stderr io.Reader) {
 			continue
 		}
 
-		var event StreamEvent
-		if err := json.Unmarshal([]byte(line), &event); err != nil {
+		event, err := parseEventLine([]byte(line))
+		if err != nil {
 			// Log parse error but continue
-			log.Printf("WARNING: Failed to unmarshal event, dropping it: %v\nRaw data: %s", err, line)
+			s.log().Warn("failed to unmarshal event, dropping it", "error", err, "line", line)
 			continue
 		}
 
@@ -405,33 +943,19 @@ func (s *Session) parseStreamingJSON(stdout, stderr io.Reader) {
 		}
 
 		// Store result if this is the final message
-		if event.Type == "result" {
-			s.result = &Result{
-				Type:              event.Type,
-				Subtype:           event.Subtype,
-				CostUSD:           event.CostUSD,
-				IsError:           event.IsError,
-				DurationMS:        event.DurationMS,
-				DurationAPI:       event.DurationAPI,
-				NumTurns:          event.NumTurns,
-				Result:            event.Result,
-				SessionID:         event.SessionID,
-				Usage:             event.Usage,
-				Error:             event.Error,
-				PermissionDenials: event.PermissionDenials,
-				UUID:              event.UUID,
-			}
+		if result, ok := event.Typed.(*ResultEvent); ok {
+			s.result = result.ToResult()
 		}
 
 		// Send event to channel
-		s.Events <- event
+		s.deliver(event, []byte(line))
 	}
 
 	// Check for scanner errors including buffer overflow
 	if err := scanner.Err(); err != nil {
 		if err == bufio.ErrTooLong {
 			s.SetError(fmt.Errorf("JSON line exceeded buffer limit (10MB): %w", err))
-		} else {
+		} else if !isClosedPipeError(err) {
 			s.SetError(fmt.Errorf("stream parsing failed: %w", err))
 		}
 	}
@@ -445,7 +969,59 @@ func (s *Session) parseStreamingJSON(stdout, stderr io.Reader) {
 	}
 
 	// Close events channel when done parsing
-	close(s.Events)
+	s.closeEvents()
+}
+
+// parseEventLine decodes one line of stream-json output
+func parseEventLine(line []byte) (StreamEvent, error) {
+	typed, err := Decode(line)
+	if err != nil {
+		return StreamEvent{}, err
+	}
+	return streamEventFrom(typed), nil
+}
+
+// streamEventFrom fills the fields of a StreamEvent from its typed variant,
+// so each line is only decoded once
+func streamEventFrom(typed Event) StreamEvent {
+	header := typed.Header()
+	event := StreamEvent{
+		Type:      header.Type,
+		Subtype:   header.Subtype,
+		SessionID: header.SessionID,
+		UUID:      header.UUID,
+		Typed:     typed,
+	}
+
+	switch ev := typed.(type) {
+	case *SystemInit:
+		event.CWD = ev.CWD
+		event.Model = ev.Model
+		event.PermissionMode = ev.PermissionMode
+		event.APIKeySource = ev.APIKeySource
+		event.Tools = ev.Tools
+		event.MCPServers = ev.MCPServers
+	case *AssistantMessage:
+		event.ParentToolUseID = ev.ParentToolUseID
+		event.Message = &ev.Message
+	case *UserToolResult:
+		event.ParentToolUseID = ev.ParentToolUseID
+		event.Message = &ev.Message
+	case *PartialMessage:
+		event.ParentToolUseID = ev.ParentToolUseID
+		event.Event = &ev.Event
+	case *ResultEvent:
+		event.CostUSD = ev.CostUSD
+		event.IsError = ev.IsError
+		event.DurationMS = ev.DurationMS
+		event.DurationAPI = ev.DurationAPI
+		event.NumTurns = ev.NumTurns
+		event.Result = ev.Result
+		event.Usage = ev.Usage
+		event.Error = ev.Error
+		event.PermissionDenials = ev.PermissionDenials
+	}
+	return event
 }
 
 // parseSingleJSON reads and parses single JSON result
@@ -465,7 +1041,7 @@ func (s *Session) parseSingleJSON(stdout, stderr io.Reader) {
 	}
 
 	// Read all stderr - ignore expected pipe closure
-	if _, err := io.Copy(&stderrBuf, stderr); err != nil && !isClosedPipeError(err) {
+	if err := s.readStderr(stderr, &stderrBuf); err != nil {
 		s.SetError(fmt.Errorf("failed to read stderr: %w", err))
 		return
 	}
@@ -505,7 +1081,7 @@ func (s *Session) parseTextOutput(stdout, stderr io.Reader) {
 	}
 
 	// Read all stderr - ignore expected pipe closure
-	if _, err := io.Copy(&stderrBuf, stderr); err != nil && !isClosedPipeError(err) {
+	if err := s.readStderr(stderr, &stderrBuf); err != nil {
 		s.SetError(fmt.Errorf("failed to read stderr: %w", err))
 		return
 	}
@@ -1,7 +1,9 @@
 package claudecode_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -571,3 +573,323 @@ func TestStreamEventWithPermissionDenials(t *testing.T) {
 		t.Errorf("Expected tool name 'Bash', got %s", event.PermissionDenials.Denials[0].ToolName)
 	}
 }
+
+// writeFakeClaude writes an executable shell script standing in for the claude binary
+func writeFakeClaude(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "claude")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatalf("failed to write fake claude: %v", err)
+	}
+	return path
+}
+
+func TestSession_WaitContextDeadline(t *testing.T) {
+	// Ignore SIGINT so the grace period has to escalate to a kill
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, "trap '' INT\nexec sleep 30"))
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:                "hang",
+		OutputFormat:         claudecode.OutputText,
+		InterruptGracePeriod: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = session.WaitContext(ctx)
+
+	var ctxErr *claudecode.ContextError
+	if !errors.As(err, &ctxErr) {
+		t.Fatalf("expected ContextError, got %v", err)
+	}
+	if !ctxErr.Timeout() || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("WaitContext took too long to return: %s", elapsed)
+	}
+
+	// The process must have been killed, so Wait returns promptly
+	done := make(chan struct{})
+	go func() {
+		_, _ = session.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("claude process was not killed after grace period")
+	}
+}
+
+func TestClient_LaunchContextCancel(t *testing.T) {
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, "exec sleep 30"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session, err := client.LaunchContext(ctx, claudecode.SessionConfig{
+		Query:                "hang",
+		OutputFormat:         claudecode.OutputText,
+		InterruptGracePeriod: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := session.Wait()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		var ctxErr *claudecode.ContextError
+		if !errors.As(err, &ctxErr) {
+			t.Fatalf("expected ContextError, got %v", err)
+		}
+		if ctxErr.Timeout() || !errors.Is(err, context.Canceled) {
+			t.Errorf("expected cancellation, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("session did not stop after context cancellation")
+	}
+}
+
+func TestClient_LaunchContextAlreadyCancelled(t *testing.T) {
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, "exit 0"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.LaunchContext(ctx, claudecode.SessionConfig{Query: "noop"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestClient_LaunchAndWaitOutputSchema(t *testing.T) {
+	// Answers in prose at first and with JSON once resumed
+	script := `case " $* " in
+*" --resume fake "*) echo '{"type":"result","subtype":"success","session_id":"fake","result":"{\\"name\\":\\"fixed\\"}"}' ;;
+*) echo '{"type":"result","subtype":"success","session_id":"fake","result":"The name is fixed."}' ;;
+esac`
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, script))
+	config := claudecode.SessionConfig{
+		Query:        "name it",
+		OutputFormat: claudecode.OutputJSON,
+		OutputSchema: []byte(`{"type":"object","required":["name"],"properties":{"name":{"type":"string"}}}`),
+	}
+
+	t.Run("without repair turns", func(t *testing.T) {
+		result, err := client.LaunchAndWait(config)
+		var validationErr *claudecode.OutputValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected OutputValidationError, got %v", err)
+		}
+		if result == nil || result.Result != "The name is fixed." {
+			t.Errorf("expected the invalid result to be returned, got %+v", result)
+		}
+	})
+
+	t.Run("repaired by resuming", func(t *testing.T) {
+		config := config
+		config.OutputSchemaRepairTurns = 1
+
+		result, err := client.LaunchAndWait(config)
+		if err != nil {
+			t.Fatalf("LaunchAndWait failed: %v", err)
+		}
+		if string(result.StructuredOutput) != `{"name":"fixed"}` {
+			t.Errorf("unexpected structured output: %s", result.StructuredOutput)
+		}
+	})
+}
+
+func TestSession_SendMessage(t *testing.T) {
+	// Emits one assistant event per input line and a result once stdin closes
+	script := `n=0
+while read -r line; do
+  n=$((n+1))
+  echo '{"type":"assistant","session_id":"fake","message":{"id":"m'$n'","role":"assistant","content":[{"type":"text","text":"ok"}]}}'
+done
+echo '{"type":"result","subtype":"success","session_id":"fake","num_turns":'$n'}'`
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, script))
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:        "first",
+		OutputFormat: claudecode.OutputStreamJSON,
+		InputFormat:  claudecode.InputStreamJSON,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	// Wait for the reply to the initial query before sending a follow-up
+	select {
+	case event := <-session.Events:
+		if event.Type != "assistant" {
+			t.Fatalf("expected assistant event, got %q", event.Type)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reply to initial query")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := session.SendMessage(ctx, "second"); err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+	if err := session.CloseInput(); err != nil {
+		t.Fatalf("failed to close input: %v", err)
+	}
+	if err := session.SendMessage(ctx, "third"); !errors.Is(err, claudecode.ErrInputClosed) {
+		t.Errorf("expected ErrInputClosed after CloseInput, got %v", err)
+	}
+
+	go func() {
+		for range session.Events {
+		}
+	}()
+
+	result, err := session.Wait()
+	if err != nil {
+		t.Fatalf("session failed: %v", err)
+	}
+	if result.NumTurns != 2 {
+		t.Errorf("expected 2 turns, got %d", result.NumTurns)
+	}
+}
+
+func TestSession_SendMessageRequiresStreamingInput(t *testing.T) {
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, "exit 0"))
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:        "hello",
+		OutputFormat: claudecode.OutputText,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+	defer func() { _, _ = session.Wait() }()
+
+	if err := session.SendMessage(context.Background(), "more"); !errors.Is(err, claudecode.ErrStreamingInputDisabled) {
+		t.Errorf("expected ErrStreamingInputDisabled, got %v", err)
+	}
+
+	_, err = client.Launch(claudecode.SessionConfig{
+		Query:        "hello",
+		OutputFormat: claudecode.OutputText,
+		InputFormat:  claudecode.InputStreamJSON,
+	})
+	if err == nil {
+		t.Error("expected error for stream-json input without stream-json output")
+	}
+}
+
+// heartbeatScript starts a background process that appends to file until killed
+func heartbeatScript(file string) string {
+	return "(while true; do echo x >> " + file + "; sleep 0.02; done) >/dev/null 2>&1 &"
+}
+
+// assertStopped fails if file keeps growing, i.e. its heartbeat process is still alive
+func assertStopped(t *testing.T, file string) {
+	t.Helper()
+	size := func() int64 {
+		info, err := os.Stat(file)
+		if err != nil {
+			return 0
+		}
+		return info.Size()
+	}
+
+	before := size()
+	time.Sleep(200 * time.Millisecond)
+	if after := size(); after != before {
+		t.Errorf("background process still running (%s grew from %d to %d bytes)", file, before, after)
+	}
+}
+
+func TestSession_KillStopsProcessGroup(t *testing.T) {
+	heartbeat := filepath.Join(t.TempDir(), "heartbeat")
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, heartbeatScript(heartbeat)+"\nexec sleep 30"))
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:        "spawn",
+		OutputFormat: claudecode.OutputText,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	// Let the background process start
+	time.Sleep(100 * time.Millisecond)
+
+	if err := session.Kill(); err != nil {
+		t.Fatalf("Kill() error = %v", err)
+	}
+	_, _ = session.Wait()
+
+	assertStopped(t, heartbeat)
+}
+
+func TestSession_TerminateEscalates(t *testing.T) {
+	// Ignore both SIGINT and SIGTERM so only SIGKILL stops the process
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, "trap '' INT TERM\nexec sleep 30"))
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:                "hang",
+		OutputFormat:         claudecode.OutputText,
+		InterruptGracePeriod: 50 * time.Millisecond,
+		TerminateGracePeriod: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Terminate()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Terminate() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Terminate() did not escalate to SIGKILL")
+	}
+
+	// Terminating an exited session is a no-op
+	if err := session.Terminate(); err != nil {
+		t.Errorf("Terminate() after exit error = %v", err)
+	}
+}
+
+func TestSession_ReapsOrphansAfterExit(t *testing.T) {
+	heartbeat := filepath.Join(t.TempDir(), "heartbeat")
+	script := heartbeatScript(heartbeat) + `
+echo '{"type":"result","subtype":"success","session_id":"fake","result":"done"}'`
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, script))
+
+	result, err := client.LaunchAndWait(claudecode.SessionConfig{
+		Query:                "spawn and exit",
+		OutputFormat:         claudecode.OutputStreamJSON,
+		TerminateGracePeriod: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("LaunchAndWait() error = %v", err)
+	}
+	if result.Result != "done" {
+		t.Errorf("expected result %q, got %q", "done", result.Result)
+	}
+
+	assertStopped(t, heartbeat)
+}
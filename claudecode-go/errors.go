@@ -0,0 +1,53 @@
+package claudecode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for stream-json input
+var (
+	// ErrStreamingInputDisabled is returned when sending input to a session
+	// that was not launched with InputStreamJSON
+	ErrStreamingInputDisabled = errors.New("session was not launched with stream-json input")
+
+	// ErrInputClosed is returned when sending input after the session's input was closed
+	ErrInputClosed = errors.New("session input is closed")
+)
+
+// ContextError is returned when a session is stopped because its context was
+// cancelled or its deadline passed before the claude process exited
+type ContextError struct {
+	Err error // context.Canceled or context.DeadlineExceeded
+}
+
+func (e *ContextError) Error() string {
+	if e.Timeout() {
+		return fmt.Sprintf("claude session deadline exceeded: %v", e.Err)
+	}
+	return fmt.Sprintf("claude session cancelled: %v", e.Err)
+}
+
+func (e *ContextError) Unwrap() error {
+	return e.Err
+}
+
+// Timeout reports whether the session was stopped because a deadline passed
+func (e *ContextError) Timeout() bool {
+	return errors.Is(e.Err, context.DeadlineExceeded)
+}
+
+// LimitError is returned when a session is stopped because it exceeded one of
+// its ResourceLimits
+type LimitError struct {
+	Limit  LimitKind // The limit that was exceeded
+	Detail string    // Human-readable description of the breach
+}
+
+func (e *LimitError) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("claude session exceeded %s limit: %s", e.Limit, e.Detail)
+	}
+	return fmt.Sprintf("claude session exceeded %s limit", e.Limit)
+}
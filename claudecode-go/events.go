@@ -0,0 +1,292 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Event is a typed stream-json event produced by Decode.
+//
+// The set of implementations is closed: *SystemInit, *SystemEvent, *AssistantMessage,
+// *UserToolResult, *PartialMessage, *ResultEvent and *UnknownEvent. Use a type switch
+// to handle them.
+type Event interface {
+	// Header returns the fields shared by all events
+	Header() *EventHeader
+
+	// Raw returns the original JSON line the event was decoded from
+	Raw() json.RawMessage
+
+	// UnknownFields returns top-level fields not modeled by the event's type
+	UnknownFields() map[string]json.RawMessage
+
+	isEvent()
+}
+
+// EventHeader holds the fields shared by all stream events
+type EventHeader struct {
+	Type      string `json:"type"`
+	Subtype   string `json:"subtype,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	UUID      string `json:"uuid,omitempty"`
+
+	raw     json.RawMessage
+	unknown map[string]json.RawMessage
+}
+
+// Header implements Event
+func (h *EventHeader) Header() *EventHeader { return h }
+
+// Raw implements Event
+func (h *EventHeader) Raw() json.RawMessage { return h.raw }
+
+// UnknownFields implements Event
+func (h *EventHeader) UnknownFields() map[string]json.RawMessage { return h.unknown }
+
+// SystemInit is emitted once at startup (type="system", subtype="init")
+type SystemInit struct {
+	EventHeader
+	CWD            string      `json:"cwd,omitempty"`
+	Model          string      `json:"model,omitempty"`
+	PermissionMode string      `json:"permissionMode,omitempty"`
+	APIKeySource   string      `json:"apiKeySource,omitempty"`
+	Tools          []string    `json:"tools,omitempty"`
+	MCPServers     []MCPStatus `json:"mcp_servers,omitempty"`
+}
+
+// SystemEvent is any system event other than init. Subtype-specific
+// fields are available through UnknownFields.
+type SystemEvent struct {
+	EventHeader
+}
+
+// AssistantMessage carries a message produced by the model (type="assistant")
+type AssistantMessage struct {
+	EventHeader
+	ParentToolUseID string  `json:"parent_tool_use_id,omitempty"`
+	Message         Message `json:"message"`
+}
+
+// UserToolResult carries a user turn (type="user"), usually the results of
+// tool calls sent back to the model
+type UserToolResult struct {
+	EventHeader
+	ParentToolUseID string  `json:"parent_tool_use_id,omitempty"`
+	Message         Message `json:"message"`
+}
+
+// ToolResults returns the tool_result content blocks of the message
+func (e *UserToolResult) ToolResults() []Content {
+	var results []Content
+	for _, content := range e.Message.Content {
+		if content.Type == "tool_result" {
+			results = append(results, content)
+		}
+	}
+	return results
+}
+
+// ResultEvent is emitted when a turn finishes (type="result")
+type ResultEvent struct {
+	EventHeader
+	CostUSD           float64            `json:"total_cost_usd,omitempty"`
+	IsError           bool               `json:"is_error,omitempty"`
+	DurationMS        int                `json:"duration_ms,omitempty"`
+	DurationAPI       int                `json:"duration_api_ms,omitempty"`
+	NumTurns          int                `json:"num_turns,omitempty"`
+	Result            string             `json:"result,omitempty"`
+	Usage             *Usage             `json:"usage,omitempty"`
+	Error             string             `json:"error,omitempty"`
+	PermissionDenials *PermissionDenials `json:"permission_denials,omitempty"`
+}
+
+// ToResult converts the event to the Result returned by Session.Wait
+func (e *ResultEvent) ToResult() *Result {
+	return &Result{
+		Type:              e.Type,
+		Subtype:           e.Subtype,
+		CostUSD:           e.CostUSD,
+		IsError:           e.IsError,
+		DurationMS:        e.DurationMS,
+		DurationAPI:       e.DurationAPI,
+		NumTurns:          e.NumTurns,
+		Result:            e.Result,
+		SessionID:         e.SessionID,
+		Usage:             e.Usage,
+		Error:             e.Error,
+		PermissionDenials: e.PermissionDenials,
+		UUID:              e.UUID,
+	}
+}
+
+// PartialMessage carries one API streaming event of a message still being
+// generated (type="stream_event"). It is only emitted with IncludePartialMessages;
+// the complete message follows as an AssistantMessage.
+type PartialMessage struct {
+	EventHeader
+	ParentToolUseID string       `json:"parent_tool_use_id,omitempty"`
+	Event           PartialEvent `json:"event"`
+}
+
+// Partial event types
+const (
+	PartialMessageStart      = "message_start"
+	PartialContentBlockStart = "content_block_start"
+	PartialContentBlockDelta = "content_block_delta"
+	PartialContentBlockStop  = "content_block_stop"
+	PartialMessageDelta      = "message_delta"
+	PartialMessageStop       = "message_stop"
+)
+
+// PartialEvent is a single API streaming event
+type PartialEvent struct {
+	Type         string   `json:"type"`
+	Index        int      `json:"index,omitempty"`         // Content block index
+	Message      *Message `json:"message,omitempty"`       // message_start
+	ContentBlock *Content `json:"content_block,omitempty"` // content_block_start
+	Delta        *Delta   `json:"delta,omitempty"`         // content_block_delta and message_delta
+	Usage        *Usage   `json:"usage,omitempty"`         // message_delta
+}
+
+// Delta types
+const (
+	DeltaText      = "text_delta"
+	DeltaThinking  = "thinking_delta"
+	DeltaInputJSON = "input_json_delta"
+	DeltaSignature = "signature_delta"
+)
+
+// Delta is the incremental content of a partial event
+type Delta struct {
+	Type        string `json:"type,omitempty"`
+	Text        string `json:"text,omitempty"`
+	Thinking    string `json:"thinking,omitempty"`
+	PartialJSON string `json:"partial_json,omitempty"`
+	Signature   string `json:"signature,omitempty"`
+	StopReason  string `json:"stop_reason,omitempty"`
+}
+
+// Content returns the text the delta adds to its content block
+func (d *Delta) Content() string {
+	switch d.Type {
+	case DeltaText:
+		return d.Text
+	case DeltaThinking:
+		return d.Thinking
+	case DeltaInputJSON:
+		return d.PartialJSON
+	default:
+		return ""
+	}
+}
+
+// UnknownEvent is an event whose type this package does not model yet
+type UnknownEvent struct {
+	EventHeader
+}
+
+func (*SystemInit) isEvent()       {}
+func (*SystemEvent) isEvent()      {}
+func (*AssistantMessage) isEvent() {}
+func (*UserToolResult) isEvent()   {}
+func (*PartialMessage) isEvent()   {}
+func (*ResultEvent) isEvent()      {}
+func (*UnknownEvent) isEvent()     {}
+
+// Decode parses a single stream-json line into its typed event.
+// Events of unrecognized types decode to *UnknownEvent rather than failing.
+func Decode(data []byte) (Event, error) {
+	var header EventHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	var event Event
+	switch header.Type {
+	case "system":
+		if header.Subtype == "init" {
+			event = &SystemInit{}
+		} else {
+			event = &SystemEvent{}
+		}
+	case "assistant":
+		event = &AssistantMessage{}
+	case "user":
+		event = &UserToolResult{}
+	case "stream_event":
+		event = &PartialMessage{}
+	case "result":
+		event = &ResultEvent{}
+	default:
+		event = &UnknownEvent{}
+	}
+
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", header.Type, err)
+	}
+
+	h := event.Header()
+	h.raw = append(json.RawMessage(nil), data...)
+	h.unknown = unknownFields(data, reflect.TypeOf(event).Elem())
+
+	return event, nil
+}
+
+// knownFieldsCache maps an event type to the set of JSON field names it models
+var knownFieldsCache sync.Map
+
+// unknownFields returns the top-level fields of data that typ does not model
+func unknownFields(data []byte, typ reflect.Type) map[string]json.RawMessage {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	known := knownFields(typ)
+	for name := range fields {
+		if known[name] {
+			delete(fields, name)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// knownFields collects the JSON field names of typ, including embedded structs
+func knownFields(typ reflect.Type) map[string]bool {
+	if cached, ok := knownFieldsCache.Load(typ); ok {
+		return cached.(map[string]bool)
+	}
+
+	known := make(map[string]bool)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for name := range knownFields(field.Type) {
+				known[name] = true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[name] = true
+	}
+
+	knownFieldsCache.Store(typ, known)
+	return known
+}
@@ -0,0 +1,224 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		check   func(t *testing.T, event Event)
+		wantErr bool
+	}{
+		{
+			name:  "system init",
+			input: `{"type":"system","subtype":"init","session_id":"sess-1","cwd":"/tmp","model":"claude-sonnet-4","tools":["Bash","Read"],"mcp_servers":[{"name":"codelayer","status":"connected"}]}`,
+			check: func(t *testing.T, event Event) {
+				init, ok := event.(*SystemInit)
+				if !ok {
+					t.Fatalf("expected *SystemInit, got %T", event)
+				}
+				if init.SessionID != "sess-1" || init.Model != "claude-sonnet-4" || len(init.Tools) != 2 {
+					t.Errorf("unexpected init event: %+v", init)
+				}
+				if len(init.MCPServers) != 1 || init.MCPServers[0].Status != "connected" {
+					t.Errorf("unexpected mcp servers: %+v", init.MCPServers)
+				}
+			},
+		},
+		{
+			name:  "other system event",
+			input: `{"type":"system","subtype":"compact_boundary","session_id":"sess-1","compact_metadata":{"trigger":"auto"}}`,
+			check: func(t *testing.T, event Event) {
+				sys, ok := event.(*SystemEvent)
+				if !ok {
+					t.Fatalf("expected *SystemEvent, got %T", event)
+				}
+				if sys.Subtype != "compact_boundary" {
+					t.Errorf("expected subtype compact_boundary, got %q", sys.Subtype)
+				}
+				if _, ok := sys.UnknownFields()["compact_metadata"]; !ok {
+					t.Error("expected compact_metadata in unknown fields")
+				}
+			},
+		},
+		{
+			name:  "partial message delta",
+			input: `{"type":"stream_event","session_id":"sess-1","uuid":"u-1","parent_tool_use_id":null,"event":{"type":"content_block_delta","index":1,"delta":{"type":"text_delta","text":"Hel"}}}`,
+			check: func(t *testing.T, event Event) {
+				partial, ok := event.(*PartialMessage)
+				if !ok {
+					t.Fatalf("expected *PartialMessage, got %T", event)
+				}
+				if partial.Event.Type != PartialContentBlockDelta || partial.Event.Index != 1 {
+					t.Errorf("unexpected partial event: %+v", partial.Event)
+				}
+				if partial.Event.Delta == nil || partial.Event.Delta.Content() != "Hel" {
+					t.Errorf("unexpected delta: %+v", partial.Event.Delta)
+				}
+				if len(partial.UnknownFields()) != 0 {
+					t.Errorf("expected no unknown fields, got %v", partial.UnknownFields())
+				}
+			},
+		},
+		{
+			name:  "partial message tool input",
+			input: `{"type":"stream_event","session_id":"sess-1","event":{"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":"{\"command\":"}}}`,
+			check: func(t *testing.T, event Event) {
+				partial := event.(*PartialMessage)
+				if got := partial.Event.Delta.Content(); got != `{"command":` {
+					t.Errorf("expected partial JSON, got %q", got)
+				}
+			},
+		},
+		{
+			name:  "assistant message",
+			input: `{"type":"assistant","session_id":"sess-1","parent_tool_use_id":"toolu_parent","message":{"id":"msg_1","type":"message","role":"assistant","content":[{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"command":"ls"}}],"usage":{"input_tokens":10,"output_tokens":5}}}`,
+			check: func(t *testing.T, event Event) {
+				msg, ok := event.(*AssistantMessage)
+				if !ok {
+					t.Fatalf("expected *AssistantMessage, got %T", event)
+				}
+				if msg.ParentToolUseID != "toolu_parent" {
+					t.Errorf("expected parent tool use ID, got %q", msg.ParentToolUseID)
+				}
+				if len(msg.Message.Content) != 1 || msg.Message.Content[0].Name != "Bash" {
+					t.Errorf("unexpected content: %+v", msg.Message.Content)
+				}
+				if msg.Message.Usage == nil || msg.Message.Usage.InputTokens != 10 {
+					t.Errorf("unexpected usage: %+v", msg.Message.Usage)
+				}
+			},
+		},
+		{
+			name:  "user tool result",
+			input: `{"type":"user","session_id":"sess-1","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"file.txt"},{"type":"text","text":"note"}]}}`,
+			check: func(t *testing.T, event Event) {
+				user, ok := event.(*UserToolResult)
+				if !ok {
+					t.Fatalf("expected *UserToolResult, got %T", event)
+				}
+				results := user.ToolResults()
+				if len(results) != 1 || results[0].ToolUseID != "toolu_1" || results[0].Content.Value != "file.txt" {
+					t.Errorf("unexpected tool results: %+v", results)
+				}
+			},
+		},
+		{
+			name:  "result with new fields",
+			input: `{"type":"result","subtype":"success","session_id":"sess-1","total_cost_usd":0.01,"num_turns":2,"result":"done","stop_reason":"end_turn"}`,
+			check: func(t *testing.T, event Event) {
+				res, ok := event.(*ResultEvent)
+				if !ok {
+					t.Fatalf("expected *ResultEvent, got %T", event)
+				}
+				result := res.ToResult()
+				if result.SessionID != "sess-1" || result.NumTurns != 2 || result.Result != "done" {
+					t.Errorf("unexpected result: %+v", result)
+				}
+				unknown := res.UnknownFields()
+				if len(unknown) != 1 || string(unknown["stop_reason"]) != `"end_turn"` {
+					t.Errorf("expected only stop_reason in unknown fields, got %v", unknown)
+				}
+			},
+		},
+		{
+			name:  "unknown type",
+			input: `{"type":"rate_limit","session_id":"sess-1","retry_after":30}`,
+			check: func(t *testing.T, event Event) {
+				unknown, ok := event.(*UnknownEvent)
+				if !ok {
+					t.Fatalf("expected *UnknownEvent, got %T", event)
+				}
+				if unknown.Type != "rate_limit" || unknown.SessionID != "sess-1" {
+					t.Errorf("unexpected header: %+v", unknown.Header())
+				}
+				if string(unknown.Raw()) != `{"type":"rate_limit","session_id":"sess-1","retry_after":30}` {
+					t.Errorf("raw payload not preserved: %s", unknown.Raw())
+				}
+			},
+		},
+		{
+			name:    "invalid json",
+			input:   `{"type":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := Decode([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, event)
+			}
+		})
+	}
+}
+
+func TestStreamEventAsEvent(t *testing.T) {
+	event := StreamEvent{
+		Type:      "assistant",
+		SessionID: "sess-1",
+		Message: &Message{
+			Role:    "assistant",
+			Content: []Content{{Type: "text", Text: "hello"}},
+		},
+	}
+
+	typed, err := event.AsEvent()
+	if err != nil {
+		t.Fatalf("AsEvent() error = %v", err)
+	}
+	msg, ok := typed.(*AssistantMessage)
+	if !ok {
+		t.Fatalf("expected *AssistantMessage, got %T", typed)
+	}
+	if msg.SessionID != "sess-1" || msg.Message.Content[0].Text != "hello" {
+		t.Errorf("unexpected event: %+v", msg)
+	}
+
+	// Events from the stream parser are returned as-is
+	event.Typed = msg
+	if again, _ := event.AsEvent(); again != Event(msg) {
+		t.Error("expected AsEvent to return the parsed variant")
+	}
+}
+
+func TestParseEventLine(t *testing.T) {
+	// The typed event fills the same fields as decoding the line into a StreamEvent
+	lines := []string{
+		`{"type":"system","subtype":"init","session_id":"sess-1","cwd":"/tmp","model":"claude-sonnet-4","permissionMode":"default","apiKeySource":"none","tools":["Bash"],"mcp_servers":[{"name":"codelayer","status":"connected"}]}`,
+		`{"type":"system","subtype":"compact_boundary","session_id":"sess-1","uuid":"u-0"}`,
+		`{"type":"assistant","session_id":"sess-1","parent_tool_use_id":"toolu_parent","message":{"id":"msg_1","type":"message","role":"assistant","content":[{"type":"text","text":"hi"}],"usage":{"input_tokens":10,"output_tokens":5}}}`,
+		`{"type":"user","session_id":"sess-1","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"ok"}]}}`,
+		`{"type":"stream_event","session_id":"sess-1","uuid":"u-1","event":{"type":"content_block_delta","index":1,"delta":{"type":"text_delta","text":"Hel"}}}`,
+		`{"type":"result","subtype":"success","session_id":"sess-1","uuid":"u-2","total_cost_usd":0.5,"is_error":false,"duration_ms":10,"duration_api_ms":8,"num_turns":2,"result":"done","usage":{"input_tokens":1,"output_tokens":2}}`,
+		`{"type":"result","subtype":"error_during_execution","session_id":"sess-1","is_error":true,"error":"boom"}`,
+		`{"type":"future_event","session_id":"sess-1"}`,
+	}
+
+	for _, line := range lines {
+		var want StreamEvent
+		if err := json.Unmarshal([]byte(line), &want); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", line, err)
+		}
+
+		got, err := parseEventLine([]byte(line))
+		if err != nil {
+			t.Fatalf("parseEventLine(%s) failed: %v", line, err)
+		}
+		if got.Typed == nil {
+			t.Errorf("expected typed event for %s", line)
+		}
+		got.Typed = nil
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("parseEventLine(%s)\n got %+v\nwant %+v", line, got, want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package claudecode
+
+import (
+	"fmt"
+	"time"
+)
+
+// limitPollInterval is how often a session's resource usage is checked
+const limitPollInterval = time.Second
+
+// LimitKind identifies a resource limit
+type LimitKind string
+
+const (
+	LimitCPUTime   LimitKind = "cpu_time"
+	LimitMemory    LimitKind = "memory"
+	LimitOpenFiles LimitKind = "open_files"
+	LimitWallClock LimitKind = "wall_clock"
+)
+
+// ResourceLimits bounds the resources used by a session's process group.
+// Zero values mean unlimited.
+//
+// On Linux, limits are applied through a cgroup v2 subtree when CgroupParent
+// names a delegated cgroup, and through rlimits plus usage polling otherwise.
+// Other platforms only support WallClock.
+type ResourceLimits struct {
+	CPUTime   time.Duration `json:"cpu_time,omitempty"`   // Total CPU time across the process group
+	Memory    int64         `json:"memory,omitempty"`     // Resident memory in bytes across the process group
+	OpenFiles uint64        `json:"open_files,omitempty"` // Open file descriptors per process (enforced, not reported)
+	WallClock time.Duration `json:"wall_clock,omitempty"` // Time from launch until the session is stopped
+
+	// CgroupParent is a writable cgroup v2 directory under which a cgroup is
+	// created for the session. Rlimits are used when empty or unusable.
+	CgroupParent string `json:"cgroup_parent,omitempty"`
+}
+
+// IsZero reports whether no limits are set
+func (l *ResourceLimits) IsZero() bool {
+	return l == nil || (l.CPUTime == 0 && l.Memory == 0 && l.OpenFiles == 0 && l.WallClock == 0)
+}
+
+// Validate checks that the limits are well formed
+func (l *ResourceLimits) Validate() error {
+	if l == nil {
+		return nil
+	}
+	if l.CPUTime < 0 {
+		return fmt.Errorf("cpu time limit must not be negative")
+	}
+	if l.Memory < 0 {
+		return fmt.Errorf("memory limit must not be negative")
+	}
+	if l.WallClock < 0 {
+		return fmt.Errorf("wall clock limit must not be negative")
+	}
+	if l.CPUTime > 0 && l.CPUTime < time.Second {
+		return fmt.Errorf("cpu time limit must be at least 1s")
+	}
+	return nil
+}
+
+// enforceLimits stops the session when it exceeds its wall clock limit or
+// when the limiter reports a breach, until exited is closed
+func (s *Session) enforceLimits(l *limiter, exited <-chan struct{}) {
+	var wallClock <-chan time.Time
+	if s.Config.Limits.WallClock > 0 {
+		timer := time.NewTimer(s.Config.Limits.WallClock)
+		defer timer.Stop()
+		wallClock = timer.C
+	}
+
+	ticker := time.NewTicker(limitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exited:
+			return
+		case <-wallClock:
+			s.stopForLimit(&LimitError{
+				Limit:  LimitWallClock,
+				Detail: fmt.Sprintf("still running after %s", s.Config.Limits.WallClock),
+			})
+			return
+		case <-ticker.C:
+			if breach := l.check(); breach != nil {
+				s.stopForLimit(breach)
+				return
+			}
+		}
+	}
+}
+
+// stopForLimit records a limit breach and terminates the session
+func (s *Session) stopForLimit(breach *LimitError) {
+	s.log().Warn("claude session exceeded resource limit, terminating", "limit", breach.Limit, "detail", breach.Detail)
+	// Record the breach first so it takes precedence over the exit error
+	s.SetError(breach)
+	if err := s.Terminate(); err != nil {
+		s.log().Warn("failed to terminate claude process", "error", err)
+	}
+}
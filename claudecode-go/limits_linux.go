@@ -0,0 +1,287 @@
+//go:build linux
+
+package claudecode
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// clockTicks is USER_HZ, the unit of CPU times in /proc/<pid>/stat
+const clockTicks = 100
+
+// limiter applies a session's resource limits and detects breaches
+type limiter struct {
+	limits ResourceLimits
+	pid    int
+	logger *slog.Logger
+
+	// Set when the session runs in its own cgroup
+	cgroup   string
+	cgroupFD *os.File
+}
+
+// newLimiter prepares limits for a session, creating its cgroup when
+// limits.CgroupParent is usable and falling back to rlimits otherwise
+func newLimiter(limits ResourceLimits, logger *slog.Logger) (*limiter, error) {
+	l := &limiter{limits: limits, logger: logger}
+	if limits.CgroupParent == "" || (limits.CPUTime == 0 && limits.Memory == 0) {
+		return l, nil
+	}
+
+	if err := l.createCgroup(); err != nil {
+		logger.Warn("cannot use cgroup, falling back to rlimits", "cgroup_parent", limits.CgroupParent, "error", err)
+		l.removeCgroup()
+	}
+	return l, nil
+}
+
+// createCgroup creates the session's cgroup and sets its memory limit
+func (l *limiter) createCgroup() error {
+	parent := l.limits.CgroupParent
+	if _, err := os.Stat(filepath.Join(parent, "cgroup.controllers")); err != nil {
+		return fmt.Errorf("not a cgroup v2 directory: %w", err)
+	}
+
+	if l.limits.Memory > 0 {
+		// May already be enabled; writing memory.max below fails if it is not
+		_ = os.WriteFile(filepath.Join(parent, "cgroup.subtree_control"), []byte("+memory"), 0)
+	}
+
+	dir, err := os.MkdirTemp(parent, "claude-")
+	if err != nil {
+		return fmt.Errorf("failed to create cgroup: %w", err)
+	}
+	l.cgroup = dir
+
+	if l.limits.Memory > 0 {
+		if err := os.WriteFile(filepath.Join(dir, "memory.max"), []byte(strconv.FormatInt(l.limits.Memory, 10)), 0); err != nil {
+			return fmt.Errorf("failed to set memory.max: %w", err)
+		}
+		// Keep the limit from being sidestepped through swap, where supported
+		_ = os.WriteFile(filepath.Join(dir, "memory.swap.max"), []byte("0"), 0)
+	}
+
+	l.cgroupFD, err = os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open cgroup: %w", err)
+	}
+	return nil
+}
+
+// prepare configures cmd to start inside the session's cgroup
+func (l *limiter) prepare(cmd *exec.Cmd) {
+	if l.cgroupFD == nil {
+		return
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(l.cgroupFD.Fd())
+}
+
+// start applies rlimits to the started process; they are inherited by
+// everything it spawns. Without a cgroup, memory is enforced only by polling:
+// per-process data limits break runtimes (node, Go) that map large regions up front.
+func (l *limiter) start(pid int) error {
+	l.pid = pid
+
+	if l.limits.OpenFiles > 0 {
+		if err := prlimit(pid, syscall.RLIMIT_NOFILE, l.limits.OpenFiles, l.limits.OpenFiles); err != nil {
+			return fmt.Errorf("failed to limit open files: %w", err)
+		}
+	}
+	if l.cgroup != "" {
+		return nil
+	}
+
+	if l.limits.CPUTime > 0 {
+		// SIGXCPU at the soft limit, SIGKILL at the hard limit
+		seconds := uint64(l.limits.CPUTime / time.Second)
+		if err := prlimit(pid, syscall.RLIMIT_CPU, seconds, seconds+5); err != nil {
+			return fmt.Errorf("failed to limit cpu time: %w", err)
+		}
+	}
+	return nil
+}
+
+// check reports a breach while the session is running
+func (l *limiter) check() *LimitError {
+	if l.pid == 0 || (l.limits.CPUTime == 0 && l.limits.Memory == 0) {
+		return nil
+	}
+
+	if l.cgroup != "" {
+		return l.checkCgroup()
+	}
+
+	cpu, memory := groupUsage(l.pid)
+	if l.limits.CPUTime > 0 && cpu >= l.limits.CPUTime {
+		return &LimitError{Limit: LimitCPUTime, Detail: fmt.Sprintf("used %s of %s", cpu.Round(time.Second), l.limits.CPUTime)}
+	}
+	if l.limits.Memory > 0 && memory >= l.limits.Memory {
+		return &LimitError{Limit: LimitMemory, Detail: fmt.Sprintf("using %d of %d bytes", memory, l.limits.Memory)}
+	}
+	return nil
+}
+
+// checkCgroup reports a breach recorded in the session's cgroup
+func (l *limiter) checkCgroup() *LimitError {
+	if l.limits.Memory > 0 {
+		if kills := readKeyedValue(filepath.Join(l.cgroup, "memory.events"), "oom_kill"); kills > 0 {
+			return &LimitError{Limit: LimitMemory, Detail: fmt.Sprintf("%d process(es) killed for exceeding %d bytes", kills, l.limits.Memory)}
+		}
+	}
+	if l.limits.CPUTime > 0 {
+		usage := time.Duration(readKeyedValue(filepath.Join(l.cgroup, "cpu.stat"), "usage_usec")) * time.Microsecond
+		if usage >= l.limits.CPUTime {
+			return &LimitError{Limit: LimitCPUTime, Detail: fmt.Sprintf("used %s of %s", usage.Round(time.Second), l.limits.CPUTime)}
+		}
+	}
+	return nil
+}
+
+// exited reports a breach that ended the process
+func (l *limiter) exited(state *os.ProcessState) *LimitError {
+	if state == nil {
+		return nil
+	}
+	if l.cgroup != "" {
+		return l.checkCgroup()
+	}
+
+	if l.limits.CPUTime > 0 {
+		if status, ok := state.Sys().(syscall.WaitStatus); ok && status.Signaled() && status.Signal() == syscall.SIGXCPU {
+			return &LimitError{Limit: LimitCPUTime, Detail: fmt.Sprintf("exceeded %s", l.limits.CPUTime)}
+		}
+		if used := state.UserTime() + state.SystemTime(); used >= l.limits.CPUTime {
+			return &LimitError{Limit: LimitCPUTime, Detail: fmt.Sprintf("used %s of %s", used.Round(time.Second), l.limits.CPUTime)}
+		}
+	}
+	return nil
+}
+
+// close stops anything left in the session's cgroup and removes it
+func (l *limiter) close() {
+	l.removeCgroup()
+}
+
+func (l *limiter) removeCgroup() {
+	if l.cgroupFD != nil {
+		_ = l.cgroupFD.Close()
+		l.cgroupFD = nil
+	}
+	if l.cgroup == "" {
+		return
+	}
+
+	// Processes that left the process group are still in the cgroup
+	_ = os.WriteFile(filepath.Join(l.cgroup, "cgroup.kill"), []byte("1"), 0)
+
+	var err error
+	for i := 0; i < 10; i++ {
+		if err = os.Remove(l.cgroup); err == nil || os.IsNotExist(err) {
+			l.cgroup = ""
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	l.logger.Warn("failed to remove cgroup", "cgroup", l.cgroup, "error", err)
+	l.cgroup = ""
+}
+
+// rlimit64 matches struct rlimit64 used by prlimit64(2)
+type rlimit64 struct {
+	cur uint64
+	max uint64
+}
+
+// prlimit sets a resource limit on another process
+func prlimit(pid int, resource int, cur, max uint64) error {
+	limit := rlimit64{cur: cur, max: max}
+	_, _, errno := syscall.RawSyscall6(syscall.SYS_PRLIMIT64,
+		uintptr(pid), uintptr(resource), uintptr(unsafe.Pointer(&limit)), 0, 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// groupUsage sums CPU time (including reaped children) and resident memory
+// of the processes in the process group led by pgid
+func groupUsage(pgid int) (time.Duration, int64) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0, 0
+	}
+
+	var ticks, pages int64
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(entry.Name()); err != nil {
+			continue
+		}
+		stat, err := os.ReadFile(filepath.Join("/proc", entry.Name(), "stat"))
+		if err != nil {
+			continue
+		}
+		group, cpu, rss, ok := parseProcStat(stat)
+		if !ok || group != pgid {
+			continue
+		}
+		ticks += cpu
+		pages += rss
+	}
+
+	return time.Duration(ticks) * time.Second / clockTicks, pages * int64(os.Getpagesize())
+}
+
+// parseProcStat extracts the process group, CPU ticks (utime, stime, cutime,
+// cstime) and resident pages from /proc/<pid>/stat
+func parseProcStat(stat []byte) (pgrp int, ticks int64, rss int64, ok bool) {
+	// The command name may contain spaces, so split after its closing paren
+	end := bytes.LastIndexByte(stat, ')')
+	if end < 0 {
+		return 0, 0, 0, false
+	}
+	// Fields from 3 (state) onwards
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 22 {
+		return 0, 0, 0, false
+	}
+
+	field := func(n int) int64 {
+		v, _ := strconv.ParseInt(fields[n-3], 10, 64)
+		return v
+	}
+	return int(field(5)), field(14) + field(15) + field(16) + field(17), field(24), true
+}
+
+// readKeyedValue reads the value for key from a cgroup "key value" file
+func readKeyedValue(path, key string) int64 {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		name, value, found := strings.Cut(scanner.Text(), " ")
+		if found && name == key {
+			v, _ := strconv.ParseInt(value, 10, 64)
+			return v
+		}
+	}
+	return 0
+}
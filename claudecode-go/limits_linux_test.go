@@ -0,0 +1,101 @@
+//go:build linux
+
+package claudecode_test
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/humanlayer/humanlayer/claudecode-go"
+)
+
+// waitForLimit launches script with limits and returns the LimitError it fails with
+func waitForLimit(t *testing.T, script string, limits claudecode.ResourceLimits) *claudecode.LimitError {
+	t.Helper()
+
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, script))
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:                "limited",
+		OutputFormat:         claudecode.OutputText,
+		InterruptGracePeriod: 100 * time.Millisecond,
+		TerminateGracePeriod: 100 * time.Millisecond,
+		Limits:               &limits,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := session.Wait()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		var limitErr *claudecode.LimitError
+		if !errors.As(err, &limitErr) {
+			t.Fatalf("expected LimitError, got %v", err)
+		}
+		return limitErr
+	case <-time.After(20 * time.Second):
+		_ = session.Kill()
+		t.Fatal("session was not stopped by its resource limit")
+		return nil
+	}
+}
+
+func TestResourceLimits_WallClock(t *testing.T) {
+	limitErr := waitForLimit(t, "exec sleep 30", claudecode.ResourceLimits{WallClock: 200 * time.Millisecond})
+	if limitErr.Limit != claudecode.LimitWallClock {
+		t.Errorf("expected wall clock breach, got %s", limitErr.Limit)
+	}
+}
+
+func TestResourceLimits_CPUTime(t *testing.T) {
+	limitErr := waitForLimit(t, "while :; do :; done", claudecode.ResourceLimits{CPUTime: time.Second})
+	if limitErr.Limit != claudecode.LimitCPUTime {
+		t.Errorf("expected cpu time breach, got %s", limitErr.Limit)
+	}
+}
+
+func TestResourceLimits_Memory(t *testing.T) {
+	// Re-run this test binary as a child that holds 48MB
+	script := "CLAUDECODE_TEST_ALLOCATE=48 exec " + os.Args[0] + " -test.run=TestHelperAllocate"
+	limitErr := waitForLimit(t, script, claudecode.ResourceLimits{Memory: 32 << 20})
+	if limitErr.Limit != claudecode.LimitMemory {
+		t.Errorf("expected memory breach, got %s", limitErr.Limit)
+	}
+}
+
+// TestHelperAllocate is not a real test: it allocates memory and sleeps when
+// run as a child process by TestResourceLimits_Memory
+func TestHelperAllocate(t *testing.T) {
+	megabytes, err := strconv.Atoi(os.Getenv("CLAUDECODE_TEST_ALLOCATE"))
+	if err != nil {
+		t.Skip("helper process")
+	}
+
+	buf := make([]byte, megabytes<<20)
+	for i := range buf {
+		buf[i] = 1
+	}
+	time.Sleep(30 * time.Second)
+	runtime.KeepAlive(buf)
+}
+
+func TestResourceLimits_Validate(t *testing.T) {
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, "exit 0"))
+
+	_, err := client.Launch(claudecode.SessionConfig{
+		Query:  "bad",
+		Limits: &claudecode.ResourceLimits{CPUTime: 500 * time.Millisecond},
+	})
+	if err == nil {
+		t.Fatal("expected an error for a sub-second cpu time limit")
+	}
+}
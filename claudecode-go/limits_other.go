@@ -0,0 +1,31 @@
+//go:build !linux
+
+package claudecode
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// limiter only supports wall clock limits outside Linux
+type limiter struct{}
+
+func newLimiter(limits ResourceLimits, logger *slog.Logger) (*limiter, error) {
+	if limits.CPUTime > 0 || limits.Memory > 0 || limits.OpenFiles > 0 {
+		return nil, fmt.Errorf("cpu, memory and open file limits are not supported on %s", runtime.GOOS)
+	}
+	return &limiter{}, nil
+}
+
+func (l *limiter) prepare(cmd *exec.Cmd) {}
+
+func (l *limiter) start(pid int) error { return nil }
+
+func (l *limiter) check() *LimitError { return nil }
+
+func (l *limiter) exited(state *os.ProcessState) *LimitError { return nil }
+
+func (l *limiter) close() {}
@@ -0,0 +1,115 @@
+package claudecode
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// redacted replaces secret values in log output
+const redacted = "[REDACTED]"
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithLogger sets the logger used by the client and its sessions. Without it,
+// slog.Default() is used.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithRedaction controls whether environment variable and header values are
+// redacted from log output. Redaction is enabled by default.
+func WithRedaction(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.redact = enabled
+	}
+}
+
+// log returns the client's logger
+func (c *Client) log() *slog.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return slog.Default()
+}
+
+// redactMCPConfig returns a copy of config with env and header values redacted
+func redactMCPConfig(config *MCPConfig) *MCPConfig {
+	if config == nil {
+		return nil
+	}
+	result := &MCPConfig{MCPServers: make(map[string]MCPServer, len(config.MCPServers))}
+	for name, server := range config.MCPServers {
+		server.Env = redactValues(server.Env)
+		server.Headers = redactValues(server.Headers)
+		result.MCPServers[name] = server
+	}
+	return result
+}
+
+// redactValues returns a copy of values with every value redacted
+func redactValues(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	result := make(map[string]string, len(values))
+	for key := range values {
+		result[key] = redacted
+	}
+	return result
+}
+
+// redactArgs returns a copy of args with inline settings JSON redacted, since
+// its "env" section commonly holds API keys
+func redactArgs(args []string) []string {
+	result := make([]string, len(args))
+	copy(result, args)
+	for i := 0; i < len(result)-1; i++ {
+		if result[i] == "--settings" && strings.HasPrefix(strings.TrimSpace(result[i+1]), "{") {
+			result[i+1] = redacted
+		}
+	}
+	return result
+}
+
+// envKeys returns the names of the environment variables in env
+func envKeys(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// readStderr copies claude's stderr into buf, logging each line as it arrives
+func (s *Session) readStderr(stderr io.Reader, buf *strings.Builder) error {
+	reader := bufio.NewReader(stderr)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			buf.WriteString(line)
+			if text := strings.TrimRight(line, "\r\n"); text != "" {
+				s.log().Warn("claude stderr", "line", text)
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) || isClosedPipeError(err) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// log returns the session's logger
+func (s *Session) log() *slog.Logger {
+	if s.logger != nil {
+		return s.logger
+	}
+	return slog.Default()
+}
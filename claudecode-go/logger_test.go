@@ -0,0 +1,108 @@
+package claudecode_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/humanlayer/humanlayer/claudecode-go"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent log writes
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// records decodes the JSON log records written so far
+func (b *syncBuffer) records(t *testing.T) []map[string]interface{} {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid log record %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func launchWithLogger(t *testing.T, script string, opts ...claudecode.ClientOption) *syncBuffer {
+	t.Helper()
+	logs := &syncBuffer{}
+	logger := slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, script), append([]claudecode.ClientOption{claudecode.WithLogger(logger)}, opts...)...)
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:        "hello",
+		OutputFormat: claudecode.OutputStreamJSON,
+		Env:          map[string]string{"ANTHROPIC_API_KEY": "sk-env-secret"},
+		MCPConfig: &claudecode.MCPConfig{
+			MCPServers: map[string]claudecode.MCPServer{
+				"stdio": {Command: "server", Env: map[string]string{"TOKEN": "stdio-secret"}},
+				"http":  {Type: "http", URL: "http://localhost", Headers: map[string]string{"Authorization": "Bearer header-secret"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Launch failed: %v", err)
+	}
+	_, _ = session.Wait()
+	return logs
+}
+
+func TestWithLogger_RedactsSecrets(t *testing.T) {
+	logs := launchWithLogger(t, "exit 0")
+
+	output := logs.String()
+	for _, secret := range []string{"sk-env-secret", "stdio-secret", "header-secret"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("log output contains secret %q:\n%s", secret, output)
+		}
+	}
+	if !strings.Contains(output, "[REDACTED]") {
+		t.Errorf("expected redacted MCP config in log output:\n%s", output)
+	}
+
+	unredacted := launchWithLogger(t, "exit 0", claudecode.WithRedaction(false))
+	if !strings.Contains(unredacted.String(), "stdio-secret") {
+		t.Error("expected MCP config values in log output with redaction disabled")
+	}
+}
+
+func TestWithLogger_StderrRecords(t *testing.T) {
+	logs := launchWithLogger(t, "echo 'first problem' >&2\necho 'second problem' >&2\nexit 1")
+
+	var lines []string
+	for _, record := range logs.records(t) {
+		if record["msg"] != "claude stderr" {
+			continue
+		}
+		if record["level"] != "WARN" || record["pid"] == nil {
+			t.Errorf("unexpected stderr record: %v", record)
+		}
+		lines = append(lines, record["line"].(string))
+	}
+	if strings.Join(lines, "|") != "first problem|second problem" {
+		t.Errorf("expected one record per stderr line, got %q", lines)
+	}
+}
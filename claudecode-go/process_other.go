@@ -0,0 +1,29 @@
+//go:build !unix
+
+package claudecode
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup is a no-op on platforms without process groups
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// signalGroup signals only the process itself on platforms without process groups
+func signalGroup(pid int, sig syscall.Signal) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	if sig == syscall.SIGKILL {
+		return process.Kill()
+	}
+	return process.Signal(sig)
+}
+
+// groupAlive cannot be determined without process groups
+func groupAlive(pid int) bool {
+	return false
+}
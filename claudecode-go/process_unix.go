@@ -0,0 +1,32 @@
+//go:build unix
+
+package claudecode
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts cmd as the leader of a new process group, so that
+// signals reach MCP servers and tool subprocesses spawned by claude
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// signalGroup sends sig to every process in the group led by pid.
+// A group with no remaining members is not an error.
+func signalGroup(pid int, sig syscall.Signal) error {
+	if err := syscall.Kill(-pid, sig); err != nil && !errors.Is(err, syscall.ESRCH) {
+		return err
+	}
+	return nil
+}
+
+// groupAlive reports whether any process in the group led by pid is still running
+func groupAlive(pid int) bool {
+	return syscall.Kill(-pid, 0) == nil
+}
@@ -0,0 +1,405 @@
+package claudecode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// OutputValidationError is returned when a session's final result does not
+// match SessionConfig.OutputSchema
+type OutputValidationError struct {
+	Problems []string // One entry per violation, e.g. "$.items[0].id: expected integer, got string"
+}
+
+func (e *OutputValidationError) Error() string {
+	return "result does not match output schema: " + strings.Join(e.Problems, "; ")
+}
+
+// outputSchemaInstructions is appended to the system prompt when an output schema is set
+const outputSchemaInstructions = `When you have finished the task, your final response must be a single JSON document that matches the following JSON Schema. Reply with the JSON only: no explanation, no Markdown and no code fences.
+
+JSON Schema:
+%s`
+
+// outputSchemaPrompt returns the system prompt addition describing schema
+func outputSchemaPrompt(schema json.RawMessage) string {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, schema, "", "  "); err != nil {
+		return fmt.Sprintf(outputSchemaInstructions, schema)
+	}
+	return fmt.Sprintf(outputSchemaInstructions, indented.String())
+}
+
+// RepairPrompt returns the query for a turn asking claude to fix a result that
+// failed validation
+func RepairPrompt(err *OutputValidationError) string {
+	var b strings.Builder
+	b.WriteString("Your final response did not match the required JSON Schema:\n")
+	for _, problem := range err.Problems {
+		b.WriteString("- ")
+		b.WriteString(problem)
+		b.WriteString("\n")
+	}
+	b.WriteString("\nReply with only the corrected JSON document, with no other text.")
+	return b.String()
+}
+
+// RepairConfig returns the config for a repair turn that resumes the Claude
+// session claudeSessionID with RepairPrompt, using one of config's repair turns
+func RepairConfig(config SessionConfig, claudeSessionID string, err *OutputValidationError) SessionConfig {
+	repair := config
+	repair.Query = RepairPrompt(err)
+	repair.SessionID = claudeSessionID
+	repair.Continue = false
+	repair.ForkSession = false
+	if repair.OutputSchemaRepairTurns > 0 {
+		repair.OutputSchemaRepairTurns--
+	}
+	return repair
+}
+
+// ValidateOutput extracts the JSON document from a final result and validates
+// it against schema, returning the document on success. A Markdown code fence
+// or text around a single JSON object or array is tolerated. Validation
+// failures are returned as *OutputValidationError.
+func ValidateOutput(schema json.RawMessage, result string) (json.RawMessage, error) {
+	compiled, err := parseSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := extractJSON(result)
+	if doc == nil {
+		return nil, &OutputValidationError{Problems: []string{"$: result is not valid JSON"}}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(doc, &value); err != nil {
+		return nil, &OutputValidationError{Problems: []string{"$: result is not valid JSON"}}
+	}
+
+	v := &schemaValidator{}
+	v.validate(compiled, value, "$")
+	if len(v.problems) > 0 {
+		return nil, &OutputValidationError{Problems: v.problems}
+	}
+	return doc, nil
+}
+
+// parseSchema decodes a JSON Schema, rejecting keywords the validator does not support
+func parseSchema(schema json.RawMessage) (interface{}, error) {
+	var decoded interface{}
+	if err := json.Unmarshal(schema, &decoded); err != nil {
+		return nil, fmt.Errorf("invalid output schema: %w", err)
+	}
+	switch decoded.(type) {
+	case map[string]interface{}, bool:
+	default:
+		return nil, fmt.Errorf("invalid output schema: must be a JSON object")
+	}
+	if err := checkSchema(decoded); err != nil {
+		return nil, fmt.Errorf("invalid output schema: %w", err)
+	}
+	return decoded, nil
+}
+
+// checkSchema rejects schemas using $ref or invalid patterns
+func checkSchema(schema interface{}) error {
+	switch s := schema.(type) {
+	case map[string]interface{}:
+		for key, value := range s {
+			switch key {
+			case "$ref", "$dynamicRef":
+				return fmt.Errorf("%s is not supported", key)
+			case "pattern":
+				if pattern, ok := value.(string); ok {
+					if _, err := regexp.Compile(pattern); err != nil {
+						return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+					}
+				}
+			}
+			if err := checkSchema(value); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range s {
+			if err := checkSchema(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// extractJSON returns the JSON document in a result, or nil if there is none
+func extractJSON(result string) []byte {
+	text := strings.TrimSpace(result)
+
+	// Strip a Markdown code fence
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```")
+		if newline := strings.IndexByte(text, '\n'); newline >= 0 {
+			text = text[newline+1:]
+		}
+		text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "```"))
+	}
+	if json.Valid([]byte(text)) {
+		return []byte(text)
+	}
+
+	// Fall back to the outermost object or array in surrounding prose
+	for _, delims := range [][2]string{{"{", "}"}, {"[", "]"}} {
+		start := strings.Index(text, delims[0])
+		end := strings.LastIndex(text, delims[1])
+		if start >= 0 && end > start && json.Valid([]byte(text[start:end+1])) {
+			return []byte(text[start : end+1])
+		}
+	}
+	return nil
+}
+
+// schemaValidator validates decoded JSON against a decoded JSON Schema. It
+// supports the keywords commonly used for structured output: type, enum,
+// const, properties, required, additionalProperties, items, minItems,
+// maxItems, minLength, maxLength, pattern, minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, allOf, anyOf and oneOf.
+type schemaValidator struct {
+	problems []string
+}
+
+func (v *schemaValidator) fail(path, format string, args ...interface{}) {
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+// matches reports whether value is valid against schema without recording problems
+func matches(schema, value interface{}, path string) bool {
+	sub := &schemaValidator{}
+	sub.validate(schema, value, path)
+	return len(sub.problems) == 0
+}
+
+func (v *schemaValidator) validate(schema, value interface{}, path string) {
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		if allowed, isBool := schema.(bool); isBool && !allowed {
+			v.fail(path, "no value is allowed")
+		}
+		return
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(t, value) {
+		v.fail(path, "expected %s, got %s", describeType(t), jsonType(value))
+		return
+	}
+
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, candidate := range enum {
+			if jsonEqual(candidate, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "must be one of %s", compactJSON(enum))
+		}
+	}
+	if constant, ok := s["const"]; ok && !jsonEqual(constant, value) {
+		v.fail(path, "must be %s", compactJSON(constant))
+	}
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(s, val, path)
+	case []interface{}:
+		v.validateArray(s, val, path)
+	case string:
+		v.validateString(s, val, path)
+	case float64:
+		v.validateNumber(s, val, path)
+	}
+
+	if all, ok := s["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			v.validate(sub, value, path)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]interface{}); ok {
+		matched := false
+		for _, sub := range anyOf {
+			if matches(sub, value, path) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.fail(path, "does not match any schema in anyOf")
+		}
+	}
+	if oneOf, ok := s["oneOf"].([]interface{}); ok {
+		count := 0
+		for _, sub := range oneOf {
+			if matches(sub, value, path) {
+				count++
+			}
+		}
+		if count != 1 {
+			v.fail(path, "must match exactly one schema in oneOf, matched %d", count)
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(s map[string]interface{}, obj map[string]interface{}, path string) {
+	if required, ok := s["required"].([]interface{}); ok {
+		for _, name := range required {
+			if key, ok := name.(string); ok {
+				if _, present := obj[key]; !present {
+					v.fail(path, "missing required property %q", key)
+				}
+			}
+		}
+	}
+
+	properties, _ := s["properties"].(map[string]interface{})
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := path + "." + key
+		if propSchema, ok := properties[key]; ok {
+			v.validate(propSchema, obj[key], childPath)
+			continue
+		}
+		switch additional := s["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(path, "unexpected property %q", key)
+			}
+		case map[string]interface{}:
+			v.validate(additional, obj[key], childPath)
+		}
+	}
+}
+
+func (v *schemaValidator) validateArray(s map[string]interface{}, arr []interface{}, path string) {
+	if limit, ok := s["minItems"].(float64); ok && float64(len(arr)) < limit {
+		v.fail(path, "must have at least %v items, got %d", limit, len(arr))
+	}
+	if limit, ok := s["maxItems"].(float64); ok && float64(len(arr)) > limit {
+		v.fail(path, "must have at most %v items, got %d", limit, len(arr))
+	}
+	if items, ok := s["items"]; ok {
+		for i, item := range arr {
+			v.validate(items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+func (v *schemaValidator) validateString(s map[string]interface{}, str string, path string) {
+	length := float64(len([]rune(str)))
+	if limit, ok := s["minLength"].(float64); ok && length < limit {
+		v.fail(path, "must be at least %v characters", limit)
+	}
+	if limit, ok := s["maxLength"].(float64); ok && length > limit {
+		v.fail(path, "must be at most %v characters", limit)
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		// Patterns were compiled by checkSchema
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(str) {
+			v.fail(path, "must match pattern %q", pattern)
+		}
+	}
+}
+
+func (v *schemaValidator) validateNumber(s map[string]interface{}, n float64, path string) {
+	if limit, ok := s["minimum"].(float64); ok && n < limit {
+		v.fail(path, "must be >= %v", limit)
+	}
+	if limit, ok := s["maximum"].(float64); ok && n > limit {
+		v.fail(path, "must be <= %v", limit)
+	}
+	if limit, ok := s["exclusiveMinimum"].(float64); ok && n <= limit {
+		v.fail(path, "must be > %v", limit)
+	}
+	if limit, ok := s["exclusiveMaximum"].(float64); ok && n >= limit {
+		v.fail(path, "must be < %v", limit)
+	}
+}
+
+// matchesType reports whether value has the type (or one of the types) t names
+func matchesType(t interface{}, value interface{}) bool {
+	switch types := t.(type) {
+	case string:
+		return isType(types, value)
+	case []interface{}:
+		for _, name := range types {
+			if s, ok := name.(string); ok && isType(s, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func isType(name string, value interface{}) bool {
+	actual := jsonType(value)
+	if name == "number" && actual == "integer" {
+		return true
+	}
+	return name == actual
+}
+
+// jsonType returns the JSON Schema type name of a decoded value
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func describeType(t interface{}) string {
+	if types, ok := t.([]interface{}); ok {
+		names := make([]string, 0, len(types))
+		for _, name := range types {
+			names = append(names, fmt.Sprint(name))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func jsonEqual(a, b interface{}) bool {
+	return compactJSON(a) == compactJSON(b)
+}
+
+func compactJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,117 @@
+package claudecode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testOutputSchema = `{
+	"type": "object",
+	"required": ["name", "tags"],
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "minLength": 1},
+		"count": {"type": "integer", "minimum": 0},
+		"status": {"enum": ["ok", "failed"]},
+		"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+		"id": {"anyOf": [{"type": "string", "pattern": "^T-[0-9]+$"}, {"type": "null"}]}
+	}
+}`
+
+func TestValidateOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   string
+		want     string
+		problems []string
+	}{
+		{
+			name:   "valid",
+			result: `{"name":"a","count":2,"status":"ok","tags":["x"],"id":"T-1"}`,
+			want:   `{"name":"a","count":2,"status":"ok","tags":["x"],"id":"T-1"}`,
+		},
+		{
+			name:   "code fence",
+			result: "```json\n{\"name\":\"a\",\"tags\":[]}\n```",
+			want:   `{"name":"a","tags":[]}`,
+		},
+		{
+			name:   "surrounding prose",
+			result: "Here you go:\n{\"name\":\"a\",\"tags\":[]}\nDone.",
+			want:   `{"name":"a","tags":[]}`,
+		},
+		{
+			name:     "not JSON",
+			result:   "I could not finish the task.",
+			problems: []string{"$: result is not valid JSON"},
+		},
+		{
+			name:     "wrong root type",
+			result:   `["a"]`,
+			problems: []string{"$: expected object, got array"},
+		},
+		{
+			name:   "violations",
+			result: `{"name":"","count":1.5,"status":"maybe","tags":["x",1,"z"],"id":"X","extra":true}`,
+			problems: []string{
+				`$.count: expected integer, got number`,
+				`$: unexpected property "extra"`,
+				`$.id: does not match any schema in anyOf`,
+				`$.name: must be at least 1 characters`,
+				`$.status: must be one of ["ok","failed"]`,
+				`$.tags: must have at most 2 items, got 3`,
+				`$.tags[1]: expected string, got integer`,
+			},
+		},
+		{
+			name:     "missing required",
+			result:   `{"name":"a"}`,
+			problems: []string{`$: missing required property "tags"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateOutput([]byte(testOutputSchema), tt.result)
+			if tt.problems == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if string(got) != tt.want {
+					t.Errorf("expected %s, got %s", tt.want, got)
+				}
+				return
+			}
+
+			var validationErr *OutputValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected OutputValidationError, got %v", err)
+			}
+			if strings.Join(validationErr.Problems, "\n") != strings.Join(tt.problems, "\n") {
+				t.Errorf("unexpected problems:\n%s\nwant:\n%s",
+					strings.Join(validationErr.Problems, "\n"), strings.Join(tt.problems, "\n"))
+			}
+		})
+	}
+}
+
+func TestRepairConfig(t *testing.T) {
+	config := SessionConfig{
+		Query:                   "summarize",
+		Continue:                true,
+		OutputSchema:            []byte(testOutputSchema),
+		OutputSchemaRepairTurns: 2,
+	}
+	repair := RepairConfig(config, "claude-1", &OutputValidationError{Problems: []string{`$: missing required property "tags"`}})
+
+	if repair.SessionID != "claude-1" || repair.Continue {
+		t.Errorf("expected repair to resume claude-1, got session %q continue %v", repair.SessionID, repair.Continue)
+	}
+	if repair.OutputSchemaRepairTurns != 1 {
+		t.Errorf("expected one repair turn left, got %d", repair.OutputSchemaRepairTurns)
+	}
+	if !strings.Contains(repair.Query, `- $: missing required property "tags"`) {
+		t.Errorf("expected problems in repair prompt, got %q", repair.Query)
+	}
+}
@@ -3,20 +3,53 @@ package claudecode
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
 	"os/exec"
 	"strings"
 	"sync"
 	"time"
 )
 
-// Model represents the available Claude models
+// Model represents the available Claude models. Besides these aliases, any
+// full model name (e.g. "claude-sonnet-4-5-20250929") is accepted.
 type Model string
 
 const (
-	ModelOpus   Model = "opus"
-	ModelSonnet Model = "sonnet"
+	ModelOpus     Model = "opus"
+	ModelSonnet   Model = "sonnet"
+	ModelHaiku    Model = "haiku"
+	ModelOpusPlan Model = "opusplan" // Opus in plan mode, Sonnet otherwise
+	ModelDefault  Model = "default"  // The account's default model
 )
 
+// PermissionMode controls how Claude asks for permission to use tools
+type PermissionMode string
+
+const (
+	PermissionModeDefault           PermissionMode = "default"
+	PermissionModeAcceptEdits       PermissionMode = "acceptEdits"
+	PermissionModePlan              PermissionMode = "plan"
+	PermissionModeBypassPermissions PermissionMode = "bypassPermissions"
+)
+
+// SettingSource is a settings location Claude loads settings from
+type SettingSource string
+
+const (
+	SettingSourceUser    SettingSource = "user"
+	SettingSourceProject SettingSource = "project"
+	SettingSourceLocal   SettingSource = "local"
+)
+
+// DefaultInterruptGracePeriod is how long a session is given to exit after
+// SIGINT before it is sent SIGTERM, when it is terminated
+const DefaultInterruptGracePeriod = 5 * time.Second
+
+// DefaultTerminateGracePeriod is how long a session is given to exit after
+// SIGTERM before it is sent SIGKILL, when it is terminated
+const DefaultTerminateGracePeriod = 3 * time.Second
+
 // OutputFormat specifies the output format for Claude CLI
 type OutputFormat string
 
@@ -26,6 +59,24 @@ const (
 	OutputStreamJSON OutputFormat = "stream-json"
 )
 
+// InputFormat specifies the input format for Claude CLI
+type InputFormat string
+
+const (
+	InputText       InputFormat = "text"
+	InputStreamJSON InputFormat = "stream-json" // Keeps stdin open for additional user turns
+)
+
+// Validate checks that the input format is known; empty selects the default
+func (f InputFormat) Validate() error {
+	switch f {
+	case "", InputText, InputStreamJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid input_format %q (must be %q or %q)", string(f), InputText, InputStreamJSON)
+	}
+}
+
 // MCPServer represents a single MCP server configuration
 // It can be either a stdio-based server (with command/args/env) or an HTTP server (with type/url/headers)
 type MCPServer struct {
@@ -51,11 +102,18 @@ type SessionConfig struct {
 	Query string
 
 	// Session management
-	SessionID string // If set, resumes this session
+	SessionID   string // If set, resumes this session
+	Continue    bool   // Continue the most recent session in WorkingDir (cannot be combined with SessionID)
+	ForkSession bool   // When resuming or continuing, start a new session ID instead of reusing the original
 
 	// Optional
 	Model                 Model
+	FallbackModel         Model           // Model to use when Model is overloaded
+	PermissionMode        PermissionMode  // Permission mode for the session
+	Settings              string          // Path to a settings file, or a settings JSON string
+	SettingSources        []SettingSource // Settings locations to load (all when empty)
 	OutputFormat          OutputFormat
+	InputFormat           InputFormat // InputStreamJSON keeps the process alive for SendMessage (requires OutputStreamJSON)
 	MCPConfig             *MCPConfig
 	PermissionPromptTool  string
 	WorkingDir            string
@@ -68,6 +126,42 @@ type SessionConfig struct {
 	CustomInstructions    string
 	Verbose               bool
 	Env                   map[string]string // Environment variables to set for the Claude process
+	InterruptGracePeriod  time.Duration     // Time between SIGINT and SIGTERM when the session is terminated (default 5s)
+	TerminateGracePeriod  time.Duration     // Time between SIGTERM and SIGKILL when the session is terminated (default 3s)
+	Limits                *ResourceLimits   // Optional resource limits for the session's processes
+
+	// IncludePartialMessages emits PartialMessage events while a message is
+	// being generated. Requires OutputStreamJSON.
+	IncludePartialMessages bool
+
+	// OutputSchema is a JSON Schema the final result must match. Instructions
+	// are appended to the system prompt, and LaunchAndWait validates the result
+	// and stores the parsed document in Result.StructuredOutput.
+	OutputSchema json.RawMessage
+	// OutputSchemaRepairTurns is how many times LaunchAndWait resumes the
+	// session to ask for a corrected result when validation fails.
+	OutputSchemaRepairTurns int
+
+	// ExtraArgs are passed to claude as-is, after the query. Flags the client
+	// manages itself (such as --print, --output-format or --resume) are rejected,
+	// as is --dangerously-skip-permissions (use PermissionModeBypassPermissions).
+	ExtraArgs []string
+}
+
+// userInputMessage is a user turn written to stdin in stream-json input mode
+type userInputMessage struct {
+	Type    string           `json:"type"`
+	Message userInputContent `json:"message"`
+}
+
+type userInputContent struct {
+	Role    string          `json:"role"`
+	Content []userInputText `json:"content"`
+}
+
+type userInputText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
 }
 
 // StreamEvent represents a single event from the streaming JSON output
@@ -99,6 +193,24 @@ type StreamEvent struct {
 	Error             string             `json:"error,omitempty"`
 	PermissionDenials *PermissionDenials `json:"permission_denials,omitempty"`
 	UUID              string             `json:"uuid,omitempty"`
+
+	// Partial message event fields (when type="stream_event")
+	Event *PartialEvent `json:"event,omitempty"`
+
+	// Typed is the decoded variant of this event, set by the stream parser
+	Typed Event `json:"-"`
+}
+
+// AsEvent returns the typed variant of the event, decoding it if the stream parser did not
+func (e StreamEvent) AsEvent() (Event, error) {
+	if e.Typed != nil {
+		return e.Typed, nil
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return Decode(data)
 }
 
 // MCPStatus represents the status of an MCP server
@@ -120,6 +232,10 @@ type Message struct {
 // ContentField handles both string and array content formats
 type ContentField struct {
 	Value string
+
+	// Blocks holds the non-text blocks of array content, such as images
+	// returned by browser tools
+	Blocks []Content
 }
 
 // UnmarshalJSON implements custom unmarshaling to handle both string and array formats
@@ -132,31 +248,48 @@ func (c *ContentField) UnmarshalJSON(data []byte) error {
 	}
 
 	// If that fails, try array format
-	var arr []struct {
-		Type string `json:"type"`
-		Text string `json:"text"`
-	}
+	var arr []Content
 	if err := json.Unmarshal(data, &arr); err == nil {
-		// Concatenate all text elements
+		// Concatenate all text elements and keep everything else as blocks
 		var texts []string
 		for _, item := range arr {
-			if item.Type == "text" && item.Text != "" {
-				texts = append(texts, item.Text)
+			if item.Type == "text" {
+				if item.Text != "" {
+					texts = append(texts, item.Text)
+				}
+				continue
 			}
+			c.Blocks = append(c.Blocks, item)
 		}
 		c.Value = strings.Join(texts, "\n")
 		return nil
 	}
 
+	// Server tool results report errors as a single typed block
+	var block Content
+	if err := json.Unmarshal(data, &block); err == nil && block.Type != "" {
+		c.Blocks = []Content{block}
+		return nil
+	}
+
 	return fmt.Errorf("content field is neither string nor array format")
 }
 
-// MarshalJSON implements custom marshaling to always output as string
+// MarshalJSON implements custom marshaling to output a string, or an array
+// when there are non-text blocks
 func (c ContentField) MarshalJSON() ([]byte, error) {
-	return json.Marshal(c.Value)
+	if len(c.Blocks) == 0 {
+		return json.Marshal(c.Value)
+	}
+
+	arr := make([]Content, 0, len(c.Blocks)+1)
+	if c.Value != "" {
+		arr = append(arr, Content{Type: "text", Text: c.Value})
+	}
+	return json.Marshal(append(arr, c.Blocks...))
 }
 
-// Content can be text or tool use
+// Content can be text, thinking, tool use, a tool result or a media block
 type Content struct {
 	Type      string                 `json:"type"`
 	Text      string                 `json:"text,omitempty"`
@@ -166,8 +299,35 @@ type Content struct {
 	Input     map[string]interface{} `json:"input,omitempty"`
 	ToolUseID string                 `json:"tool_use_id,omitempty"`
 	Content   ContentField           `json:"content,omitempty"`
+
+	// Image and document blocks
+	Source *ContentSource `json:"source,omitempty"`
+	Title  string         `json:"title,omitempty"`
+
+	// Encrypted reasoning in redacted_thinking blocks
+	Data string `json:"data,omitempty"`
+
+	// Web search result blocks
+	URL string `json:"url,omitempty"`
 }
 
+// ContentSource holds the payload of an image or document block
+type ContentSource struct {
+	Type      string `json:"type"` // base64, url or text
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+// Content block types beyond text, thinking, tool_use and tool_result
+const (
+	ContentTypeImage               = "image"
+	ContentTypeDocument            = "document"
+	ContentTypeRedactedThinking    = "redacted_thinking"
+	ContentTypeServerToolUse       = "server_tool_use"
+	ContentTypeWebSearchToolResult = "web_search_tool_result"
+)
+
 // ServerToolUse tracks server-side tool usage
 type ServerToolUse struct {
 	WebSearchRequests int `json:"web_search_requests,omitempty"`
@@ -255,6 +415,9 @@ type Result struct {
 	Error             string             `json:"error,omitempty"`
 	PermissionDenials *PermissionDenials `json:"permission_denials,omitempty"`
 	UUID              string             `json:"uuid,omitempty"`
+
+	// StructuredOutput is the result parsed against SessionConfig.OutputSchema
+	StructuredOutput json.RawMessage `json:"structured_output,omitempty"`
 }
 
 // Session represents an active Claude session
@@ -267,13 +430,26 @@ type Session struct {
 	Events chan StreamEvent
 
 	// Process management
-	cmd    *exec.Cmd
-	done   chan struct{}
-	result *Result
+	cmd           *exec.Cmd
+	done          chan struct{}
+	result        *Result
+	interruptedAt time.Time // When SIGINT was last sent, guarded by mu
+
+	// Stream-json input (nil unless launched with InputStreamJSON)
+	stdin       io.WriteCloser
+	stdinMu     sync.Mutex
+	inputClosed bool
 
 	// Thread-safe error handling
 	mu  sync.RWMutex
 	err error
+
+	logger *slog.Logger
+
+	// Event delivery when Events is full
+	overflow OverflowPolicy
+	spill    *spillQueue
+	dropped  uint64 // Accessed atomically
 }
 
 // SetError safely sets the error
@@ -129,6 +129,78 @@ func TestContentFieldMarshal(t *testing.T) {
 	}
 }
 
+func TestContentFieldBlocks(t *testing.T) {
+	input := `[
+		{"type": "text", "text": "Took a screenshot"},
+		{"type": "image", "source": {"type": "base64", "media_type": "image/png", "data": "iVBORw0KGgo="}}
+	]`
+
+	var c ContentField
+	if err := json.Unmarshal([]byte(input), &c); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if c.Value != "Took a screenshot" {
+		t.Errorf("Value = %q, want text only", c.Value)
+	}
+	if len(c.Blocks) != 1 || c.Blocks[0].Type != ContentTypeImage {
+		t.Fatalf("expected one image block, got %+v", c.Blocks)
+	}
+	if src := c.Blocks[0].Source; src == nil || src.MediaType != "image/png" || src.Data != "iVBORw0KGgo=" {
+		t.Errorf("unexpected image source: %+v", src)
+	}
+
+	// Blocks survive a round trip
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	var roundTrip ContentField
+	if err := json.Unmarshal(data, &roundTrip); err != nil {
+		t.Fatalf("unmarshal of %s failed: %v", data, err)
+	}
+	if roundTrip.Value != c.Value || len(roundTrip.Blocks) != 1 || roundTrip.Blocks[0].Source.Data != "iVBORw0KGgo=" {
+		t.Errorf("round trip lost content: %s", data)
+	}
+
+	// Server tool errors arrive as a single object
+	var errField ContentField
+	if err := json.Unmarshal([]byte(`{"type": "web_search_tool_result_error", "error_code": "max_uses_exceeded"}`), &errField); err != nil {
+		t.Fatalf("unmarshal of error object failed: %v", err)
+	}
+	if len(errField.Blocks) != 1 || errField.Blocks[0].Type != "web_search_tool_result_error" {
+		t.Errorf("expected error block, got %+v", errField.Blocks)
+	}
+}
+
+func TestContentRichBlocks(t *testing.T) {
+	input := `[
+		{"type": "redacted_thinking", "data": "EmwKAhgBEgy3va3pzix"},
+		{"type": "document", "title": "spec.pdf", "source": {"type": "base64", "media_type": "application/pdf", "data": "JVBERi0="}},
+		{"type": "server_tool_use", "id": "srvtoolu_1", "name": "web_search", "input": {"query": "go slog"}},
+		{"type": "web_search_tool_result", "tool_use_id": "srvtoolu_1", "content": [
+			{"type": "web_search_result", "title": "slog package", "url": "https://pkg.go.dev/log/slog"}
+		]}
+	]`
+
+	var blocks []Content
+	if err := json.Unmarshal([]byte(input), &blocks); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if blocks[0].Type != ContentTypeRedactedThinking || blocks[0].Data != "EmwKAhgBEgy3va3pzix" {
+		t.Errorf("unexpected redacted thinking block: %+v", blocks[0])
+	}
+	if blocks[1].Title != "spec.pdf" || blocks[1].Source == nil || blocks[1].Source.MediaType != "application/pdf" {
+		t.Errorf("unexpected document block: %+v", blocks[1])
+	}
+	if blocks[2].Type != ContentTypeServerToolUse || blocks[2].Name != "web_search" || blocks[2].Input["query"] != "go slog" {
+		t.Errorf("unexpected server tool use block: %+v", blocks[2])
+	}
+	results := blocks[3].Content.Blocks
+	if blocks[3].ToolUseID != "srvtoolu_1" || len(results) != 1 || results[0].URL != "https://pkg.go.dev/log/slog" {
+		t.Errorf("unexpected web search result block: %+v", blocks[3])
+	}
+}
+
 func TestContentStructWithContentField(t *testing.T) {
 	// Test the full Content struct with ContentField
 	tests := []struct {
@@ -0,0 +1,170 @@
+package claudecode
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// versionProbeTimeout bounds how long `claude --version` may take
+const versionProbeTimeout = 5 * time.Second
+
+// Version is a claude CLI version
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// versionPattern matches the version in `claude --version` output, e.g. "1.0.110 (Claude Code)"
+var versionPattern = regexp.MustCompile(`v?(\d+)\.(\d+)\.(\d+)`)
+
+// ParseVersion extracts a version from `claude --version` output
+func ParseVersion(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("no version found in %q", s)
+	}
+	var v Version
+	for i, field := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %w", m[0], err)
+		}
+		*field = n
+	}
+	return v, nil
+}
+
+// String returns the version as major.minor.patch
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// IsZero reports whether the version is unknown
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// Less reports whether v is older than other
+func (v Version) Less(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+// flagMinVersions lists the first claude release supporting each gated flag
+var flagMinVersions = map[string]Version{
+	"--permission-prompt-tool":   {1, 0, 0},
+	"--add-dir":                  {1, 0, 18},
+	"--include-partial-messages": {1, 0, 86},
+	"--setting-sources":          {2, 0, 0},
+}
+
+// Capabilities describes which optional features the claude binary supports.
+// When the version is unknown every feature is assumed to be supported.
+type Capabilities struct {
+	Version                Version // Zero when the version could not be determined
+	PermissionPromptTool   bool    // --permission-prompt-tool
+	AdditionalDirectories  bool    // --add-dir, with the query read from stdin
+	IncludePartialMessages bool    // --include-partial-messages
+	SettingSources         bool    // --setting-sources
+}
+
+// capabilitiesFor returns the capabilities of a claude binary at version v
+func capabilitiesFor(v Version) Capabilities {
+	return Capabilities{
+		Version:                v,
+		PermissionPromptTool:   supportsFlag(v, "--permission-prompt-tool"),
+		AdditionalDirectories:  supportsFlag(v, "--add-dir"),
+		IncludePartialMessages: supportsFlag(v, "--include-partial-messages"),
+		SettingSources:         supportsFlag(v, "--setting-sources"),
+	}
+}
+
+// supportsFlag reports whether claude at version v accepts flag
+func supportsFlag(v Version, flag string) bool {
+	since, ok := flagMinVersions[flag]
+	return !ok || v.IsZero() || !v.Less(since)
+}
+
+// UnsupportedOptionError is returned when a session uses an option the claude
+// binary is too old to support
+type UnsupportedOptionError struct {
+	Flag     string
+	Required Version
+	Version  Version
+}
+
+func (e *UnsupportedOptionError) Error() string {
+	return fmt.Sprintf("%s requires claude %s or later (found %s)", e.Flag, e.Required, e.Version)
+}
+
+// gatedFlags returns the version-gated flags config uses
+func gatedFlags(config SessionConfig) []string {
+	var flags []string
+	if config.PermissionPromptTool != "" {
+		flags = append(flags, "--permission-prompt-tool")
+	}
+	if len(config.AdditionalDirectories) > 0 {
+		flags = append(flags, "--add-dir")
+	}
+	if config.IncludePartialMessages {
+		flags = append(flags, "--include-partial-messages")
+	}
+	if len(config.SettingSources) > 0 {
+		flags = append(flags, "--setting-sources")
+	}
+	return flags
+}
+
+// require returns an UnsupportedOptionError for the first flag the binary
+// does not support
+func (caps Capabilities) require(flags []string) error {
+	for _, flag := range flags {
+		if !supportsFlag(caps.Version, flag) {
+			return &UnsupportedOptionError{
+				Flag:     flag,
+				Required: flagMinVersions[flag],
+				Version:  caps.Version,
+			}
+		}
+	}
+	return nil
+}
+
+// Capabilities returns the features supported by the claude binary. The
+// version is probed with `claude --version` on first use and cached; if the
+// probe fails, the version is left zero and no options are rejected.
+func (c *Client) Capabilities() Capabilities {
+	c.probeOnce.Do(func() {
+		version, err := c.probeVersion()
+		if err != nil {
+			c.log().Warn("failed to detect claude version, assuming all options are supported",
+				"path", c.claudePath, "error", err)
+		} else {
+			c.log().Debug("detected claude version", "path", c.claudePath, "version", version)
+		}
+		c.capabilities = capabilitiesFor(version)
+	})
+	return c.capabilities
+}
+
+// probeVersion runs `claude --version`
+func (c *Client) probeVersion() (Version, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), versionProbeTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, c.claudePath, "--version").Output()
+	if err != nil {
+		return Version{}, fmt.Errorf("claude --version failed: %w", err)
+	}
+	return ParseVersion(string(out))
+}
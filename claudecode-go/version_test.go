@@ -0,0 +1,107 @@
+package claudecode_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/humanlayer/humanlayer/claudecode-go"
+)
+
+// versionScript answers --version with version and otherwise exits successfully
+func versionScript(version string) string {
+	return `if [ "$1" = "--version" ]; then echo "` + version + `"; exit 0; fi
+exit 0`
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    claudecode.Version
+		wantErr bool
+	}{
+		{input: "1.0.110 (Claude Code)\n", want: claudecode.Version{Major: 1, Minor: 0, Patch: 110}},
+		{input: "v2.0.14", want: claudecode.Version{Major: 2, Minor: 0, Patch: 14}},
+		{input: "claude 0.2.125-beta", want: claudecode.Version{Major: 0, Minor: 2, Patch: 125}},
+		{input: "unknown", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := claudecode.ParseVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestVersionLess(t *testing.T) {
+	older := claudecode.Version{Major: 1, Minor: 0, Patch: 9}
+	newer := claudecode.Version{Major: 1, Minor: 0, Patch: 18}
+	if !older.Less(newer) || newer.Less(older) || newer.Less(newer) {
+		t.Errorf("unexpected ordering of %s and %s", older, newer)
+	}
+	if !newer.Less(claudecode.Version{Major: 2}) {
+		t.Errorf("expected %s to be older than 2.0.0", newer)
+	}
+}
+
+func TestClientCapabilities(t *testing.T) {
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, versionScript("1.0.20 (Claude Code)")))
+
+	caps := client.Capabilities()
+	if caps.Version != (claudecode.Version{Major: 1, Minor: 0, Patch: 20}) {
+		t.Fatalf("unexpected version %s", caps.Version)
+	}
+	if !caps.PermissionPromptTool || !caps.AdditionalDirectories {
+		t.Errorf("expected --permission-prompt-tool and --add-dir to be supported: %+v", caps)
+	}
+	if caps.IncludePartialMessages || caps.SettingSources {
+		t.Errorf("expected partial messages and setting sources to be unsupported: %+v", caps)
+	}
+}
+
+func TestLaunch_RejectsUnsupportedOption(t *testing.T) {
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, versionScript("1.0.10 (Claude Code)")))
+
+	_, err := client.Launch(claudecode.SessionConfig{
+		Query:                 "hello",
+		OutputFormat:          claudecode.OutputText,
+		AdditionalDirectories: []string{t.TempDir()},
+	})
+	var unsupported *claudecode.UnsupportedOptionError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected UnsupportedOptionError, got %v", err)
+	}
+	if unsupported.Flag != "--add-dir" || unsupported.Required != (claudecode.Version{Major: 1, Minor: 0, Patch: 18}) {
+		t.Errorf("unexpected error details: %+v", unsupported)
+	}
+	if err.Error() != "--add-dir requires claude 1.0.18 or later (found 1.0.10)" {
+		t.Errorf("unexpected error message: %s", err)
+	}
+}
+
+func TestCapabilities_UnknownVersion(t *testing.T) {
+	client := claudecode.NewClientWithPath(writeFakeClaude(t, "exit 1"))
+
+	caps := client.Capabilities()
+	if !caps.Version.IsZero() {
+		t.Errorf("expected unknown version, got %s", caps.Version)
+	}
+	if !caps.AdditionalDirectories || !caps.IncludePartialMessages || !caps.SettingSources {
+		t.Errorf("expected all options to be allowed when the version is unknown: %+v", caps)
+	}
+
+	session, err := client.Launch(claudecode.SessionConfig{
+		Query:          "hello",
+		OutputFormat:   claudecode.OutputText,
+		SettingSources: []claudecode.SettingSource{claudecode.SettingSourceUser},
+	})
+	if err != nil {
+		t.Fatalf("expected launch to be allowed, got %v", err)
+	}
+	_, _ = session.Wait()
+}
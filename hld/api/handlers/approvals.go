@@ -63,8 +63,15 @@ func (h *ApprovalHandlers) CreateApproval(ctx context.Context, req api.CreateApp
 	return api.CreateApproval201JSONResponse(resp), nil
 }
 
-// ListApprovals retrieves approval requests with optional filtering
+// ListApprovals retrieves approval requests with optional filtering. Without
+// filters other than the session, it returns the session's pending approvals.
 func (h *ApprovalHandlers) ListApprovals(ctx context.Context, req api.ListApprovalsRequestObject) (api.ListApprovalsResponseObject, error) {
+	params := req.Params
+	if params.Status != nil || params.ToolName != nil || params.WorkingDir != nil || params.DecidedBy != nil ||
+		params.Since != nil || params.Until != nil || params.Limit != nil || params.Offset != nil {
+		return h.listApprovalHistory(ctx, params)
+	}
+
 	var approvals []*store.Approval
 	var err error
 
@@ -98,6 +105,123 @@ func (h *ApprovalHandlers) ListApprovals(ctx context.Context, req api.ListApprov
 	return api.ListApprovals200JSONResponse(resp), nil
 }
 
+// listApprovalHistory retrieves a page of approvals across sessions
+func (h *ApprovalHandlers) listApprovalHistory(ctx context.Context, params api.ListApprovalsParams) (api.ListApprovalsResponseObject, error) {
+	filter := approvalFilter(api.GetApprovalStatsParams{
+		SessionId:  params.SessionId,
+		Status:     params.Status,
+		ToolName:   params.ToolName,
+		WorkingDir: params.WorkingDir,
+		DecidedBy:  params.DecidedBy,
+		Since:      params.Since,
+		Until:      params.Until,
+	})
+	filter.Limit = approval.DefaultApprovalHistoryLimit
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+	if params.Offset != nil {
+		filter.Offset = *params.Offset
+	}
+	if filter.Limit < 1 {
+		return api.ListApprovals400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: "limit must be at least 1",
+				},
+			},
+		}, nil
+	}
+
+	approvals, err := h.approvalManager.ListApprovals(ctx, filter)
+	if err != nil {
+		if errors.Is(err, approval.ErrInvalidApprovalFilter) {
+			return api.ListApprovals400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: err.Error(),
+					},
+				},
+			}, nil
+		}
+		return api.ListApprovals500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	resp := api.ApprovalsResponse{
+		Data: make([]api.Approval, len(approvals)),
+	}
+	for i, a := range approvals {
+		resp.Data[i] = h.mapper.ApprovalToAPI(*a)
+	}
+	if len(approvals) == filter.Limit {
+		nextOffset := filter.Offset + len(approvals)
+		resp.NextOffset = &nextOffset
+	}
+	return api.ListApprovals200JSONResponse(resp), nil
+}
+
+// GetApprovalStats aggregates the approvals matching the filters
+func (h *ApprovalHandlers) GetApprovalStats(ctx context.Context, req api.GetApprovalStatsRequestObject) (api.GetApprovalStatsResponseObject, error) {
+	stats, err := h.approvalManager.GetApprovalStats(ctx, approvalFilter(req.Params))
+	if err != nil {
+		if errors.Is(err, approval.ErrInvalidApprovalFilter) {
+			return api.GetApprovalStats400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: err.Error(),
+					},
+				},
+			}, nil
+		}
+		return api.GetApprovalStats500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	resp := api.ApprovalStatsResponse{
+		Data: h.mapper.ApprovalStatsToAPI(*stats),
+	}
+	return api.GetApprovalStats200JSONResponse(resp), nil
+}
+
+// approvalFilter converts approval history query parameters to a store filter
+func approvalFilter(params api.GetApprovalStatsParams) store.ApprovalFilter {
+	var filter store.ApprovalFilter
+	if params.SessionId != nil {
+		filter.SessionID = *params.SessionId
+	}
+	if params.Status != nil {
+		filter.Status = store.ApprovalStatus(*params.Status)
+	}
+	if params.ToolName != nil {
+		filter.ToolName = *params.ToolName
+	}
+	if params.WorkingDir != nil {
+		filter.WorkingDir = *params.WorkingDir
+	}
+	if params.DecidedBy != nil {
+		filter.DecidedBy = string(*params.DecidedBy)
+	}
+	filter.CreatedAfter = params.Since
+	filter.CreatedBefore = params.Until
+	return filter
+}
+
 // GetApproval retrieves details for a specific approval
 func (h *ApprovalHandlers) GetApproval(ctx context.Context, req api.GetApprovalRequestObject) (api.GetApprovalResponseObject, error) {
 	approval, err := h.approvalManager.GetApproval(ctx, string(req.Id))
@@ -140,17 +264,57 @@ func (h *ApprovalHandlers) DecideApproval(ctx context.Context, req api.DecideApp
 		}, nil
 	}
 
+	if req.Body.Decision == api.Deny && req.Body.UpdatedInput != nil {
+		return api.DecideApproval400JSONResponse{
+			Error: api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: "updated_input is only allowed when approving",
+			},
+		}, nil
+	}
+
 	comment := ""
 	if req.Body.Comment != nil {
 		comment = *req.Body.Comment
 	}
 
 	var err error
+	var rule *store.ApprovalRule
 	switch req.Body.Decision {
+	case api.ApproveAlways:
+		if req.Body.UpdatedInput != nil {
+			return api.DecideApproval400JSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: "updated_input is not allowed with approve_always",
+				},
+			}, nil
+		}
+		var opts approval.RememberOptions
+		if remember := req.Body.Remember; remember != nil {
+			if remember.Scope != nil {
+				opts.Scope = string(*remember.Scope)
+			}
+			if remember.Match != nil {
+				opts.Match = string(*remember.Match)
+			}
+			if remember.Prefix != nil {
+				opts.Prefix = *remember.Prefix
+			}
+		}
+		rule, err = h.approvalManager.ApproveAndRemember(ctx, string(req.Id), comment, opts)
 	case api.Approve:
-		err = h.approvalManager.ApproveToolCall(ctx, string(req.Id), comment)
+		if req.Body.UpdatedInput != nil {
+			var updatedInput json.RawMessage
+			updatedInput, err = json.Marshal(*req.Body.UpdatedInput)
+			if err == nil {
+				err = h.approvalManager.ApproveToolCallWithInput(ctx, string(req.Id), comment, updatedInput, store.ApprovalDecidedByHuman)
+			}
+		} else {
+			err = h.approvalManager.ApproveToolCall(ctx, string(req.Id), comment)
+		}
 	case api.Deny:
-		err = h.approvalManager.DenyToolCall(ctx, string(req.Id), comment)
+		err = h.approvalManager.DenyToolCall(ctx, string(req.Id), comment, store.ApprovalDecidedByHuman)
 	default:
 		return api.DecideApproval400JSONResponse{
 			Error: api.ErrorDetail{
@@ -179,6 +343,14 @@ func (h *ApprovalHandlers) DecideApproval(ctx context.Context, req api.DecideApp
 				},
 			}, nil
 		}
+		if errors.Is(err, approval.ErrInvalidApprovalRule) {
+			return api.DecideApproval400JSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: err.Error(),
+				},
+			}, nil
+		}
 		return api.DecideApproval500JSONResponse{
 			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
 				Error: api.ErrorDetail{
@@ -191,5 +363,58 @@ func (h *ApprovalHandlers) DecideApproval(ctx context.Context, req api.DecideApp
 
 	resp := api.DecideApprovalResponse{}
 	resp.Data.Success = true
+	if rule != nil {
+		apiRule := h.mapper.ApprovalRuleToAPI(*rule)
+		resp.Data.Rule = &apiRule
+	}
 	return api.DecideApproval200JSONResponse(resp), nil
 }
+
+// ListApprovalRules retrieves the always allow rules that apply to a session
+func (h *ApprovalHandlers) ListApprovalRules(ctx context.Context, req api.ListApprovalRulesRequestObject) (api.ListApprovalRulesResponseObject, error) {
+	rules, err := h.approvalManager.ListApprovalRules(ctx, req.Params.SessionId)
+	if err != nil {
+		return api.ListApprovalRules500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	resp := api.ApprovalRulesResponse{
+		Data: make([]api.ApprovalRule, len(rules)),
+	}
+	for i, rule := range rules {
+		resp.Data[i] = h.mapper.ApprovalRuleToAPI(*rule)
+	}
+	return api.ListApprovalRules200JSONResponse(resp), nil
+}
+
+// RevokeApprovalRule deletes an always allow rule
+func (h *ApprovalHandlers) RevokeApprovalRule(ctx context.Context, req api.RevokeApprovalRuleRequestObject) (api.RevokeApprovalRuleResponseObject, error) {
+	if err := h.approvalManager.RevokeApprovalRule(ctx, req.Id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return api.RevokeApprovalRule404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-1002",
+						Message: "Approval rule not found",
+					},
+				},
+			}, nil
+		}
+		return api.RevokeApprovalRule500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	return api.RevokeApprovalRule204Response{}, nil
+}
@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http/httptest"
@@ -14,6 +15,7 @@ import (
 	"github.com/humanlayer/humanlayer/hld/session"
 	"github.com/humanlayer/humanlayer/hld/store"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -330,6 +332,68 @@ func TestApprovalHandlers_DecideApproval(t *testing.T) {
 			},
 			expectedStatus: 200,
 		},
+		{
+			name:       "approve with edited input",
+			approvalID: "appr-125",
+			request: api.DecideApprovalRequest{
+				Decision:     api.Approve,
+				UpdatedInput: &map[string]interface{}{"command": "ls -la"},
+			},
+			mockSetup: func() {
+				mockApprovalManager.EXPECT().
+					ApproveToolCallWithInput(gomock.Any(), "appr-125", "", json.RawMessage(`{"command":"ls -la"}`), store.ApprovalDecidedByHuman).
+					Return(nil)
+			},
+			expectedStatus: 200,
+		},
+		{
+			name:       "approve always",
+			approvalID: "appr-126",
+			request: api.DecideApprovalRequest{
+				Decision: api.ApproveAlways,
+				Remember: &api.ApprovalRememberOptions{
+					Match:  &[]api.ApprovalRuleMatch{api.ApprovalRuleMatchPrefix}[0],
+					Prefix: stringPtr("go test"),
+				},
+			},
+			mockSetup: func() {
+				mockApprovalManager.EXPECT().
+					ApproveAndRemember(gomock.Any(), "appr-126", "", approval.RememberOptions{Match: "prefix", Prefix: "go test"}).
+					Return(&store.ApprovalRule{ID: "rule-1", Scope: "session", Match: "prefix", CommandPrefix: "go test"}, nil)
+			},
+			expectedStatus: 200,
+		},
+		{
+			name:       "approve always with invalid rule",
+			approvalID: "appr-127",
+			request: api.DecideApprovalRequest{
+				Decision: api.ApproveAlways,
+			},
+			mockSetup: func() {
+				mockApprovalManager.EXPECT().
+					ApproveAndRemember(gomock.Any(), "appr-127", "", approval.RememberOptions{}).
+					Return(nil, fmt.Errorf("%w: prefix rules need a tool call with a command", approval.ErrInvalidApprovalRule))
+			},
+			expectedStatus: 400,
+			expectedError: &api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: "invalid approval rule: prefix rules need a tool call with a command",
+			},
+		},
+		{
+			name:       "deny with edited input fails validation",
+			approvalID: "appr-457",
+			request: api.DecideApprovalRequest{
+				Decision:     api.Deny,
+				Comment:      stringPtr("No"),
+				UpdatedInput: &map[string]interface{}{"command": "ls"},
+			},
+			expectedStatus: 400,
+			expectedError: &api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: "updated_input is only allowed when approving",
+			},
+		},
 		{
 			name:       "deny decision with required comment",
 			approvalID: "appr-456",
@@ -339,7 +403,7 @@ func TestApprovalHandlers_DecideApproval(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockApprovalManager.EXPECT().
-					DenyToolCall(gomock.Any(), "appr-456", "This could delete important files").
+					DenyToolCall(gomock.Any(), "appr-456", "This could delete important files", store.ApprovalDecidedByHuman).
 					Return(nil)
 			},
 			expectedStatus: 200,
@@ -443,6 +507,151 @@ func TestApprovalHandlers_DecideApproval(t *testing.T) {
 	}
 }
 
+func TestApprovalHandlers_ApprovalRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApprovalManager := approval.NewMockManager(ctrl)
+	mockSessionManager := session.NewMockSessionManager(ctrl)
+
+	handlers := handlers.NewApprovalHandlers(mockApprovalManager, mockSessionManager)
+	router := setupTestRouter(t, nil, handlers, nil)
+
+	t.Run("list approval rules", func(t *testing.T) {
+		mockApprovalManager.EXPECT().
+			ListApprovalRules(gomock.Any(), "sess-123").
+			Return([]*store.ApprovalRule{
+				{ID: "rule-1", Scope: "session", SessionID: "sess-123", ToolName: "Bash", Match: "exact", ToolInput: `{"command":"make"}`},
+				{ID: "rule-2", Scope: "project", SessionID: "sess-100", WorkingDir: "/work/repo", ToolName: "Read", Match: "tool"},
+			}, nil)
+
+		w := makeRequest(t, router, "GET", "/api/v1/approval-rules?sessionId=sess-123", nil)
+
+		var resp api.ApprovalRulesResponse
+		assertJSONResponse(t, w, 200, &resp)
+		require.Len(t, resp.Data, 2)
+		assert.Equal(t, api.ApprovalRuleMatchExact, resp.Data[0].Match)
+		assert.Equal(t, map[string]interface{}{"command": "make"}, *resp.Data[0].ToolInput)
+		assert.Equal(t, api.ApprovalRuleScopeProject, resp.Data[1].Scope)
+		assert.Equal(t, "/work/repo", *resp.Data[1].WorkingDir)
+	})
+
+	t.Run("revoke approval rule", func(t *testing.T) {
+		mockApprovalManager.EXPECT().RevokeApprovalRule(gomock.Any(), "rule-1").Return(nil)
+
+		w := makeRequest(t, router, "DELETE", "/api/v1/approval-rules/rule-1", nil)
+		assert.Equal(t, 204, w.Code)
+	})
+
+	t.Run("revoke unknown approval rule", func(t *testing.T) {
+		mockApprovalManager.EXPECT().
+			RevokeApprovalRule(gomock.Any(), "rule-404").
+			Return(fmt.Errorf("failed to revoke approval rule: %w", &store.NotFoundError{Type: "approval rule", ID: "rule-404"}))
+
+		w := makeRequest(t, router, "DELETE", "/api/v1/approval-rules/rule-404", nil)
+		assert.Equal(t, 404, w.Code)
+		assertErrorResponse(t, w, "HLD-1002", "Approval rule not found")
+	})
+}
+
+func TestApprovalHandlers_ApprovalHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApprovalManager := approval.NewMockManager(ctrl)
+	mockSessionManager := session.NewMockSessionManager(ctrl)
+
+	handlers := handlers.NewApprovalHandlers(mockApprovalManager, mockSessionManager)
+	router := setupTestRouter(t, nil, handlers, nil)
+
+	since := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("query approval history", func(t *testing.T) {
+		mockApprovalManager.EXPECT().
+			ListApprovals(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, filter store.ApprovalFilter) ([]*store.Approval, error) {
+				assert.Equal(t, store.ApprovalStatusLocalApproved, filter.Status)
+				assert.Equal(t, "Bash", filter.ToolName)
+				assert.Equal(t, "/work/repo", filter.WorkingDir)
+				assert.Equal(t, store.ApprovalDecidedByPolicy, filter.DecidedBy)
+				require.NotNil(t, filter.CreatedAfter)
+				assert.True(t, since.Equal(*filter.CreatedAfter))
+				assert.Nil(t, filter.CreatedBefore)
+				assert.Equal(t, 2, filter.Limit)
+				assert.Equal(t, 4, filter.Offset)
+				return []*store.Approval{
+					{ID: "appr-1", SessionID: "sess-1", Status: "approved", ToolName: "Bash", ToolInput: json.RawMessage(`{}`), DecidedBy: "policy", PolicyRule: "go"},
+					{ID: "appr-2", SessionID: "sess-2", Status: "approved", ToolName: "Bash", ToolInput: json.RawMessage(`{}`), DecidedBy: "policy", PolicyRule: "go"},
+				}, nil
+			})
+
+		w := makeRequest(t, router, "GET", "/api/v1/approvals?status=approved&toolName=Bash&workingDir=/work/repo&decidedBy=policy&since=2026-10-10T00:00:00Z&limit=2&offset=4", nil)
+
+		var resp api.ApprovalsResponse
+		assertJSONResponse(t, w, 200, &resp)
+		require.Len(t, resp.Data, 2)
+		assert.Equal(t, api.DecidedByPolicy, *resp.Data[0].DecidedBy)
+		require.NotNil(t, resp.NextOffset)
+		assert.Equal(t, 6, *resp.NextOffset)
+	})
+
+	t.Run("last page has no next offset", func(t *testing.T) {
+		mockApprovalManager.EXPECT().
+			ListApprovals(gomock.Any(), gomock.Any()).
+			Return([]*store.Approval{}, nil)
+
+		w := makeRequest(t, router, "GET", "/api/v1/approvals?sessionId=sess-1&status=denied", nil)
+
+		var resp api.ApprovalsResponse
+		assertJSONResponse(t, w, 200, &resp)
+		assert.Empty(t, resp.Data)
+		assert.Nil(t, resp.NextOffset)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		mockApprovalManager.EXPECT().
+			ListApprovals(gomock.Any(), gomock.Any()).
+			Return(nil, fmt.Errorf("%w: since must be before until", approval.ErrInvalidApprovalFilter))
+
+		w := makeRequest(t, router, "GET", "/api/v1/approvals?since=2026-10-10T00:00:00Z&until=2026-10-01T00:00:00Z", nil)
+		assert.Equal(t, 400, w.Code)
+		assertErrorResponse(t, w, "HLD-3001", "since must be before until")
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		w := makeRequest(t, router, "GET", "/api/v1/approvals?limit=0", nil)
+		assert.Equal(t, 400, w.Code)
+		assertErrorResponse(t, w, "HLD-3001", "limit must be at least 1")
+	})
+
+	t.Run("approval stats", func(t *testing.T) {
+		medianMS := int64(90000)
+		mockApprovalManager.EXPECT().
+			GetApprovalStats(gomock.Any(), store.ApprovalFilter{WorkingDir: "/work/repo", CreatedAfter: &since}).
+			Return(&store.ApprovalStats{
+				Total:            3,
+				ByStatus:         map[store.ApprovalStatus]int{"approved": 2, "denied": 1},
+				ByDecidedBy:      map[string]int{"human": 1, "policy": 2},
+				ApprovalRate:     2.0 / 3,
+				MedianResponseMS: &medianMS,
+				Tools: []store.ToolApprovalStats{
+					{ToolName: "Bash", Total: 3, Approved: 2, Denied: 1, ApprovalRate: 2.0 / 3, MedianResponseMS: &medianMS},
+				},
+			}, nil)
+
+		w := makeRequest(t, router, "GET", "/api/v1/approvals/stats?workingDir=/work/repo&since=2026-10-10T00:00:00Z", nil)
+
+		var resp api.ApprovalStatsResponse
+		assertJSONResponse(t, w, 200, &resp)
+		assert.Equal(t, 3, resp.Data.Total)
+		assert.Equal(t, map[string]int{"approved": 2, "denied": 1}, resp.Data.ByStatus)
+		assert.Equal(t, int64(90000), *resp.Data.MedianResponseMs)
+		require.Len(t, resp.Data.Tools, 1)
+		assert.Equal(t, "Bash", resp.Data.Tools[0].ToolName)
+		assert.Equal(t, 2, resp.Data.Tools[0].Approved)
+	})
+}
+
 func TestApprovalHandlers_HTTPSpecificBehavior(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
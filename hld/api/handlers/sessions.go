@@ -112,6 +112,104 @@ func (h *SessionHandlers) CreateSession(ctx context.Context, req api.CreateSessi
 	if req.Body.Verbose != nil {
 		config.Verbose = *req.Body.Verbose
 	}
+	if req.Body.InputFormat != nil {
+		inputFormat := claudecode.InputFormat(*req.Body.InputFormat)
+		if err := inputFormat.Validate(); err != nil {
+			return api.CreateSession400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: err.Error(),
+					},
+				},
+			}, nil
+		}
+		config.InputFormat = inputFormat
+	}
+	if req.Body.FallbackModel != nil {
+		config.FallbackModel = claudecode.Model(*req.Body.FallbackModel)
+	}
+	if req.Body.PermissionMode != nil {
+		config.PermissionMode = claudecode.PermissionMode(*req.Body.PermissionMode)
+	}
+	if req.Body.Settings != nil {
+		config.Settings = *req.Body.Settings
+	}
+	if req.Body.SettingSources != nil {
+		config.SettingSources = h.mapper.SettingSourcesFromAPI(*req.Body.SettingSources)
+	}
+	if req.Body.ExtraArgs != nil {
+		config.ExtraArgs = *req.Body.ExtraArgs
+	}
+	if err := session.ValidatePermissions(config.PermissionMode, config.ExtraArgs); err != nil {
+		return api.CreateSession400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+	if req.Body.OutputSchema != nil {
+		schema, err := json.Marshal(*req.Body.OutputSchema)
+		if err != nil {
+			return api.CreateSession400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: fmt.Sprintf("invalid output_schema: %v", err),
+					},
+				},
+			}, nil
+		}
+		config.OutputSchema = schema
+	}
+	if req.Body.OutputSchemaRepairTurns != nil {
+		turns := *req.Body.OutputSchemaRepairTurns
+		if turns < 0 || turns > session.MaxOutputSchemaRepairTurns {
+			return api.CreateSession400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: fmt.Sprintf("output_schema_repair_turns must be between 0 and %d", session.MaxOutputSchemaRepairTurns),
+					},
+				},
+			}, nil
+		}
+		config.OutputSchemaRepairTurns = turns
+	}
+	if req.Body.ApprovalPolicy != nil {
+		policy, err := json.Marshal(*req.Body.ApprovalPolicy)
+		if err == nil {
+			_, err = approval.ParsePolicy(policy, approval.PolicySourceSession)
+		}
+		if err != nil {
+			return api.CreateSession400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: err.Error(),
+					},
+				},
+			}, nil
+		}
+		config.ApprovalPolicy = policy
+	}
+	if req.Body.ResourceLimits != nil {
+		limits := h.mapper.ResourceLimitsFromAPI(req.Body.ResourceLimits)
+		if err := limits.Validate(); err != nil {
+			return api.CreateSession400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: fmt.Sprintf("invalid resource_limits: %v", err),
+					},
+				},
+			}, nil
+		}
+		config.Limits = limits
+	}
 
 	// Parse model if provided
 	if req.Body.Model != nil && *req.Body.Model != "" {
@@ -120,6 +218,10 @@ func (h *SessionHandlers) CreateSession(ctx context.Context, req api.CreateSessi
 			config.Model = claudecode.ModelOpus
 		case api.Sonnet:
 			config.Model = claudecode.ModelSonnet
+		case api.Haiku:
+			config.Model = claudecode.ModelHaiku
+		case api.Opusplan:
+			config.Model = claudecode.ModelOpusPlan
 		default:
 			// Let Claude decide the default
 		}
@@ -229,6 +331,7 @@ func (h *SessionHandlers) ListSessions(ctx context.Context, req api.ListSessions
 			LastActivityAt:                      info.LastActivityAt,
 			CompletedAt:                         info.EndTime,
 			ErrorMessage:                        info.Error,
+			FailureReason:                       info.FailureReason,
 			AutoAcceptEdits:                     info.AutoAcceptEdits,
 			DangerouslySkipPermissions:          info.DangerouslySkipPermissions,
 			DangerouslySkipPermissionsExpiresAt: info.DangerouslySkipPermissionsExpiresAt,
@@ -459,6 +562,62 @@ func (h *SessionHandlers) ContinueSession(ctx context.Context, req api.ContinueS
 	if req.Body.MaxTurns != nil {
 		continueConfig.MaxTurns = *req.Body.MaxTurns
 	}
+	if req.Body.InputFormat != nil {
+		inputFormat := claudecode.InputFormat(*req.Body.InputFormat)
+		if err := inputFormat.Validate(); err != nil {
+			return api.ContinueSession400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: err.Error(),
+					},
+				},
+			}, nil
+		}
+		continueConfig.InputFormat = inputFormat
+	}
+	if req.Body.FallbackModel != nil {
+		continueConfig.FallbackModel = claudecode.Model(*req.Body.FallbackModel)
+	}
+	if req.Body.PermissionMode != nil {
+		continueConfig.PermissionMode = claudecode.PermissionMode(*req.Body.PermissionMode)
+	}
+	if req.Body.Settings != nil {
+		continueConfig.Settings = *req.Body.Settings
+	}
+	if req.Body.SettingSources != nil {
+		continueConfig.SettingSources = h.mapper.SettingSourcesFromAPI(*req.Body.SettingSources)
+	}
+	if req.Body.ExtraArgs != nil {
+		continueConfig.ExtraArgs = *req.Body.ExtraArgs
+	}
+	if err := session.ValidatePermissions(continueConfig.PermissionMode, continueConfig.ExtraArgs); err != nil {
+		return api.ContinueSession400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+	if req.Body.ForkSession != nil {
+		continueConfig.ForkSession = *req.Body.ForkSession
+	}
+	if req.Body.ResourceLimits != nil {
+		limits := h.mapper.ResourceLimitsFromAPI(req.Body.ResourceLimits)
+		if err := limits.Validate(); err != nil {
+			return api.ContinueSession400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-3001",
+						Message: fmt.Sprintf("invalid resource_limits: %v", err),
+					},
+				},
+			}, nil
+		}
+		continueConfig.Limits = limits
+	}
 
 	// Handle MCP config if provided
 	if req.Body.McpConfig != nil {
@@ -550,6 +709,55 @@ func (h *SessionHandlers) InterruptSession(ctx context.Context, req api.Interrup
 	return api.InterruptSession200JSONResponse(resp), nil
 }
 
+// SendSessionMessage sends a follow-up message to a session running with stream-json input
+func (h *SessionHandlers) SendSessionMessage(ctx context.Context, req api.SendSessionMessageRequestObject) (api.SendSessionMessageResponseObject, error) {
+	_, err := h.store.GetSession(ctx, string(req.Id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return api.SendSessionMessage404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error: api.ErrorDetail{
+						Code:    "HLD-1002",
+						Message: "Session not found",
+					},
+				},
+			}, nil
+		}
+		return api.SendSessionMessage500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	if req.Body.Message == "" {
+		return api.SendSessionMessage400JSONResponse{
+			Error: api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: "Message is required",
+			},
+		}, nil
+	}
+
+	// The manager rejects sessions without a live stream-json process
+	if err := h.manager.SendMessage(ctx, string(req.Id), req.Body.Message); err != nil {
+		return api.SendSessionMessage400JSONResponse{
+			Error: api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: err.Error(),
+			},
+		}, nil
+	}
+
+	resp := api.SendMessageResponse{}
+	resp.Data.Success = true
+	resp.Data.SessionId = string(req.Id)
+	return api.SendSessionMessage200JSONResponse(resp), nil
+}
+
 // GetSessionMessages retrieves conversation history for a session
 func (h *SessionHandlers) GetSessionMessages(ctx context.Context, req api.GetSessionMessagesRequestObject) (api.GetSessionMessagesResponseObject, error) {
 	events, err := h.store.GetSessionConversation(ctx, string(req.Id))
@@ -674,6 +882,54 @@ func (h *SessionHandlers) BulkArchiveSessions(ctx context.Context, req api.BulkA
 	}, nil
 }
 
+// ImportSessions imports sessions from Claude's on-disk transcripts
+func (h *SessionHandlers) ImportSessions(ctx context.Context, req api.ImportSessionsRequestObject) (api.ImportSessionsResponseObject, error) {
+	if req.Body.ProjectDir == "" {
+		return api.ImportSessions400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-3001",
+					Message: "project_dir is required",
+				},
+			},
+		}, nil
+	}
+
+	config := session.ImportTranscriptsConfig{
+		ProjectDir: req.Body.ProjectDir,
+	}
+	if req.Body.ClaudeDir != nil {
+		config.ClaudeDir = *req.Body.ClaudeDir
+	}
+	if req.Body.ClaudeSessionIds != nil {
+		config.ClaudeSessionIDs = *req.Body.ClaudeSessionIds
+	}
+
+	result, err := h.manager.ImportTranscripts(ctx, config)
+	if err != nil {
+		return api.ImportSessions500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error: api.ErrorDetail{
+					Code:    "HLD-4001",
+					Message: err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	resp := api.ImportSessions200JSONResponse{}
+	resp.Data.Imported = make([]api.ImportedSession, len(result.Imported))
+	for i, imported := range result.Imported {
+		resp.Data.Imported[i] = api.ImportedSession{
+			SessionId:       imported.SessionID,
+			ClaudeSessionId: imported.ClaudeSessionID,
+			EventCount:      imported.EventCount,
+		}
+	}
+	resp.Data.Skipped = result.Skipped
+	return resp, nil
+}
+
 // GetRecentPaths retrieves recently used working directories
 func (h *SessionHandlers) GetRecentPaths(ctx context.Context, req api.GetRecentPathsRequestObject) (api.GetRecentPathsResponseObject, error) {
 	limit := 20
@@ -756,6 +1012,9 @@ func (h *SessionHandlers) GetDebugInfo(ctx context.Context, req api.GetDebugInfo
 	if lastModified != nil {
 		response.LastModified = lastModified
 	}
+	if version := h.manager.ClaudeVersion(); version != "" {
+		response.ClaudeVersion = &version
+	}
 
 	return response, nil
 }
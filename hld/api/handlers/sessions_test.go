@@ -121,6 +121,98 @@ func TestSessionHandlers_CreateSession(t *testing.T) {
 				assert.Equal(t, "run-012", resp.Data.RunId)
 			},
 		},
+		{
+			name: "with output schema",
+			request: api.CreateSessionRequest{
+				Query: "Summarize the build",
+				OutputSchema: &map[string]interface{}{
+					"type":     "object",
+					"required": []string{"status"},
+				},
+				OutputSchemaRepairTurns: intPtr(2),
+			},
+			mockSetup: func() {
+				mockManager.EXPECT().
+					LaunchSession(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, config session.LaunchSessionConfig) (*session.Session, error) {
+						assert.JSONEq(t, `{"type":"object","required":["status"]}`, string(config.OutputSchema))
+						assert.Equal(t, 2, config.OutputSchemaRepairTurns)
+						return &session.Session{
+							ID:    "sess-schema",
+							RunID: "run-schema",
+						}, nil
+					})
+			},
+			expectedStatus: 201,
+			validateBody: func(t *testing.T, resp *api.CreateSessionResponse) {
+				assert.Equal(t, "sess-schema", resp.Data.SessionId)
+			},
+		},
+		{
+			name: "too many output schema repair turns",
+			request: api.CreateSessionRequest{
+				Query:                   "Summarize the build",
+				OutputSchema:            &map[string]interface{}{"type": "object"},
+				OutputSchemaRepairTurns: intPtr(session.MaxOutputSchemaRepairTurns + 1),
+			},
+			expectedStatus: 400,
+			expectedError: &api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: "output_schema_repair_turns must be between 0 and 3",
+			},
+		},
+		{
+			name: "with approval policy",
+			request: api.CreateSessionRequest{
+				Query: "Fix the tests",
+				ApprovalPolicy: &api.ApprovalPolicy{
+					Rules: []api.ApprovalPolicyRule{
+						{Name: stringPtr("no-force-push"), Tool: stringPtr("Bash"), Command: stringPtr("git push .*--force"), Action: api.PolicyDeny},
+					},
+				},
+			},
+			mockSetup: func() {
+				mockManager.EXPECT().
+					LaunchSession(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, config session.LaunchSessionConfig) (*session.Session, error) {
+						assert.JSONEq(t, `{"rules":[{"name":"no-force-push","tool":"Bash","command":"git push .*--force","action":"deny"}]}`, string(config.ApprovalPolicy))
+						return &session.Session{
+							ID:    "sess-policy",
+							RunID: "run-policy",
+						}, nil
+					})
+			},
+			expectedStatus: 201,
+			validateBody: func(t *testing.T, resp *api.CreateSessionResponse) {
+				assert.Equal(t, "sess-policy", resp.Data.SessionId)
+			},
+		},
+		{
+			name: "invalid approval policy",
+			request: api.CreateSessionRequest{
+				Query: "Fix the tests",
+				ApprovalPolicy: &api.ApprovalPolicy{
+					Rules: []api.ApprovalPolicyRule{{Command: stringPtr("("), Action: api.PolicyDeny}},
+				},
+			},
+			expectedStatus: 400,
+			expectedError: &api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: "invalid session approval policy rule \"session rule 1\": invalid command pattern: error parsing regexp: missing closing ): `(`",
+			},
+		},
+		{
+			name: "invalid input format",
+			request: api.CreateSessionRequest{
+				Query:       "Fix the tests",
+				InputFormat: inputFormatPtr("stream"),
+			},
+			expectedStatus: 400,
+			expectedError: &api.ErrorDetail{
+				Code:    "HLD-3001",
+				Message: `invalid input_format "stream" (must be "text" or "stream-json")`,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -501,6 +593,65 @@ func TestSessionHandlers_UpdateSession(t *testing.T) {
 	})
 }
 
+func TestSessionHandlers_SendSessionMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := session.NewMockSessionManager(ctrl)
+	mockStore := store.NewMockConversationStore(ctrl)
+	mockApprovalManager := approval.NewMockManager(ctrl)
+
+	handlers := handlers.NewSessionHandlers(mockManager, mockStore, mockApprovalManager)
+	router := setupTestRouter(t, handlers, nil, nil)
+
+	t.Run("send message to running session", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-123").
+			Return(&store.Session{ID: "sess-123", Status: "completed"}, nil)
+		mockManager.EXPECT().
+			SendMessage(gomock.Any(), "sess-123", "Now add tests").
+			Return(nil)
+
+		w := makeRequest(t, router, "POST", "/api/v1/sessions/sess-123/messages", api.SendMessageRequest{
+			Message: "Now add tests",
+		})
+
+		var resp api.SendMessageResponse
+		assertJSONResponse(t, w, 200, &resp)
+		assert.True(t, resp.Data.Success)
+		assert.Equal(t, "sess-123", resp.Data.SessionId)
+	})
+
+	t.Run("session without live process", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-456").
+			Return(&store.Session{ID: "sess-456", Status: "completed"}, nil)
+		mockManager.EXPECT().
+			SendMessage(gomock.Any(), "sess-456", "hello").
+			Return(fmt.Errorf("session not found or not active"))
+
+		w := makeRequest(t, router, "POST", "/api/v1/sessions/sess-456/messages", api.SendMessageRequest{
+			Message: "hello",
+		})
+
+		assertErrorResponse(t, w, "HLD-3001", "not active")
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("session not found", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSession(gomock.Any(), "sess-999").
+			Return(nil, sql.ErrNoRows)
+
+		w := makeRequest(t, router, "POST", "/api/v1/sessions/sess-999/messages", api.SendMessageRequest{
+			Message: "hello",
+		})
+
+		assertErrorResponse(t, w, "HLD-1002", "Session not found")
+		assert.Equal(t, 404, w.Code)
+	})
+}
+
 func TestSessionHandlers_GetHealth(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -523,6 +674,41 @@ func TestSessionHandlers_GetHealth(t *testing.T) {
 	})
 }
 
+func TestSessionHandlers_GetDebugInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := session.NewMockSessionManager(ctrl)
+	mockStore := store.NewMockConversationStore(ctrl)
+	mockApprovalManager := approval.NewMockManager(ctrl)
+
+	handlers := handlers.NewSessionHandlers(mockManager, mockStore, mockApprovalManager)
+	router := setupTestRouter(t, handlers, nil, nil)
+
+	t.Run("reports claude version", func(t *testing.T) {
+		mockManager.EXPECT().ClaudeVersion().Return("2.0.14")
+
+		w := makeRequest(t, router, "GET", "/api/v1/debug-info", nil)
+
+		var resp api.DebugInfoResponse
+		assertJSONResponse(t, w, 200, &resp)
+
+		require.NotNil(t, resp.ClaudeVersion)
+		assert.Equal(t, "2.0.14", *resp.ClaudeVersion)
+	})
+
+	t.Run("omits unknown claude version", func(t *testing.T) {
+		mockManager.EXPECT().ClaudeVersion().Return("")
+
+		w := makeRequest(t, router, "GET", "/api/v1/debug-info", nil)
+
+		var resp api.DebugInfoResponse
+		assertJSONResponse(t, w, 200, &resp)
+
+		assert.Nil(t, resp.ClaudeVersion)
+	})
+}
+
 // Helper functions
 func floatPtr(f float64) *float64 {
 	return &f
@@ -543,3 +729,7 @@ func stringPtr(s string) *string {
 func modelPtr(m api.CreateSessionRequestModel) *api.CreateSessionRequestModel {
 	return &m
 }
+
+func inputFormatPtr(f api.InputFormat) *api.InputFormat {
+	return &f
+}
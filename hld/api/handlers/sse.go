@@ -27,12 +27,18 @@ func parseEventTypes(types []string) []bus.EventType {
 			eventTypes = append(eventTypes, bus.EventNewApproval)
 		case "approval_resolved":
 			eventTypes = append(eventTypes, bus.EventApprovalResolved)
+		case "approval_expired":
+			eventTypes = append(eventTypes, bus.EventApprovalExpired)
 		case "session_status_changed":
 			eventTypes = append(eventTypes, bus.EventSessionStatusChanged)
 		case "conversation_updated":
 			eventTypes = append(eventTypes, bus.EventConversationUpdated)
 		case "session_settings_changed":
 			eventTypes = append(eventTypes, bus.EventSessionSettingsChanged)
+		case "session_resource_limit_exceeded":
+			eventTypes = append(eventTypes, bus.EventSessionResourceLimitExceeded)
+		case "conversation_delta":
+			eventTypes = append(eventTypes, bus.EventConversationDelta)
 		}
 		// Ignore unknown event types
 	}
@@ -2,6 +2,8 @@ package mapper
 
 import (
 	"encoding/json"
+	"time"
+
 	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
 	"github.com/humanlayer/humanlayer/hld/api"
 	"github.com/humanlayer/humanlayer/hld/rpc"
@@ -57,6 +59,15 @@ func (m *Mapper) SessionToAPI(s store.Session) api.Session {
 	if s.ErrorMessage != "" {
 		session.ErrorMessage = &s.ErrorMessage
 	}
+	if s.FailureReason != "" {
+		session.FailureReason = &s.FailureReason
+	}
+	if s.StructuredOutput != "" {
+		var output interface{}
+		if err := json.Unmarshal([]byte(s.StructuredOutput), &output); err == nil {
+			session.StructuredOutput = &output
+		}
+	}
 	if s.CostUSD != nil && *s.CostUSD > 0 {
 		costUsd := float32(*s.CostUSD)
 		session.CostUsd = &costUsd
@@ -137,6 +148,27 @@ func (m *Mapper) ApprovalToAPI(a store.Approval) api.Approval {
 	if a.Comment != "" {
 		approval.Comment = &a.Comment
 	}
+	if a.PolicyRule != "" {
+		approval.PolicyRule = &a.PolicyRule
+	}
+	if a.ApprovalRuleID != "" {
+		approval.ApprovalRuleId = &a.ApprovalRuleID
+	}
+	if a.DecidedBy != "" {
+		decidedBy := api.ApprovalDecidedBy(a.DecidedBy)
+		approval.DecidedBy = &decidedBy
+	}
+	if a.UpdatedInput != nil {
+		var updatedInput map[string]interface{}
+		if err := json.Unmarshal(a.UpdatedInput, &updatedInput); err == nil {
+			approval.UpdatedInput = &updatedInput
+		}
+	}
+	if a.ExpiresAt != nil {
+		approval.ExpiresAt = a.ExpiresAt
+		onTimeout := api.ApprovalTimeoutOutcome(a.OnTimeout)
+		approval.OnTimeout = &onTimeout
+	}
 
 	return approval
 }
@@ -149,6 +181,66 @@ func (m *Mapper) ApprovalsToAPI(approvals []store.Approval) []api.Approval {
 	return result
 }
 
+// ApprovalRuleToAPI converts an always allow rule
+func (m *Mapper) ApprovalRuleToAPI(r store.ApprovalRule) api.ApprovalRule {
+	rule := api.ApprovalRule{
+		Id:        r.ID,
+		Scope:     api.ApprovalRuleScope(r.Scope),
+		SessionId: r.SessionID,
+		ToolName:  r.ToolName,
+		Match:     api.ApprovalRuleMatch(r.Match),
+		CreatedAt: r.CreatedAt,
+	}
+
+	if r.WorkingDir != "" {
+		rule.WorkingDir = &r.WorkingDir
+	}
+	if r.ToolInput != "" {
+		var toolInput map[string]interface{}
+		if err := json.Unmarshal([]byte(r.ToolInput), &toolInput); err == nil {
+			rule.ToolInput = &toolInput
+		}
+	}
+	if r.CommandPrefix != "" {
+		rule.CommandPrefix = &r.CommandPrefix
+	}
+	if r.ApprovalID != "" {
+		rule.ApprovalId = &r.ApprovalID
+	}
+
+	return rule
+}
+
+// ApprovalStatsToAPI converts approval statistics
+func (m *Mapper) ApprovalStatsToAPI(s store.ApprovalStats) api.ApprovalStats {
+	stats := api.ApprovalStats{
+		Total:            s.Total,
+		ByStatus:         make(map[string]int, len(s.ByStatus)),
+		ByDecidedBy:      s.ByDecidedBy,
+		ApprovalRate:     s.ApprovalRate,
+		MedianResponseMs: s.MedianResponseMS,
+		Tools:            make([]api.ToolApprovalStats, len(s.Tools)),
+	}
+
+	for status, count := range s.ByStatus {
+		stats.ByStatus[status.String()] = count
+	}
+	for i, t := range s.Tools {
+		stats.Tools[i] = api.ToolApprovalStats{
+			ToolName:         t.ToolName,
+			Total:            t.Total,
+			Approved:         t.Approved,
+			Denied:           t.Denied,
+			Expired:          t.Expired,
+			Pending:          t.Pending,
+			ApprovalRate:     t.ApprovalRate,
+			MedianResponseMs: t.MedianResponseMS,
+		}
+	}
+
+	return stats
+}
+
 // Event conversions
 func (m *Mapper) ConversationEventToAPI(e store.ConversationEvent) api.ConversationEvent {
 	event := api.ConversationEvent{
@@ -196,10 +288,42 @@ func (m *Mapper) ConversationEventToAPI(e store.ConversationEvent) api.Conversat
 	if e.ApprovalID != "" {
 		event.ApprovalId = &e.ApprovalID
 	}
+	if e.UpdatedToolInputJSON != "" {
+		event.UpdatedToolInputJson = &e.UpdatedToolInputJSON
+	}
+	if len(e.Attachments) > 0 {
+		attachments := m.AttachmentsToAPI(e.Attachments)
+		event.Attachments = &attachments
+	}
 
 	return event
 }
 
+func (m *Mapper) AttachmentsToAPI(attachments []store.Attachment) []api.ConversationAttachment {
+	result := make([]api.ConversationAttachment, len(attachments))
+	for i := range attachments {
+		a := &attachments[i]
+		attachment := api.ConversationAttachment{BlockType: a.BlockType}
+		if a.SourceType != "" {
+			attachment.SourceType = &a.SourceType
+		}
+		if a.MediaType != "" {
+			attachment.MediaType = &a.MediaType
+		}
+		if a.Data != "" {
+			attachment.Data = &a.Data
+		}
+		if a.URL != "" {
+			attachment.Url = &a.URL
+		}
+		if a.Title != "" {
+			attachment.Title = &a.Title
+		}
+		result[i] = attachment
+	}
+	return result
+}
+
 func (m *Mapper) ConversationEventsToAPI(events []store.ConversationEvent) []api.ConversationEvent {
 	result := make([]api.ConversationEvent, len(events))
 	for i, e := range events {
@@ -209,6 +333,34 @@ func (m *Mapper) ConversationEventsToAPI(events []store.ConversationEvent) []api
 }
 
 // Other conversions
+func (m *Mapper) SettingSourcesFromAPI(sources []api.SettingSource) []claudecode.SettingSource {
+	result := make([]claudecode.SettingSource, len(sources))
+	for i, source := range sources {
+		result[i] = claudecode.SettingSource(source)
+	}
+	return result
+}
+
+func (m *Mapper) ResourceLimitsFromAPI(limits *api.ResourceLimits) *claudecode.ResourceLimits {
+	if limits == nil {
+		return nil
+	}
+	result := &claudecode.ResourceLimits{}
+	if limits.CpuTimeSeconds != nil {
+		result.CPUTime = time.Duration(*limits.CpuTimeSeconds) * time.Second
+	}
+	if limits.MemoryBytes != nil {
+		result.Memory = *limits.MemoryBytes
+	}
+	if limits.OpenFiles != nil && *limits.OpenFiles > 0 {
+		result.OpenFiles = uint64(*limits.OpenFiles)
+	}
+	if limits.WallClockSeconds != nil {
+		result.WallClock = time.Duration(*limits.WallClockSeconds) * time.Second
+	}
+	return result
+}
+
 func (m *Mapper) MCPConfigFromAPI(config *api.MCPConfig) *claudecode.MCPConfig {
 	if config == nil {
 		return nil
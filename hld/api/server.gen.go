@@ -22,19 +22,57 @@ import (
 	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
 )
 
+// Defines values for ApprovalDecidedBy.
+const (
+	DecidedByAutoAccept ApprovalDecidedBy = "auto_accept"
+	DecidedByHuman      ApprovalDecidedBy = "human"
+	DecidedByHumanLayer ApprovalDecidedBy = "humanlayer"
+	DecidedByPolicy     ApprovalDecidedBy = "policy"
+	DecidedByRule       ApprovalDecidedBy = "rule"
+	DecidedByTimeout    ApprovalDecidedBy = "timeout"
+)
+
+// Defines values for ApprovalPolicyRuleAction.
+const (
+	PolicyAllow ApprovalPolicyRuleAction = "allow"
+	PolicyAsk   ApprovalPolicyRuleAction = "ask"
+	PolicyDeny  ApprovalPolicyRuleAction = "deny"
+)
+
+// Defines values for ApprovalRuleMatch.
+const (
+	ApprovalRuleMatchExact  ApprovalRuleMatch = "exact"
+	ApprovalRuleMatchPrefix ApprovalRuleMatch = "prefix"
+	ApprovalRuleMatchTool   ApprovalRuleMatch = "tool"
+)
+
+// Defines values for ApprovalRuleScope.
+const (
+	ApprovalRuleScopeProject ApprovalRuleScope = "project"
+	ApprovalRuleScopeSession ApprovalRuleScope = "session"
+)
+
 // Defines values for ApprovalStatus.
 const (
 	ApprovalStatusApproved ApprovalStatus = "approved"
 	ApprovalStatusDenied   ApprovalStatus = "denied"
+	ApprovalStatusExpired  ApprovalStatus = "approval_expired"
 	ApprovalStatusPending  ApprovalStatus = "pending"
 )
 
+// Defines values for ApprovalTimeoutOutcome.
+const (
+	TimeoutApprove   ApprovalTimeoutOutcome = "approve"
+	TimeoutDeny      ApprovalTimeoutOutcome = "deny"
+	TimeoutInterrupt ApprovalTimeoutOutcome = "interrupt"
+)
+
 // Defines values for ConversationEventApprovalStatus.
 const (
-	ConversationEventApprovalStatusApproved ConversationEventApprovalStatus = "approved"
-	ConversationEventApprovalStatusDenied   ConversationEventApprovalStatus = "denied"
-	ConversationEventApprovalStatusPending  ConversationEventApprovalStatus = "pending"
-	ConversationEventApprovalStatusResolved ConversationEventApprovalStatus = "resolved"
+	Approved ConversationEventApprovalStatus = "approved"
+	Denied   ConversationEventApprovalStatus = "denied"
+	Pending  ConversationEventApprovalStatus = "pending"
+	Resolved ConversationEventApprovalStatus = "resolved"
 )
 
 // Defines values for ConversationEventEventType.
@@ -55,23 +93,29 @@ const (
 
 // Defines values for CreateSessionRequestModel.
 const (
-	Opus   CreateSessionRequestModel = "opus"
-	Sonnet CreateSessionRequestModel = "sonnet"
+	Haiku    CreateSessionRequestModel = "haiku"
+	Opus     CreateSessionRequestModel = "opus"
+	Opusplan CreateSessionRequestModel = "opusplan"
+	Sonnet   CreateSessionRequestModel = "sonnet"
 )
 
 // Defines values for DecideApprovalRequestDecision.
 const (
-	Approve DecideApprovalRequestDecision = "approve"
-	Deny    DecideApprovalRequestDecision = "deny"
+	Approve       DecideApprovalRequestDecision = "approve"
+	ApproveAlways DecideApprovalRequestDecision = "approve_always"
+	Deny          DecideApprovalRequestDecision = "deny"
 )
 
 // Defines values for EventType.
 const (
-	ApprovalResolved       EventType = "approval_resolved"
-	ConversationUpdated    EventType = "conversation_updated"
-	NewApproval            EventType = "new_approval"
-	SessionSettingsChanged EventType = "session_settings_changed"
-	SessionStatusChanged   EventType = "session_status_changed"
+	ApprovalExpired              EventType = "approval_expired"
+	ApprovalResolved             EventType = "approval_resolved"
+	ConversationDelta            EventType = "conversation_delta"
+	ConversationUpdated          EventType = "conversation_updated"
+	NewApproval                  EventType = "new_approval"
+	SessionResourceLimitExceeded EventType = "session_resource_limit_exceeded"
+	SessionSettingsChanged       EventType = "session_settings_changed"
+	SessionStatusChanged         EventType = "session_status_changed"
 )
 
 // Defines values for HealthResponseStatus.
@@ -79,11 +123,24 @@ const (
 	Ok HealthResponseStatus = "ok"
 )
 
+// Defines values for InputFormat.
+const (
+	StreamJson InputFormat = "stream-json"
+	Text       InputFormat = "text"
+)
+
 // Defines values for InterruptSessionResponseDataStatus.
 const (
 	InterruptSessionResponseDataStatusInterrupting InterruptSessionResponseDataStatus = "interrupting"
 )
 
+// Defines values for PermissionMode.
+const (
+	AcceptEdits PermissionMode = "acceptEdits"
+	Default     PermissionMode = "default"
+	Plan        PermissionMode = "plan"
+)
+
 // Defines values for SessionStatus.
 const (
 	SessionStatusCompleted    SessionStatus = "completed"
@@ -95,17 +152,41 @@ const (
 	SessionStatusWaitingInput SessionStatus = "waiting_input"
 )
 
+// Defines values for SettingSource.
+const (
+	Local   SettingSource = "local"
+	Project SettingSource = "project"
+	User    SettingSource = "user"
+)
+
 // Approval defines model for Approval.
 type Approval struct {
+	// ApprovalRuleId Always allow rule that approved the tool call, if any
+	ApprovalRuleId *string `json:"approval_rule_id,omitempty"`
+
 	// Comment Approver's comment
 	Comment *string `json:"comment,omitempty"`
 
 	// CreatedAt Creation timestamp
 	CreatedAt time.Time `json:"created_at"`
 
+	// DecidedBy Who or what decided the approval: a human through the daemon, a human
+	// through HumanLayer, an auto-accept mode, an approval policy rule, an always
+	// allow rule, or the approval's timeout. Unset while the approval is pending.
+	DecidedBy *ApprovalDecidedBy `json:"decided_by,omitempty"`
+
+	// ExpiresAt When a pending approval times out, if its policy sets a timeout
+	ExpiresAt *time.Time `json:"expires_at"`
+
 	// Id Unique approval identifier
 	Id string `json:"id"`
 
+	// OnTimeout What happens when nobody answers an approval before its timeout. Interrupt denies the tool call and interrupts the session. Defaults to deny.
+	OnTimeout *ApprovalTimeoutOutcome `json:"on_timeout,omitempty"`
+
+	// PolicyRule Approval policy rule that decided the approval, if any
+	PolicyRule *string `json:"policy_rule,omitempty"`
+
 	// RespondedAt Response timestamp
 	RespondedAt *time.Time `json:"responded_at"`
 
@@ -123,6 +204,74 @@ type Approval struct {
 
 	// ToolName Tool requesting approval
 	ToolName string `json:"tool_name"`
+
+	// UpdatedInput Tool input as edited by the approver; the tool ran with it instead of tool_input
+	UpdatedInput *map[string]interface{} `json:"updated_input,omitempty"`
+}
+
+// ApprovalDecidedBy Who or what decided the approval: a human through the daemon, a human
+// through HumanLayer, an auto-accept mode, an approval policy rule, an always
+// allow rule, or the approval's timeout. Unset while the approval is pending.
+type ApprovalDecidedBy string
+
+// ApprovalPolicy Approval policy rules, checked in order before the session's auto-accept modes. The first matching rule decides.
+type ApprovalPolicy struct {
+	// OnTimeout What happens when nobody answers an approval before its timeout. Interrupt denies the tool call and interrupts the session. Defaults to deny.
+	OnTimeout *ApprovalTimeoutOutcome `json:"on_timeout,omitempty"`
+	Rules     []ApprovalPolicyRule    `json:"rules"`
+
+	// Timeout How long approvals left pending wait for a human, as a Go duration. Applies when the matching rule sets no timeout.
+	Timeout *string `json:"timeout,omitempty"`
+
+	// TimeoutMessage Reason given to Claude when the approval expires
+	TimeoutMessage *string `json:"timeout_message,omitempty"`
+}
+
+// ApprovalPolicyRule Matches tool calls on every condition that is set and decides them
+type ApprovalPolicyRule struct {
+	Action ApprovalPolicyRuleAction `json:"action"`
+
+	// Command Regular expression searched in a Bash command
+	Command *string `json:"command,omitempty"`
+
+	// McpServer Glob on the MCP server of mcp__<server>__<tool> tools
+	McpServer *string `json:"mcp_server,omitempty"`
+
+	// Message Reason given to Claude when the rule denies
+	Message *string `json:"message,omitempty"`
+
+	// Name Rule name recorded on approvals it decides
+	Name *string `json:"name,omitempty"`
+
+	// OnTimeout What happens when nobody answers an approval before its timeout. Interrupt denies the tool call and interrupts the session. Defaults to deny.
+	OnTimeout *ApprovalTimeoutOutcome `json:"on_timeout,omitempty"`
+
+	// Path Glob on the file path in the tool input; relative globs match inside the working directory
+	Path *string `json:"path,omitempty"`
+
+	// Timeout How long approvals left pending wait for a human, as a Go duration
+	Timeout *string `json:"timeout,omitempty"`
+
+	// TimeoutMessage Reason given to Claude when the approval expires
+	TimeoutMessage *string `json:"timeout_message,omitempty"`
+
+	// Tool Glob on the tool name
+	Tool *string `json:"tool,omitempty"`
+}
+
+// ApprovalPolicyRuleAction defines model for ApprovalPolicyRule.Action.
+type ApprovalPolicyRuleAction string
+
+// ApprovalRememberOptions The "always allow" rule created by an approve_always decision
+type ApprovalRememberOptions struct {
+	// Match How an approval rule matches tool calls of its tool. exact needs the same input, tool matches any input, prefix matches commands starting with the command prefix that contain no shell operators.
+	Match *ApprovalRuleMatch `json:"match,omitempty"`
+
+	// Prefix Command prefix for prefix rules; defaults to the approved command
+	Prefix *string `json:"prefix,omitempty"`
+
+	// Scope Where an approval rule applies. Session rules also apply to sessions continued from the session; project rules apply to every session in the working directory.
+	Scope *ApprovalRuleScope `json:"scope,omitempty"`
 }
 
 // ApprovalResponse defines model for ApprovalResponse.
@@ -130,12 +279,86 @@ type ApprovalResponse struct {
 	Data Approval `json:"data"`
 }
 
+// ApprovalRule defines model for ApprovalRule.
+type ApprovalRule struct {
+	// ApprovalId Approval the rule was created from
+	ApprovalId *string `json:"approval_id,omitempty"`
+
+	// CommandPrefix Command prefix of prefix rules
+	CommandPrefix *string   `json:"command_prefix,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Id Unique rule identifier
+	Id string `json:"id"`
+
+	// Match How an approval rule matches tool calls of its tool. exact needs the same input, tool matches any input, prefix matches commands starting with the command prefix that contain no shell operators.
+	Match ApprovalRuleMatch `json:"match"`
+
+	// Scope Where an approval rule applies. Session rules also apply to sessions continued from the session; project rules apply to every session in the working directory.
+	Scope ApprovalRuleScope `json:"scope"`
+
+	// SessionId Session the rule was created in
+	SessionId string `json:"session_id"`
+
+	// ToolInput Tool input of exact rules
+	ToolInput *map[string]interface{} `json:"tool_input,omitempty"`
+	ToolName  string                  `json:"tool_name"`
+
+	// WorkingDir Working directory of project rules
+	WorkingDir *string `json:"working_dir,omitempty"`
+}
+
+// ApprovalRuleMatch How an approval rule matches tool calls of its tool. exact needs the same input, tool matches any input, prefix matches commands starting with the command prefix that contain no shell operators.
+type ApprovalRuleMatch string
+
+// ApprovalRuleScope Where an approval rule applies. Session rules also apply to sessions continued from the session; project rules apply to every session in the working directory.
+type ApprovalRuleScope string
+
+// ApprovalRulesResponse defines model for ApprovalRulesResponse.
+type ApprovalRulesResponse struct {
+	Data []ApprovalRule `json:"data"`
+}
+
+// ApprovalStats Approval statistics. Approval rates are the approved share of decided
+// (not pending) approvals. Response times are measured from creation to
+// the answer, for approvals decided by a human, locally or through HumanLayer.
+type ApprovalStats struct {
+	ApprovalRate float64 `json:"approval_rate"`
+
+	// ByDecidedBy Number of decided approvals per decider
+	ByDecidedBy map[string]int `json:"by_decided_by"`
+
+	// ByStatus Number of approvals per status
+	ByStatus map[string]int `json:"by_status"`
+
+	// MedianResponseMs Median response time, unset without human decisions
+	MedianResponseMs *int64 `json:"median_response_ms,omitempty"`
+
+	// Tools Statistics per tool, sorted by tool name
+	Tools []ToolApprovalStats `json:"tools"`
+	Total int                 `json:"total"`
+}
+
+// ApprovalStatsResponse defines model for ApprovalStatsResponse.
+type ApprovalStatsResponse struct {
+	// Data Approval statistics. Approval rates are the approved share of decided
+	// (not pending) approvals. Response times are measured from creation to
+	// the answer, for approvals decided by a human, locally or through HumanLayer.
+	Data ApprovalStats `json:"data"`
+}
+
 // ApprovalStatus Current status of the approval
 type ApprovalStatus string
 
+// ApprovalTimeoutOutcome What happens when nobody answers an approval before its timeout. Interrupt denies the tool call and interrupts the session. Defaults to deny.
+type ApprovalTimeoutOutcome string
+
 // ApprovalsResponse defines model for ApprovalsResponse.
 type ApprovalsResponse struct {
 	Data []Approval `json:"data"`
+
+	// NextOffset Offset of the next page of the approval history, set when the page is full
+	NextOffset *int `json:"next_offset,omitempty"`
 }
 
 // BulkArchiveRequest defines model for BulkArchiveRequest.
@@ -170,16 +393,43 @@ type ContinueSessionRequest struct {
 	// DisallowedTools Disallowed tools list
 	DisallowedTools *[]string `json:"disallowed_tools,omitempty"`
 
+	// ExtraArgs Override the additional claude CLI arguments
+	ExtraArgs *[]string `json:"extra_args,omitempty"`
+
+	// FallbackModel Override the fallback model
+	FallbackModel *string `json:"fallback_model,omitempty"`
+
+	// ForkSession Resume under a new Claude session ID, leaving the parent's conversation untouched
+	ForkSession *bool `json:"fork_session,omitempty"`
+
+	// InputFormat How the query is passed to Claude. With stream-json the process stays
+	// open after each turn so follow-up messages can be sent to it.
+	InputFormat *InputFormat `json:"input_format,omitempty"`
+
 	// MaxTurns Max conversation turns
 	MaxTurns  *int       `json:"max_turns,omitempty"`
 	McpConfig *MCPConfig `json:"mcp_config,omitempty"`
 
+	// PermissionMode Claude CLI permission mode (use dangerously_skip_permissions to bypass permission checks)
+	PermissionMode *PermissionMode `json:"permission_mode,omitempty"`
+
 	// PermissionPromptTool MCP tool for permissions
 	PermissionPromptTool *string `json:"permission_prompt_tool,omitempty"`
 
 	// Query New query to continue with
 	Query string `json:"query"`
 
+	// ResourceLimits Limits on the resources used by the session's processes. Zero or
+	// omitted values mean unlimited. CPU time, memory and open files are
+	// only enforced on Linux.
+	ResourceLimits *ResourceLimits `json:"resource_limits,omitempty"`
+
+	// SettingSources Override the settings locations to load
+	SettingSources *[]SettingSource `json:"setting_sources,omitempty"`
+
+	// Settings Override the settings file or JSON
+	Settings *string `json:"settings,omitempty"`
+
 	// SystemPrompt Override system prompt
 	SystemPrompt *string `json:"system_prompt,omitempty"`
 }
@@ -194,14 +444,32 @@ type ContinueSessionResponse struct {
 	} `json:"data"`
 }
 
+// ConversationAttachment defines model for ConversationAttachment.
+type ConversationAttachment struct {
+	// BlockType Content block type (image, document, redacted_thinking, web_search_result)
+	BlockType string `json:"block_type"`
+
+	// Data Base64 payload, document text or encrypted thinking data
+	Data      *string `json:"data,omitempty"`
+	MediaType *string `json:"media_type,omitempty"`
+
+	// SourceType How the payload is provided (base64, url, text)
+	SourceType *string `json:"source_type,omitempty"`
+	Title      *string `json:"title,omitempty"`
+	Url        *string `json:"url,omitempty"`
+}
+
 // ConversationEvent defines model for ConversationEvent.
 type ConversationEvent struct {
 	// ApprovalId Associated approval ID
 	ApprovalId *string `json:"approval_id"`
 
 	// ApprovalStatus Approval status for tool calls
-	ApprovalStatus  *ConversationEventApprovalStatus `json:"approval_status"`
-	ClaudeSessionId *string                          `json:"claude_session_id,omitempty"`
+	ApprovalStatus *ConversationEventApprovalStatus `json:"approval_status"`
+
+	// Attachments Non-text content such as images, documents and redacted thinking
+	Attachments     *[]ConversationAttachment `json:"attachments,omitempty"`
+	ClaudeSessionId *string                   `json:"claude_session_id,omitempty"`
 
 	// Content Message content
 	Content   *string   `json:"content,omitempty"`
@@ -238,6 +506,9 @@ type ConversationEvent struct {
 
 	// ToolResultForId Tool call ID this result is for
 	ToolResultForId *string `json:"tool_result_for_id,omitempty"`
+
+	// UpdatedToolInputJson JSON string of the tool input as edited by the approver, when the tool call was approved with changes
+	UpdatedToolInputJson *string `json:"updated_tool_input_json,omitempty"`
 }
 
 // ConversationEventApprovalStatus Approval status for tool calls
@@ -285,6 +556,9 @@ type CreateSessionRequest struct {
 	// AppendSystemPrompt Text to append to system prompt
 	AppendSystemPrompt *string `json:"append_system_prompt,omitempty"`
 
+	// ApprovalPolicy Approval policy rules, checked in order before the session's auto-accept modes. The first matching rule decides.
+	ApprovalPolicy *ApprovalPolicy `json:"approval_policy,omitempty"`
+
 	// CustomInstructions Custom instructions for Claude
 	CustomInstructions *string `json:"custom_instructions,omitempty"`
 
@@ -297,6 +571,16 @@ type CreateSessionRequest struct {
 	// DisallowedTools Blacklist of disallowed tools
 	DisallowedTools *[]string `json:"disallowed_tools,omitempty"`
 
+	// ExtraArgs Additional claude CLI arguments, passed through as-is after the query (--dangerously-skip-permissions is rejected)
+	ExtraArgs *[]string `json:"extra_args,omitempty"`
+
+	// FallbackModel Model to use when the main model is overloaded
+	FallbackModel *string `json:"fallback_model,omitempty"`
+
+	// InputFormat How the query is passed to Claude. With stream-json the process stays
+	// open after each turn so follow-up messages can be sent to it.
+	InputFormat *InputFormat `json:"input_format,omitempty"`
+
 	// MaxTurns Maximum conversation turns
 	MaxTurns  *int       `json:"max_turns,omitempty"`
 	McpConfig *MCPConfig `json:"mcp_config,omitempty"`
@@ -304,6 +588,15 @@ type CreateSessionRequest struct {
 	// Model Model to use for the session
 	Model *CreateSessionRequestModel `json:"model,omitempty"`
 
+	// OutputSchema JSON Schema the final result must match. The validated document is stored as the session's structured_output.
+	OutputSchema *map[string]interface{} `json:"output_schema,omitempty"`
+
+	// OutputSchemaRepairTurns Number of turns allowed to fix a result that does not match output_schema
+	OutputSchemaRepairTurns *int `json:"output_schema_repair_turns,omitempty"`
+
+	// PermissionMode Claude CLI permission mode (use dangerously_skip_permissions to bypass permission checks)
+	PermissionMode *PermissionMode `json:"permission_mode,omitempty"`
+
 	// PermissionPromptTool MCP tool for permission prompts
 	PermissionPromptTool *string `json:"permission_prompt_tool,omitempty"`
 
@@ -322,6 +615,17 @@ type CreateSessionRequest struct {
 	// Query Initial query for Claude
 	Query string `json:"query"`
 
+	// ResourceLimits Limits on the resources used by the session's processes. Zero or
+	// omitted values mean unlimited. CPU time, memory and open files are
+	// only enforced on Linux.
+	ResourceLimits *ResourceLimits `json:"resource_limits,omitempty"`
+
+	// SettingSources Settings locations to load (all when omitted)
+	SettingSources *[]SettingSource `json:"setting_sources,omitempty"`
+
+	// Settings Path to a Claude settings file, or a settings JSON string
+	Settings *string `json:"settings,omitempty"`
+
 	// SystemPrompt Override system prompt
 	SystemPrompt *string `json:"system_prompt,omitempty"`
 
@@ -351,6 +655,9 @@ type CreateSessionResponse struct {
 
 // DebugInfoResponse defines model for DebugInfoResponse.
 type DebugInfoResponse struct {
+	// ClaudeVersion Version of the claude CLI used to launch sessions, when it could be detected
+	ClaudeVersion *string `json:"claude_version,omitempty"`
+
 	// CliCommand CLI command configured for MCP servers
 	CliCommand string `json:"cli_command"`
 
@@ -375,19 +682,26 @@ type DecideApprovalRequest struct {
 	// Comment Optional comment (required for deny)
 	Comment *string `json:"comment,omitempty"`
 
-	// Decision Approval decision
+	// Decision Approval decision. approve_always also creates an "always allow" rule from the tool call.
 	Decision DecideApprovalRequestDecision `json:"decision"`
+
+	// Remember The "always allow" rule created by an approve_always decision
+	Remember *ApprovalRememberOptions `json:"remember,omitempty"`
+
+	// UpdatedInput Edited tool input to run instead of the original, only allowed when approving
+	UpdatedInput *map[string]interface{} `json:"updated_input,omitempty"`
 }
 
-// DecideApprovalRequestDecision Approval decision
+// DecideApprovalRequestDecision Approval decision. approve_always also creates an "always allow" rule from the tool call.
 type DecideApprovalRequestDecision string
 
 // DecideApprovalResponse defines model for DecideApprovalResponse.
 type DecideApprovalResponse struct {
 	Data struct {
 		// Error Error message if failed
-		Error   *string `json:"error,omitempty"`
-		Success bool    `json:"success"`
+		Error   *string       `json:"error,omitempty"`
+		Rule    *ApprovalRule `json:"rule,omitempty"`
+		Success bool          `json:"success"`
 	} `json:"data"`
 }
 
@@ -445,6 +759,44 @@ type HealthResponse struct {
 // HealthResponseStatus defines model for HealthResponse.Status.
 type HealthResponseStatus string
 
+// ImportSessionsRequest defines model for ImportSessionsRequest.
+type ImportSessionsRequest struct {
+	// ClaudeDir Claude config directory (defaults to $CLAUDE_CONFIG_DIR or ~/.claude)
+	ClaudeDir *string `json:"claude_dir,omitempty"`
+
+	// ClaudeSessionIds Only import these Claude sessions
+	ClaudeSessionIds *[]string `json:"claude_session_ids,omitempty"`
+
+	// ProjectDir Project directory whose transcripts should be imported
+	ProjectDir string `json:"project_dir"`
+}
+
+// ImportSessionsResponse defines model for ImportSessionsResponse.
+type ImportSessionsResponse struct {
+	Data struct {
+		Imported []ImportedSession `json:"imported"`
+
+		// Skipped Claude session IDs that were already imported
+		Skipped []string `json:"skipped"`
+	} `json:"data"`
+}
+
+// ImportedSession defines model for ImportedSession.
+type ImportedSession struct {
+	// ClaudeSessionId Claude session ID from the transcript
+	ClaudeSessionId string `json:"claude_session_id"`
+
+	// EventCount Number of transcript entries imported
+	EventCount int `json:"event_count"`
+
+	// SessionId Daemon session ID
+	SessionId string `json:"session_id"`
+}
+
+// InputFormat How the query is passed to Claude. With stream-json the process stays
+// open after each turn so follow-up messages can be sent to it.
+type InputFormat string
+
 // InterruptSessionResponse defines model for InterruptSessionResponse.
 type InterruptSessionResponse struct {
 	Data struct {
@@ -484,6 +836,9 @@ type MCPServer struct {
 	Url *string `json:"url,omitempty"`
 }
 
+// PermissionMode Claude CLI permission mode (use dangerously_skip_permissions to bypass permission checks)
+type PermissionMode string
+
 // RecentPath defines model for RecentPath.
 type RecentPath struct {
 	// LastUsed Last time this path was used
@@ -501,6 +856,37 @@ type RecentPathsResponse struct {
 	Data []RecentPath `json:"data"`
 }
 
+// ResourceLimits Limits on the resources used by the session's processes. Zero or
+// omitted values mean unlimited. CPU time, memory and open files are
+// only enforced on Linux.
+type ResourceLimits struct {
+	// CpuTimeSeconds Total CPU time across the session's processes
+	CpuTimeSeconds *int64 `json:"cpu_time_seconds,omitempty"`
+
+	// MemoryBytes Resident memory across the session's processes
+	MemoryBytes *int64 `json:"memory_bytes,omitempty"`
+
+	// OpenFiles Open file descriptors per process
+	OpenFiles *int64 `json:"open_files,omitempty"`
+
+	// WallClockSeconds Time from launch until the session is stopped
+	WallClockSeconds *int64 `json:"wall_clock_seconds,omitempty"`
+}
+
+// SendMessageRequest defines model for SendMessageRequest.
+type SendMessageRequest struct {
+	// Message Message to send to Claude
+	Message string `json:"message"`
+}
+
+// SendMessageResponse defines model for SendMessageResponse.
+type SendMessageResponse struct {
+	Data struct {
+		SessionId string `json:"session_id"`
+		Success   bool   `json:"success"`
+	} `json:"data"`
+}
+
 // Session defines model for Session.
 type Session struct {
 	// AdditionalDirectories Additional directories Claude can access
@@ -548,6 +934,9 @@ type Session struct {
 	// ErrorMessage Error message if session failed
 	ErrorMessage *string `json:"error_message,omitempty"`
 
+	// FailureReason Why the session failed, when known
+	FailureReason *string `json:"failure_reason,omitempty"`
+
 	// Id Unique session identifier
 	Id string `json:"id"`
 
@@ -587,6 +976,9 @@ type Session struct {
 	// Status Current status of the session
 	Status SessionStatus `json:"status"`
 
+	// StructuredOutput Final result parsed and validated against the session's output_schema (any JSON value)
+	StructuredOutput *interface{} `json:"structured_output,omitempty"`
+
 	// Summary AI-generated summary of the session
 	Summary *string `json:"summary,omitempty"`
 
@@ -610,11 +1002,26 @@ type SessionsResponse struct {
 	Data []Session `json:"data"`
 }
 
+// SettingSource Settings location Claude loads settings from
+type SettingSource string
+
 // SnapshotsResponse defines model for SnapshotsResponse.
 type SnapshotsResponse struct {
 	Data []FileSnapshot `json:"data"`
 }
 
+// ToolApprovalStats defines model for ToolApprovalStats.
+type ToolApprovalStats struct {
+	ApprovalRate     float64 `json:"approval_rate"`
+	Approved         int     `json:"approved"`
+	Denied           int     `json:"denied"`
+	Expired          int     `json:"expired"`
+	MedianResponseMs *int64  `json:"median_response_ms,omitempty"`
+	Pending          int     `json:"pending"`
+	ToolName         string  `json:"tool_name"`
+	Total            int     `json:"total"`
+}
+
 // UpdateSessionRequest defines model for UpdateSessionRequest.
 type UpdateSessionRequest struct {
 	// AdditionalDirectories Update additional directories Claude can access
@@ -673,9 +1080,29 @@ type UserSettingsResponse struct {
 	Data UserSettings `json:"data"`
 }
 
+// ApprovalDecidedByFilter Who or what decided the approval: a human through the daemon, a human
+// through HumanLayer, an auto-accept mode, an approval policy rule, an always
+// allow rule, or the approval's timeout. Unset while the approval is pending.
+type ApprovalDecidedByFilter = ApprovalDecidedBy
+
 // ApprovalId defines model for approvalId.
 type ApprovalId = string
 
+// ApprovalSinceFilter defines model for approvalSinceFilter.
+type ApprovalSinceFilter = time.Time
+
+// ApprovalStatusFilter Current status of the approval
+type ApprovalStatusFilter = ApprovalStatus
+
+// ApprovalToolNameFilter defines model for approvalToolNameFilter.
+type ApprovalToolNameFilter = string
+
+// ApprovalUntilFilter defines model for approvalUntilFilter.
+type ApprovalUntilFilter = time.Time
+
+// ApprovalWorkingDirFilter defines model for approvalWorkingDirFilter.
+type ApprovalWorkingDirFilter = string
+
 // SessionId defines model for sessionId.
 type SessionId = string
 
@@ -688,10 +1115,64 @@ type InternalError = ErrorResponse
 // NotFound defines model for NotFound.
 type NotFound = ErrorResponse
 
+// ListApprovalRulesParams defines parameters for ListApprovalRules.
+type ListApprovalRulesParams struct {
+	// SessionId Session ID
+	SessionId string `form:"sessionId" json:"sessionId"`
+}
+
 // ListApprovalsParams defines parameters for ListApprovals.
 type ListApprovalsParams struct {
 	// SessionId Filter by session ID
 	SessionId *string `form:"sessionId,omitempty" json:"sessionId,omitempty"`
+
+	// Status Filter by approval status
+	Status *ApprovalStatusFilter `form:"status,omitempty" json:"status,omitempty"`
+
+	// ToolName Filter by tool name
+	ToolName *ApprovalToolNameFilter `form:"toolName,omitempty" json:"toolName,omitempty"`
+
+	// WorkingDir Filter by the working directory of the approval's session
+	WorkingDir *ApprovalWorkingDirFilter `form:"workingDir,omitempty" json:"workingDir,omitempty"`
+
+	// DecidedBy Filter by who or what decided the approval
+	DecidedBy *ApprovalDecidedByFilter `form:"decidedBy,omitempty" json:"decidedBy,omitempty"`
+
+	// Since Only approvals created at or after this time
+	Since *ApprovalSinceFilter `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only approvals created before this time
+	Until *ApprovalUntilFilter `form:"until,omitempty" json:"until,omitempty"`
+
+	// Limit Maximum number of approvals to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of approvals to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetApprovalStatsParams defines parameters for GetApprovalStats.
+type GetApprovalStatsParams struct {
+	// SessionId Filter by session ID
+	SessionId *string `form:"sessionId,omitempty" json:"sessionId,omitempty"`
+
+	// Status Filter by approval status
+	Status *ApprovalStatusFilter `form:"status,omitempty" json:"status,omitempty"`
+
+	// ToolName Filter by tool name
+	ToolName *ApprovalToolNameFilter `form:"toolName,omitempty" json:"toolName,omitempty"`
+
+	// WorkingDir Filter by the working directory of the approval's session
+	WorkingDir *ApprovalWorkingDirFilter `form:"workingDir,omitempty" json:"workingDir,omitempty"`
+
+	// DecidedBy Filter by who or what decided the approval
+	DecidedBy *ApprovalDecidedByFilter `form:"decidedBy,omitempty" json:"decidedBy,omitempty"`
+
+	// Since Only approvals created at or after this time
+	Since *ApprovalSinceFilter `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only approvals created before this time
+	Until *ApprovalUntilFilter `form:"until,omitempty" json:"until,omitempty"`
 }
 
 // GetRecentPathsParams defines parameters for GetRecentPaths.
@@ -724,23 +1205,38 @@ type CreateSessionJSONRequestBody = CreateSessionRequest
 // BulkArchiveSessionsJSONRequestBody defines body for BulkArchiveSessions for application/json ContentType.
 type BulkArchiveSessionsJSONRequestBody = BulkArchiveRequest
 
+// ImportSessionsJSONRequestBody defines body for ImportSessions for application/json ContentType.
+type ImportSessionsJSONRequestBody = ImportSessionsRequest
+
 // UpdateSessionJSONRequestBody defines body for UpdateSession for application/json ContentType.
 type UpdateSessionJSONRequestBody = UpdateSessionRequest
 
 // ContinueSessionJSONRequestBody defines body for ContinueSession for application/json ContentType.
 type ContinueSessionJSONRequestBody = ContinueSessionRequest
 
+// SendSessionMessageJSONRequestBody defines body for SendSessionMessage for application/json ContentType.
+type SendSessionMessageJSONRequestBody = SendMessageRequest
+
 // UpdateUserSettingsJSONRequestBody defines body for UpdateUserSettings for application/json ContentType.
 type UpdateUserSettingsJSONRequestBody = UpdateUserSettingsRequest
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List approval rules
+	// (GET /approval-rules)
+	ListApprovalRules(c *gin.Context, params ListApprovalRulesParams)
+	// Revoke approval rule
+	// (DELETE /approval-rules/{id})
+	RevokeApprovalRule(c *gin.Context, id string)
 	// List approval requests
 	// (GET /approvals)
 	ListApprovals(c *gin.Context, params ListApprovalsParams)
 	// Create approval request
 	// (POST /approvals)
 	CreateApproval(c *gin.Context)
+	// Get approval statistics
+	// (GET /approvals/stats)
+	GetApprovalStats(c *gin.Context, params GetApprovalStatsParams)
 	// Get approval details
 	// (GET /approvals/{id})
 	GetApproval(c *gin.Context, id ApprovalId)
@@ -765,6 +1261,9 @@ type ServerInterface interface {
 	// Bulk archive/unarchive sessions
 	// (POST /sessions/archive)
 	BulkArchiveSessions(c *gin.Context)
+	// Import Claude transcripts
+	// (POST /sessions/import)
+	ImportSessions(c *gin.Context)
 	// Get session details
 	// (GET /sessions/{id})
 	GetSession(c *gin.Context, id SessionId)
@@ -780,6 +1279,9 @@ type ServerInterface interface {
 	// Get conversation messages
 	// (GET /sessions/{id}/messages)
 	GetSessionMessages(c *gin.Context, id SessionId)
+	// Send a message to a running session
+	// (POST /sessions/{id}/messages)
+	SendSessionMessage(c *gin.Context, id SessionId)
 	// Get file snapshots
 	// (GET /sessions/{id}/snapshots)
 	GetSessionSnapshots(c *gin.Context, id SessionId)
@@ -800,6 +1302,63 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// ListApprovalRules operation middleware
+func (siw *ServerInterfaceWrapper) ListApprovalRules(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListApprovalRulesParams
+
+	// ------------- Required query parameter "sessionId" -------------
+
+	if paramValue := c.Query("sessionId"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument sessionId is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "sessionId", c.Request.URL.Query(), &params.SessionId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sessionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListApprovalRules(c, params)
+}
+
+// RevokeApprovalRule operation middleware
+func (siw *ServerInterfaceWrapper) RevokeApprovalRule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RevokeApprovalRule(c, id)
+}
+
 // ListApprovals operation middleware
 func (siw *ServerInterfaceWrapper) ListApprovals(c *gin.Context) {
 
@@ -816,6 +1375,70 @@ func (siw *ServerInterfaceWrapper) ListApprovals(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "toolName" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "toolName", c.Request.URL.Query(), &params.ToolName)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter toolName: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "workingDir" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "workingDir", c.Request.URL.Query(), &params.WorkingDir)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter workingDir: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "decidedBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "decidedBy", c.Request.URL.Query(), &params.DecidedBy)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter decidedBy: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", c.Request.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter since: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", c.Request.URL.Query(), &params.Until)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter until: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -839,6 +1462,80 @@ func (siw *ServerInterfaceWrapper) CreateApproval(c *gin.Context) {
 	siw.Handler.CreateApproval(c)
 }
 
+// GetApprovalStats operation middleware
+func (siw *ServerInterfaceWrapper) GetApprovalStats(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApprovalStatsParams
+
+	// ------------- Optional query parameter "sessionId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sessionId", c.Request.URL.Query(), &params.SessionId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sessionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "toolName" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "toolName", c.Request.URL.Query(), &params.ToolName)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter toolName: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "workingDir" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "workingDir", c.Request.URL.Query(), &params.WorkingDir)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter workingDir: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "decidedBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "decidedBy", c.Request.URL.Query(), &params.DecidedBy)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter decidedBy: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", c.Request.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter since: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", c.Request.URL.Query(), &params.Until)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter until: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApprovalStats(c, params)
+}
+
 // GetApproval operation middleware
 func (siw *ServerInterfaceWrapper) GetApproval(c *gin.Context) {
 
@@ -1007,6 +1704,19 @@ func (siw *ServerInterfaceWrapper) BulkArchiveSessions(c *gin.Context) {
 	siw.Handler.BulkArchiveSessions(c)
 }
 
+// ImportSessions operation middleware
+func (siw *ServerInterfaceWrapper) ImportSessions(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportSessions(c)
+}
+
 // GetSession operation middleware
 func (siw *ServerInterfaceWrapper) GetSession(c *gin.Context) {
 
@@ -1100,11 +1810,35 @@ func (siw *ServerInterfaceWrapper) InterruptSession(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.InterruptSession(c, id)
+	siw.Handler.InterruptSession(c, id)
+}
+
+// GetSessionMessages operation middleware
+func (siw *ServerInterfaceWrapper) GetSessionMessages(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id SessionId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSessionMessages(c, id)
 }
 
-// GetSessionMessages operation middleware
-func (siw *ServerInterfaceWrapper) GetSessionMessages(c *gin.Context) {
+// SendSessionMessage operation middleware
+func (siw *ServerInterfaceWrapper) SendSessionMessage(c *gin.Context) {
 
 	var err error
 
@@ -1124,7 +1858,7 @@ func (siw *ServerInterfaceWrapper) GetSessionMessages(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetSessionMessages(c, id)
+	siw.Handler.SendSessionMessage(c, id)
 }
 
 // GetSessionSnapshots operation middleware
@@ -1204,8 +1938,11 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.GET(options.BaseURL+"/approval-rules", wrapper.ListApprovalRules)
+	router.DELETE(options.BaseURL+"/approval-rules/:id", wrapper.RevokeApprovalRule)
 	router.GET(options.BaseURL+"/approvals", wrapper.ListApprovals)
 	router.POST(options.BaseURL+"/approvals", wrapper.CreateApproval)
+	router.GET(options.BaseURL+"/approvals/stats", wrapper.GetApprovalStats)
 	router.GET(options.BaseURL+"/approvals/:id", wrapper.GetApproval)
 	router.POST(options.BaseURL+"/approvals/:id/decide", wrapper.DecideApproval)
 	router.GET(options.BaseURL+"/debug-info", wrapper.GetDebugInfo)
@@ -1214,11 +1951,13 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.GET(options.BaseURL+"/sessions", wrapper.ListSessions)
 	router.POST(options.BaseURL+"/sessions", wrapper.CreateSession)
 	router.POST(options.BaseURL+"/sessions/archive", wrapper.BulkArchiveSessions)
+	router.POST(options.BaseURL+"/sessions/import", wrapper.ImportSessions)
 	router.GET(options.BaseURL+"/sessions/:id", wrapper.GetSession)
 	router.PATCH(options.BaseURL+"/sessions/:id", wrapper.UpdateSession)
 	router.POST(options.BaseURL+"/sessions/:id/continue", wrapper.ContinueSession)
 	router.POST(options.BaseURL+"/sessions/:id/interrupt", wrapper.InterruptSession)
 	router.GET(options.BaseURL+"/sessions/:id/messages", wrapper.GetSessionMessages)
+	router.POST(options.BaseURL+"/sessions/:id/messages", wrapper.SendSessionMessage)
 	router.GET(options.BaseURL+"/sessions/:id/snapshots", wrapper.GetSessionSnapshots)
 	router.GET(options.BaseURL+"/user-settings", wrapper.GetUserSettings)
 	router.PATCH(options.BaseURL+"/user-settings", wrapper.UpdateUserSettings)
@@ -1230,6 +1969,66 @@ type InternalErrorJSONResponse ErrorResponse
 
 type NotFoundJSONResponse ErrorResponse
 
+type ListApprovalRulesRequestObject struct {
+	Params ListApprovalRulesParams
+}
+
+type ListApprovalRulesResponseObject interface {
+	VisitListApprovalRulesResponse(w http.ResponseWriter) error
+}
+
+type ListApprovalRules200JSONResponse ApprovalRulesResponse
+
+func (response ListApprovalRules200JSONResponse) VisitListApprovalRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListApprovalRules500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response ListApprovalRules500JSONResponse) VisitListApprovalRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeApprovalRuleRequestObject struct {
+	Id string `json:"id"`
+}
+
+type RevokeApprovalRuleResponseObject interface {
+	VisitRevokeApprovalRuleResponse(w http.ResponseWriter) error
+}
+
+type RevokeApprovalRule204Response struct {
+}
+
+func (response RevokeApprovalRule204Response) VisitRevokeApprovalRuleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RevokeApprovalRule404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RevokeApprovalRule404JSONResponse) VisitRevokeApprovalRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeApprovalRule500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response RevokeApprovalRule500JSONResponse) VisitRevokeApprovalRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type ListApprovalsRequestObject struct {
 	Params ListApprovalsParams
 }
@@ -1247,6 +2046,15 @@ func (response ListApprovals200JSONResponse) VisitListApprovalsResponse(w http.R
 	return json.NewEncoder(w).Encode(response)
 }
 
+type ListApprovals400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ListApprovals400JSONResponse) VisitListApprovalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type ListApprovals500JSONResponse struct{ InternalErrorJSONResponse }
 
 func (response ListApprovals500JSONResponse) VisitListApprovalsResponse(w http.ResponseWriter) error {
@@ -1291,6 +2099,41 @@ func (response CreateApproval500JSONResponse) VisitCreateApprovalResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetApprovalStatsRequestObject struct {
+	Params GetApprovalStatsParams
+}
+
+type GetApprovalStatsResponseObject interface {
+	VisitGetApprovalStatsResponse(w http.ResponseWriter) error
+}
+
+type GetApprovalStats200JSONResponse ApprovalStatsResponse
+
+func (response GetApprovalStats200JSONResponse) VisitGetApprovalStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApprovalStats400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetApprovalStats400JSONResponse) VisitGetApprovalStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApprovalStats500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetApprovalStats500JSONResponse) VisitGetApprovalStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetApprovalRequestObject struct {
 	Id ApprovalId `json:"id"`
 }
@@ -1534,6 +2377,41 @@ func (response BulkArchiveSessions500JSONResponse) VisitBulkArchiveSessionsRespo
 	return json.NewEncoder(w).Encode(response)
 }
 
+type ImportSessionsRequestObject struct {
+	Body *ImportSessionsJSONRequestBody
+}
+
+type ImportSessionsResponseObject interface {
+	VisitImportSessionsResponse(w http.ResponseWriter) error
+}
+
+type ImportSessions200JSONResponse ImportSessionsResponse
+
+func (response ImportSessions200JSONResponse) VisitImportSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportSessions400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ImportSessions400JSONResponse) VisitImportSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportSessions500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response ImportSessions500JSONResponse) VisitImportSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetSessionRequestObject struct {
 	Id SessionId `json:"id"`
 }
@@ -1623,6 +2501,15 @@ func (response ContinueSession201JSONResponse) VisitContinueSessionResponse(w ht
 	return json.NewEncoder(w).Encode(response)
 }
 
+type ContinueSession400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ContinueSession400JSONResponse) VisitContinueSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type ContinueSession404JSONResponse struct{ NotFoundJSONResponse }
 
 func (response ContinueSession404JSONResponse) VisitContinueSessionResponse(w http.ResponseWriter) error {
@@ -1720,6 +2607,51 @@ func (response GetSessionMessages500JSONResponse) VisitGetSessionMessagesRespons
 	return json.NewEncoder(w).Encode(response)
 }
 
+type SendSessionMessageRequestObject struct {
+	Id   SessionId `json:"id"`
+	Body *SendSessionMessageJSONRequestBody
+}
+
+type SendSessionMessageResponseObject interface {
+	VisitSendSessionMessageResponse(w http.ResponseWriter) error
+}
+
+type SendSessionMessage200JSONResponse SendMessageResponse
+
+func (response SendSessionMessage200JSONResponse) VisitSendSessionMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendSessionMessage400JSONResponse ErrorResponse
+
+func (response SendSessionMessage400JSONResponse) VisitSendSessionMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendSessionMessage404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response SendSessionMessage404JSONResponse) VisitSendSessionMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendSessionMessage500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response SendSessionMessage500JSONResponse) VisitSendSessionMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetSessionSnapshotsRequestObject struct {
 	Id SessionId `json:"id"`
 }
@@ -1817,12 +2749,21 @@ func (response UpdateUserSettings500JSONResponse) VisitUpdateUserSettingsRespons
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// List approval rules
+	// (GET /approval-rules)
+	ListApprovalRules(ctx context.Context, request ListApprovalRulesRequestObject) (ListApprovalRulesResponseObject, error)
+	// Revoke approval rule
+	// (DELETE /approval-rules/{id})
+	RevokeApprovalRule(ctx context.Context, request RevokeApprovalRuleRequestObject) (RevokeApprovalRuleResponseObject, error)
 	// List approval requests
 	// (GET /approvals)
 	ListApprovals(ctx context.Context, request ListApprovalsRequestObject) (ListApprovalsResponseObject, error)
 	// Create approval request
 	// (POST /approvals)
 	CreateApproval(ctx context.Context, request CreateApprovalRequestObject) (CreateApprovalResponseObject, error)
+	// Get approval statistics
+	// (GET /approvals/stats)
+	GetApprovalStats(ctx context.Context, request GetApprovalStatsRequestObject) (GetApprovalStatsResponseObject, error)
 	// Get approval details
 	// (GET /approvals/{id})
 	GetApproval(ctx context.Context, request GetApprovalRequestObject) (GetApprovalResponseObject, error)
@@ -1847,6 +2788,9 @@ type StrictServerInterface interface {
 	// Bulk archive/unarchive sessions
 	// (POST /sessions/archive)
 	BulkArchiveSessions(ctx context.Context, request BulkArchiveSessionsRequestObject) (BulkArchiveSessionsResponseObject, error)
+	// Import Claude transcripts
+	// (POST /sessions/import)
+	ImportSessions(ctx context.Context, request ImportSessionsRequestObject) (ImportSessionsResponseObject, error)
 	// Get session details
 	// (GET /sessions/{id})
 	GetSession(ctx context.Context, request GetSessionRequestObject) (GetSessionResponseObject, error)
@@ -1862,6 +2806,9 @@ type StrictServerInterface interface {
 	// Get conversation messages
 	// (GET /sessions/{id}/messages)
 	GetSessionMessages(ctx context.Context, request GetSessionMessagesRequestObject) (GetSessionMessagesResponseObject, error)
+	// Send a message to a running session
+	// (POST /sessions/{id}/messages)
+	SendSessionMessage(ctx context.Context, request SendSessionMessageRequestObject) (SendSessionMessageResponseObject, error)
 	// Get file snapshots
 	// (GET /sessions/{id}/snapshots)
 	GetSessionSnapshots(ctx context.Context, request GetSessionSnapshotsRequestObject) (GetSessionSnapshotsResponseObject, error)
@@ -1885,6 +2832,60 @@ type strictHandler struct {
 	middlewares []StrictMiddlewareFunc
 }
 
+// ListApprovalRules operation middleware
+func (sh *strictHandler) ListApprovalRules(ctx *gin.Context, params ListApprovalRulesParams) {
+	var request ListApprovalRulesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListApprovalRules(ctx, request.(ListApprovalRulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListApprovalRules")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListApprovalRulesResponseObject); ok {
+		if err := validResponse.VisitListApprovalRulesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RevokeApprovalRule operation middleware
+func (sh *strictHandler) RevokeApprovalRule(ctx *gin.Context, id string) {
+	var request RevokeApprovalRuleRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeApprovalRule(ctx, request.(RevokeApprovalRuleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeApprovalRule")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RevokeApprovalRuleResponseObject); ok {
+		if err := validResponse.VisitRevokeApprovalRuleResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ListApprovals operation middleware
 func (sh *strictHandler) ListApprovals(ctx *gin.Context, params ListApprovalsParams) {
 	var request ListApprovalsRequestObject
@@ -1945,6 +2946,33 @@ func (sh *strictHandler) CreateApproval(ctx *gin.Context) {
 	}
 }
 
+// GetApprovalStats operation middleware
+func (sh *strictHandler) GetApprovalStats(ctx *gin.Context, params GetApprovalStatsParams) {
+	var request GetApprovalStatsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApprovalStats(ctx, request.(GetApprovalStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApprovalStats")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetApprovalStatsResponseObject); ok {
+		if err := validResponse.VisitGetApprovalStatsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetApproval operation middleware
 func (sh *strictHandler) GetApproval(ctx *gin.Context, id ApprovalId) {
 	var request GetApprovalRequestObject
@@ -2177,6 +3205,39 @@ func (sh *strictHandler) BulkArchiveSessions(ctx *gin.Context) {
 	}
 }
 
+// ImportSessions operation middleware
+func (sh *strictHandler) ImportSessions(ctx *gin.Context) {
+	var request ImportSessionsRequestObject
+
+	var body ImportSessionsJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportSessions(ctx, request.(ImportSessionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportSessions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ImportSessionsResponseObject); ok {
+		if err := validResponse.VisitImportSessionsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetSession operation middleware
 func (sh *strictHandler) GetSession(ctx *gin.Context, id SessionId) {
 	var request GetSessionRequestObject
@@ -2328,6 +3389,41 @@ func (sh *strictHandler) GetSessionMessages(ctx *gin.Context, id SessionId) {
 	}
 }
 
+// SendSessionMessage operation middleware
+func (sh *strictHandler) SendSessionMessage(ctx *gin.Context, id SessionId) {
+	var request SendSessionMessageRequestObject
+
+	request.Id = id
+
+	var body SendSessionMessageJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SendSessionMessage(ctx, request.(SendSessionMessageRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SendSessionMessage")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(SendSessionMessageResponseObject); ok {
+		if err := validResponse.VisitSendSessionMessageResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetSessionSnapshots operation middleware
 func (sh *strictHandler) GetSessionSnapshots(ctx *gin.Context, id SessionId) {
 	var request GetSessionSnapshotsRequestObject
@@ -2416,105 +3512,172 @@ func (sh *strictHandler) UpdateUserSettings(ctx *gin.Context) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9R9a3PbuJL2X0HxfasmqZIs2bEnc1y1H5I4M+OtTJKNkz1be5JSwWRLwjEJMAAoRyfl",
-	"/e1buBIkQZHyLdn5NBZxaXQ3Gn15gHxPUlaUjAKVIjn9npSY4wIkcP0XLkvONjg/z9RfGYiUk1ISRpPT",
-	"5IX9hs7PkkkC33BR5pCc6j6Lb9t/Pf/tb8kkIappieU6mSQUF6oByZJJwuFrRThkyankFUwSka6hwGoW",
-	"uS1VKyE5oavk5maSCBCCMBoj4sJ8atOgeizwZZrB8vDo2fHJr/dCyY1qLEpGBWjuvMTZB/hagZDqr5RR",
-	"CVRatuUkxYrG2T+FIvR7Tdz3BDhn3HTJ1AR/vjmbPpsfJpOkACHwSv32FxGC0BVy1KElgTxDv3ytgG9/",
-	"MWzxhP5/DsvkNPl/s1qWM/NVzF6ryT5Yss0imix8iTM9i1rGzSQ5pxI4xfnrmsi7rOtYrysDiUmumSY5",
-	"TmFBMqUpl+nh0TM1ab1uNz0SwDfAkRnzHpfbM8Ekecvk76yi2d3XfDg/asjSKSllEi31FPe4ng8gWMVT",
-	"iI6uOe42qt7enJXAJTEKnLKisMuM7W3gvwjk2oTby37O0DWRa5TiSnebtDfMJEk5YAnZAkfmeKW+KbZI",
-	"UoCQuCiTSbJkvFCNkwxLmKovsWFJxBJ8ouRrBchZLEQyoJIsCfCudbKKFxnZ7O+sh2Qnh2GSaZXn+FLN",
-	"aIxKd6KKLmLLeCEES4liGuJVx66pXt60dsa0dnJoXLHDZmawNNayO7jEshJD6up07cK0vpkkkrF8QWhZ",
-	"md2UZURRhPP3gSYaHjUJ/shYjnQ/FJxJk3DvKdXEasMmvEBTvkQzWZQzaQ2ZXQG7/Cek0lNiLP/32GTW",
-	"CCqr67SowSD4BmklYeGmnUSOqvow+Yc9XYycG8LxzGxskJDABtu+RNbi+OwtQ2dvZ1jisdLqkK4775r3",
-	"wmtDa1NXnAOVyCwQsSWSa2iwk1aFmqEEmimmTayPAZk+JiiBLJi4Vj83sRheMZFQiPFL95NhzvF2PCte",
-	"VvnVC56uyQYCL6BJEjbfI/vxI68ASYZsiwla4lzoXypqf6sV7JKxHDBt7nHR6w2JYOBZOJzX5X+Y3W6M",
-	"oP5fteu/TGredQRQEHpuPh4OcCwkcVKzYJCHQ3Jt/rrEJIdsYSfbyYw1lsg01/wtlaGOcENZ1Z0saK56",
-	"kogqTUGIhkfQMPdebm0O2Y5dloxVvleMSkIrsIvsV8A8Z9eQLZQ5ifDohfmM9GeUE6HM0HgG4FJt44XY",
-	"CgnFouSsKOPOBFDNetMQ2YYxf6ESkhULQoXkVSrjgn2lG6FGo8hYGREDqz/zLW7LgAJ/W8iKx6j8C39D",
-	"KaMb4MK6Obqd3kikUEaw3keESliB9kKLtFykjC7JasiC/fXq/SvT8GaSlMALYrad4a5ec4SqV+/1WtGS",
-	"cVR3ijJQhxrdId7CNdKflERTq4faE2yclm/ZNcJZZvxrtMY0y9XJKpk+EcyAUT9jtzK92wDnJIMhXWpt",
-	"JLOWUTtpPzOU5rjKYNF0vWouBJ8X6ZrkWWzJJVZnZu8YurNp0+e1Vt1e6jc9Y58/t2s23TE6Wa+tD32d",
-	"LlNii7yT9fP76vXGRjAtw2fP9yFnGDeyGINuux9W9DhAPitiPSC1z/SGS3Gei5EOkI5DWG5PzUGi9tDB",
-	"HgUK4t2WvTBBLHINBmO8cQEcKKEtzM8dz2hbgnIcG8ZTdwi454Jr6ygr5rr/5yCqXLU1FkL9vCb0Ss38",
-	"pTeW9Nw6PHp2HMR0hMpfj5OYoSZCBQJlDtK5d0us5j3Vjlw7mvn7GuQaAlVAaywQhxSUb4Q8zV2Hz+4b",
-	"vbRKQFSf3+s2ZvBKADo/03pHQSgVd5rXNRssh36Rq6/oiRrHMtsIQTwNxFAJHV5jIYiQmAZc/xI1OV8r",
-	"oCnEfDXzBdGquASOCG2IPzxYTmLC2GnM+qN9E2RlPfEgoRtmEj+KoU/8Tq7Z0DOgitoWLlfUHPjfL969",
-	"Raa9Do7qINePr5V5cJIdcaz6tO9wRgEXvXbABsiq0S5bEI61ZLyft5qo8zMk10S4cYm2luPC6mY07fSq",
-	"YVgalmnoFLmnqLJ7MN06vNTpMajj/B4Hvy+P9EEnj8zx04rAR2aT7jtxs08+5q1SYZs8kA+Rm/Guyh45",
-	"l7ZE9nMUdzokZui2N9LKWlK4HuOShRPdwcXSFA2Gl14rFhnhkErGHROaXpFvh4J26JX2TFCKKcImIm5E",
-	"5f8zO1hXBaY53gKf5Wylvs82WP//rNjistwvYB+IB/++JhJUDKhUrxEZNunigLPFkuRKZ645kWD++HL/",
-	"ofNH+CZ1MufhQ2htKoxAotE0pivgrBL5diGuSLkIg8dB9+cNrmi69plnXTYIRkRqxDAcRUCVx5tFPaJd",
-	"pCyUx8kq2SDpb3P1X5umd6XTSNMO2a7K+ShInhMBKaOZYcwuYpOIv9jjswcuy3B64mWO0yunjlkrV9HU",
-	"yLb5+3J/SQxSVEU8kVH7zPMHymoULINYEkP9rFN5AvwJZ3UrcE5ZqVPsglEKMuqQ3jFrYndh1LcuOfu2",
-	"XeCSLK4gkkR58f4cXcHWDKiaIlzJNVBpS439Q15iAYuKR6h8iQWgTx/eBIMK4BuSNpKdyVrKUpzOZqwE",
-	"ylklgR9gMsMlmW0O+6d1G3Jwq7/WDe38anx1ZhshERFIKRLp6Im0zBfMpnn6hF9X94LV2tkaq1WrxGS2",
-	"KuX0eI8k1zklkuDcJroaprEe+0/IS1QA0mcAwuj9Vq4ZtbktpZ8lZ+pUQ68u/hOpI0I8YMJrkkgiY/Gc",
-	"t3P6e2y/+AUpOt8bmpXULnqTdBvgl0zAaG2w7RGrpPKuYtK/ZlzF6MqPiJzM5qN3HrY7lzFbswJmKjad",
-	"lZxpj+YO+cGmI7Sf09fnnTt/r6fES+F6VNYuPuiu+u5IHzKW1ru9L3kGl9XqnC5ZP/vSnPjDq7uwN+fI",
-	"fkTmFKk0IoZxpCyzAXKIppHLtzzGvxwLqUyMMh2Rmd5gIZH5nNbgBBeJqAUq84us71dPdzQ/Op7OD6eH",
-	"Jx8P56fP5qfz+X+PRjNoTFIkpyPXLlt+8R9vlIXpnz/Q+NBlzjAUjB5kl1FVIv+KZWLIv+LrVW7R5VZC",
-	"6+Q//u3k+a+jEmZCYin6Q8nvY8Zo1W8cfWpoIiRJWwABFwqJ5PTwxCYHRHJ69Oy530kiOT0+iqIFlOFa",
-	"pKyKpUPemjSV4pNqJhRzQo4NJKxaG8eC0rRAmhM7rk0aGyS+x1KSDacLehE//pSwLdCTGnmm/F+g26cN",
-	"lXvD2JVAAi/Bn3QQrW5kkBJtovtz5b5J7bzZpLjJiW8j3lvb+LghxjBnPyPuIV6to00XtlxulCxtiTm6",
-	"1X5coVhTeabhdzFtiHlYZmHqG3oCB6uDCTKgtsOmAtRIt4jIPdxvfN4oyBGApYBK+CZjqSOPrWvT/qcy",
-	"flMVoGu/A0IZNajvYvKGNEwzq566l9n96uUVaRDwZwXWJsEMEJ05XgVzCj1eCnqgqSghVWegNmjR3J0H",
-	"wHXVZ6NrEXuD+lw1aCdz1NgfVcM2a2zKN5y2f0/4UXqLT9bZbpedKFwvgvyjT7X5cl3toZn63yJdY7rS",
-	"H8IYemFAKI32IFXwUveIBay/kxwuKC7FmkWNe0/2XnVzaXuEJRJ2CNQni9vU9JSTsBj2ZXb5LtZbnxWY",
-	"0INye6eSjUb9pM4ldjwLJ/YltTEesZs3XGddNx2sNfwJOJfrftNQl5N99uJKDRQEslc9gZg7W+um84PD",
-	"g/mwj+8wiG6MGN0aLc2rUt4yALplYa7LDuIIsXXceqjGl4c4fOPYzdsfyXWKq8OuIi0vbDSzw1EeyJ+Z",
-	"Ebru8l+41KbN4N51ldAgeHRA1Sm0fteabsu5Gsu4EmpdU328ThnNtVtWg3CLtJyawadBz5ubGKNiTLF0",
-	"R0CUq1gK2waEmK8q5bAKU/IUMiPMhYRPm1nSkPJJYHf2S5f2h6mWIsmQzccOkdTDshhggm52aUTEA2tm",
-	"YTaEM6r9+g3mxMQsA8R9T85ev/z0hzKUvIIoonoNOBvQ1QHK/vz48T2ywyjGEZrmVWYZpz/GSfuvqTVI",
-	"0/Mza07UH/Y6SdddiR72RuGQ+oierKUsUXvWCWIFkcgz6mknkxoTVjQ7q4cFmpWMUKnTtLvXqEc/nc1y",
-	"luJ8zYQ8ff78+XObp50VaRk18J2Vf4AUqHxvj+XmxtLJkEr0JkJ07kOnbdVxh66xQLr13RIbZz6HZw/R",
-	"Xa6AsGW+GJeVGz4iQFceoaO7TlyMjsprJjWn/LKT2fcFUw/Ed2skgd0p//ertw0w/Ri0lcuEEoF851ju",
-	"GVeSLdQaSrmAjEgxfgrV3KKHMQekRpqakXrmSnG6hkVqLz9ZsJBkVxCrv9VqrLsh183iK2y3RkJuPqb4",
-	"aIjQdez9CFBdeic/MWXWEdPHAIutw1Q3+UUgUt/Xi6a1R6EbLU4veqnLZQJsq1E30oYhmSZ3schJQWJ3",
-	"38xndE1oxq6RbuVLGqbqGQr119/GMpZpYxUNj6RO7gld3/500WDi/GB+Eqx0mTN9H6lnPoPRG7re59l6",
-	"+2t+d8Mc/H0NFGnCEc7zAITrN2qBJVG/bBEOLzSySqrzQiegRAPoNhaEAN9KwkFE+XJ+8a5mBbpWRO5E",
-	"QihtQHZA9ITZNO3TW2tmZl39RdF/Zwa5Rm0sRKg0xycjlRKWS0gl2cDC7Yo+a2OU1HxF+pg1VwauMc9c",
-	"OrCxZxrW53Ck8dNZtEVvCrGT13WGpz+/u+M+qj+C4tdRYxfVu8OPNNE7DoVRjNGuDlaiInIbVV7tFroW",
-	"t9jROwEdysmKIQUCbhkoR9/A0YPk9yrPjUntk4E5QaasrMT0eHo4PZofncx/m5/E5jEF7BGyMA3jh+QY",
-	"WUTvhERR3/W5qJRV8075O4qTV3U1uKt1O2+UjMaa2FJhDTdpMfeB0SbODTPzEw8cu3/EiYUbaeiaX3Ef",
-	"1IQJMT08ml/eGnGis5dCYi4h68U5OPwJhyVWTrlZsK1TjL5xbg0Vr3qN1MCt81EXw+3JUt8LF1VR4Bgj",
-	"XpxPV0CBm8StaeXULMaFD3b1kLUwVGrXVznsAZX5JIBPlUevi0duY5nG4ZR/bdF5UTIuMZXoIxbR1OyP",
-	"BbS0Lp+7XK9Rvta9847d3xFF3u3CuQtF941d97tu3oUC6p1k8sO8otT8X319Z5L4s72VTfZ/6o/XmKjf",
-	"OxjxWuju0vE9hf+eX7eO/W3h474IahSgbk3VJ139ui9wuRkN4Z8xS9EwbO0b+S1lHZ2X6ALsZhkR2mYF",
-	"+QdtUer0xN5RTN9ciHHkphuMXG4N4I6GJwGU8XZQbUfTLfDaY7DIT5QPOUHGTZ0oRkFRyq1F0Gg/5unj",
-	"ObTPpidTM4FyaY8P50dHD4NUDtZzNWV8enBw8HPjl2+DVx6oHT4QfBlTueasJOnMCfXACXUfv8ZYyH6H",
-	"xjTItC+D3uJY8HbTa8eV13Rh0Qs7jPkG0xSyRcnZhriq0ZB9cb2Q64VMAiRmzaIEBqTdiaZeQlBOrgC9",
-	"K4F+0LoYz/neAk5hESJ79GnfA+uuruX3BVN8GWDe3dy+hhhGegk3OvGxZA7YglPNCPtyn8Z5vVFnNrqo",
-	"SuWKJ7bu5u1DfawfZLDplh4/vL74iJR102W4ejwDmkVqjdrAiYkVujIMbgsVmOIVFEDl5DP1t/aUy7/M",
-	"2bWYIEwzxAHnWlYGR4SE5IALNUyKS3xJcqKYePBZH/5m54YLOzOEODoDpMZpcngwP5jrhIQKOkuSnCbP",
-	"LOqjxHKtJTPzxmOhDczse51NuNFFRJPjUo1vJsksQMt+T1YQS/8QIes7ivZOpjAxsctM1ukykkvgxqB5",
-	"Zp5ndhj/npN5GsK/OfmPCHZJAkeX22YBQL/m6KIZqxT1O5G7XnH80nrF8Wg+H/Hk37jX+rqvVEVe7Hvj",
-	"bhh6FtxMkhNDRWxwT+2s+TbjTRhF98hG44kNbqPm+Bd1WjHR9yYfIIwoXHcG0xtF7yrEYUPguiPY5g1Z",
-	"+7YmCPmSZdt743H8YvRN06yoQ/qmI+jDByOiX9oe5GxNrxL28RhhB6+L3od+ONG2hNqjIA17MPtOspte",
-	"o/AHSGQAv5AhZbHVSaX2Kb5ULjpGHkwambupP3+ADJSnZRZiS6+bzIKnah9li4+SuQNCa5kfDwvQv0F6",
-	"HxJXgsFtSsaKe5ZpzLw+76Omwr4BiuzlAKTi6yH5NnH4dxfx/RuX+DWKUcZl/mBE9Cvamb31gDikjGcN",
-	"63IvpIx4TneDc5L5KxxKH7we4JwDzrbI6FL2Y7aB4SZidB/bl8FltZo6/3OH3busVhGjZ+4CaffNINp0",
-	"rje8riSMh1hR7R+2QZgds+iv0CUPqnfte3pRlWsvmYPkBDY6W68zbMsqz7cRY9ThViCAC/tskOb+WqOl",
-	"ezn/ag3plSl21WwWyGZ2NWPNCNsYKw0U+yH52AJ7R5h4YfIaimpHaZNdZgiUqpX2cYlrbNjU+/tRXn2w",
-	"wkGmdb41ZdbrVv2BgEmefa1IelUnSjvMCxBuQ467ezuA+pqophRJpjSm4rTHi3eF/ZrXvgR4NNevFdiH",
-	"BuYDzw48qBsQg/pFX+dWzczK7+1QN6KMyTBUFXe/0ShL+E7qjtgu9/FbO6zz4dwBern1b2YYSQrEaL5F",
-	"OWCPlhCf6ZPmSJQh/awgB/r0AF2A1O3f0Xz7b/7t2xU0aTCxcTd69Isb0MEPmrwIdehJSE6fJlr64srY",
-	"d4egW9g1kGZXGKhpINQ+fSV6CLBo6Bc1djFCh62LDxMSMqNDTA8Frl0/G/qmf8jN16my7Yiy/QLvLcgO",
-	"WBbZbEOhNc1MXd8G2bY89YplYSkoFlZf+K8PF1W3SnI/JKhuF5qjx2eALez4HT8mvrZPHBmpBkW93eZ4",
-	"5h7n7o+zbK2Q8fplcFRUuSRljVHQtgQjQegqhzp12dGk4L3twIQ+hD5FXkd/5Cgq9rZ47J8/qfKrmmOo",
-	"BgXcTJKj+fPHJuc95hoG5C6f/SBt1lzpPCE/YPoain1POaM+m/gHyNog7pdGqNPEj3FIjbFjPzxNJFqE",
-	"9J1sWKbrwfKiu8msdHiNsGiAEjScmfHAAdHQnYPPVLkYTu7u3ztSrmOeo0uwr/ZnMYewgSa5szbcvymM",
-	"ol0e2RjuoYyW05FD9bE1s0evRhqfmXubvv9sbZQ7fHVcXxy3fQVaclYgTBF8I+b9Udtu8pkSugauEWGI",
-	"SNF8JG9NhGR8G9PX1ovzP6HG9vzrEo/tDva8zB/R3beB/Bp1lsdWWUezMnFLxq/UUTbaF9Ra6xGH/Wp7",
-	"ATRTKumbIkFWGhbFEPZpMKenKMWVMDqKJPtMnYeDVhynoLd3TEvbN/9/1mO294WCHSYuQHX2xQ6Pkz0P",
-	"X6EhtBadxBJ+jAJ7dnY1aawGB7CCgZykfg+kyvOo6dT5SFyrsU+kf6Zuhklwq8tALmT9Tnk0eVS7jX85",
-	"Kn9SvY6+Th5RobAd8qz/YZ5kGiVnpOa4Z1pGqI5+ks63RykupX4UMKv02/oBsnaCxJpda73Rv6q9hdjS",
-	"PMipX8NxsYa+mm/eciMF7FYfD6z+acOPDvI7ojy/N7j447SmKc0d6qIRVDP3kJ+GLVUC+FQEIL/diqNv",
-	"dpYclsCBpmDqcIFr2RF4A7z2gAKLwu0iMlPt6hirr/h2T4KpwskacrE/DYeF+zG8CylNHjIqi2FXHzk0",
-	"Gyt312ZHgPb4eaJQxrvVxPxDzvZto06h5g1Lce5quf4aYQ3o7HsIRdtQO1vHTTZvuJkCq0u8y0r4V1hE",
-	"XeewhdVu0cR5aDlZQrpNcwign0H3usgQf5GQ0KlcwzRnrERduGg90IsAE9g1YT1w0rr7a2MYu30N7Nzg",
-	"zP3yjYeVa+lKsgEUIoXtiO/1NcebLzf/GwAA//89C3akKXwAAA==",
+	"H4sIAAAAAAAC/+x9bXPcNpPgX0Hxtir2U/MmWbYTpe6DbDmJrmzHZ9mbq125piASo8EjDsAAoOR5XNrf",
+	"ftUNgARJcIajF8t3tfkSawgCzUaj37vxLUnlqpCCCaOTw29JQRVdMcMU/kWLQskrmh+zlGcse7X+jeeG",
+	"KXiUMZ0qXhguRXKY2N/J+ZpcLyWRilwvqSGZfY2YJSN+qmSUcHjj75KpdTJKBF2x5DDJ/ArJKNHpkq0o",
+	"LPJvii2Sw+R/TGsgp/apnh61YUtubkYVxCdZF0j/Bjk5TkYJ+0pXRQ5rwzvzr+t/vfz5Fw9dQc2yBo5n",
+	"yShR7O+SK5Ylh0aVLITSrAsYpY3i4qIBxSkXKevD2Z8iX1do0SRVjBqWEWoAf3QB+DRLronhK9aDNQ3z",
+	"NzC2kGpFDSCUGjZ2r24A0FBT6u276scTjS/0geMf7raDFogGXJ+kzN/TFdsOmZEyJ7h+uKWvqF72AGnc",
+	"1MmwLfwsDM933MJztpCKbd29Eqa+y+79JdUlFxfHXA3A05KRazueZFyx1Ei1JnLROJw/aaKZ1vBuHOTr",
+	"asUt6HPTxI7hqX3UPoXwxpyepxlb7O0/O3j+4l7O4g0M1oUUmiFHe0Wzj+zvkmkDf6VSGCaMY3U5TynA",
+	"OP2nBkC/1cB9S5hSUtlXMljgj7fH42ezvWSUrJjW9AJ+e8e1BvR66MiCszwjPyEOf7JoGXQw3sBiHx3Y",
+	"9iOaKHxFM6LcZ9yMkhNhmBI0f1MDeZfvOsDvypihPEekGUVTNudZcpjQ83Rv/1lyE363X55opq6YInbO",
+	"e/zcngVGyXtpfpOlyO7+zXuz/cZeeiIV0pAFLnGP3/ORaVmqlEVnR4x71gj/LpQsmDKcNUTyXJW53ZOO",
+	"mMuv6VoTmufymsAoYkAY2xedNEaumdI8HxG+IFSsG0cRXhr/vNg7YAfPF+OUMTo+ePFyMaY/073x8/MX",
+	"bI+ls/O9dL/LnUZJKlcrtw8x8cvUT5r4MeGiRx6+a26WJKWlsWyou4DlsnMaWeM1PIN9A96pDV0VyWgQ",
+	"Vx15DWR+vr6F5gEfUnDFdBSqv5ZMEEoKJjLgD5UwRSCJLA3uAjeaFDLn6ZpoZjSh+FyWpu8TRJnn9Dxn",
+	"ngt2PilGHZ8F/7usOT7hGROGLzhTXZ3IHfbIzFLMPXADkfXJDv+zNKlcMZjDfivS8QZdzWGkpuOYUhml",
+	"YiHHC6lSNi5KvYx9hBUMWQ8p+QO8nZS27oMqRfykai1TjiqDKjsCEd6qtNLOnE7AbptXbxC2GVtYMdud",
+	"3GpkO+pvI9St5lwUlixolnGAiOYfAhZmcdQEGNQ9gu+RwAAZhUwbWAYFTp+oFRmrBZmaVTE1TgK6L5Dn",
+	"/2SpqSCxKsO32GJOeobHsYEg9pWlpWFzv2wESWWRIR+62/dSTVjGUWtcBzTN1K81o1ZUWK7IDeFCG0Yz",
+	"1NxqbHcwcBMqSf/ptCZLhg3aGdVKe8BXQ/w1dvVLBNVddhhhgJuNwkNCybJcUUHMUsnyYolPM8pWUoz8",
+	"szPhH/4Bf76la6ZGhApCSyPHNE1ZYchKZsz+GGEg9gGKxzNRy8cRwNZSgx13m5DPQjNDrpc8Z40xhGvP",
+	"0CdnIhklTJQrQDXCmozs/3MAMhklAOLcgph4zoc7kiOC7WLJlzaVjZKvY5h3fEUVbIaGBSo8/+FWav7w",
+	"1i1Z/XpUGnnkl65+/eBhqH75aIGp/v7koQr22L01iF3rEUmXLL1kGeGCSJUxVVtGzHOmn3Rn//SEfFoy",
+	"suBKG7KiJl2iVg0SwBKPngASG2rRfQgkBBpe54atBnM/ixJEXs2IqFIU1YIAqCbG/pDXJJcB+9EkZwtT",
+	"KQnXlINuqDz1j4BTUPK7JFmpUMeZkCPQc5km16BfAE6byEItQsiKlhsM7tlsFWNqbuy80oS7YpFqKcgF",
+	"v4I1JXmd0zJjNQjV+XAKUdSADVmTxfomxhJguAPOO/hipmuFVhMpCLtiak1SKSxDtooD14ARQkXmyQgA",
+	"XnVIiaZ27m/VkUZWgSYRqhhUXw48qhbyI/e6/evYTuIewVROaaYii6H7osypAmwqJ8o1oypd2lNFCXg6",
+	"SC2l6g2+4IaA5kMm/xhbRSi236u0mFurqrv277k8J9Ju67vXH7z1JRcE3pqflbPZs9T+iP9m/jfYDPsL",
+	"7ktDkic5F4yqKCy3pTnHGgSPkZv3HHQmhZfgEVEsBe6UwbfWp5F7UaV30yrvRTUGl8fG/ViAPIJhhNsf",
+	"TKVS/EoUy6nhV4xc5PJcW64AigPPWNwP1PjCTKZ6+o9/bOAOD8HNfhDmZLWdzbjf6HLczOwcb9nE7T6y",
+	"FVudM/UnLq0j+uuSkbOEBlb+WWKPQOV/XNcaEJu7kUDMzrPX5HdIHkNpFU4N8lwkU8UW/GvECrfsiNjn",
+	"uO/un8jsfyUZW9AyN8C2Q503izMySVDNj9kpqSzYLqCf4gs3Nxvx7xw3HddLRs1gn3Zn5/Hljfte5pE1",
+	"K3cP3xTQqJjgNa290AslV30OGiqy+cDdk4vG5g3dmqaXZpgLZoO/Ar+ux1cBz8b9vorb0/dtyWuzde5d",
+	"i9FN46KPJ93RzpQLwr7S1FS7uNlk3sbXRj4UMM94RHX4KxZpKJT8J+tC0MMqrXGKCG1ZrKFtaje3QW7b",
+	"Dtk7Tw9dERbajbg1q4h6aT118MvEoVQwlmlr1IA+gQgf2Xf8BFSs/e/uNPkn7jhqog1V6JBAUx9mS5sn",
+	"EbXYVApDOTimiV6yPCdAAdRIhUaR11gRLoeqpGLUw5TWDq7euMk6Dz7Z2Tu/f3DLtRB/6o9TxzuqWBf1",
+	"1Bo3E+LPC/wKAk9LfAahP29EakQLF6Vje6GB+WuT7up3rZHgRnk1qqMahVitY2NuyltgFJFwWk3UefTB",
+	"z9xCnt4ul3YyW+MG665CC1x/eoNg0oYarg1P9YRUPypqYB8Ua8p+vYSf5MJ7iM7EEyErFfJprVpOSNM3",
+	"i1OtGNWl8vufVnEACW4jIC99zdTI6qB+Ir8SKkxeMc0lnPO19Qm1/U3W1dMXkaGmyTtnk1+ehT5jWZ7n",
+	"geATJeh5gM7z9bwZfoiz+Cq8yYVhF/bVJuLf45QBEoOPLZhyv6qmaxU/PDnc2688U4fP9mIq0vl6XnuG",
+	"7w5iE7TKDxlA5mkjOTyYwTyCw7+fxWBbsYxTMffB3vkqQpbvcAxRIfWMSGk9fNwsZWmcG9Iryzr0+XNh",
+	"Xhwko8gXWiu3K+kr6scvhFEjoqXy3t7Ajhh0doHfNk9ezOMkjY0btsFsnW07LtzUNh2OWqTtv3MrQ7gf",
+	"Fdp94W1YUhnZjNelUkwYR2ftzIeAyzuGU30+y5KK9gKUWBMy21EEWOg+VEs0fz+qF2w+OPbLN39+42EI",
+	"vr/lTYiIW2rIkhYFE85zKOS5zNaOReqGJHb+WlR4vEcco/GqLIxzuTQDyuhf436IDiXxhBwHdh8400Lh",
+	"6p1rFgWY/OEmGYhi9+HOv+b+Oqqmcz+c1LMGSLtv6Ro7mIJ9NXO5WGgWcaD8ib97qoShpKAXrE2mZMk1",
+	"aCUjYqMSzq+BQ7kmizLPIwxq6BF6VeaXRypd8isWJMm0pJ19HrFrPqkS6IC4ESOyoLnGX0rhfqthO5cy",
+	"Z1Q0bSXdayzpYOJpOF0lLKx2Nrc2IP4TYptfAs7aNQy5OLEP97boQSGIoxoFW3G4jaaavy4oz1k2d4tt",
+	"RAacYTsc8YuRyAg2IHa8EQVtGtVlmjKtG1pMI6hd7VsbQ+7FL9sikb3E99pp8O4j+wkQPF4sm/fI3CP7",
+	"2DqeSc7ROzEcAcgXs7lea8NW80LJVRFPZWECUW8HEjcw5gcptZGrORfaqDLtcem9xkGkMSgyV8b1lq8/",
+	"rkbcFgHsq1F0TtVFZPY/r5hS3olc638ktW7W129PCFUX5QpY4k6LLmien9P0cr6SGcu3LOwHEzs4gqeF",
+	"VJf+GNnJUOwkh8iRItlY5YqRUmQM/NOCXXu/cZ08MSI5o1dgGFp2q5gwmMckrpjS1tQohZElBGaiXA6N",
+	"/7nXJTdLkRMY+5sdig6sr3NTqhjhvKNfm0DYccjb+Kpchawt0FchfpNKseAX20B59/rDazsQ/L1Mrbjl",
+	"hCtMndv87odq+DsY3ZzAnph53NsO0SZ4Yl3H1UvRQ2EzVDtTvGfXBB/BKfXeAdTyG77D9+D1yTKbUkiW",
+	"VGQ57rL1S9sJe3KHMIVvnvMVN1v1Ap/x99aORqlnwNkzt79vO2xutEbr1HiBmEuaDbUdTu0Mp7hclPW7",
+	"JYZCghEoqcj/Ov3zfQxDW1hoNekWDtoSH3a3B8mP3YSv5WHzpuO2ppPg8Txd8jyaEWT5Qu8c+LId0+Oq",
+	"VmX3LfgNV+zL1dq0Gr4YXaxXwwkThbpIiX3knWR+xbqOjKHp0qeONjfnPJfp5dy+3g1XYN4vwTEExpAn",
+	"fEUv2IhkMkVhNCKKZTQF/7BZcgEOvhG5ZudzG0oHp0GZm6cNxoBTRIWwI592RrZmLw5IQddwKOuliQFV",
+	"XirCRKrWhcHEJwsCwZmicfCM0+prWyBNC7R/Oi85dhTHEfi2reBC8DB3SckrdBA9OUfQR6RU+QjBbSLC",
+	"Po6HY41103SelCqPlwWEVBFs6jbaeHMVJYvNwbE6CZI2Cn+2pmtW0+oeV8JRsxoGpVQdIRjsSlBMy9zZ",
+	"EduBqo5HBKD3UoyR0FwSPNFluoQQO5KMrslRo3nuT0NFikOFSM95jUiTHdhpDy8M8vnbXjyM/vtvva/g",
+	"IwMa6zk/n4CryEVT1cIXgs32WQkuQAW04P9tGUzihSL87DH/pTcOWmELymEGuSG5hnzVImeGZdv13r+W",
+	"zCxZQLlkSTVRLGVg3JIK5q4u60QAflqp41UIH3CMnbzUjJwc4zERTAPh+YPSlYAymuXlthyekicwj0O2",
+	"3QT9NNiGUtuMS625NlQEWP8SlZ5/l0ykLGZs2yfEuukhQBRuf8gjn8c2Y6Nc7o9WI1JjCHWx3CunAAJC",
+	"n1SMp0bDhtjx3NfCNCcGBY7Y8T6r2MWMq/mRmLcusiHdGh7tOp0lwHkvH3B53DBoEy8I51pI1Y9bBOrk",
+	"2JbtuXk5MvdN2d87o7eRq9Wf/j2qvXv1AYVMAdqsk1lScTE4mt7I+/aU32B9WyPpoQi4J6dpV9LfOi6J",
+	"9T+sTuXp8SH1FWR8xCoMK89bwYGBZRn3XQGxS2EDVLU2SOzORQ6dVN1IBsaW6oD2juxmlW3U8F77iulN",
+	"dd2CXQ+xf8KF7mDPIERbPZgVVcx9mgGPeQCOqnEkGOedUynEaazTteH4/a/ppC49mObyAp5Pryj+e7pa",
+	"06LYzSe8xeX415IblnONwYuG87EJl2I0m4PTIBkl14obZv/4cv/e2U+gCRvMEhnqpa22v6gKHIbn/t/F",
+	"zYu8xu5o3NgUF0zJUufrub7kxTx0hm3V8N7SUqTLyo2JAiOYkcCMoXuNMAE2SNyBuQmUeSMx2IH0ywz+",
+	"a8P0Z+FJ2o7zcUXQr1Y8z7lmqRSZRcwmYGOR+R4rKsxF2OpCf5XT9NLTc8b1BpJu888v9+VoP9rsWx+R",
+	"gmrNsipBheox11XPCOYcn0/G4wCBY0DgONxtVHOAdbHsafPDxmOAOzXjVVqMnaP4y3069MEhnGPoSrOw",
+	"fAWIAB9xTUALAm8Fa6YDaykEix7iB3Oxgyc97mavbbTZA/nchyDQ6yt1fpo3hmRh+3F4nC0pvyyTEf5e",
+	"5FREbSJZGkBkXew+XI1BZfcUX3RlCkDDTplelb6eyxZ4XdGcoxJde8u4JtpIBUJdt6rELM8sFcvmFsBJ",
+	"TD1qwD5XrKBc9e1snYmEAwLhRSDdknq4bdmxZBrr9fEDSBNHSEB2758FdDCL0cFjB1GcFIxa34WSX9dz",
+	"WvD5JYtV+X04IZdsbSeEoVC6t2TCuGYL/VOCE3HuvIJdxyn5/PFtMKlm6oqnzeqKpTGFPpxOZcGEkqVh",
+	"akL5lBZ8erXXv6yXZ1sl5Rsc6NaH+UFntseKhz1RIr4QXAhP6Vy6mEbfca3z14Ovdas1vha+kvLpRWHG",
+	"BzvEvE4EN5zmjv03NIt67j9YXpAVI6iDEUo+rM1SChfqAtovlEyZ1uT16b9jkEc/TvzrtDfkRZ6gLQxS",
+	"Q664ceLroaNgH6DYCrTKOjocRMKwhpjWvwVW/wMGxwIffI+Whc9jAqKiB9jmD3bLgehPe0OeV0ydS80G",
+	"HyY33rHK6OHZsYZg02dMl3LFpqVmauqTs+8QS2zacbvZrH3OBW+u9rR6EOx6UIQvPummPg8DTeBYCPD2",
+	"pvAxOy8vTsRC9qPPBQFAq+Ixz9m/2wfepRFowqW2YjpvGDja+cy4Iaksc+jDRTJmUMFtYGV/MpvsRRlr",
+	"mvN5by0urOweEqvJ2YRzqYLy2GaB1DJfR72HOdUGhAYIg8hKb6nGUni+cJIVrSSPCMA5CFTirOngw2b7",
+	"B+PZ3njv+ae92eGz2eFs9h+DG+DEi04924OFT//3W242rR8cwtAJYRs5TLLzKHXzf8W87/xf8e8FO/F8",
+	"bVhL+z74+fnLF4OCJNqXLPQlsA+Yo5V05eGrqx1iaew0h0zD587dqpPD/Wcvq8Otk8OD/WgjE+Cl81SW",
+	"wmzUYGGY9lUsHmNbghSts+wareGGNBf2WGsekPixT3m23QHb2ySqElxuBHlSd1OTCpOGm+Hpt1JeaqLp",
+	"glW6C8v6+jvF+YyHtsr6n7RrZ7HoyDrGMT86Xn5bVR5VDvswv7lObG5mOrs1dsojt306cBb3w5GbBLfU",
+	"FhAPrgRqFRzfrbXNGxvNCEIcRqLICxvXLBmRil+AcTgiEpsoOtsLGbjFTEsp3tQCaJrSdMkijYDa0srT",
+	"wBDS3U3qV03lWuhQKohW8oXL2o0n/+Rs1+qtR8zXxS87xiaBsfMds4IsMuAZecImF5MRsa339ppHuu7H",
+	"FznEVVPC4SQZeNOYg0AY9tUk0TKintYCWAM2VoxmqNyycF8b0Hc7B25TvBBZ9dK9yO4nyYr4trYldBvW",
+	"BsFOEF05nnrjD8EOjAEmGuuCpaDVtJKf6vXqbmuH32Iz3KKzn/1hC3Jgbkjz6KDGhUXDZfvPRDVLbwqJ",
+	"s+jaySOCXc+DGF1deOVzhCKVR7VlYPOQ5jYYDA9CZ+XccfNwvDNTgzf8o6ZhP2dfQZ5258xY3sBBje3f",
+	"eM5OBS30UkYFf080H16r0peoIdpNQfp29TY5PqBAzrfruZv0WmdcTsFXPSnWd0rhsAXd3oLzOAsXrlJs",
+	"hhhwft3wO+s8qq2R/T8Yzc2yn8nU2XCVdxn7INXQyssev4HXu+qhs8neZLb1i6rCRD9HDO6TVSGV8bUx",
+	"/SqntTajrgYfUkWjLnA4PAkblfzb67dHn4/fzF//+f63k9/nxycfiVTkv6YTO/PTuD3ZSoPTPU2kOX4E",
+	"UJ9mrfKDVtDpl/ODdH+xx8bP6YtsfMBeLsY/01/Ox7N0L9tnzxYH9Pn5bsEaR9Jx1Lh69AAp10upGTGK",
+	"CjtOE7305rb9DJZtOT1bNz4Eacie76arVVAOzVA5cS+4BWNIhOhaEbPlO7UkrnzrGrse5KBRrEO8Dd23",
+	"FsaCGTwot9ft2t87LFt+y3cH9lFFO/2JmNst3moSwoTBzIgY9R3sb0/Oa5vzbIVt1ja2Kd/ZoxbLpQ8/",
+	"NboPQZQydLsmTn2NZ3lb9z/XVZTYN8KakL8gBUAbxehqDOlqON47/LXBxpiyYMLFkhlNlxgcI1qShQQb",
+	"bVwWXu3VmINyDnuMmZ6Em2YrTAdlsF5UY6hKcm/pcL1lpmVXnlUVxy4xt56q8SQ2113tsHhT1tuf4DqG",
+	"3EHXKi1OnatygxdsS4DaztD1hb2jBWq5+NimfRpZeUs7mbPfUFVx+bkADaZDQAIC8MUxuAbg82rLHxIS",
+	"7OTj4M2bqOUfQ8pp1euwXdZ8oftbYVWZFzaHVZuMS/eNupM6UUM+CkTfbiK53wftIDKSuOyTbSD1oCzG",
+	"eMXVgMYeoTHejPpccSUFOu2uqOLWIbkFuG/J8ZtXn39PDhM4LdFOzktGsy20ugWyPz59+kDcNMimRJqD",
+	"aELY8GEctP8zdgxpfHLs2An84e4/6AAaLx2wBOfqkiCKTdqrjjCKSSpEPe0EvqP5x7FgOk7LRFZILgxG",
+	"1Td/I85+OJ1iz5ul1Obw5cuXL11YfbpKi6iI63x5K0ehTxWA+EmQh7BCP1CpGdmUWgYbdr4GSRa+i42F",
+	"G2n/XjaOEttJGPyQOhklvVkuH1nKhPngjMEmN8DwDASZekIzGI3B1ABswQlp2Tj6bqGW40rFdqbbJhVa",
+	"u1TOGGmAcB6gQGHvJAd3HUoZHCeokdRcMiaOamTfV6eNesbbZ4u3shK6e42/+7af3jNicebz9esEJadG",
+	"Qcuy/2BKEqnOhMtPgFSnkmmyYhTqxNG1wrIJef3hs2tCtGIr2Hhg7ah/IacmVLEzgf5xJrDrLPaofctF",
+	"+TXWiSotSszEnLscypgHwtC8WpbQVEmt+74jJIsXs1lA3D4ytjnTyX7T3EbrYvcrYD5M9emDYdnfO3h5",
+	"8POzFwc/7wwS4BbzjmN2uMc78b9LZRs3OSgaZsXslxc7r35N83yeYtlj/w7BvqCl5ILbeEtUiBaXJIcm",
+	"XgDRs923KMbLT5nIXLlVryul10nuXrSd+URgeURr7g3TxhVPoiMMfZFbjapNbvIG8N/HiLhXxf/2+n6v",
+	"pf7/WrFBo73QkPLF4FRUL8dyjYIrGOYsq/j9kCVguOunQhUL7yvoWQvDkXPfhNCVhxl5yTanoeJrde9C",
+	"Hz7F1xrZDrMhqe4WCCy72A0AeKV38eez2cDlh7qIftK2X5i94CvqdBlULuwKX6OX+fignBs16IaorSXZ",
+	"LoxoIyU9jQm+QmdBkUF7dO61fEx1d71r6k198fNQxErUu7I+6Q7PCRfk82kDibPJ7HnwpYtcUtP/lXVv",
+	"yk3XbVVovcO1W3eqcMGbtRBwyCAI2/T6g7qihtuGno3CSVkaUOMwFqwblaNDS142Xfh1cvpnjQqb07Cx",
+	"7gZNITcheSJdDszTW1Omb6of7YLpN80PalfeNNSc5wOJki0WLDX8is39qejjNpZI7VOCFoNtuHNNVUbS",
+	"yJlpcJ+9gcwPA9r9FwV00jI84+lPz4AnpUKGGq3z/WvZMAjcTC4l8VLI62a+ajPOumM3dL9GT0P02FWa",
+	"PdUy22XCBik0aCfQTKRAG9yso6cFTWo/4hYsZGOJDNpqkUz+IQVFOHFUcv1W5rmvVIrvgRVZY1mUenww",
+	"3hvvz/afz36ePU/6C26274UdGJfKQ/Yi2qAo2rchCNvwhcUdKFiAycs63bhLdRvbGw2uBXE5YnU5CFMd",
+	"t9UDVoN4vc+uz6u6yPuvCPEVcL7jO77bVwoitR7v7c/Ob10RgmYWtpl396/FttHXhyi2oKnxH+xylAZf",
+	"bVhd2yD6b23YeL3hoBsInSirLyDsFIlFjm5YklZQBfwB3C51NRq9oFxo03JFNCq+yBNo6I9FHujaeWpt",
+	"wdWKxnbh6GR8wQRTOLsb5Wk8tgUfHepZ1iqwApZT5myHQpDPmqkxyzhm81an2g4Ol3y3JjboS4Uhn+CG",
+	"q7tf+XDP5RqtOwx9aoi/obpxfWFH6Gywme/WrbpKCdjRUt+tTXW3stPfFmFxIuy/KiMoGSWVJtOKXVZ/",
+	"4kO4mAm2tN3Aod704UkWAyuwelIohuMvLOHaXjbmXRi5pJkOirbs7TitrkE1LcLreRwhLlHrvjDSSJi7",
+	"NVq6veE3XB3tLioYcDNB3YE/3tlf8L5nPksx+jDerX9A5YXvqBadtdEbpcshBzfGD5ubmCAfs9m+rU7D",
+	"bLd58yiO7dNnTMa8rxYhdrawF++P47xrSMB26+4WVxvsruvWGU4zruH/jWtEQfTUXrudjfu+tYhUxC+3",
+	"1aC/dReNqNUeVHTerl+Gh+kWTTOG9CB4ApbOiFhjCqth2aowVglw2vbT72d2PRs/H9sFwPA62Jvt7z9M",
+	"vXvwPZdjqcaTyeTHroK/TdX7lsjGAxXBU7zvueDp1G/qxG/qLgqw5ZD9mq8dkKHSS97TFRuW82BfA/Xa",
+	"axwbmPkVFSnL5q7nqhrEX/xbvlOrItYvGONmUQAD0O4EUy8gJOeXjEDY9CPSYjwUcos0fV9+Nvyddjev",
+	"7te1DIRgiS9bkHc3+6CxDQO1uRt0zy2kL5igKSLCajZJfRsVOS2LQir8HpUH/KEW65OMXXUzoj6+Of1E",
+	"gLthdlA9ny3UdVfK2Ypqi0FgDP4IraigFwz7Kp+Jqvca2IaLXF7rkWsxS3PcK1vp4nJOYZqUFvSc5xyQ",
+	"aJMY3MkNP8zl4Ho4gwqAw2RvMpvMfDCfFhzui3XVBJCZgjszrZjHHBnM9Fvt87rB3CaXwpocfrsZJVP/",
+	"GePqDvCL2C0tb7lzC0QLQF1id3XBHPUoG7mUM38zt+5cUofXDeM9kM177ACV3OjutXQ2N6JxnWK1ayeZ",
+	"A7Vxfxyip+osePiffWEBDL1x+MVb1o7uHKgnWRISsRUJda+g9sH8AoPtAUK87s9mrTogwJcTqFPfQrOe",
+	"b2g1ZH1M8fhE9i24cczh7GaUPJ/N+lapwJ6euPAkRg3wEFeuHjt1a95RYuhF49onqMzt0tn0G89uLJXl",
+	"zESE1zH+3ltxPCGf6oAXd62JWIb3UdYwoTtr0iGQj+xKXrIQhdso5Cj8yoBOXD6YIxN+V/o46LmrWyHA",
+	"Gezawexg+669l+Y3rL+8j2222Gpu9IB93sJK6ums7qBdhj6mfFXnbUQUsx2qGh7JM+Fv1w5uRsTXwTcp",
+	"0YO94LlhqjlB+y6pM+FSr+o2GoJdM23IgisNurxwN0wBc0P7w/Ltfoazldn8hnBBDp3ehe30k9Eovrk1",
+	"EFMaOEdKbQFIdngPjhqoh7u/6Tyjx1zt/q4tSs9erXd/9ZSL9BbQfhaG58Fr8W54InKLo5GOznp20kc6",
+	"612swjAY1a16qNnQ4qZ2el3A3scBAiu4Bxx3FVsUntmWxLnvIdV2kmjassUBXO4Vzbyl8gDyz87cJwJH",
+	"SSG16eti5C5fak+GSqq9llOxK86uO6yn2WPYiR+mzSuZre9tW+KtpW9ubtrS7qZDG3sPBkQ/gfgxvr74",
+	"sejDb21rU4fIzmnVqicqQY8uLhS7gMlDqaatFuSv6rICUI+wm6i/ntJfxtohpN+ZafrQ/1uM/X8hxr4H",
+	"u27efLvpRAZtoh7pUP7OAp7d6Fq1/VB6myV6JmFi26OFZYQL67qBc0HPwWdNSdX/I8IQes9i9xgOJIKT",
+	"7Pvs/aBt971rHsV+aey4h2Todk/tpdAAQVx+29et+5KJdfMy/579bbZbuvsW37/Ej/cyGyTxZw8GRD+h",
+	"HbvmVkSxVKqsIfLvBZRmE6IIBCcCk2iqPmokuGy/anVgaemRzHiLTSLFLgpJBq0sx94hu4HvnZcXEaZn",
+	"G/KhP7N2A2Zhz0B3K1cp0GHaLpbusMWqtWbyoHTX7t8ZJbn2JytmFGdXmOeEIWe4knodYUYdbAUbcOru",
+	"a0LsL7EtTS/mX0MlqM1RrNGsicuJQcTaGdYxVNqeNw+Jx1ZXnQgST22gD6D2kDbRZaewNa99WFJYDjmu",
+	"HOBRXH10m0Ps6Hxts2PbzmXObDT575Knl3XmQAd5QVHnNkW56zZASO/iMthveQy2OAweUg2IVbdGNtoO",
+	"s19+b0LdbmVsD0NS8Z2ELLGEN4xvcE7meR2ewAxVX5jgrCouLibk1bq6ycM7GdF7mTNaZdXrM/GkOZOQ",
+	"BK8mVUw8nZBTZnA8dEL6n9Wt8ResCUOf0/G0bpO0kQY/IngR6MiTEJw+SnTwxYmxr+Svm49rWw/4TJka",
+	"Bi5cTqruAcDKDnZU17hF4HDpzNsBCZHRAaYHAj+uHw19yz/k4evkJ27wllUfeG+erwBlkcO2zd8lMpuO",
+	"3bx2/LXMwtyomK/rtHr6cK6uVo7ao3i62im6UfEZ1KB19I5Hcora0m27q/VObmHHU3fANthZdgDo1XUm",
+	"3arMDS9y1uAllGguLnJWx/I7lPSqzC/dhAELfQh6ClZ6JCuqAUE/LcGwGmOkTqe+GSX7s5ffG5wPVGH1",
+	"hiPpx6JmxArtZHBuZn0NwraN2frp2pYe1ATsa1RkabS/eN7p9pgWURUOSzHOuL48E2E7wgVexVG0GxdO",
+	"iG9rVy9EFat3+UxgZ3/b06xOxMAspzqk6t6dkE/Bks64PRO+BR3O7BrxxRSXZgPDBzp38c6Y3/no9bRq",
+	"jJB7iFCPx8eieUeRTiIH1DWA2u/JQ9qnAfzOTC3+d3Oa1UGI76GSDZHaj+4U1S1A+vQ4CCptzS6tqkv8",
+	"/ethTjoWeQNrqtRtDOFMzgR2gHX7DplfnOUZGEp5DpzIpSrGuEijmODO1HD/DCha7PCd+c8OxOgwHVEh",
+	"vzdl9tDVQOYz9cKrX+I2Iu5+GduGx72rfQYiYV+5vUTYjRudCS6WTGHlGKYnNu5GdGlFMXp97eb+cSm2",
+	"BeFjGT9tKPpp932wf3cL9X9/KvefCVwRCsvrjNmhhF4VM/ZTOvSEAiquhhLNL7CQRhJa+YmrTN2UltqS",
+	"NTHyTHjlkFwomjLkCFF1rtXC9keVzL2tdjdwxaBgtM+4/j7hpfBmDS7qrTPUsMch4AqdXUoaSsFBIvoW",
+	"pz2ms0ABVIzbOqOnm3B+JvwKo6A9jk3Sx7+d13FytknTfOeh/EHp+nWAkk0kFI6r2lg/nvKZRsHZyZVo",
+	"2VvQnBu7Gq3qRnwVUbiGgq4BEpDTmQhbgdtGL9YgIddLvGvNVEWkvkk4Nh8EzdTR++RMfBZoIjvFAaNu",
+	"NSFiKVl1eW7q1Q50t1e2dIT24LuaxPcDaguRZonfXbvtdjyM0L0bYju1/xBMnFuCsLYR0MzjnkZ3jBrn",
+	"5pYc3V/kMoClY7fRajyURmHvEpKVyicwVgdJL+U18nP8FWQeRDRcn1hTuw2w97O9CbAnU79m61Urgx/W",
+	"k9DptRAhqd8aWHw8bt7czQ3kgkx36q+BxAI04Nrj8PLhzYQDw0mh2IIpJlJmE0gCK7Gz4Y0yxAfcsGjh",
+	"ZGTPYFwFcG/WyD1tTBku1tgX99N2D89uCO8WBycP6WCJVSF/Zzk0dN/9mA2+lu/v7A33eDOZ4Hv+8oxO",
+	"hsFbmdLcByqqtmV1aW5fp33koW61jn5n74uzmUE+YmxKXbX513WA3o6NVO14oZvzBUvXac6CIt7g9To6",
+	"Hr/9kIuxWbJxLmVBuoW/9URHQVFKl4X1FAbXr7+xjPFmFG8gYDsGVJ9vLZ8cd9dAXCqs+XYzfoBXkpsv",
+	"N/93ACMmiCPvyAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
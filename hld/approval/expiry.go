@@ -0,0 +1,165 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// DefaultExpiryCheckInterval is how often the expiry monitor looks for expired approvals
+const DefaultExpiryCheckInterval = 10 * time.Second
+
+// SessionInterrupter interrupts a running session
+type SessionInterrupter interface {
+	InterruptSession(ctx context.Context, sessionID string) error
+}
+
+// ExpiryMonitor resolves pending approvals whose timeout has passed with the
+// approval's timeout outcome
+type ExpiryMonitor struct {
+	store       store.ConversationStore
+	eventBus    bus.EventBus
+	interrupter SessionInterrupter
+	interval    time.Duration
+}
+
+// NewExpiryMonitor creates a new approval expiry monitor. interrupter may be
+// nil, in which case the interrupt outcome only denies the tool call.
+func NewExpiryMonitor(store store.ConversationStore, eventBus bus.EventBus, interrupter SessionInterrupter, interval time.Duration) *ExpiryMonitor {
+	if interval <= 0 {
+		interval = DefaultExpiryCheckInterval
+	}
+	return &ExpiryMonitor{
+		store:       store,
+		eventBus:    eventBus,
+		interrupter: interrupter,
+		interval:    interval,
+	}
+}
+
+// Start begins monitoring for expired approvals
+func (em *ExpiryMonitor) Start(ctx context.Context) {
+	slog.Info("starting approval expiry monitor", "interval", em.interval)
+
+	ticker := time.NewTicker(em.interval)
+	defer ticker.Stop()
+
+	// Do an initial check immediately
+	em.expireApprovals(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("approval expiry monitor shutting down")
+			return
+		case <-ticker.C:
+			em.expireApprovals(ctx)
+		}
+	}
+}
+
+func (em *ExpiryMonitor) expireApprovals(ctx context.Context) {
+	// Guard against nil store (can happen during shutdown)
+	if em.store == nil {
+		return
+	}
+
+	approvals, err := em.store.GetExpiredApprovals(ctx, time.Now())
+	if err != nil {
+		slog.Error("failed to query expired approvals", "error", err)
+		return
+	}
+
+	for _, approval := range approvals {
+		if err := em.expire(ctx, approval); err != nil {
+			slog.Error("failed to expire approval",
+				"approval_id", approval.ID,
+				"session_id", approval.SessionID,
+				"error", err)
+			// Continue with other approvals
+		}
+	}
+}
+
+func (em *ExpiryMonitor) expire(ctx context.Context, approval *store.Approval) error {
+	outcome := TimeoutOutcome(approval.OnTimeout)
+	if outcome == "" {
+		outcome = TimeoutDeny
+	}
+	approved := outcome == TimeoutApprove
+
+	message := approval.TimeoutMessage
+	if message == "" {
+		message = fmt.Sprintf("No response within %s", approval.ExpiresAt.Sub(approval.CreatedAt).Round(time.Second))
+	}
+
+	if err := em.store.UpdateApprovalResponse(ctx, approval.ID, store.ApprovalStatusLocalExpired, store.ApprovalDecidedByTimeout, message); err != nil {
+		var alreadyDecided *store.AlreadyDecidedError
+		if errors.As(err, &alreadyDecided) {
+			// Answered since we queried
+			return nil
+		}
+		return fmt.Errorf("failed to update approval: %w", err)
+	}
+
+	// The tool call itself was approved or denied
+	eventStatus := store.ApprovalStatusDenied
+	if approved {
+		eventStatus = store.ApprovalStatusApproved
+	}
+	if err := em.store.UpdateApprovalStatus(ctx, approval.ID, eventStatus); err != nil {
+		slog.Warn("failed to update approval status in conversation events",
+			"error", err,
+			"approval_id", approval.ID)
+	}
+
+	if em.eventBus != nil {
+		resolvedData := map[string]interface{}{
+			"approval_id":   approval.ID,
+			"session_id":    approval.SessionID,
+			"approved":      approved,
+			"response_text": message,
+		}
+		expiredData := map[string]interface{}{
+			"approval_id": approval.ID,
+			"session_id":  approval.SessionID,
+			"tool_name":   approval.ToolName,
+			"outcome":     string(outcome),
+		}
+		if approval.ToolUseID != nil {
+			resolvedData["tool_use_id"] = *approval.ToolUseID
+			expiredData["tool_use_id"] = *approval.ToolUseID
+		}
+		em.eventBus.Publish(bus.Event{Type: bus.EventApprovalResolved, Timestamp: time.Now(), Data: resolvedData})
+		em.eventBus.Publish(bus.Event{Type: bus.EventApprovalExpired, Timestamp: time.Now(), Data: expiredData})
+	}
+
+	if outcome == TimeoutInterrupt && em.interrupter != nil {
+		if err := em.interrupter.InterruptSession(ctx, approval.SessionID); err != nil {
+			slog.Warn("failed to interrupt session after approval expired",
+				"error", err,
+				"session_id", approval.SessionID)
+		}
+	} else {
+		status := store.SessionStatusRunning
+		now := time.Now()
+		if err := em.store.UpdateSession(ctx, approval.SessionID, store.SessionUpdate{Status: &status, LastActivityAt: &now}); err != nil {
+			slog.Warn("failed to update session status",
+				"error", err,
+				"session_id", approval.SessionID)
+		}
+	}
+
+	slog.Info("expired approval",
+		"approval_id", approval.ID,
+		"session_id", approval.SessionID,
+		"tool_name", approval.ToolName,
+		"outcome", outcome)
+
+	return nil
+}
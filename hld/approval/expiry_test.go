@@ -0,0 +1,104 @@
+package approval
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInterrupter struct {
+	interrupted []string
+}
+
+func (f *fakeInterrupter) InterruptSession(ctx context.Context, sessionID string) error {
+	f.interrupted = append(f.interrupted, sessionID)
+	return nil
+}
+
+func TestExpiryMonitor(t *testing.T) {
+	tests := []struct {
+		name            string
+		onTimeout       string
+		timeoutMessage  string
+		wantApproved    bool
+		wantComment     string
+		wantInterrupted bool
+		wantStatus      string
+	}{
+		{"deny by default", "", "", false, "No response within 30m0s", false, store.SessionStatusRunning},
+		{"deny with message", "deny", "Nobody answered", false, "Nobody answered", false, store.SessionStatusRunning},
+		{"approve", "approve", "", true, "No response within 30m0s", false, store.SessionStatusRunning},
+		{"interrupt", "interrupt", "", false, "No response within 30m0s", true, store.SessionStatusWaitingInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sqliteStore, err := store.NewSQLiteStore(":memory:")
+			require.NoError(t, err)
+			defer func() { _ = sqliteStore.Close() }()
+
+			require.NoError(t, sqliteStore.CreateSession(ctx, &store.Session{
+				ID:     "sess-1",
+				RunID:  "run-1",
+				Query:  "q",
+				Status: store.SessionStatusWaitingInput,
+			}))
+
+			createdAt := time.Now().Add(-time.Hour)
+			expiresAt := createdAt.Add(30 * time.Minute)
+			toolUseID := "toolu_1"
+			require.NoError(t, sqliteStore.CreateApproval(ctx, &store.Approval{
+				ID:             "approval-1",
+				RunID:          "run-1",
+				SessionID:      "sess-1",
+				ToolUseID:      &toolUseID,
+				Status:         store.ApprovalStatusLocalPending,
+				CreatedAt:      createdAt,
+				ToolName:       "Bash",
+				ToolInput:      []byte(`{"command": "ls"}`),
+				ExpiresAt:      &expiresAt,
+				OnTimeout:      tt.onTimeout,
+				TimeoutMessage: tt.timeoutMessage,
+			}))
+
+			eventBus := bus.NewEventBus()
+			sub := eventBus.Subscribe(ctx, bus.EventFilter{Types: []bus.EventType{bus.EventApprovalResolved, bus.EventApprovalExpired}})
+			interrupter := &fakeInterrupter{}
+
+			NewExpiryMonitor(sqliteStore, eventBus, interrupter, time.Minute).expireApprovals(ctx)
+
+			approval, err := sqliteStore.GetApproval(ctx, "approval-1")
+			require.NoError(t, err)
+			assert.Equal(t, store.ApprovalStatusLocalExpired, approval.Status)
+			assert.Equal(t, tt.wantComment, approval.Comment)
+
+			for _, wantType := range []bus.EventType{bus.EventApprovalResolved, bus.EventApprovalExpired} {
+				select {
+				case event := <-sub.Channel:
+					assert.Equal(t, wantType, event.Type)
+					assert.Equal(t, "toolu_1", event.Data["tool_use_id"])
+					if wantType == bus.EventApprovalResolved {
+						assert.Equal(t, tt.wantApproved, event.Data["approved"])
+						assert.Equal(t, tt.wantComment, event.Data["response_text"])
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("expected %s event", wantType)
+				}
+			}
+
+			assert.Equal(t, tt.wantInterrupted, len(interrupter.interrupted) == 1)
+
+			session, err := sqliteStore.GetSession(ctx, "sess-1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, session.Status)
+		})
+	}
+}
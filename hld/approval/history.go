@@ -0,0 +1,43 @@
+package approval
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// ErrInvalidApprovalFilter is returned for approval history queries with
+// invalid filters
+var ErrInvalidApprovalFilter = errors.New("invalid approval filter")
+
+// Page sizes of approval history queries
+const (
+	DefaultApprovalHistoryLimit = 100
+	MaxApprovalHistoryLimit     = 1000
+)
+
+// validateFilter checks the filters of an approval history query
+func validateFilter(filter store.ApprovalFilter) error {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidApprovalFilter, filter.Status)
+	}
+
+	switch filter.DecidedBy {
+	case "", store.ApprovalDecidedByHuman, store.ApprovalDecidedByHumanLayer, store.ApprovalDecidedByAutoAccept,
+		store.ApprovalDecidedByPolicy, store.ApprovalDecidedByRule, store.ApprovalDecidedByTimeout:
+	default:
+		return fmt.Errorf("%w: decided_by must be human, humanlayer, auto_accept, policy, rule or timeout, got %q", ErrInvalidApprovalFilter, filter.DecidedBy)
+	}
+
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return fmt.Errorf("%w: since must be before until", ErrInvalidApprovalFilter)
+	}
+	if filter.Limit < 0 || filter.Limit > MaxApprovalHistoryLimit {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidApprovalFilter, MaxApprovalHistoryLimit)
+	}
+	if filter.Offset < 0 {
+		return fmt.Errorf("%w: offset must not be negative", ErrInvalidApprovalFilter)
+	}
+	return nil
+}
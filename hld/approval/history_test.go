@@ -0,0 +1,61 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_ApprovalHistory(t *testing.T) {
+	m, _ := newPolicyTestManager(t, &store.Session{ID: "sess-1", RunID: "run-1", Query: "q", AutoAcceptEdits: true},
+		`{"rules": [{"name": "no-rm", "tool": "Bash", "command": "rm -rf", "action": "deny"}]}`)
+	ctx := context.Background()
+
+	denied, err := m.CreateApprovalWithToolUseID(ctx, "sess-1", "Bash", json.RawMessage(`{"command": "rm -rf /"}`), "toolu_1")
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalDecidedByPolicy, denied.DecidedBy)
+
+	edit, err := m.CreateApprovalWithToolUseID(ctx, "sess-1", "Edit", json.RawMessage(`{"file_path": "a.go"}`), "toolu_2")
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalDecidedByAutoAccept, edit.DecidedBy)
+
+	pending, err := m.CreateApprovalWithToolUseID(ctx, "sess-1", "Bash", json.RawMessage(`{"command": "ls"}`), "toolu_3")
+	require.NoError(t, err)
+	assert.Empty(t, pending.DecidedBy)
+	require.NoError(t, m.ApproveToolCall(ctx, pending.ID, ""))
+
+	approvals, err := m.ListApprovals(ctx, store.ApprovalFilter{DecidedBy: store.ApprovalDecidedByHuman})
+	require.NoError(t, err)
+	require.Len(t, approvals, 1)
+	assert.Equal(t, pending.ID, approvals[0].ID)
+
+	stats, err := m.GetApprovalStats(ctx, store.ApprovalFilter{SessionID: "sess-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Total)
+	assert.Equal(t, map[string]int{"human": 1, "policy": 1, "auto_accept": 1}, stats.ByDecidedBy)
+
+	since := time.Now()
+	tests := []struct {
+		name   string
+		filter store.ApprovalFilter
+	}{
+		{"unknown status", store.ApprovalFilter{Status: "resolved"}},
+		{"unknown decider", store.ApprovalFilter{DecidedBy: "robot"}},
+		{"empty time range", store.ApprovalFilter{CreatedAfter: &since, CreatedBefore: &since}},
+		{"limit too large", store.ApprovalFilter{Limit: MaxApprovalHistoryLimit + 1}},
+		{"negative offset", store.ApprovalFilter{Offset: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.ListApprovals(ctx, tt.filter)
+			assert.ErrorIs(t, err, ErrInvalidApprovalFilter)
+			_, err = m.GetApprovalStats(ctx, tt.filter)
+			assert.ErrorIs(t, err, ErrInvalidApprovalFilter)
+		})
+	}
+}
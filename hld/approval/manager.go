@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"path/filepath"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,13 +17,31 @@ import (
 type manager struct {
 	store    store.ConversationStore
 	eventBus bus.EventBus
+	config   PolicyConfig
+}
+
+// PolicyConfig configures the approval policies a manager applies
+type PolicyConfig struct {
+	Daemon     *Policy // Daemon-level approval policy, nil if none
+	DaemonPath string  // File the daemon policy is loaded from, whether or not it exists
+
+	// Working directory policies are usually checked into the repository, so
+	// their allow rules, and timeouts that approve, only apply when trusted
+	TrustDirectoryPolicies bool
 }
 
 // NewManager creates a new local approval manager
 func NewManager(store store.ConversationStore, eventBus bus.EventBus) Manager {
+	return NewManagerWithPolicy(store, eventBus, PolicyConfig{})
+}
+
+// NewManagerWithPolicy creates a new local approval manager that applies a
+// daemon-level approval policy after session and working directory policies
+func NewManagerWithPolicy(store store.ConversationStore, eventBus bus.EventBus, config PolicyConfig) Manager {
 	return &manager{
 		store:    store,
 		eventBus: eventBus,
+		config:   config,
 	}
 }
 
@@ -37,45 +56,17 @@ func (m *manager) CreateApproval(ctx context.Context, runID, toolName string, to
 		return "", fmt.Errorf("session not found for run_id: %s", runID)
 	}
 
-	// Check if auto-accept is enabled (either mode)
-	status := store.ApprovalStatusLocalPending
-	comment := ""
-
-	// Check dangerously skip permissions first (overrides edit mode)
-	if session.DangerouslySkipPermissions {
-		// Check if it has an expiry and if it's expired
-		if session.DangerouslySkipPermissionsExpiresAt != nil && time.Now().After(*session.DangerouslySkipPermissionsExpiresAt) {
-			// Expired - disable it
-			update := store.SessionUpdate{
-				DangerouslySkipPermissions:          &[]bool{false}[0],
-				DangerouslySkipPermissionsExpiresAt: &[]*time.Time{nil}[0],
-			}
-			if err := m.store.UpdateSession(ctx, session.ID, update); err != nil {
-				slog.Error("failed to disable expired dangerously skip permissions", "session_id", session.ID, "error", err)
-			}
-			// Continue with normal approval
-		} else {
-			// Dangerously skip permissions is active (no expiry or not expired)
-			status = store.ApprovalStatusLocalApproved
-			comment = "Auto-accepted (dangerous skip permissions enabled)"
-		}
-	} else if session.AutoAcceptEdits && isEditTool(toolName) {
-		// Regular auto-accept edits mode
-		status = store.ApprovalStatusLocalApproved
-		comment = "Auto-accepted (auto-accept mode enabled)"
-	}
-
 	// Create approval
 	approval := &store.Approval{
 		ID:        "local-" + uuid.New().String(),
 		RunID:     runID,
 		SessionID: session.ID,
-		Status:    status,
 		CreatedAt: time.Now(),
 		ToolName:  toolName,
 		ToolInput: toolInput,
-		Comment:   comment,
 	}
+	m.decide(ctx, session, approval)
+	status, comment, policyRule := approval.Status, approval.Comment, approval.PolicyRule
 
 	// Store it
 	if err := m.store.CreateApproval(ctx, approval); err != nil {
@@ -112,6 +103,14 @@ func (m *manager) CreateApproval(ctx context.Context, runID, toolName string, to
 		}
 		// Publish resolved event for auto-approved
 		m.publishApprovalResolvedEvent(approval, true, comment)
+	case store.ApprovalStatusLocalDenied:
+		// Denied by policy, resolve it the same way
+		if err := m.store.UpdateApprovalStatus(ctx, approval.ID, store.ApprovalStatusDenied); err != nil {
+			slog.Warn("failed to update approval status in conversation events",
+				"error", err,
+				"approval_id", approval.ID)
+		}
+		m.publishApprovalResolvedEvent(approval, false, comment)
 	}
 
 	logLevel := slog.LevelInfo
@@ -123,7 +122,8 @@ func (m *manager) CreateApproval(ctx context.Context, runID, toolName string, to
 		"session_id", session.ID,
 		"tool_name", toolName,
 		"status", status,
-		"auto_accepted", status == store.ApprovalStatusLocalApproved)
+		"auto_accepted", status == store.ApprovalStatusLocalApproved,
+		"policy_rule", policyRule)
 
 	return approval.ID, nil
 }
@@ -137,6 +137,36 @@ func (m *manager) GetPendingApprovals(ctx context.Context, sessionID string) ([]
 	return approvals, nil
 }
 
+// ListApprovals retrieves a page of approvals across sessions matching
+// filter, DefaultApprovalHistoryLimit when no limit is set
+func (m *manager) ListApprovals(ctx context.Context, filter store.ApprovalFilter) ([]*store.Approval, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+	if filter.Limit == 0 {
+		filter.Limit = DefaultApprovalHistoryLimit
+	}
+
+	approvals, err := m.store.ListApprovals(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approvals: %w", err)
+	}
+	return approvals, nil
+}
+
+// GetApprovalStats aggregates the approvals matching filter
+func (m *manager) GetApprovalStats(ctx context.Context, filter store.ApprovalFilter) (*store.ApprovalStats, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+
+	stats, err := m.store.GetApprovalStats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval stats: %w", err)
+	}
+	return stats, nil
+}
+
 // GetApproval retrieves a specific approval by ID
 func (m *manager) GetApproval(ctx context.Context, id string) (*store.Approval, error) {
 	approval, err := m.store.GetApproval(ctx, id)
@@ -146,8 +176,21 @@ func (m *manager) GetApproval(ctx context.Context, id string) (*store.Approval,
 	return approval, nil
 }
 
-// ApproveToolCall approves a tool call
+// ApproveToolCall approves a tool call on behalf of a human using the daemon
 func (m *manager) ApproveToolCall(ctx context.Context, id string, comment string) error {
+	return m.ApproveToolCallWithInput(ctx, id, comment, nil, store.ApprovalDecidedByHuman)
+}
+
+// ApproveToolCallWithInput approves a tool call, running it with updatedInput
+// instead of the original input unless it is nil
+func (m *manager) ApproveToolCallWithInput(ctx context.Context, id string, comment string, updatedInput json.RawMessage, decidedBy string) error {
+	if updatedInput != nil {
+		var input map[string]interface{}
+		if err := json.Unmarshal(updatedInput, &input); err != nil || input == nil {
+			return fmt.Errorf("updated input must be a JSON object")
+		}
+	}
+
 	// Get the approval first
 	approval, err := m.store.GetApproval(ctx, id)
 	if err != nil {
@@ -155,9 +198,15 @@ func (m *manager) ApproveToolCall(ctx context.Context, id string, comment string
 	}
 
 	// Update approval status
-	if err := m.store.UpdateApprovalResponse(ctx, id, store.ApprovalStatusLocalApproved, comment); err != nil {
+	if updatedInput != nil {
+		err = m.store.UpdateApprovalResponseWithInput(ctx, id, store.ApprovalStatusLocalApproved, decidedBy, comment, updatedInput)
+	} else {
+		err = m.store.UpdateApprovalResponse(ctx, id, store.ApprovalStatusLocalApproved, decidedBy, comment)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to update approval: %w", err)
 	}
+	approval.UpdatedInput = updatedInput
 
 	// Update correlation status in conversation events
 	if err := m.store.UpdateApprovalStatus(ctx, id, store.ApprovalStatusApproved); err != nil {
@@ -178,13 +227,14 @@ func (m *manager) ApproveToolCall(ctx context.Context, id string, comment string
 
 	slog.Info("approved tool call",
 		"approval_id", id,
-		"comment", comment)
+		"comment", comment,
+		"edited_input", updatedInput != nil)
 
 	return nil
 }
 
 // DenyToolCall denies a tool call
-func (m *manager) DenyToolCall(ctx context.Context, id string, reason string) error {
+func (m *manager) DenyToolCall(ctx context.Context, id string, reason string, decidedBy string) error {
 	// Get the approval first
 	approval, err := m.store.GetApproval(ctx, id)
 	if err != nil {
@@ -192,7 +242,7 @@ func (m *manager) DenyToolCall(ctx context.Context, id string, reason string) er
 	}
 
 	// Update approval status
-	if err := m.store.UpdateApprovalResponse(ctx, id, store.ApprovalStatusLocalDenied, reason); err != nil {
+	if err := m.store.UpdateApprovalResponse(ctx, id, store.ApprovalStatusLocalDenied, decidedBy, reason); err != nil {
 		return fmt.Errorf("failed to update approval: %w", err)
 	}
 
@@ -220,6 +270,70 @@ func (m *manager) DenyToolCall(ctx context.Context, id string, reason string) er
 	return nil
 }
 
+// ApproveAndRemember approves a tool call and creates an allow rule from it.
+// The rule is validated and stored before approving, so an invalid rule leaves
+// the approval pending, and the rule is removed again if approving fails.
+func (m *manager) ApproveAndRemember(ctx context.Context, id string, comment string, opts RememberOptions) (*store.ApprovalRule, error) {
+	approval, err := m.store.GetApproval(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval: %w", err)
+	}
+	if approval.Status != store.ApprovalStatusLocalPending {
+		return nil, fmt.Errorf("failed to update approval: %w", &store.AlreadyDecidedError{ID: id, Status: approval.Status.String()})
+	}
+	session, err := m.store.GetSession(ctx, approval.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	rule, err := newApprovalRule(approval, session, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidApprovalRule, err)
+	}
+
+	if err := m.store.CreateApprovalRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to store approval rule: %w", err)
+	}
+
+	if err := m.ApproveToolCall(ctx, id, comment); err != nil {
+		if deleteErr := m.store.DeleteApprovalRule(ctx, rule.ID); deleteErr != nil {
+			slog.Error("failed to remove approval rule after approving failed",
+				"error", deleteErr,
+				"rule_id", rule.ID,
+				"approval_id", id)
+		}
+		return nil, err
+	}
+
+	slog.Info("created approval rule",
+		"rule_id", rule.ID,
+		"approval_id", id,
+		"session_id", rule.SessionID,
+		"scope", rule.Scope,
+		"match", rule.Match,
+		"tool_name", rule.ToolName)
+
+	return rule, nil
+}
+
+// ListApprovalRules retrieves the approval rules that apply to a session
+func (m *manager) ListApprovalRules(ctx context.Context, sessionID string) ([]*store.ApprovalRule, error) {
+	rules, err := m.store.ListApprovalRules(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approval rules: %w", err)
+	}
+	return rules, nil
+}
+
+// RevokeApprovalRule deletes an approval rule
+func (m *manager) RevokeApprovalRule(ctx context.Context, id string) error {
+	if err := m.store.DeleteApprovalRule(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke approval rule: %w", err)
+	}
+	slog.Info("revoked approval rule", "rule_id", id)
+	return nil
+}
+
 // correlateApproval tries to correlate an approval with a tool call
 func (m *manager) correlateApproval(ctx context.Context, approval *store.Approval) error {
 	// Find the most recent uncorrelated pending tool call
@@ -268,6 +382,10 @@ func (m *manager) publishApprovalResolvedEvent(approval *store.Approval, approve
 		if approval.ToolUseID != nil {
 			eventData["tool_use_id"] = *approval.ToolUseID
 		}
+		// Include the input the approver edited, which replaces the tool input
+		if approved && approval.UpdatedInput != nil {
+			eventData["updated_input"] = approval.UpdatedInput
+		}
 		event := bus.Event{
 			Type:      bus.EventApprovalResolved,
 			Timestamp: time.Now(),
@@ -297,45 +415,18 @@ func (m *manager) CreateApprovalWithToolUseID(ctx context.Context, sessionID, to
 		return nil, fmt.Errorf("session not found: %s", sessionID)
 	}
 
-	status := store.ApprovalStatusLocalPending
-	comment := ""
-
-	// Check dangerously skip permissions first (overrides edit mode)
-	if session.DangerouslySkipPermissions {
-		// Check if it has an expiry and if it's expired
-		if session.DangerouslySkipPermissionsExpiresAt != nil && time.Now().After(*session.DangerouslySkipPermissionsExpiresAt) {
-			// Expired - disable it
-			update := store.SessionUpdate{
-				DangerouslySkipPermissions:          &[]bool{false}[0],
-				DangerouslySkipPermissionsExpiresAt: &[]*time.Time{nil}[0],
-			}
-			if err := m.store.UpdateSession(ctx, session.ID, update); err != nil {
-				slog.Error("failed to disable expired dangerously skip permissions", "session_id", session.ID, "error", err)
-			}
-			// Continue with normal approval
-		} else {
-			// Dangerously skip permissions is active (no expiry or not expired)
-			status = store.ApprovalStatusLocalApproved
-			comment = "Auto-accepted (dangerous skip permissions enabled)"
-		}
-	} else if session.AutoAcceptEdits && isEditTool(toolName) {
-		// Regular auto-accept edits mode
-		status = store.ApprovalStatusLocalApproved
-		comment = "Auto-accepted (auto-accept mode enabled)"
-	}
-
 	// Create approval with tool_use_id
 	approval := &store.Approval{
 		ID:        "local-" + uuid.New().String(),
 		RunID:     session.RunID,
 		SessionID: sessionID,
 		ToolUseID: &toolUseID,
-		Status:    status,
 		CreatedAt: time.Now(),
 		ToolName:  toolName,
 		ToolInput: toolInput,
-		Comment:   comment,
 	}
+	m.decide(ctx, session, approval)
+	status, comment, policyRule := approval.Status, approval.Comment, approval.PolicyRule
 
 	// Store it
 	if err := m.store.CreateApproval(ctx, approval); err != nil {
@@ -372,6 +463,14 @@ func (m *manager) CreateApprovalWithToolUseID(ctx context.Context, sessionID, to
 		}
 		// Publish resolved event for auto-approved
 		m.publishApprovalResolvedEvent(approval, true, comment)
+	case store.ApprovalStatusLocalDenied:
+		// Denied by policy, resolve it the same way
+		if err := m.store.UpdateApprovalStatus(ctx, approval.ID, store.ApprovalStatusDenied); err != nil {
+			slog.Warn("failed to update approval status in conversation events",
+				"error", err,
+				"approval_id", approval.ID)
+		}
+		m.publishApprovalResolvedEvent(approval, false, comment)
 	}
 
 	logLevel := slog.LevelInfo
@@ -384,11 +483,184 @@ func (m *manager) CreateApprovalWithToolUseID(ctx context.Context, sessionID, to
 		"tool_name", toolName,
 		"tool_use_id", toolUseID,
 		"status", status,
-		"auto_accepted", status == store.ApprovalStatusLocalApproved)
+		"auto_accepted", status == store.ApprovalStatusLocalApproved,
+		"policy_rule", policyRule)
 
 	return approval, nil
 }
 
+// decide sets the status of a new approval, deciding it without asking when
+// a policy rule or an auto-accept mode applies, and sets the timeout of
+// approvals left pending
+func (m *manager) decide(ctx context.Context, session *store.Session, approval *store.Approval) {
+	policies := m.loadPolicies(session)
+
+	// Changes to the working directory's or the daemon's policy always need a
+	// human, or a session could allow its own tool calls
+	if writesPolicyFile(approval.ToolName, approval.ToolInput, session.WorkingDir, m.policyFiles(session)...) {
+		slog.Info("asking for approval of a change to an approval policy",
+			"session_id", session.ID,
+			"tool_name", approval.ToolName)
+		approval.Status = store.ApprovalStatusLocalPending
+		setApprovalTimeout(approval, policies, nil)
+		return
+	}
+
+	decision := evaluatePolicies(policies, m.config.Daemon, approval.ToolName, approval.ToolInput, session.WorkingDir)
+
+	// Always allow rules apply when no policy rule matches
+	if decision == nil {
+		if rule := m.matchApprovalRule(ctx, session.ID, approval.ToolName, approval.ToolInput); rule != nil {
+			approval.Status = store.ApprovalStatusLocalApproved
+			approval.Comment = fmt.Sprintf("Auto-accepted (always allow rule %q)", rule.ID)
+			approval.ApprovalRuleID = rule.ID
+			approval.DecidedBy = store.ApprovalDecidedByRule
+			return
+		}
+	}
+
+	approval.Status, approval.Comment = m.autoDecision(ctx, session, decision, approval.ToolName)
+	if decision != nil {
+		approval.PolicyRule = decision.Rule
+	}
+	if approval.Status != store.ApprovalStatusLocalPending {
+		approval.DecidedBy = store.ApprovalDecidedByAutoAccept
+		if decision != nil {
+			approval.DecidedBy = store.ApprovalDecidedByPolicy
+		}
+		return
+	}
+
+	setApprovalTimeout(approval, policies, decision)
+}
+
+// autoDecision returns the status and comment of a new approval. A matching
+// policy rule decides first, then the session's auto-accept modes.
+func (m *manager) autoDecision(ctx context.Context, session *store.Session, decision *PolicyDecision, toolName string) (store.ApprovalStatus, string) {
+	if decision != nil {
+		switch decision.Action {
+		case PolicyAllow:
+			return store.ApprovalStatusLocalApproved, fmt.Sprintf("Auto-accepted (policy rule %q)", decision.Rule)
+		case PolicyDeny:
+			message := decision.Message
+			if message == "" {
+				message = "Denied"
+			}
+			return store.ApprovalStatusLocalDenied, fmt.Sprintf("%s (policy rule %q)", message, decision.Rule)
+		default:
+			// Ask even if an auto-accept mode is enabled
+			return store.ApprovalStatusLocalPending, ""
+		}
+	}
+
+	// Check dangerously skip permissions first (overrides edit mode)
+	if session.DangerouslySkipPermissions {
+		// Check if it has an expiry and if it's expired
+		if session.DangerouslySkipPermissionsExpiresAt != nil && time.Now().After(*session.DangerouslySkipPermissionsExpiresAt) {
+			// Expired - disable it
+			update := store.SessionUpdate{
+				DangerouslySkipPermissions:          &[]bool{false}[0],
+				DangerouslySkipPermissionsExpiresAt: &[]*time.Time{nil}[0],
+			}
+			if err := m.store.UpdateSession(ctx, session.ID, update); err != nil {
+				slog.Error("failed to disable expired dangerously skip permissions", "session_id", session.ID, "error", err)
+			}
+			// Continue with normal approval
+		} else {
+			// Dangerously skip permissions is active (no expiry or not expired)
+			return store.ApprovalStatusLocalApproved, "Auto-accepted (dangerous skip permissions enabled)"
+		}
+	} else if session.AutoAcceptEdits && isEditTool(toolName) {
+		// Regular auto-accept edits mode
+		return store.ApprovalStatusLocalApproved, "Auto-accepted (auto-accept mode enabled)"
+	}
+
+	return store.ApprovalStatusLocalPending, ""
+}
+
+// matchApprovalRule returns the first always allow rule of the session that
+// matches the tool call, or nil
+func (m *manager) matchApprovalRule(ctx context.Context, sessionID, toolName string, toolInput json.RawMessage) *store.ApprovalRule {
+	rules, err := m.store.ListApprovalRules(ctx, sessionID)
+	if err != nil {
+		slog.Warn("failed to list approval rules", "session_id", sessionID, "error", err)
+		return nil
+	}
+	for _, rule := range rules {
+		if ruleMatches(rule, toolName, toolInput) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// loadPolicies returns the session, working directory and daemon policies
+// that exist, in that order. Invalid session or directory policies are logged
+// and skipped.
+func (m *manager) loadPolicies(session *store.Session) []*Policy {
+	var policies []*Policy
+
+	if session.ApprovalPolicy != "" {
+		policy, err := ParsePolicy([]byte(session.ApprovalPolicy), PolicySourceSession)
+		if err != nil {
+			slog.Warn("ignoring invalid session approval policy", "session_id", session.ID, "error", err)
+		} else {
+			policies = append(policies, policy)
+		}
+	}
+
+	if session.WorkingDir != "" {
+		policy, err := LoadPolicyFile(filepath.Join(session.WorkingDir, DirectoryPolicyFile), PolicySourceDirectory)
+		if err != nil {
+			slog.Warn("ignoring invalid working directory approval policy", "working_dir", session.WorkingDir, "error", err)
+		} else if policy != nil {
+			if !m.config.TrustDirectoryPolicies {
+				policy = policy.untrusted()
+			}
+			policies = append(policies, policy)
+		}
+	}
+
+	if m.config.Daemon != nil {
+		policies = append(policies, m.config.Daemon)
+	}
+	return policies
+}
+
+// policyFiles returns the approval policy files a session must not edit
+// without asking
+func (m *manager) policyFiles(session *store.Session) []string {
+	files := []string{m.config.DaemonPath}
+	if session.WorkingDir != "" {
+		files = append(files, filepath.Join(session.WorkingDir, DirectoryPolicyFile))
+	}
+	return files
+}
+
+// setApprovalTimeout sets when a pending approval expires, if a policy sets a timeout
+func setApprovalTimeout(approval *store.Approval, policies []*Policy, decision *PolicyDecision) {
+	if timeout := approvalTimeout(policies, decision); timeout != nil {
+		expiresAt := approval.CreatedAt.Add(timeout.Duration())
+		approval.ExpiresAt = &expiresAt
+		approval.OnTimeout = string(timeout.OnTimeout)
+		approval.TimeoutMessage = timeout.TimeoutMessage
+	}
+}
+
+// approvalTimeout returns the timeout of the matching rule, or else of the
+// first policy that sets one, or nil if the approval should wait forever
+func approvalTimeout(policies []*Policy, decision *PolicyDecision) *ApprovalTimeout {
+	if decision != nil && decision.Timeout != nil {
+		return decision.Timeout
+	}
+	for _, policy := range policies {
+		if policy.Duration() > 0 {
+			return &policy.ApprovalTimeout
+		}
+	}
+	return nil
+}
+
 // isEditTool checks if a tool name is one of the edit tools
 func isEditTool(toolName string) bool {
 	return toolName == "Edit" || toolName == "Write" || toolName == "MultiEdit"
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/humanlayer/humanlayer/hld/bus"
 	"github.com/humanlayer/humanlayer/hld/store"
@@ -34,6 +35,9 @@ func TestManager_CreateApproval(t *testing.T) {
 		RunID: runID,
 	}, nil)
 
+	// No always allow rules
+	mockStore.EXPECT().ListApprovalRules(ctx, sessionID).Return(nil, nil)
+
 	// Mock creating approval
 	mockStore.EXPECT().CreateApproval(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, approval *store.Approval) error {
 		assert.Equal(t, runID, approval.RunID)
@@ -148,7 +152,7 @@ func TestManager_ApproveToolCall(t *testing.T) {
 	mockStore.EXPECT().GetApproval(ctx, approvalID).Return(approval, nil)
 
 	// Mock updating approval response
-	mockStore.EXPECT().UpdateApprovalResponse(ctx, approvalID, store.ApprovalStatusLocalApproved, comment).Return(nil)
+	mockStore.EXPECT().UpdateApprovalResponse(ctx, approvalID, store.ApprovalStatusLocalApproved, store.ApprovalDecidedByHuman, comment).Return(nil)
 
 	// Mock updating approval status in conversation events
 	mockStore.EXPECT().UpdateApprovalStatus(ctx, approvalID, store.ApprovalStatusApproved).Return(nil)
@@ -194,7 +198,7 @@ func TestManager_DenyToolCall(t *testing.T) {
 	mockStore.EXPECT().GetApproval(ctx, approvalID).Return(approval, nil)
 
 	// Mock updating approval response
-	mockStore.EXPECT().UpdateApprovalResponse(ctx, approvalID, store.ApprovalStatusLocalDenied, reason).Return(nil)
+	mockStore.EXPECT().UpdateApprovalResponse(ctx, approvalID, store.ApprovalStatusLocalDenied, store.ApprovalDecidedByHuman, reason).Return(nil)
 
 	// Mock updating approval status in conversation events
 	mockStore.EXPECT().UpdateApprovalStatus(ctx, approvalID, store.ApprovalStatusDenied).Return(nil)
@@ -211,7 +215,7 @@ func TestManager_DenyToolCall(t *testing.T) {
 	// Mock session status update
 	mockStore.EXPECT().UpdateSession(ctx, sessionID, gomock.Any()).Return(nil)
 
-	err := manager.DenyToolCall(ctx, approvalID, reason)
+	err := manager.DenyToolCall(ctx, approvalID, reason, store.ApprovalDecidedByHuman)
 	require.NoError(t, err)
 }
 
@@ -236,6 +240,9 @@ func TestManager_CorrelateApproval(t *testing.T) {
 		RunID: runID,
 	}, nil)
 
+	// No always allow rules
+	mockStore.EXPECT().ListApprovalRules(ctx, sessionID).Return(nil, nil)
+
 	// Mock creating approval
 	mockStore.EXPECT().CreateApproval(ctx, gomock.Any()).Return(nil)
 
@@ -261,3 +268,47 @@ func TestManager_CorrelateApproval(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, approvalID)
 }
+
+func TestManager_ApproveToolCallWithInput(t *testing.T) {
+	m, eventBus := newPolicyTestManager(t, &store.Session{ID: "sess-1", RunID: "run-1", ClaudeSessionID: "claude-1", Query: "q"}, "")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sqliteStore := m.(*manager).store
+	require.NoError(t, sqliteStore.AddConversationEvent(ctx, &store.ConversationEvent{
+		SessionID:       "sess-1",
+		ClaudeSessionID: "claude-1",
+		EventType:       store.EventTypeToolCall,
+		Role:            "assistant",
+		ToolID:          "toolu_1",
+		ToolName:        "Bash",
+		ToolInputJSON:   `{"command": "rm -rf /"}`,
+	}))
+
+	approval, err := m.CreateApprovalWithToolUseID(ctx, "sess-1", "Bash", json.RawMessage(`{"command": "rm -rf /"}`), "toolu_1")
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, m.ApproveToolCallWithInput(ctx, approval.ID, "", json.RawMessage(`"ls"`), store.ApprovalDecidedByHuman), "updated input must be a JSON object")
+
+	sub := eventBus.Subscribe(ctx, bus.EventFilter{Types: []bus.EventType{bus.EventApprovalResolved}})
+	updatedInput := json.RawMessage(`{"command": "rm -rf ./build"}`)
+	require.NoError(t, m.ApproveToolCallWithInput(ctx, approval.ID, "Narrowed the path", updatedInput, store.ApprovalDecidedByHuman))
+
+	select {
+	case event := <-sub.Channel:
+		assert.Equal(t, true, event.Data["approved"])
+		assert.Equal(t, updatedInput, event.Data["updated_input"])
+	case <-time.After(time.Second):
+		t.Fatal("expected approval_resolved event")
+	}
+
+	stored, err := m.GetApproval(ctx, approval.ID)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"command": "rm -rf /"}`, string(stored.ToolInput))
+	assert.JSONEq(t, string(updatedInput), string(stored.UpdatedInput))
+
+	events, err := sqliteStore.GetSessionConversation(ctx, "sess-1")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.JSONEq(t, string(updatedInput), events[0].UpdatedToolInputJSON)
+}
@@ -0,0 +1,407 @@
+package approval
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DirectoryPolicyFile is where a working directory's approval policy lives,
+// relative to the directory
+const DirectoryPolicyFile = ".humanlayer/approval-policy.json"
+
+// Approval policy sources, used to name rules that have no name
+const (
+	PolicySourceSession   = "session"
+	PolicySourceDirectory = "directory"
+	PolicySourceDaemon    = "daemon"
+)
+
+// PolicyAction is what a matching policy rule does with a tool call
+type PolicyAction string
+
+const (
+	PolicyAllow PolicyAction = "allow" // Approve without asking
+	PolicyDeny  PolicyAction = "deny"  // Deny with the rule's message
+	PolicyAsk   PolicyAction = "ask"   // Ask a human, even if an auto-accept mode is on
+)
+
+// TimeoutOutcome is what happens to an approval nobody answers before its timeout
+type TimeoutOutcome string
+
+const (
+	TimeoutDeny      TimeoutOutcome = "deny"      // Deny with the timeout message
+	TimeoutApprove   TimeoutOutcome = "approve"   // Approve the tool call
+	TimeoutInterrupt TimeoutOutcome = "interrupt" // Deny and interrupt the session
+)
+
+// ApprovalTimeout bounds how long a pending approval waits for a human
+type ApprovalTimeout struct {
+	Timeout        string         `json:"timeout,omitempty"`         // Go duration, e.g. "30m"
+	OnTimeout      TimeoutOutcome `json:"on_timeout,omitempty"`      // Defaults to deny
+	TimeoutMessage string         `json:"timeout_message,omitempty"` // Reason given to Claude when the approval expires
+
+	timeout time.Duration
+}
+
+// Duration returns the parsed timeout, zero if none is set
+func (t *ApprovalTimeout) Duration() time.Duration {
+	return t.timeout
+}
+
+func (t *ApprovalTimeout) compile() error {
+	if t.Timeout == "" {
+		if t.OnTimeout != "" || t.TimeoutMessage != "" {
+			return fmt.Errorf("on_timeout and timeout_message require timeout")
+		}
+		return nil
+	}
+
+	var err error
+	if t.timeout, err = time.ParseDuration(t.Timeout); err != nil {
+		return fmt.Errorf("invalid timeout: %w", err)
+	}
+	if t.timeout <= 0 {
+		return fmt.Errorf("timeout must be positive, got %q", t.Timeout)
+	}
+	switch t.OnTimeout {
+	case "":
+		t.OnTimeout = TimeoutDeny
+	case TimeoutDeny, TimeoutApprove, TimeoutInterrupt:
+	default:
+		return fmt.Errorf("on_timeout must be deny, approve or interrupt, got %q", t.OnTimeout)
+	}
+	return nil
+}
+
+// PolicyRule matches tool calls and decides them. Every condition that is set
+// must match; a rule with no conditions matches every tool call.
+type PolicyRule struct {
+	Name string `json:"name,omitempty"`
+
+	Tool      string `json:"tool,omitempty"`       // Glob on the tool name, e.g. "mcp__*"
+	Path      string `json:"path,omitempty"`       // Glob on the file path in the tool input; relative globs match inside the working directory
+	Command   string `json:"command,omitempty"`    // Regular expression searched in a Bash command
+	MCPServer string `json:"mcp_server,omitempty"` // Glob on the server of an mcp__<server>__<tool> tool
+
+	Action  PolicyAction `json:"action"`
+	Message string       `json:"message,omitempty"` // Reason given to Claude when the rule denies
+
+	// Timeout for approvals the rule leaves pending, overriding the policy's
+	ApprovalTimeout
+
+	tool      *regexp.Regexp
+	path      *regexp.Regexp
+	command   *regexp.Regexp
+	mcpServer *regexp.Regexp
+}
+
+// Policy is an ordered list of rules; the first matching rule decides
+type Policy struct {
+	Rules []PolicyRule `json:"rules"`
+
+	// Timeout for pending approvals that no matching rule sets a timeout for
+	ApprovalTimeout
+}
+
+// PolicyDecision is the outcome of the rule that matched a tool call
+type PolicyDecision struct {
+	Action  PolicyAction
+	Rule    string // Name of the rule
+	Message string
+	Timeout *ApprovalTimeout // The rule's timeout, nil if it sets none
+}
+
+// ParsePolicy parses and validates a JSON policy. Rules without a name are
+// named after source and their position.
+func ParsePolicy(data []byte, source string) (*Policy, error) {
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("invalid %s approval policy: %w", source, err)
+	}
+	if err := policy.ApprovalTimeout.compile(); err != nil {
+		return nil, fmt.Errorf("invalid %s approval policy: %w", source, err)
+	}
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("%s rule %d", source, i+1)
+		}
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("invalid %s approval policy rule %q: %w", source, rule.Name, err)
+		}
+	}
+	return &policy, nil
+}
+
+// LoadPolicyFile reads a policy from path, returning nil if the file does not exist
+func LoadPolicyFile(path, source string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read approval policy: %w", err)
+	}
+	return ParsePolicy(data, source)
+}
+
+// Evaluate returns the decision of the first rule matching the tool call, or
+// nil if no rule matches. A nil policy matches nothing.
+func (p *Policy) Evaluate(toolName string, toolInput json.RawMessage, workingDir string) *PolicyDecision {
+	if p == nil {
+		return nil
+	}
+
+	var input map[string]interface{}
+	_ = json.Unmarshal(toolInput, &input)
+
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.matches(toolName, input, workingDir) {
+			decision := &PolicyDecision{Action: rule.Action, Rule: rule.Name, Message: rule.Message}
+			if rule.timeout > 0 {
+				decision.Timeout = &rule.ApprovalTimeout
+			}
+			return decision
+		}
+	}
+	return nil
+}
+
+// evaluatePolicies returns the decision of the first policy with a rule
+// matching the tool call. The daemon policy is set by whoever runs the daemon,
+// so its deny and ask rules win over allow rules of the other policies.
+func evaluatePolicies(policies []*Policy, daemon *Policy, toolName string, toolInput json.RawMessage, workingDir string) *PolicyDecision {
+	var decision *PolicyDecision
+	for _, policy := range policies {
+		if decision = policy.Evaluate(toolName, toolInput, workingDir); decision != nil {
+			break
+		}
+	}
+
+	if decision != nil && decision.Action == PolicyAllow {
+		if daemonDecision := daemon.Evaluate(toolName, toolInput, workingDir); daemonDecision != nil && daemonDecision.Action != PolicyAllow {
+			return daemonDecision
+		}
+	}
+	return decision
+}
+
+// untrusted returns the policy without the rules and timeouts that approve
+// tool calls, for working directory policies the daemon does not trust
+func (p *Policy) untrusted() *Policy {
+	restricted := &Policy{ApprovalTimeout: p.ApprovalTimeout.untrusted()}
+	for _, rule := range p.Rules {
+		if rule.Action == PolicyAllow {
+			continue
+		}
+		rule.ApprovalTimeout = rule.ApprovalTimeout.untrusted()
+		restricted.Rules = append(restricted.Rules, rule)
+	}
+	return restricted
+}
+
+func (t ApprovalTimeout) untrusted() ApprovalTimeout {
+	if t.OnTimeout == TimeoutApprove {
+		return ApprovalTimeout{}
+	}
+	return t
+}
+
+// writesPolicyFile reports whether a tool call edits one of the approval
+// policy files. Paths are compared after resolving symlinks, and without
+// regard to case since macOS file systems usually ignore it.
+func writesPolicyFile(toolName string, toolInput json.RawMessage, workingDir string, policyFiles ...string) bool {
+	if !isEditTool(toolName) && toolName != "NotebookEdit" {
+		return false
+	}
+
+	var input struct {
+		FilePath     string `json:"file_path"`
+		NotebookPath string `json:"notebook_path"`
+	}
+	if err := json.Unmarshal(toolInput, &input); err != nil {
+		return false
+	}
+	for _, path := range []string{input.FilePath, input.NotebookPath} {
+		if path == "" {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			if workingDir == "" {
+				continue
+			}
+			path = filepath.Join(workingDir, path)
+		}
+		for _, policyFile := range policyFiles {
+			if policyFile != "" && samePath(path, policyFile) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// samePath reports whether two paths name the same file, which need not exist
+func samePath(a, b string) bool {
+	a, b = resolvePath(a), resolvePath(b)
+	if strings.EqualFold(a, b) {
+		return true
+	}
+	aInfo, aErr := os.Stat(a)
+	bInfo, bErr := os.Stat(b)
+	return aErr == nil && bErr == nil && os.SameFile(aInfo, bInfo)
+}
+
+// maxSymlinks bounds how many dangling symlinks resolvePath follows
+const maxSymlinks = 40
+
+// resolvePath returns path with its symlinks resolved. Parts of the path that
+// do not exist yet are kept as they are, and dangling symlinks are followed to
+// the file a write would create.
+func resolvePath(path string) string {
+	path = filepath.Clean(path)
+	for i := 0; i < maxSymlinks; i++ {
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			return resolved
+		}
+		target, err := os.Readlink(path)
+		if err != nil {
+			break
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		path = filepath.Clean(target)
+	}
+
+	parent := filepath.Dir(path)
+	if parent == path {
+		return path
+	}
+	return filepath.Join(resolvePath(parent), filepath.Base(path))
+}
+
+func (r *PolicyRule) compile() error {
+	switch r.Action {
+	case PolicyAllow, PolicyDeny, PolicyAsk:
+	default:
+		return fmt.Errorf("action must be allow, deny or ask, got %q", r.Action)
+	}
+	if err := r.ApprovalTimeout.compile(); err != nil {
+		return err
+	}
+
+	var err error
+	if r.Tool != "" {
+		r.tool = compileGlob(r.Tool)
+	}
+	if r.Path != "" {
+		r.path = compileGlob(r.Path)
+	}
+	if r.Command != "" {
+		if r.command, err = regexp.Compile(r.Command); err != nil {
+			return fmt.Errorf("invalid command pattern: %w", err)
+		}
+	}
+	if r.MCPServer != "" {
+		r.mcpServer = compileGlob(r.MCPServer)
+	}
+	return nil
+}
+
+func (r *PolicyRule) matches(toolName string, input map[string]interface{}, workingDir string) bool {
+	if r.tool != nil && !r.tool.MatchString(toolName) {
+		return false
+	}
+	if r.mcpServer != nil {
+		server, ok := mcpServerName(toolName)
+		if !ok || !r.mcpServer.MatchString(server) {
+			return false
+		}
+	}
+	if r.command != nil {
+		command, ok := input["command"].(string)
+		if !ok || !r.command.MatchString(command) {
+			return false
+		}
+	}
+	if r.path != nil && !r.matchesPath(input, workingDir) {
+		return false
+	}
+	return true
+}
+
+// matchesPath reports whether a file path in the tool input matches the rule
+func (r *PolicyRule) matchesPath(input map[string]interface{}, workingDir string) bool {
+	for _, key := range []string{"file_path", "path", "notebook_path"} {
+		path, ok := input[key].(string)
+		if !ok || path == "" {
+			continue
+		}
+		if !filepath.IsAbs(r.Path) {
+			if workingDir == "" {
+				continue
+			}
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(workingDir, path)
+			}
+			rel, err := filepath.Rel(workingDir, path)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				// Relative globs only match inside the working directory
+				continue
+			}
+			path = rel
+		}
+		if r.path.MatchString(filepath.ToSlash(filepath.Clean(path))) {
+			return true
+		}
+	}
+	return false
+}
+
+// mcpServerName returns the server of an mcp__<server>__<tool> tool name
+func mcpServerName(toolName string) (string, bool) {
+	rest, ok := strings.CutPrefix(toolName, "mcp__")
+	if !ok {
+		return "", false
+	}
+	server, _, ok := strings.Cut(rest, "__")
+	return server, ok && server != ""
+}
+
+// compileGlob converts a glob to an anchored regular expression. "*" and "?"
+// do not match "/", "**" matches any number of path segments.
+func compileGlob(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					// "**/" also matches no directories at all
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,360 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Evaluate(t *testing.T) {
+	policy, err := ParsePolicy([]byte(`{"rules": [
+		{"name": "no-force-push", "tool": "Bash", "command": "git push .*--force", "action": "deny", "message": "Never force push"},
+		{"name": "safe-git", "tool": "Bash", "command": "^git (status|diff|log)\\b", "action": "allow"},
+		{"name": "docs", "tool": "Edit", "path": "docs/**", "action": "allow"},
+		{"name": "env-files", "path": "**/.env", "action": "ask"},
+		{"name": "etc", "tool": "Write", "path": "/etc/*", "action": "deny"},
+		{"name": "linear", "mcp_server": "linear", "action": "allow"},
+		{"tool": "mcp__*", "action": "ask"}
+	]}`), PolicySourceDaemon)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		toolName string
+		input    string
+		wantRule string // Empty when no rule matches
+	}{
+		{"command regex", "Bash", `{"command": "git push origin main --force"}`, "no-force-push"},
+		{"anchored command", "Bash", `{"command": "git status"}`, "safe-git"},
+		{"unmatched command", "Bash", `{"command": "echo git status"}`, ""},
+		{"relative path glob", "Edit", `{"file_path": "/work/repo/docs/guide/intro.md"}`, "docs"},
+		{"relative input path", "Edit", `{"file_path": "docs/intro.md"}`, "docs"},
+		{"path outside working directory", "Edit", `{"file_path": "/other/docs/intro.md"}`, ""},
+		{"double star matches no directories", "Read", `{"file_path": "/work/repo/.env"}`, "env-files"},
+		{"double star matches nested directories", "Read", `{"file_path": "/work/repo/a/b/.env"}`, "env-files"},
+		{"absolute path glob", "Write", `{"file_path": "/etc/hosts"}`, "etc"},
+		{"single star stops at slash", "Write", `{"file_path": "/etc/ssh/sshd_config"}`, ""},
+		{"mcp server", "mcp__linear__create_issue", `{}`, "linear"},
+		{"tool glob", "mcp__github__create_pr", `{}`, "daemon rule 7"},
+		{"no path in input", "Edit", `{}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := policy.Evaluate(tt.toolName, json.RawMessage(tt.input), "/work/repo")
+			if tt.wantRule == "" {
+				assert.Nil(t, decision)
+				return
+			}
+			require.NotNil(t, decision)
+			assert.Equal(t, tt.wantRule, decision.Rule)
+		})
+	}
+}
+
+func TestParsePolicy_Errors(t *testing.T) {
+	_, err := ParsePolicy([]byte(`{"rules": [{"tool": "Bash"}]}`), PolicySourceSession)
+	assert.ErrorContains(t, err, "action must be allow, deny or ask")
+
+	_, err = ParsePolicy([]byte(`{"rules": [{"command": "(", "action": "deny"}]}`), PolicySourceSession)
+	assert.ErrorContains(t, err, `rule "session rule 1"`)
+
+	_, err = ParsePolicy([]byte(`not json`), PolicySourceSession)
+	assert.Error(t, err)
+}
+
+func TestParsePolicy_Timeouts(t *testing.T) {
+	policy, err := ParsePolicy([]byte(`{"timeout": "1h", "rules": [{"tool": "Bash", "action": "ask", "timeout": "5m", "on_timeout": "interrupt"}]}`), PolicySourceSession)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, policy.Duration())
+	assert.Equal(t, TimeoutDeny, policy.OnTimeout, "on_timeout defaults to deny")
+	assert.Equal(t, 5*time.Minute, policy.Rules[0].Duration())
+
+	for input, wantErr := range map[string]string{
+		`{"timeout": "soon", "rules": []}`:                              "invalid timeout",
+		`{"timeout": "-1m", "rules": []}`:                               "timeout must be positive",
+		`{"timeout": "1m", "on_timeout": "retry", "rules": []}`:         "on_timeout must be deny, approve or interrupt",
+		`{"rules": [{"action": "ask", "on_timeout": "approve"}]}`:       "on_timeout and timeout_message require timeout",
+		`{"rules": [{"action": "ask", "timeout_message": "too slow"}]}`: "on_timeout and timeout_message require timeout",
+	} {
+		_, err := ParsePolicy([]byte(input), PolicySourceSession)
+		assert.ErrorContains(t, err, wantErr, input)
+	}
+}
+
+func TestLoadPolicyFile_Missing(t *testing.T) {
+	policy, err := LoadPolicyFile(filepath.Join(t.TempDir(), "missing.json"), PolicySourceDaemon)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+	assert.Nil(t, policy.Evaluate("Bash", nil, ""))
+}
+
+// newPolicyTestManager returns a manager with an in-memory store holding session
+func newPolicyTestManager(t *testing.T, session *store.Session, daemonPolicy string) (Manager, bus.EventBus) {
+	t.Helper()
+
+	var config PolicyConfig
+	if daemonPolicy != "" {
+		var err error
+		config.Daemon, err = ParsePolicy([]byte(daemonPolicy), PolicySourceDaemon)
+		require.NoError(t, err)
+	}
+	return newPolicyTestManagerWithConfig(t, session, config)
+}
+
+// newPolicyTestManagerWithConfig is newPolicyTestManager with the policy config given
+func newPolicyTestManagerWithConfig(t *testing.T, session *store.Session, config PolicyConfig) (Manager, bus.EventBus) {
+	t.Helper()
+
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqliteStore.Close() })
+
+	session.Status = store.SessionStatusRunning
+	session.CreatedAt = time.Now()
+	session.LastActivityAt = time.Now()
+	require.NoError(t, sqliteStore.CreateSession(context.Background(), session))
+
+	eventBus := bus.NewEventBus()
+	return NewManagerWithPolicy(sqliteStore, eventBus, config), eventBus
+}
+
+func TestManager_PolicyDeny(t *testing.T) {
+	m, eventBus := newPolicyTestManager(t, &store.Session{ID: "sess-1", RunID: "run-1", Query: "q"},
+		`{"rules": [{"name": "no-rm", "tool": "Bash", "command": "rm -rf", "action": "deny", "message": "Do not delete files"}]}`)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := eventBus.Subscribe(ctx, bus.EventFilter{Types: []bus.EventType{bus.EventApprovalResolved}})
+
+	approval, err := m.CreateApprovalWithToolUseID(ctx, "sess-1", "Bash", json.RawMessage(`{"command": "rm -rf /"}`), "toolu_1")
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalStatusLocalDenied, approval.Status)
+	assert.Equal(t, `Do not delete files (policy rule "no-rm")`, approval.Comment)
+	assert.Equal(t, "no-rm", approval.PolicyRule)
+
+	stored, err := m.GetApproval(ctx, approval.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "no-rm", stored.PolicyRule)
+
+	select {
+	case event := <-sub.Channel:
+		assert.Equal(t, false, event.Data["approved"])
+		assert.Equal(t, "toolu_1", event.Data["tool_use_id"])
+	case <-time.After(time.Second):
+		t.Fatal("expected approval_resolved event")
+	}
+}
+
+func TestManager_PolicyPrecedence(t *testing.T) {
+	workingDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workingDir, ".humanlayer"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, DirectoryPolicyFile),
+		[]byte(`{"rules": [{"name": "dir-bash", "tool": "Bash", "action": "allow"}, {"name": "dir-read", "tool": "Read", "action": "allow"}, {"name": "dir-glob", "tool": "Glob", "action": "allow"}, {"name": "dir-write", "tool": "Write", "action": "allow"}]}`), 0o644))
+
+	daemonPolicy, err := ParsePolicy([]byte(`{"rules": [{"name": "daemon-read", "tool": "Read", "action": "deny"}, {"name": "daemon-glob", "tool": "Glob", "action": "ask"}, {"name": "daemon-grep", "tool": "Grep", "action": "allow"}]}`), PolicySourceDaemon)
+	require.NoError(t, err)
+	m, _ := newPolicyTestManagerWithConfig(t, &store.Session{
+		ID:              "sess-1",
+		RunID:           "run-1",
+		Query:           "q",
+		WorkingDir:      workingDir,
+		AutoAcceptEdits: true,
+		ApprovalPolicy:  `{"rules": [{"name": "session-bash", "tool": "Bash", "action": "deny"}, {"name": "ask-edits", "tool": "Edit", "path": "*.go", "action": "ask"}]}`,
+	}, PolicyConfig{Daemon: daemonPolicy, TrustDirectoryPolicies: true})
+	ctx := context.Background()
+
+	tests := []struct {
+		toolName   string
+		input      string
+		wantStatus store.ApprovalStatus
+		wantRule   string
+	}{
+		{"Bash", `{"command": "ls"}`, store.ApprovalStatusLocalDenied, "session-bash"},
+		// Daemon deny and ask rules win over working directory allow rules
+		{"Read", `{"file_path": "main.go"}`, store.ApprovalStatusLocalDenied, "daemon-read"},
+		{"Glob", `{"pattern": "*.go"}`, store.ApprovalStatusLocalPending, "daemon-glob"},
+		{"Grep", `{"pattern": "x"}`, store.ApprovalStatusLocalApproved, "daemon-grep"},
+		{"Write", `{"file_path": "main.go"}`, store.ApprovalStatusLocalApproved, "dir-write"},
+		// Changes to the working directory policy always ask
+		{"Write", `{"file_path": ".humanlayer/approval-policy.json"}`, store.ApprovalStatusLocalPending, ""},
+		{"Edit", `{"file_path": "` + filepath.Join(workingDir, "docs", "..", DirectoryPolicyFile) + `"}`, store.ApprovalStatusLocalPending, ""},
+		// An ask rule keeps the approval pending despite auto-accept edits
+		{"Edit", `{"file_path": "main.go"}`, store.ApprovalStatusLocalPending, "ask-edits"},
+		// No rule: auto-accept edits applies
+		{"Edit", `{"file_path": "README.md"}`, store.ApprovalStatusLocalApproved, ""},
+	}
+
+	for i, tt := range tests {
+		approval, err := m.CreateApprovalWithToolUseID(ctx, "sess-1", tt.toolName, json.RawMessage(tt.input), "toolu_"+string(rune('a'+i)))
+		require.NoError(t, err)
+		assert.Equal(t, tt.wantStatus, approval.Status, tt.toolName)
+		assert.Equal(t, tt.wantRule, approval.PolicyRule, tt.toolName)
+	}
+}
+
+func TestWritesPolicyFile(t *testing.T) {
+	workingDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workingDir, ".humanlayer"), 0o755))
+	policyFile := filepath.Join(workingDir, DirectoryPolicyFile)
+	require.NoError(t, os.WriteFile(policyFile, []byte(`{"rules": []}`), 0o644))
+	require.NoError(t, os.Symlink(".humanlayer", filepath.Join(workingDir, "config")))
+	require.NoError(t, os.Symlink(DirectoryPolicyFile, filepath.Join(workingDir, "policy.json")))
+	linkedDir := filepath.Join(t.TempDir(), "repo")
+	require.NoError(t, os.Symlink(workingDir, linkedDir))
+
+	// A daemon policy that does not exist yet, reached through a dangling symlink
+	daemonDir := t.TempDir()
+	daemonPolicy := filepath.Join(daemonDir, "approval-policy.json")
+	require.NoError(t, os.Symlink(daemonPolicy, filepath.Join(workingDir, "daemon.json")))
+
+	tests := []struct {
+		name       string
+		toolName   string
+		input      string
+		workingDir string
+		want       bool
+	}{
+		{"relative", "Write", `{"file_path": ".humanlayer/approval-policy.json"}`, workingDir, true},
+		{"absolute", "Edit", `{"file_path": "` + policyFile + `"}`, workingDir, true},
+		{"parent directory", "MultiEdit", `{"file_path": "docs/../.humanlayer/approval-policy.json"}`, workingDir, true},
+		{"other case", "Write", `{"file_path": ".HumanLayer/Approval-Policy.json"}`, workingDir, true},
+		{"symlinked directory", "Write", `{"file_path": "config/approval-policy.json"}`, workingDir, true},
+		{"symlinked file", "Edit", `{"file_path": "policy.json"}`, workingDir, true},
+		{"symlinked working directory", "Write", `{"file_path": "` + policyFile + `"}`, linkedDir, true},
+		{"notebook path", "NotebookEdit", `{"notebook_path": "policy.json"}`, workingDir, true},
+		{"daemon policy", "Write", `{"file_path": "` + daemonPolicy + `"}`, workingDir, true},
+		{"daemon policy through a dangling symlink", "Write", `{"file_path": "daemon.json"}`, workingDir, true},
+		{"other file", "Write", `{"file_path": ".humanlayer/notes.md"}`, workingDir, false},
+		{"read", "Read", `{"file_path": ".humanlayer/approval-policy.json"}`, workingDir, false},
+		{"relative without working directory", "Write", `{"file_path": ".humanlayer/approval-policy.json"}`, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := []string{daemonPolicy}
+			if tt.workingDir != "" {
+				files = append(files, filepath.Join(tt.workingDir, DirectoryPolicyFile))
+			}
+			assert.Equal(t, tt.want, writesPolicyFile(tt.toolName, json.RawMessage(tt.input), tt.workingDir, files...))
+		})
+	}
+}
+
+func TestManager_DirectoryPolicyEdits(t *testing.T) {
+	workingDir := t.TempDir()
+	daemonPolicy := filepath.Join(t.TempDir(), "approval-policy.json")
+	m, _ := newPolicyTestManagerWithConfig(t, &store.Session{
+		ID:                         "sess-1",
+		RunID:                      "run-1",
+		Query:                      "q",
+		WorkingDir:                 workingDir,
+		DangerouslySkipPermissions: true,
+	}, PolicyConfig{DaemonPath: daemonPolicy})
+	ctx := context.Background()
+
+	require.NoError(t, m.(*manager).store.CreateApprovalRule(ctx, &store.ApprovalRule{
+		ID: "rule-1", Scope: store.ApprovalRuleScopeSession, SessionID: "sess-1",
+		ToolName: "Write", Match: store.ApprovalRuleMatchTool, CreatedAt: time.Now(),
+	}))
+
+	// Neither dangerous skip permissions nor always allow rules approve edits of a policy file
+	for i, path := range []string{".humanlayer/approval-policy.json", daemonPolicy} {
+		approval, err := m.CreateApprovalWithToolUseID(ctx, "sess-1", "Write", json.RawMessage(`{"file_path": "`+path+`"}`), "toolu_"+string(rune('a'+i)))
+		require.NoError(t, err)
+		assert.Equal(t, store.ApprovalStatusLocalPending, approval.Status, path)
+	}
+
+	approval, err := m.CreateApprovalWithToolUseID(ctx, "sess-1", "Write", json.RawMessage(`{"file_path": ".humanlayer/notes.md"}`), "toolu_z")
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalStatusLocalApproved, approval.Status)
+}
+
+func TestManager_UntrustedDirectoryPolicy(t *testing.T) {
+	workingDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workingDir, ".humanlayer"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, DirectoryPolicyFile), []byte(`{
+		"timeout": "1m", "on_timeout": "approve",
+		"rules": [
+			{"name": "no-rm", "tool": "Bash", "command": "rm", "action": "deny"},
+			{"name": "bash", "tool": "Bash", "action": "allow"},
+			{"name": "deploy", "tool": "Deploy", "action": "ask", "timeout": "5m"}
+		]
+	}`), 0o644))
+
+	tests := []struct {
+		name        string
+		trusted     bool
+		toolName    string
+		input       string
+		wantStatus  store.ApprovalStatus
+		wantRule    string
+		wantTimeout time.Duration
+	}{
+		{"deny rules apply", false, "Bash", `{"command": "rm -rf /"}`, store.ApprovalStatusLocalDenied, "no-rm", 0},
+		{"allow rules are ignored", false, "Bash", `{"command": "ls"}`, store.ApprovalStatusLocalPending, "", 0},
+		{"ask rules keep their timeout", false, "Deploy", `{}`, store.ApprovalStatusLocalPending, "deploy", 5 * time.Minute},
+		{"trusted allow rules apply", true, "Bash", `{"command": "ls"}`, store.ApprovalStatusLocalApproved, "bash", 0},
+		{"trusted timeouts apply", true, "Read", `{}`, store.ApprovalStatusLocalPending, "", time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newPolicyTestManagerWithConfig(t, &store.Session{ID: "sess-1", RunID: "run-1", Query: "q", WorkingDir: workingDir},
+				PolicyConfig{TrustDirectoryPolicies: tt.trusted})
+
+			approval, err := m.CreateApprovalWithToolUseID(context.Background(), "sess-1", tt.toolName, json.RawMessage(tt.input), "toolu_1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, approval.Status)
+			assert.Equal(t, tt.wantRule, approval.PolicyRule)
+			if tt.wantTimeout == 0 {
+				assert.Nil(t, approval.ExpiresAt)
+				return
+			}
+			require.NotNil(t, approval.ExpiresAt)
+			assert.Equal(t, tt.wantTimeout, approval.ExpiresAt.Sub(approval.CreatedAt))
+		})
+	}
+}
+
+func TestManager_PolicyTimeout(t *testing.T) {
+	m, _ := newPolicyTestManager(t, &store.Session{
+		ID:             "sess-1",
+		RunID:          "run-1",
+		Query:          "q",
+		ApprovalPolicy: `{"rules": [{"name": "slow-bash", "tool": "Bash", "action": "ask", "timeout": "1h", "on_timeout": "approve"}]}`,
+	}, `{"timeout": "10m", "on_timeout": "interrupt", "timeout_message": "Nobody is watching", "rules": [{"tool": "Read", "action": "allow"}]}`)
+	ctx := context.Background()
+
+	// The matching rule's timeout wins
+	approval, err := m.CreateApprovalWithToolUseID(ctx, "sess-1", "Bash", json.RawMessage(`{"command": "ls"}`), "toolu_1")
+	require.NoError(t, err)
+	require.NotNil(t, approval.ExpiresAt)
+	assert.Equal(t, time.Hour, approval.ExpiresAt.Sub(approval.CreatedAt))
+	assert.Equal(t, "approve", approval.OnTimeout)
+
+	// Otherwise the first policy that sets a timeout applies
+	approval, err = m.CreateApprovalWithToolUseID(ctx, "sess-1", "Edit", json.RawMessage(`{"file_path": "main.go"}`), "toolu_2")
+	require.NoError(t, err)
+	require.NotNil(t, approval.ExpiresAt)
+	assert.Equal(t, 10*time.Minute, approval.ExpiresAt.Sub(approval.CreatedAt))
+	assert.Equal(t, "interrupt", approval.OnTimeout)
+	assert.Equal(t, "Nobody is watching", approval.TimeoutMessage)
+
+	stored, err := m.GetApproval(ctx, approval.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.ExpiresAt)
+	assert.Equal(t, "interrupt", stored.OnTimeout)
+
+	// Decided approvals never expire
+	approval, err = m.CreateApprovalWithToolUseID(ctx, "sess-1", "Read", json.RawMessage(`{"file_path": "main.go"}`), "toolu_3")
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalStatusLocalApproved, approval.Status)
+	assert.Nil(t, approval.ExpiresAt)
+}
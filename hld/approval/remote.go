@@ -0,0 +1,346 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	humanlayer "github.com/humanlayer/humanlayer-go"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// DefaultRemotePollInterval is how often RemoteManager checks HumanLayer for
+// decisions on mirrored approvals
+const DefaultRemotePollInterval = 3 * time.Second
+
+// remoteDeniedReason is used when a call is denied in HumanLayer without a comment
+const remoteDeniedReason = "Denied in HumanLayer"
+
+// RemoteManager is a Manager that mirrors pending local approvals to
+// HumanLayer function calls, so they can also be decided from Slack, email
+// or the HumanLayer dashboard. Decisions made remotely are applied to the
+// local approval, and local decisions are sent to HumanLayer.
+//
+// Approvals are mirrored in the background. Approvals resolved without going
+// through the RemoteManager, such as by the expiry monitor, are resolved in
+// HumanLayer the next time decisions are synced.
+type RemoteManager struct {
+	Manager // Local approvals
+
+	client   *humanlayer.Client
+	interval time.Duration
+
+	mirrors sync.WaitGroup // Mirrors in progress
+
+	mu      sync.Mutex
+	pending map[string]bool // Mirrored approval IDs, which are also the call IDs
+}
+
+// NewRemoteManager wraps local with mirroring to HumanLayer through client
+func NewRemoteManager(local Manager, client *humanlayer.Client, interval time.Duration) *RemoteManager {
+	if interval <= 0 {
+		interval = DefaultRemotePollInterval
+	}
+	return &RemoteManager{
+		Manager:  local,
+		client:   client,
+		interval: interval,
+		pending:  make(map[string]bool),
+	}
+}
+
+// Start mirrors approvals left pending by a previous run of the daemon, then
+// polls HumanLayer for decisions on mirrored approvals until ctx is done
+func (m *RemoteManager) Start(ctx context.Context) {
+	slog.Info("starting HumanLayer approval sync", "interval", m.interval)
+
+	m.mirrorPending(ctx)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("HumanLayer approval sync shutting down")
+			return
+		case <-ticker.C:
+			m.syncDecisions(ctx)
+		}
+	}
+}
+
+// CreateApproval creates a local approval and mirrors it if it is pending
+func (m *RemoteManager) CreateApproval(ctx context.Context, runID, toolName string, toolInput json.RawMessage) (string, error) {
+	id, err := m.Manager.CreateApproval(ctx, runID, toolName, toolInput)
+	if err != nil {
+		return "", err
+	}
+
+	approval, err := m.Manager.GetApproval(ctx, id)
+	if err != nil {
+		slog.Warn("failed to load approval for HumanLayer mirroring",
+			"approval_id", id,
+			"error", err)
+		return id, nil
+	}
+	m.mirrorAsync(ctx, approval)
+	return id, nil
+}
+
+// CreateApprovalWithToolUseID creates a local approval and mirrors it if it is pending
+func (m *RemoteManager) CreateApprovalWithToolUseID(ctx context.Context, sessionID, toolName string, toolInput json.RawMessage, toolUseID string) (*store.Approval, error) {
+	approval, err := m.Manager.CreateApprovalWithToolUseID(ctx, sessionID, toolName, toolInput, toolUseID)
+	if err != nil {
+		return nil, err
+	}
+	m.mirrorAsync(ctx, approval)
+	return approval, nil
+}
+
+// ApproveToolCall approves locally and records the decision in HumanLayer
+func (m *RemoteManager) ApproveToolCall(ctx context.Context, id string, comment string) error {
+	return m.ApproveToolCallWithInput(ctx, id, comment, nil, store.ApprovalDecidedByHuman)
+}
+
+// ApproveToolCallWithInput approves locally with edited tool input and
+// records the decision in HumanLayer
+func (m *RemoteManager) ApproveToolCallWithInput(ctx context.Context, id string, comment string, updatedInput json.RawMessage, decidedBy string) error {
+	if err := m.Manager.ApproveToolCallWithInput(ctx, id, comment, updatedInput, decidedBy); err != nil {
+		return err
+	}
+	m.respondRemote(ctx, id, true, comment)
+	return nil
+}
+
+// ApproveAndRemember approves locally, creating an approval rule, and records
+// the decision in HumanLayer
+func (m *RemoteManager) ApproveAndRemember(ctx context.Context, id string, comment string, opts RememberOptions) (*store.ApprovalRule, error) {
+	rule, err := m.Manager.ApproveAndRemember(ctx, id, comment, opts)
+	if err != nil {
+		return nil, err
+	}
+	m.respondRemote(ctx, id, true, comment)
+	return rule, nil
+}
+
+// DenyToolCall denies locally and records the decision in HumanLayer
+func (m *RemoteManager) DenyToolCall(ctx context.Context, id string, reason string, decidedBy string) error {
+	if err := m.Manager.DenyToolCall(ctx, id, reason, decidedBy); err != nil {
+		return err
+	}
+	m.respondRemote(ctx, id, false, reason)
+	return nil
+}
+
+// mirrorPending mirrors every pending approval. Approvals already mirrored
+// before a restart are tracked again so their decisions are synced.
+func (m *RemoteManager) mirrorPending(ctx context.Context) {
+	approvals, err := m.Manager.ListApprovals(ctx, store.ApprovalFilter{Status: store.ApprovalStatusLocalPending})
+	if err != nil {
+		slog.Warn("failed to load pending approvals for HumanLayer mirroring", "error", err)
+		return
+	}
+	for _, approval := range approvals {
+		if ctx.Err() != nil {
+			return
+		}
+		m.mirror(ctx, approval)
+	}
+}
+
+// mirrorAsync mirrors a pending approval in the background, so that creating
+// it does not wait on HumanLayer
+func (m *RemoteManager) mirrorAsync(ctx context.Context, approval *store.Approval) {
+	if approval == nil || approval.Status != store.ApprovalStatusLocalPending {
+		return
+	}
+	// The approval outlives the request that created it
+	ctx = context.WithoutCancel(ctx)
+
+	m.mirrors.Add(1)
+	go func() {
+		defer m.mirrors.Done()
+		m.mirror(ctx, approval)
+	}()
+}
+
+// mirror creates a HumanLayer function call for a pending approval. Failures
+// are logged: the approval can still be decided locally.
+func (m *RemoteManager) mirror(ctx context.Context, approval *store.Approval) {
+	if approval == nil || approval.Status != store.ApprovalStatusLocalPending {
+		return
+	}
+
+	state := map[string]interface{}{
+		"approval_id": approval.ID,
+		"session_id":  approval.SessionID,
+	}
+	if approval.ToolUseID != nil {
+		state["tool_use_id"] = *approval.ToolUseID
+	}
+
+	_, err := m.client.CreateFunctionCall(ctx, humanlayer.FunctionCall{
+		RunID:  approval.RunID,
+		CallID: approval.ID,
+		Spec: humanlayer.FunctionCallSpec{
+			Fn:     approval.ToolName,
+			Kwargs: toolKwargs(approval.ToolInput),
+			State:  state,
+		},
+	})
+	var apiErr *humanlayer.APIError
+	if errors.As(err, &apiErr) && apiErr.IsConflict() {
+		// Mirrored before the daemon restarted
+		err = nil
+	}
+	if err != nil {
+		slog.Warn("failed to mirror approval to HumanLayer",
+			"approval_id", approval.ID,
+			"session_id", approval.SessionID,
+			"error", err)
+		return
+	}
+
+	m.mu.Lock()
+	m.pending[approval.ID] = true
+	m.mu.Unlock()
+
+	slog.Debug("mirrored approval to HumanLayer",
+		"approval_id", approval.ID,
+		"session_id", approval.SessionID)
+}
+
+// respondRemote sends a local decision to HumanLayer
+func (m *RemoteManager) respondRemote(ctx context.Context, id string, approved bool, comment string) {
+	if !m.untrack(id) {
+		return
+	}
+
+	var err error
+	if approved {
+		err = m.client.ApproveFunctionCall(ctx, id, comment)
+	} else {
+		err = m.client.DenyFunctionCall(ctx, id, comment)
+	}
+
+	var apiErr *humanlayer.APIError
+	if errors.As(err, &apiErr) && apiErr.IsConflict() {
+		// Someone answered in HumanLayer at the same time; the local decision stands
+		slog.Info("HumanLayer function call was already decided",
+			"approval_id", id)
+		return
+	}
+	if err != nil {
+		slog.Warn("failed to send approval decision to HumanLayer",
+			"approval_id", id,
+			"error", err)
+	}
+}
+
+// syncDecisions applies decisions made in HumanLayer to pending approvals
+func (m *RemoteManager) syncDecisions(ctx context.Context) {
+	m.mu.Lock()
+	ids := make([]string, 0, len(m.pending))
+	for id := range m.pending {
+		ids = append(ids, id)
+	}
+	m.mu.Unlock()
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := m.syncDecision(ctx, id); err != nil {
+			slog.Warn("failed to sync approval decision from HumanLayer",
+				"approval_id", id,
+				"error", err)
+		}
+	}
+}
+
+// syncDecision checks one mirrored approval and resolves it locally once it
+// has been decided in HumanLayer
+func (m *RemoteManager) syncDecision(ctx context.Context, id string) error {
+	approval, err := m.Manager.GetApproval(ctx, id)
+	if err != nil {
+		return err
+	}
+	if approval.Status != store.ApprovalStatusLocalPending {
+		// Resolved locally without this manager, e.g. expired, or while it
+		// was being mirrored
+		m.respondRemote(ctx, id, localApproved(approval), approval.Comment)
+		return nil
+	}
+
+	call, err := m.client.GetFunctionCall(ctx, id)
+	if err != nil {
+		var apiErr *humanlayer.APIError
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			m.untrack(id)
+		}
+		return err
+	}
+	if call.Status == nil || call.Status.Approved == nil {
+		return nil
+	}
+
+	// Untrack first so the local decision is not sent back to HumanLayer
+	if !m.untrack(id) {
+		return nil
+	}
+
+	if *call.Status.Approved {
+		err = m.Manager.ApproveToolCallWithInput(ctx, id, call.Status.Comment, nil, store.ApprovalDecidedByHumanLayer)
+	} else {
+		reason := call.Status.Comment
+		if reason == "" {
+			reason = remoteDeniedReason
+		}
+		err = m.Manager.DenyToolCall(ctx, id, reason, store.ApprovalDecidedByHumanLayer)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply HumanLayer decision: %w", err)
+	}
+
+	slog.Info("applied approval decision from HumanLayer",
+		"approval_id", id,
+		"session_id", approval.SessionID,
+		"approved", *call.Status.Approved)
+	return nil
+}
+
+// localApproved reports whether a resolved approval let the tool call run
+func localApproved(approval *store.Approval) bool {
+	if approval.Status == store.ApprovalStatusLocalExpired {
+		return TimeoutOutcome(approval.OnTimeout) == TimeoutApprove
+	}
+	return approval.Status == store.ApprovalStatusLocalApproved
+}
+
+// untrack stops tracking a mirrored approval, reporting whether it was tracked
+func (m *RemoteManager) untrack(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.pending[id] {
+		return false
+	}
+	delete(m.pending, id)
+	return true
+}
+
+// toolKwargs converts tool input to function call kwargs. Inputs that are not
+// JSON objects are passed as {"input": ...}.
+func toolKwargs(toolInput json.RawMessage) map[string]interface{} {
+	var kwargs map[string]interface{}
+	if err := json.Unmarshal(toolInput, &kwargs); err == nil && kwargs != nil {
+		return kwargs
+	}
+	var input interface{}
+	_ = json.Unmarshal(toolInput, &input)
+	return map[string]interface{}{"input": input}
+}
@@ -0,0 +1,307 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	humanlayer "github.com/humanlayer/humanlayer-go"
+	"github.com/humanlayer/humanlayer-go/humanlayertest"
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRemoteTestManager returns a RemoteManager backed by an in-memory store
+// with one running session, and the fake HumanLayer server it mirrors to
+func newRemoteTestManager(t *testing.T, autoAcceptEdits bool) (*RemoteManager, *humanlayertest.Server, bus.EventBus) {
+	t.Helper()
+
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqliteStore.Close() })
+
+	require.NoError(t, sqliteStore.CreateSession(context.Background(), &store.Session{
+		ID:              "sess-remote",
+		RunID:           "run-remote",
+		Query:           "edit the config",
+		Status:          store.SessionStatusRunning,
+		AutoAcceptEdits: autoAcceptEdits,
+		CreatedAt:       time.Now(),
+		LastActivityAt:  time.Now(),
+	}))
+
+	server := humanlayertest.NewServer()
+	t.Cleanup(server.Close)
+	client, err := server.Client(humanlayer.WithRetryPolicy(humanlayer.RetryPolicy{MaxAttempts: 1}))
+	require.NoError(t, err)
+
+	eventBus := bus.NewEventBus()
+	local := NewManager(sqliteStore, eventBus)
+	return NewRemoteManager(local, client, time.Hour), server, eventBus
+}
+
+func TestRemoteManager_MirrorsPendingApproval(t *testing.T) {
+	m, server, _ := newRemoteTestManager(t, false)
+	ctx := context.Background()
+
+	approval, err := m.CreateApprovalWithToolUseID(ctx, "sess-remote", "Bash", json.RawMessage(`{"command":"ls"}`), "toolu_1")
+	require.NoError(t, err)
+	m.mirrors.Wait()
+
+	call, ok := server.FunctionCall(approval.ID)
+	require.True(t, ok, "approval should be mirrored as a function call")
+	assert.Equal(t, "run-remote", call.RunID)
+	assert.Equal(t, "Bash", call.Spec.Fn)
+	assert.Equal(t, map[string]interface{}{"command": "ls"}, call.Spec.Kwargs)
+	assert.Equal(t, "sess-remote", call.Spec.State["session_id"])
+	assert.Equal(t, "toolu_1", call.Spec.State["tool_use_id"])
+}
+
+func TestRemoteManager_AutoApprovedNotMirrored(t *testing.T) {
+	m, server, _ := newRemoteTestManager(t, true)
+	ctx := context.Background()
+
+	approval, err := m.CreateApprovalWithToolUseID(ctx, "sess-remote", "Edit", json.RawMessage(`{}`), "toolu_1")
+	require.NoError(t, err)
+	m.mirrors.Wait()
+	assert.Equal(t, store.ApprovalStatusLocalApproved, approval.Status)
+
+	_, ok := server.FunctionCall(approval.ID)
+	assert.False(t, ok)
+}
+
+func TestRemoteManager_MirrorFailureKeepsLocalApproval(t *testing.T) {
+	m, server, _ := newRemoteTestManager(t, false)
+	ctx := context.Background()
+
+	server.FailNext(http.StatusInternalServerError)
+	approval, err := m.CreateApprovalWithToolUseID(ctx, "sess-remote", "Bash", json.RawMessage(`{}`), "toolu_1")
+	require.NoError(t, err)
+	m.mirrors.Wait()
+	assert.Equal(t, store.ApprovalStatusLocalPending, approval.Status)
+
+	// Still decidable locally
+	require.NoError(t, m.ApproveToolCall(ctx, approval.ID, ""))
+}
+
+func TestRemoteManager_AppliesRemoteDecision(t *testing.T) {
+	tests := []struct {
+		name        string
+		decide      func(server *humanlayertest.Server, id string) error
+		wantStatus  store.ApprovalStatus
+		wantComment string
+	}{
+		{
+			name:        "approved",
+			decide:      func(s *humanlayertest.Server, id string) error { return s.Approve(id, "ok from slack") },
+			wantStatus:  store.ApprovalStatusLocalApproved,
+			wantComment: "ok from slack",
+		},
+		{
+			name:        "denied",
+			decide:      func(s *humanlayertest.Server, id string) error { return s.Deny(id, "not on prod") },
+			wantStatus:  store.ApprovalStatusLocalDenied,
+			wantComment: "not on prod",
+		},
+		{
+			name:        "denied without comment",
+			decide:      func(s *humanlayertest.Server, id string) error { return s.Deny(id, "") },
+			wantStatus:  store.ApprovalStatusLocalDenied,
+			wantComment: remoteDeniedReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, server, eventBus := newRemoteTestManager(t, false)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sub := eventBus.Subscribe(ctx, bus.EventFilter{Types: []bus.EventType{bus.EventApprovalResolved}})
+
+			approval, err := m.CreateApprovalWithToolUseID(ctx, "sess-remote", "Bash", json.RawMessage(`{}`), "toolu_1")
+			require.NoError(t, err)
+			m.mirrors.Wait()
+
+			// Nothing to apply while the call is pending
+			m.syncDecisions(ctx)
+			got, err := m.GetApproval(ctx, approval.ID)
+			require.NoError(t, err)
+			assert.Equal(t, store.ApprovalStatusLocalPending, got.Status)
+
+			require.NoError(t, tt.decide(server, approval.ID))
+			m.syncDecisions(ctx)
+
+			got, err = m.GetApproval(ctx, approval.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Equal(t, tt.wantComment, got.Comment)
+			assert.Equal(t, store.ApprovalDecidedByHumanLayer, got.DecidedBy)
+
+			select {
+			case event := <-sub.Channel:
+				assert.Equal(t, approval.ID, event.Data["approval_id"])
+				assert.Equal(t, "toolu_1", event.Data["tool_use_id"])
+			case <-time.After(time.Second):
+				t.Fatal("expected approval_resolved event")
+			}
+
+			// Untracked once applied
+			assert.False(t, m.pending[approval.ID])
+		})
+	}
+}
+
+func TestRemoteManager_LocalDecisionSentToHumanLayer(t *testing.T) {
+	m, server, _ := newRemoteTestManager(t, false)
+	ctx := context.Background()
+
+	approved, err := m.CreateApprovalWithToolUseID(ctx, "sess-remote", "Bash", json.RawMessage(`{}`), "toolu_1")
+	require.NoError(t, err)
+	m.mirrors.Wait()
+	require.NoError(t, m.ApproveToolCall(ctx, approved.ID, "looks good"))
+
+	call, ok := server.FunctionCall(approved.ID)
+	require.True(t, ok)
+	require.NotNil(t, call.Status)
+	require.NotNil(t, call.Status.Approved)
+	assert.True(t, *call.Status.Approved)
+	assert.Equal(t, "looks good", call.Status.Comment)
+
+	got, err := m.GetApproval(ctx, approved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalDecidedByHuman, got.DecidedBy)
+
+	denied, err := m.CreateApprovalWithToolUseID(ctx, "sess-remote", "Bash", json.RawMessage(`{}`), "toolu_2")
+	require.NoError(t, err)
+	m.mirrors.Wait()
+	require.NoError(t, m.DenyToolCall(ctx, denied.ID, "no", store.ApprovalDecidedByHuman))
+
+	call, ok = server.FunctionCall(denied.ID)
+	require.True(t, ok)
+	require.NotNil(t, call.Status.Approved)
+	assert.False(t, *call.Status.Approved)
+}
+
+func TestRemoteManager_LocalDecisionAfterRemoteConflict(t *testing.T) {
+	m, server, _ := newRemoteTestManager(t, false)
+	ctx := context.Background()
+
+	approval, err := m.CreateApprovalWithToolUseID(ctx, "sess-remote", "Bash", json.RawMessage(`{}`), "toolu_1")
+	require.NoError(t, err)
+	m.mirrors.Wait()
+
+	// Decided in HumanLayer, but the daemon has not synced yet
+	require.NoError(t, server.Deny(approval.ID, "remote said no"))
+
+	// The local decision still succeeds; HumanLayer keeps its own answer
+	require.NoError(t, m.ApproveToolCall(ctx, approval.ID, "local yes"))
+
+	got, err := m.GetApproval(ctx, approval.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalStatusLocalApproved, got.Status)
+
+	call, _ := server.FunctionCall(approval.ID)
+	assert.False(t, *call.Status.Approved)
+}
+
+func TestRemoteManager_MirrorsPendingOnStart(t *testing.T) {
+	m, server, _ := newRemoteTestManager(t, false)
+	ctx := context.Background()
+
+	// Pending before the daemon started: one never mirrored, one mirrored
+	// and since denied in HumanLayer
+	local := m.Manager
+	unmirrored, err := local.CreateApprovalWithToolUseID(ctx, "sess-remote", "Bash", json.RawMessage(`{"command":"ls"}`), "toolu_1")
+	require.NoError(t, err)
+	mirrored, err := local.CreateApprovalWithToolUseID(ctx, "sess-remote", "Bash", json.RawMessage(`{}`), "toolu_2")
+	require.NoError(t, err)
+	server.AddFunctionCall(humanlayer.FunctionCall{RunID: "run-remote", CallID: mirrored.ID})
+	require.NoError(t, server.Deny(mirrored.ID, "remote said no"))
+
+	m.mirrorPending(ctx)
+
+	call, ok := server.FunctionCall(unmirrored.ID)
+	require.True(t, ok, "pending approval should be mirrored on start")
+	assert.Equal(t, "Bash", call.Spec.Fn)
+	assert.True(t, m.pending[mirrored.ID], "mirrored approval should be tracked again")
+
+	m.syncDecisions(ctx)
+	got, err := m.GetApproval(ctx, mirrored.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalStatusLocalDenied, got.Status)
+	assert.Equal(t, "remote said no", got.Comment)
+}
+
+func TestRemoteManager_ExpiredApprovalResolvedInHumanLayer(t *testing.T) {
+	tests := []struct {
+		onTimeout    TimeoutOutcome
+		wantApproved bool
+	}{
+		{onTimeout: TimeoutApprove, wantApproved: true},
+		{onTimeout: TimeoutDeny, wantApproved: false},
+		{onTimeout: TimeoutInterrupt, wantApproved: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.onTimeout), func(t *testing.T) {
+			m, server, eventBus := newRemoteTestManager(t, false)
+			ctx := context.Background()
+			sqliteStore := m.Manager.(*manager).store
+
+			expiresAt := time.Now().Add(-time.Minute)
+			require.NoError(t, sqliteStore.CreateApproval(ctx, &store.Approval{
+				ID:             "local-expiring",
+				RunID:          "run-remote",
+				SessionID:      "sess-remote",
+				Status:         store.ApprovalStatusLocalPending,
+				CreatedAt:      time.Now().Add(-2 * time.Minute),
+				ToolName:       "Bash",
+				ToolInput:      json.RawMessage(`{}`),
+				ExpiresAt:      &expiresAt,
+				OnTimeout:      string(tt.onTimeout),
+				TimeoutMessage: "nobody answered",
+			}))
+			m.mirrorPending(ctx)
+
+			NewExpiryMonitor(sqliteStore, eventBus, nil, time.Hour).expireApprovals(ctx)
+			m.syncDecisions(ctx)
+
+			call, ok := server.FunctionCall("local-expiring")
+			require.True(t, ok)
+			require.NotNil(t, call.Status.Approved, "expired approval should be resolved in HumanLayer")
+			assert.Equal(t, tt.wantApproved, *call.Status.Approved)
+			assert.Equal(t, "nobody answered", call.Status.Comment)
+			assert.False(t, m.pending["local-expiring"])
+		})
+	}
+}
+
+func TestRemoteManager_LocalDecisionWhileMirroring(t *testing.T) {
+	m, server, _ := newRemoteTestManager(t, false)
+	ctx := context.Background()
+
+	// Decided before the background mirror finished
+	approval, err := m.Manager.CreateApprovalWithToolUseID(ctx, "sess-remote", "Bash", json.RawMessage(`{}`), "toolu_1")
+	require.NoError(t, err)
+	require.NoError(t, m.ApproveToolCall(ctx, approval.ID, "quick yes"))
+	m.mirror(ctx, approval)
+
+	m.syncDecisions(ctx)
+
+	call, ok := server.FunctionCall(approval.ID)
+	require.True(t, ok)
+	require.NotNil(t, call.Status.Approved)
+	assert.True(t, *call.Status.Approved)
+	assert.Equal(t, "quick yes", call.Status.Comment)
+}
+
+func TestToolKwargs(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"a": float64(1)}, toolKwargs(json.RawMessage(`{"a":1}`)))
+	assert.Equal(t, map[string]interface{}{"input": []interface{}{"x"}}, toolKwargs(json.RawMessage(`["x"]`)))
+	assert.Equal(t, map[string]interface{}{"input": nil}, toolKwargs(nil))
+}
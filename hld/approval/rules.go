@@ -0,0 +1,135 @@
+package approval
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// ErrInvalidApprovalRule is returned when the options of an "always allow"
+// rule don't fit the approved tool call
+var ErrInvalidApprovalRule = errors.New("invalid approval rule")
+
+// RememberOptions describes the "always allow" rule created when approving
+type RememberOptions struct {
+	Scope  string // store.ApprovalRuleScopeSession (default) or store.ApprovalRuleScopeProject
+	Match  string // store.ApprovalRuleMatchExact (default), Tool or Prefix
+	Prefix string // Command prefix of prefix rules, defaults to the approved command
+}
+
+// shellOperators are not allowed in commands matched by prefix rules, so a
+// rule for "go test" can't approve "go test ./... && rm -rf /"
+const shellOperators = ";&|<>`$\n"
+
+// newApprovalRule builds an allow rule from an approval in session
+func newApprovalRule(approval *store.Approval, session *store.Session, opts RememberOptions) (*store.ApprovalRule, error) {
+	rule := &store.ApprovalRule{
+		ID:         "rule-" + uuid.New().String(),
+		Scope:      opts.Scope,
+		SessionID:  approval.SessionID,
+		ToolName:   approval.ToolName,
+		Match:      opts.Match,
+		ApprovalID: approval.ID,
+		CreatedAt:  time.Now(),
+	}
+
+	switch rule.Scope {
+	case "", store.ApprovalRuleScopeSession:
+		rule.Scope = store.ApprovalRuleScopeSession
+	case store.ApprovalRuleScopeProject:
+		if session.WorkingDir == "" {
+			return nil, fmt.Errorf("project rules need a session with a working directory")
+		}
+		rule.WorkingDir = session.WorkingDir
+	default:
+		return nil, fmt.Errorf("scope must be session or project, got %q", rule.Scope)
+	}
+
+	switch rule.Match {
+	case "", store.ApprovalRuleMatchExact:
+		rule.Match = store.ApprovalRuleMatchExact
+		input, err := canonicalJSON(approval.ToolInput)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tool input: %w", err)
+		}
+		rule.ToolInput = input
+	case store.ApprovalRuleMatchTool:
+	case store.ApprovalRuleMatchPrefix:
+		command, ok := toolCommand(approval.ToolInput)
+		if !ok {
+			return nil, fmt.Errorf("prefix rules need a tool call with a command")
+		}
+		prefix := strings.TrimSpace(opts.Prefix)
+		if prefix == "" {
+			prefix = strings.TrimSpace(command)
+		}
+		if !strings.HasPrefix(command, prefix) {
+			return nil, fmt.Errorf("prefix %q does not match the approved command", prefix)
+		}
+		if strings.ContainsAny(prefix, shellOperators) {
+			return nil, fmt.Errorf("prefix %q contains shell operators", prefix)
+		}
+		rule.CommandPrefix = prefix
+	default:
+		return nil, fmt.Errorf("match must be exact, tool or prefix, got %q", rule.Match)
+	}
+
+	return rule, nil
+}
+
+// ruleMatches reports whether an approval rule allows a tool call
+func ruleMatches(rule *store.ApprovalRule, toolName string, toolInput json.RawMessage) bool {
+	if rule.ToolName != toolName {
+		return false
+	}
+
+	switch rule.Match {
+	case store.ApprovalRuleMatchTool:
+		return true
+	case store.ApprovalRuleMatchExact:
+		input, err := canonicalJSON(toolInput)
+		return err == nil && input == rule.ToolInput
+	case store.ApprovalRuleMatchPrefix:
+		command, ok := toolCommand(toolInput)
+		if !ok || strings.ContainsAny(command, shellOperators) {
+			return false
+		}
+		command = strings.TrimSpace(command)
+		rest, ok := strings.CutPrefix(command, rule.CommandPrefix)
+		// Match whole words: "go test" matches "go test ./..." but not "go testify"
+		return ok && (rest == "" || unicode.IsSpace(rune(rest[0])))
+	default:
+		return false
+	}
+}
+
+// toolCommand returns the command of a Bash-like tool call
+func toolCommand(toolInput json.RawMessage) (string, bool) {
+	var input struct {
+		Command string `json:"command"`
+	}
+	if err := json.Unmarshal(toolInput, &input); err != nil || input.Command == "" {
+		return "", false
+	}
+	return input.Command, true
+}
+
+// canonicalJSON re-encodes JSON with sorted keys and no insignificant
+// whitespace, so equal inputs compare equal as strings
+func canonicalJSON(data json.RawMessage) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", err
+	}
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(canonical), nil
+}
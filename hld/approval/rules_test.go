@@ -0,0 +1,151 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/humanlayer/humanlayer/hld/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewApprovalRule(t *testing.T) {
+	bash := &store.Approval{ID: "approval-1", SessionID: "sess-1", ToolName: "Bash", ToolInput: json.RawMessage(`{"command": "go test ./...", "timeout": 60000}`)}
+	session := &store.Session{ID: "sess-1", WorkingDir: "/work/repo"}
+
+	rule, err := newApprovalRule(bash, session, RememberOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalRuleScopeSession, rule.Scope)
+	assert.Equal(t, store.ApprovalRuleMatchExact, rule.Match)
+	assert.Equal(t, `{"command":"go test ./...","timeout":60000}`, rule.ToolInput)
+	assert.Empty(t, rule.WorkingDir)
+
+	rule, err = newApprovalRule(bash, session, RememberOptions{Scope: "project", Match: "prefix"})
+	require.NoError(t, err)
+	assert.Equal(t, "/work/repo", rule.WorkingDir)
+	assert.Equal(t, "go test ./...", rule.CommandPrefix, "prefix defaults to the approved command")
+
+	tests := []struct {
+		name     string
+		approval *store.Approval
+		session  *store.Session
+		opts     RememberOptions
+		wantErr  string
+	}{
+		{"unknown scope", bash, session, RememberOptions{Scope: "global"}, "scope must be session or project"},
+		{"unknown match", bash, session, RememberOptions{Match: "regex"}, "match must be exact, tool or prefix"},
+		{"project without working directory", bash, &store.Session{ID: "sess-1"}, RememberOptions{Scope: "project"}, "working directory"},
+		{"prefix of another command", bash, session, RememberOptions{Match: "prefix", Prefix: "go build"}, "does not match the approved command"},
+		{"prefix without command", &store.Approval{ToolName: "Read", ToolInput: json.RawMessage(`{"file_path": "a"}`)}, session, RememberOptions{Match: "prefix"}, "need a tool call with a command"},
+		{"prefix with shell operators", &store.Approval{ToolName: "Bash", ToolInput: json.RawMessage(`{"command": "make && make install"}`)}, session, RememberOptions{Match: "prefix"}, "shell operators"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newApprovalRule(tt.approval, tt.session, tt.opts)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	exact := &store.ApprovalRule{ToolName: "Bash", Match: store.ApprovalRuleMatchExact, ToolInput: `{"command":"go test ./...","timeout":60000}`}
+	tool := &store.ApprovalRule{ToolName: "Read", Match: store.ApprovalRuleMatchTool}
+	prefix := &store.ApprovalRule{ToolName: "Bash", Match: store.ApprovalRuleMatchPrefix, CommandPrefix: "go test"}
+
+	tests := []struct {
+		name     string
+		rule     *store.ApprovalRule
+		toolName string
+		input    string
+		want     bool
+	}{
+		{"exact with reordered keys", exact, "Bash", `{"timeout": 60000, "command": "go test ./..."}`, true},
+		{"exact with other input", exact, "Bash", `{"command": "go test ./..."}`, false},
+		{"tool with any input", tool, "Read", `{"file_path": "/etc/passwd"}`, true},
+		{"tool with other tool", tool, "Write", `{}`, false},
+		{"prefix", prefix, "Bash", `{"command": "go test ./store -run TestMigration"}`, true},
+		{"prefix equal to command", prefix, "Bash", `{"command": "go test"}`, true},
+		{"prefix stops at word boundary", prefix, "Bash", `{"command": "go testify"}`, false},
+		{"prefix with chained command", prefix, "Bash", `{"command": "go test ./... && rm -rf /"}`, false},
+		{"prefix with substitution", prefix, "Bash", `{"command": "go test $(rm -rf /)"}`, false},
+		{"prefix with other tool", prefix, "Shell", `{"command": "go test"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ruleMatches(tt.rule, tt.toolName, json.RawMessage(tt.input)))
+		})
+	}
+}
+
+func TestManager_ApproveAndRemember(t *testing.T) {
+	m, _ := newPolicyTestManager(t, &store.Session{ID: "sess-1", RunID: "run-1", Query: "q", WorkingDir: "/work/repo"}, "")
+	ctx := context.Background()
+	sqliteStore := m.(*manager).store
+
+	// Sessions continued from sess-1 and other sessions in the same project
+	for _, session := range []*store.Session{
+		{ID: "sess-2", RunID: "run-2", ParentSessionID: "sess-1", WorkingDir: "/work/repo"},
+		{ID: "sess-3", RunID: "run-3", WorkingDir: "/work/repo"},
+	} {
+		session.Query = "q"
+		session.Status = store.SessionStatusRunning
+		require.NoError(t, sqliteStore.CreateSession(ctx, session))
+	}
+
+	toolUseCount := 0
+	createApproval := func(sessionID, command string) *store.Approval {
+		toolUseCount++
+		approval, err := m.CreateApprovalWithToolUseID(ctx, sessionID, "Bash", json.RawMessage(`{"command": "`+command+`"}`), "toolu_"+string(rune('a'+toolUseCount)))
+		require.NoError(t, err)
+		return approval
+	}
+
+	pending := createApproval("sess-1", "go test ./...")
+	require.Equal(t, store.ApprovalStatusLocalPending, pending.Status)
+
+	_, err := m.ApproveAndRemember(ctx, pending.ID, "", RememberOptions{Match: "prefix", Prefix: "go build"})
+	require.ErrorIs(t, err, ErrInvalidApprovalRule)
+	stored, err := m.GetApproval(ctx, pending.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalStatusLocalPending, stored.Status, "an invalid rule leaves the approval pending")
+
+	rule, err := m.ApproveAndRemember(ctx, pending.ID, "", RememberOptions{Match: "prefix", Prefix: "go test"})
+	require.NoError(t, err)
+	stored, err = m.GetApproval(ctx, pending.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalStatusLocalApproved, stored.Status)
+
+	// Decided approvals can't create rules
+	_, err = m.ApproveAndRemember(ctx, pending.ID, "", RememberOptions{Match: "tool"})
+	var alreadyDecided *store.AlreadyDecidedError
+	require.ErrorAs(t, err, &alreadyDecided)
+	rules, err := m.ListApprovalRules(ctx, "sess-1")
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, rule.ID, rules[0].ID)
+
+	approval := createApproval("sess-1", "go test ./store")
+	assert.Equal(t, store.ApprovalStatusLocalApproved, approval.Status)
+	assert.Equal(t, rule.ID, approval.ApprovalRuleID)
+
+	assert.Equal(t, store.ApprovalStatusLocalPending, createApproval("sess-1", "go test ./... ; rm -rf /").Status)
+	assert.Equal(t, store.ApprovalStatusLocalApproved, createApproval("sess-2", "go test ./api").Status, "session rules apply to continued sessions")
+	assert.Equal(t, store.ApprovalStatusLocalPending, createApproval("sess-3", "go test ./api").Status, "session rules don't apply to other sessions")
+
+	rules, err = m.ListApprovalRules(ctx, "sess-2")
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, rule.ID, rules[0].ID)
+
+	require.NoError(t, m.RevokeApprovalRule(ctx, rule.ID))
+	assert.Equal(t, store.ApprovalStatusLocalPending, createApproval("sess-1", "go test ./store").Status)
+	assert.ErrorIs(t, m.RevokeApprovalRule(ctx, rule.ID), store.ErrNotFound)
+
+	// Project rules apply to every session in the working directory
+	pending = createApproval("sess-3", "make lint")
+	_, err = m.ApproveAndRemember(ctx, pending.ID, "", RememberOptions{Scope: "project"})
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalStatusLocalApproved, createApproval("sess-1", "make lint").Status)
+	assert.Equal(t, store.ApprovalStatusLocalPending, createApproval("sess-1", "make lint-fix").Status)
+}
@@ -18,8 +18,23 @@ type Manager interface {
 	// Retrieval methods
 	GetPendingApprovals(ctx context.Context, sessionID string) ([]*store.Approval, error)
 	GetApproval(ctx context.Context, id string) (*store.Approval, error)
+	// ListApprovals returns the approval history across sessions, newest first
+	ListApprovals(ctx context.Context, filter store.ApprovalFilter) ([]*store.Approval, error)
+	GetApprovalStats(ctx context.Context, filter store.ApprovalFilter) (*store.ApprovalStats, error)
 
-	// Decision methods
+	// Decision methods. decidedBy records who answered, such as
+	// store.ApprovalDecidedByHuman or store.ApprovalDecidedByHumanLayer.
+	// ApproveToolCall approves as store.ApprovalDecidedByHuman.
 	ApproveToolCall(ctx context.Context, id string, comment string) error
-	DenyToolCall(ctx context.Context, id string, reason string) error
+	// ApproveToolCallWithInput approves with tool input edited by the approver,
+	// which must be a JSON object. A nil updatedInput keeps the original input.
+	ApproveToolCallWithInput(ctx context.Context, id string, comment string, updatedInput json.RawMessage, decidedBy string) error
+	DenyToolCall(ctx context.Context, id string, reason string, decidedBy string) error
+	// ApproveAndRemember approves and creates an "always allow" rule from the
+	// tool call, which auto-approves matching tool calls from then on
+	ApproveAndRemember(ctx context.Context, id string, comment string, opts RememberOptions) (*store.ApprovalRule, error)
+
+	// Approval rule methods
+	ListApprovalRules(ctx context.Context, sessionID string) ([]*store.ApprovalRule, error)
+	RevokeApprovalRule(ctx context.Context, id string) error
 }
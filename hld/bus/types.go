@@ -13,6 +13,10 @@ const (
 	EventNewApproval EventType = "new_approval"
 	// EventApprovalResolved indicates an approval has been resolved (approved/denied/responded)
 	EventApprovalResolved EventType = "approval_resolved"
+	// EventApprovalExpired indicates nobody answered an approval before its timeout.
+	// Data includes: approval_id, session_id, tool_name, outcome (deny, approve, interrupt)
+	// and tool_use_id when present. An approval_resolved event for the approval precedes it.
+	EventApprovalExpired EventType = "approval_expired"
 	// EventSessionStatusChanged indicates a session status has changed
 	EventSessionStatusChanged EventType = "session_status_changed"
 	// EventConversationUpdated indicates new conversation content has been added to a session
@@ -21,6 +25,14 @@ const (
 	// Data includes: session_id, run_id, changed settings, and optional "reason" field
 	// For dangerous skip permissions expiry: reason="expired", expired_at=timestamp
 	EventSessionSettingsChanged EventType = "session_settings_changed"
+	// EventSessionResourceLimitExceeded indicates a session was stopped for exceeding a resource limit
+	// Data includes: session_id, run_id, limit (cpu_time, memory, wall_clock) and error
+	EventSessionResourceLimitExceeded EventType = "session_resource_limit_exceeded"
+	// EventConversationDelta carries assistant text, thinking or tool input while it is generated.
+	// Data includes: session_id, run_id, claude_session_id, message_id, index, block_type,
+	// delta_type, content (text since the previous delta), tool_id, tool_name and parent_tool_use_id.
+	// Deltas are not stored; the complete block follows as conversation_updated.
+	EventConversationDelta EventType = "conversation_delta"
 )
 
 // SessionSettingsChangeReason represents reasons for session settings changes
@@ -406,3 +406,25 @@ func (c *client) InterruptSession(sessionID string) error {
 	}
 	return nil
 }
+
+// SendMessage sends a follow-up message to a session launched with stream-json input
+func (c *client) SendMessage(sessionID string, message string) error {
+	req := rpc.SendMessageRequest{
+		SessionID: sessionID,
+		Message:   message,
+	}
+	var resp rpc.SendMessageResponse
+	if err := c.call("sendMessage", req, &resp); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	return nil
+}
+
+// ImportTranscripts imports sessions from Claude's on-disk transcripts for a project
+func (c *client) ImportTranscripts(req rpc.ImportTranscriptsRequest) (*rpc.ImportTranscriptsResponse, error) {
+	var resp rpc.ImportTranscriptsResponse
+	if err := c.call("importTranscripts", req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to import transcripts: %w", err)
+	}
+	return &resp, nil
+}
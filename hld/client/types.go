@@ -24,9 +24,15 @@ type Client interface {
 	// InterruptSession interrupts a running session
 	InterruptSession(sessionID string) error
 
+	// SendMessage sends a follow-up message to a session launched with stream-json input
+	SendMessage(sessionID string, message string) error
+
 	// ContinueSession continues an existing completed session with a new query
 	ContinueSession(req rpc.ContinueSessionRequest) (*rpc.ContinueSessionResponse, error)
 
+	// ImportTranscripts imports sessions from Claude's on-disk transcripts for a project
+	ImportTranscripts(req rpc.ImportTranscriptsRequest) (*rpc.ImportTranscriptsResponse, error)
+
 	// FetchApprovals fetches pending approvals from the daemon
 	FetchApprovals(sessionID string) ([]*store.Approval, error)
 
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/humanlayer/humanlayer/hld/client"
+	"github.com/humanlayer/humanlayer/hld/config"
+	"github.com/humanlayer/humanlayer/hld/rpc"
+)
+
+// runImport implements `hld import`, asking a running daemon to import
+// Claude transcripts for a project directory
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	claudeDir := fs.String("claude-dir", "", "Claude config directory (default $CLAUDE_CONFIG_DIR or ~/.claude)")
+	sessions := fs.String("sessions", "", "Comma-separated Claude session IDs to import (default all)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: hld import [flags] [PROJECT_DIR]\n\n")
+		fmt.Fprintf(fs.Output(), "Import sessions started with claude outside the daemon. PROJECT_DIR defaults to the current directory.\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+	projectDir := "."
+	if fs.NArg() == 1 {
+		projectDir = fs.Arg(0)
+	}
+	projectDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid project directory: %v\n", err)
+		return 1
+	}
+
+	req := rpc.ImportTranscriptsRequest{
+		ProjectDir: projectDir,
+		ClaudeDir:  *claudeDir,
+	}
+	if *sessions != "" {
+		req.ClaudeSessionIDs = strings.Split(*sessions, ",")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		return 1
+	}
+	c, err := client.New(cfg.SocketPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to daemon at %s: %v\n", cfg.SocketPath, err)
+		return 1
+	}
+	defer func() { _ = c.Close() }()
+
+	resp, err := c.ImportTranscripts(req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	for _, imported := range resp.Imported {
+		fmt.Printf("imported %s as %s (%d events)\n", imported.ClaudeSessionID, imported.SessionID, imported.EventCount)
+	}
+	for _, skipped := range resp.Skipped {
+		fmt.Printf("skipped %s (already imported)\n", skipped)
+	}
+	fmt.Printf("%d imported, %d skipped\n", len(resp.Imported), len(resp.Skipped))
+	return 0
+}
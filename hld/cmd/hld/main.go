@@ -12,6 +12,10 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(runImport(os.Args[2:]))
+	}
+
 	// Parse command line flags
 	debug := flag.Bool("debug", false, "Enable debug logging")
 	flag.Parse()
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -26,10 +27,15 @@ type Config struct {
 	// Database configuration
 	DatabasePath string `mapstructure:"database_path"`
 
-	// API configuration (for future phases)
+	// HumanLayer API configuration
 	APIKey     string `mapstructure:"api_key"`
 	APIBaseURL string `mapstructure:"api_base_url"`
 
+	// Mirror approvals to HumanLayer (requires APIKey), polling for decisions every
+	// RemoteApprovalInterval; zero uses the default
+	RemoteApprovals        bool          `mapstructure:"remote_approvals"`
+	RemoteApprovalInterval time.Duration `mapstructure:"remote_approval_interval"`
+
 	// Logging configuration
 	LogLevel string `mapstructure:"log_level"`
 
@@ -39,6 +45,23 @@ type Config struct {
 	// HTTP Server configuration
 	HTTPPort int    `mapstructure:"http_port"`
 	HTTPHost string `mapstructure:"http_host"`
+
+	// Delegated cgroup v2 directory for per-session resource limits (Linux only)
+	CgroupParent string `mapstructure:"cgroup_parent"`
+
+	// Daemon-level approval policy file; ignored if it does not exist
+	ApprovalPolicyPath string `mapstructure:"approval_policy_path"`
+
+	// Apply allow rules of working directory approval policies, which are
+	// otherwise ignored since anyone who can commit to a repository can change them
+	TrustDirectoryPolicies bool `mapstructure:"trust_directory_policies"`
+
+	// How often pending approvals are checked for expired timeouts; zero uses the default
+	ApprovalExpiryInterval time.Duration `mapstructure:"approval_expiry_interval"`
+
+	// What happens to a session's events when the daemon falls behind storing
+	// them: block (default), spill or drop
+	EventOverflowPolicy string `mapstructure:"event_overflow_policy"`
 }
 
 // Load loads configuration with priority: flags > env vars > config file > defaults
@@ -67,6 +90,13 @@ func Load() (*Config, error) {
 	_ = v.BindEnv("version_override", "HUMANLAYER_DAEMON_VERSION_OVERRIDE")
 	_ = v.BindEnv("http_port", "HUMANLAYER_DAEMON_HTTP_PORT")
 	_ = v.BindEnv("http_host", "HUMANLAYER_DAEMON_HTTP_HOST")
+	_ = v.BindEnv("cgroup_parent", "HUMANLAYER_CGROUP_PARENT")
+	_ = v.BindEnv("approval_policy_path", "HUMANLAYER_APPROVAL_POLICY")
+	_ = v.BindEnv("trust_directory_policies", "HUMANLAYER_TRUST_DIRECTORY_POLICIES")
+	_ = v.BindEnv("approval_expiry_interval", "HUMANLAYER_APPROVAL_EXPIRY_INTERVAL")
+	_ = v.BindEnv("remote_approvals", "HUMANLAYER_REMOTE_APPROVALS")
+	_ = v.BindEnv("remote_approval_interval", "HUMANLAYER_REMOTE_APPROVAL_INTERVAL")
+	_ = v.BindEnv("event_overflow_policy", "HUMANLAYER_EVENT_OVERFLOW_POLICY")
 
 	// Set defaults
 	setDefaults(v)
@@ -87,6 +117,7 @@ func Load() (*Config, error) {
 	// Expand home directory in paths
 	config.SocketPath = expandHome(config.SocketPath)
 	config.DatabasePath = expandHome(config.DatabasePath)
+	config.ApprovalPolicyPath = expandHome(config.ApprovalPolicyPath)
 
 	return &config, nil
 }
@@ -95,6 +126,7 @@ func Load() (*Config, error) {
 func setDefaults(v *viper.Viper) {
 	v.SetDefault("socket_path", DefaultSocketPath)
 	v.SetDefault("database_path", DefaultDatabasePath)
+	v.SetDefault("approval_policy_path", "~/.humanlayer/approval-policy.json")
 	v.SetDefault("api_base_url", "https://api.humanlayer.dev/humanlayer/v1")
 	v.SetDefault("log_level", "info")
 
@@ -141,5 +173,10 @@ func (c *Config) Validate() error {
 	if c.SocketPath == "" {
 		return fmt.Errorf("socket path cannot be empty")
 	}
+	switch c.EventOverflowPolicy {
+	case "", "block", "spill", "drop":
+	default:
+		return fmt.Errorf("event overflow policy must be block, spill or drop, got %q", c.EventOverflowPolicy)
+	}
 	return nil
 }
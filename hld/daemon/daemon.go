@@ -11,6 +11,8 @@ import (
 	"sync"
 	"time"
 
+	humanlayer "github.com/humanlayer/humanlayer-go"
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
 	"github.com/humanlayer/humanlayer/hld/approval"
 	"github.com/humanlayer/humanlayer/hld/bus"
 	"github.com/humanlayer/humanlayer/hld/config"
@@ -61,6 +63,8 @@ type Daemon struct {
 	eventBus          bus.EventBus
 	store             store.ConversationStore
 	permissionMonitor *session.PermissionMonitor
+	approvalExpiry    *approval.ExpiryMonitor
+	remoteApprovals   *approval.RemoteManager // nil unless remote approvals are enabled
 }
 
 // New creates a new daemon instance
@@ -117,29 +121,75 @@ func New() (*Daemon, error) {
 	}
 
 	// Create session manager with store and config
-	sessionManager, err := session.NewManager(eventBus, conversationStore, cfg.SocketPath)
+	var clientOpts []claudecode.ClientOption
+	if cfg.EventOverflowPolicy != "" {
+		clientOpts = append(clientOpts, claudecode.WithOverflowPolicy(claudecode.OverflowPolicy(cfg.EventOverflowPolicy)))
+	}
+	sessionManager, err := session.NewManager(eventBus, conversationStore, cfg.SocketPath, clientOpts...)
 	if err != nil {
 		_ = conversationStore.Close()
 		return nil, fmt.Errorf("failed to create session manager: %w", err)
 	}
+	if version := sessionManager.ClaudeVersion(); version != "" {
+		slog.Info("detected claude CLI", "version", version)
+	}
+	if cfg.CgroupParent != "" {
+		sessionManager.SetCgroupParent(cfg.CgroupParent)
+	}
+
+	// Load the daemon-level approval policy, if there is one
+	approvalPolicy, err := approval.LoadPolicyFile(cfg.ApprovalPolicyPath, approval.PolicySourceDaemon)
+	if err != nil {
+		_ = conversationStore.Close()
+		return nil, fmt.Errorf("failed to load approval policy %s: %w", cfg.ApprovalPolicyPath, err)
+	}
+	if approvalPolicy != nil {
+		slog.Info("loaded approval policy", "path", cfg.ApprovalPolicyPath, "rules", len(approvalPolicy.Rules))
+	}
 
 	// Always create local approval manager
 	slog.Info("creating local approval manager")
-	approvalManager := approval.NewManager(conversationStore, eventBus)
+	approvalManager := approval.NewManagerWithPolicy(conversationStore, eventBus, approval.PolicyConfig{
+		Daemon:                 approvalPolicy,
+		DaemonPath:             cfg.ApprovalPolicyPath,
+		TrustDirectoryPolicies: cfg.TrustDirectoryPolicies,
+	})
 	slog.Debug("local approval manager created successfully")
 
+	// Mirror approvals to HumanLayer only when explicitly enabled, so an API key
+	// alone never sends tool calls to HumanLayer
+	var remoteApprovals *approval.RemoteManager
+	if cfg.RemoteApprovals {
+		if cfg.APIKey == "" {
+			_ = conversationStore.Close()
+			return nil, fmt.Errorf("remote approvals require a HumanLayer API key")
+		}
+		client, err := humanlayer.NewClient(
+			humanlayer.WithAPIKey(cfg.APIKey),
+			humanlayer.WithBaseURL(cfg.APIBaseURL),
+		)
+		if err != nil {
+			_ = conversationStore.Close()
+			return nil, fmt.Errorf("failed to create HumanLayer client: %w", err)
+		}
+		slog.Info("mirroring approvals to HumanLayer", "api_base_url", cfg.APIBaseURL)
+		remoteApprovals = approval.NewRemoteManager(approvalManager, client, cfg.RemoteApprovalInterval)
+		approvalManager = remoteApprovals
+	}
+
 	// Create HTTP server (always enabled, port 0 means dynamic allocation)
 	slog.Info("creating HTTP server", "port", cfg.HTTPPort)
 	httpServer := NewHTTPServer(cfg, sessionManager, approvalManager, conversationStore, eventBus)
 
 	return &Daemon{
-		config:     cfg,
-		socketPath: socketPath,
-		sessions:   sessionManager,
-		approvals:  approvalManager,
-		eventBus:   eventBus,
-		store:      conversationStore,
-		httpServer: httpServer,
+		config:          cfg,
+		socketPath:      socketPath,
+		sessions:        sessionManager,
+		approvals:       approvalManager,
+		eventBus:        eventBus,
+		store:           conversationStore,
+		httpServer:      httpServer,
+		remoteApprovals: remoteApprovals,
 	}, nil
 }
 
@@ -212,6 +262,20 @@ func (d *Daemon) Run(ctx context.Context) error {
 	}()
 	slog.Info("started dangerous skip permissions expiry monitor")
 
+	// Create and start approval expiry monitor for approvals with a policy timeout
+	approvalExpiry := approval.NewExpiryMonitor(d.store, d.eventBus, d.sessions, d.config.ApprovalExpiryInterval)
+	d.approvalExpiry = approvalExpiry
+	go func() {
+		approvalExpiry.Start(ctx)
+	}()
+
+	// Sync decisions made in HumanLayer back to local approvals
+	if d.remoteApprovals != nil {
+		go func() {
+			d.remoteApprovals.Start(ctx)
+		}()
+	}
+
 	// Register subscription handlers
 	subscriptionHandlers := rpc.NewSubscriptionHandlers(d.eventBus)
 	d.rpcServer.SetSubscriptionHandlers(subscriptionHandlers)
@@ -0,0 +1,134 @@
+//go:build integration
+
+package daemon
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/approval"
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/internal/testutil/fakeclaude"
+	"github.com/humanlayer/humanlayer/hld/mcp"
+	"github.com/humanlayer/humanlayer/hld/session"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// TestFakeClaudeApprovalFlowIntegration runs a session whose permission prompt
+// calls back into the daemon's MCP endpoint, using the fake claude executable
+// so the whole flow runs without network access or an account.
+func TestFakeClaudeApprovalFlowIntegration(t *testing.T) {
+	tests := []struct {
+		name       string
+		approve    bool
+		wantResult string
+	}{
+		{name: "approved", approve: true, wantResult: `ran with {"command":"ls"}`},
+		{name: "denied", approve: false, wantResult: "denied: not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeclaude.Install(t, "../internal/testutil/fakeclaude/testdata/approval.jsonl")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			testStore, err := store.NewSQLiteStore(":memory:")
+			if err != nil {
+				t.Fatalf("failed to create test store: %v", err)
+			}
+			defer testStore.Close()
+
+			eventBus := bus.NewEventBus()
+			approvalManager := approval.NewManager(testStore, eventBus)
+			sessionManager, err := session.NewManager(eventBus, testStore, "")
+			if err != nil {
+				t.Fatalf("failed to create session manager: %v", err)
+			}
+
+			mcpServer := mcp.NewMCPServer(approvalManager, eventBus)
+			mcpServer.Start(ctx)
+			httpServer := httptest.NewServer(mcpServer)
+			defer httpServer.Close()
+
+			launched, err := sessionManager.LaunchSession(ctx, session.LaunchSessionConfig{
+				SessionConfig: claudecode.SessionConfig{
+					Query:        "list files",
+					OutputFormat: claudecode.OutputStreamJSON,
+					WorkingDir:   t.TempDir(),
+					MCPConfig: &claudecode.MCPConfig{
+						MCPServers: map[string]claudecode.MCPServer{
+							"approvals": {Type: "http", URL: httpServer.URL},
+						},
+					},
+					PermissionPromptTool: "mcp__approvals__request_approval",
+				},
+			})
+			if err != nil {
+				t.Fatalf("failed to launch session: %v", err)
+			}
+
+			// Wait for the permission prompt to create an approval
+			var pending []*store.Approval
+			deadline := time.Now().Add(10 * time.Second)
+			for len(pending) == 0 && time.Now().Before(deadline) {
+				pending, err = approvalManager.GetPendingApprovals(ctx, launched.ID)
+				if err != nil {
+					t.Fatalf("failed to get pending approvals: %v", err)
+				}
+				time.Sleep(20 * time.Millisecond)
+			}
+			if len(pending) != 1 {
+				t.Fatalf("expected 1 pending approval, got %d", len(pending))
+			}
+			if pending[0].ToolName != "Bash" || pending[0].ToolUseID == nil || *pending[0].ToolUseID != "toolu_01" {
+				t.Errorf("unexpected approval: %+v", pending[0])
+			}
+
+			if tt.approve {
+				err = approvalManager.ApproveToolCall(ctx, pending[0].ID, "")
+			} else {
+				err = approvalManager.DenyToolCall(ctx, pending[0].ID, "not allowed", store.ApprovalDecidedByHuman)
+			}
+			if err != nil {
+				t.Fatalf("failed to resolve approval: %v", err)
+			}
+
+			// Wait for the session to finish
+			var sess *store.Session
+			deadline = time.Now().Add(10 * time.Second)
+			for time.Now().Before(deadline) {
+				sess, err = testStore.GetSession(ctx, launched.ID)
+				if err != nil {
+					t.Fatalf("failed to get session: %v", err)
+				}
+				if sess.Status == store.SessionStatusCompleted || sess.Status == store.SessionStatusFailed {
+					break
+				}
+				time.Sleep(20 * time.Millisecond)
+			}
+			if sess.Status != store.SessionStatusCompleted {
+				t.Fatalf("expected completed session, got %s (error: %s)", sess.Status, sess.ErrorMessage)
+			}
+
+			events, err := testStore.GetConversation(ctx, sess.ClaudeSessionID)
+			if err != nil {
+				t.Fatalf("failed to get conversation: %v", err)
+			}
+			var toolResult string
+			for _, event := range events {
+				if event.EventType == store.EventTypeToolResult {
+					toolResult = event.ToolResultContent
+				}
+			}
+			if !strings.Contains(toolResult, tt.wantResult) {
+				t.Errorf("expected tool result %q, got %q", tt.wantResult, toolResult)
+			}
+		})
+	}
+}
@@ -3,8 +3,8 @@ module github.com/humanlayer/humanlayer/hld
 go 1.24.0
 
 replace (
+	github.com/humanlayer/humanlayer-go => ../humanlayer-go
 	github.com/humanlayer/humanlayer/claudecode-go => ../claudecode-go
-	github.com/humanlayer/humanlayer/humanlayer-go => ../humanlayer-go
 )
 
 require (
@@ -13,6 +13,7 @@ require (
 	github.com/gin-gonic/gin v1.10.1
 	github.com/golang/mock v1.6.0
 	github.com/google/uuid v1.6.0
+	github.com/humanlayer/humanlayer-go v0.0.0-00010101000000-000000000000
 	github.com/humanlayer/humanlayer/claudecode-go v0.0.0-00010101000000-000000000000
 	github.com/mark3labs/mcp-go v0.37.0
 	github.com/mattn/go-sqlite3 v1.14.28
@@ -0,0 +1,13 @@
+// Command claude is a fake claude CLI that replays fixture scripts.
+// See package fakeclaude for the script format.
+package main
+
+import (
+	"os"
+
+	"github.com/humanlayer/humanlayer/hld/internal/testutil/fakeclaude"
+)
+
+func main() {
+	os.Exit(fakeclaude.Main(os.Args[1:]))
+}
@@ -0,0 +1,549 @@
+// Package fakeclaude provides a fake claude executable that replays scripted
+// stream-json transcripts, so session, approval and resume flows can be tested
+// without the real CLI, network access or an account.
+//
+// A fixture directory holds one or more scripts (*.jsonl). Each line of a script
+// is one step:
+//
+//	{"match": {"query": "deploy", "resume": true}}  first line only: when this script applies
+//	{"emit": {...}}                                  write a stream-json event to stdout
+//	{"emit": {...}, "if": "allow"}                   emit only if the last permission was allowed ("deny" also valid)
+//	{"stderr": "text"}                               write text to stderr
+//	{"sleep_ms": 50}                                 pause
+//	{"permission": {"tool_name": "Bash", "input": {...}, "tool_use_id": "toolu_1"}}
+//	                                                 call --permission-prompt-tool over MCP and wait for the decision
+//	{"read_input": true}                             wait for the next stream-json user message on stdin
+//	{"exit": 1}                                      exit immediately with the given code
+//
+// For each invocation the first script (in file name order) whose match conditions
+// hold is replayed; a script without a match step always applies. Emitted events
+// may use the placeholders {{session_id}}, {{resume_id}}, {{query}}, {{input}},
+// {{permission_message}} and {{updated_input}} inside strings; "{{updated_input}}"
+// as a whole value is replaced by the (possibly edited) tool input object.
+//
+// `claude --version` prints DefaultVersion, or the value of FAKE_CLAUDE_VERSION.
+package fakeclaude
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Environment variables read by the fake executable
+const (
+	EnvFixtures = "FAKE_CLAUDE_FIXTURES" // Directory (or single file) of scripts
+	EnvLog      = "FAKE_CLAUDE_LOG"      // Optional JSONL file recording each invocation
+	EnvVersion  = "FAKE_CLAUDE_VERSION"  // Optional version reported by --version
+)
+
+// DefaultVersion is reported by --version unless EnvVersion is set
+const DefaultVersion = "2.0.14"
+
+// permissionTimeout bounds how long a permission step waits for a decision
+const permissionTimeout = 2 * time.Minute
+
+// Step is a single line of a script
+type Step struct {
+	Match      *Match          `json:"match,omitempty"`
+	Emit       json.RawMessage `json:"emit,omitempty"`
+	If         string          `json:"if,omitempty"`
+	Stderr     string          `json:"stderr,omitempty"`
+	SleepMS    int             `json:"sleep_ms,omitempty"`
+	Permission *Permission     `json:"permission,omitempty"`
+	ReadInput  bool            `json:"read_input,omitempty"`
+	Exit       *int            `json:"exit,omitempty"`
+}
+
+// Match selects the invocations a script applies to
+type Match struct {
+	Query  string `json:"query,omitempty"`  // Substring of the query
+	Resume *bool  `json:"resume,omitempty"` // Whether --resume was passed
+}
+
+// Permission is a call to the permission prompt tool
+type Permission struct {
+	ToolName  string                 `json:"tool_name"`
+	Input     map[string]interface{} `json:"input"`
+	ToolUseID string                 `json:"tool_use_id"`
+}
+
+// Script is a named sequence of steps
+type Script struct {
+	Name  string
+	Match *Match
+	Steps []Step
+}
+
+// Invocation records how the fake was called
+type Invocation struct {
+	Args       []string `json:"args"`
+	Query      string   `json:"query"`
+	ResumeID   string   `json:"resume_id,omitempty"`
+	SessionID  string   `json:"session_id"`
+	Script     string   `json:"script"`
+	WorkingDir string   `json:"working_dir"`
+	ExitCode   int      `json:"exit_code"`
+}
+
+// LoadScripts reads all scripts from a fixture directory, or a single script file
+func LoadScripts(path string) ([]Script, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.jsonl"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list fixtures: %w", err)
+		}
+		sort.Strings(files)
+	}
+
+	scripts := make([]Script, 0, len(files))
+	for _, file := range files {
+		script, err := loadScript(file)
+		if err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, script)
+	}
+	if len(scripts) == 0 {
+		return nil, fmt.Errorf("no scripts found in %s", path)
+	}
+	return scripts, nil
+}
+
+func loadScript(file string) (Script, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return Script{}, fmt.Errorf("failed to read script: %w", err)
+	}
+
+	script := Script{Name: filepath.Base(file)}
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		var step Step
+		if err := json.Unmarshal([]byte(line), &step); err != nil {
+			return Script{}, fmt.Errorf("%s:%d: invalid step: %w", script.Name, i+1, err)
+		}
+		if step.Match != nil {
+			if len(script.Steps) > 0 || script.Match != nil {
+				return Script{}, fmt.Errorf("%s:%d: match must be the first step", script.Name, i+1)
+			}
+			script.Match = step.Match
+			continue
+		}
+		script.Steps = append(script.Steps, step)
+	}
+	return script, nil
+}
+
+// matches reports whether the script applies to an invocation
+func (s Script) matches(query string, resume bool) bool {
+	if s.Match == nil {
+		return true
+	}
+	if s.Match.Query != "" && !strings.Contains(query, s.Match.Query) {
+		return false
+	}
+	if s.Match.Resume != nil && *s.Match.Resume != resume {
+		return false
+	}
+	return true
+}
+
+// Runner replays scripts for a single invocation
+type Runner struct {
+	Fixtures string
+	LogPath  string
+	Version  string // Reported by --version; DefaultVersion when empty
+	Stdin    io.Reader
+	Stdout   io.Writer
+	Stderr   io.Writer
+}
+
+// Main runs the fake with the process's arguments, environment and standard streams
+func Main(args []string) int {
+	r := &Runner{
+		Fixtures: os.Getenv(EnvFixtures),
+		LogPath:  os.Getenv(EnvLog),
+		Version:  os.Getenv(EnvVersion),
+		Stdin:    os.Stdin,
+		Stdout:   os.Stdout,
+		Stderr:   os.Stderr,
+	}
+	return r.Run(args)
+}
+
+// cliArgs holds the flags the fake understands
+type cliArgs struct {
+	query                string
+	resumeID             string
+	inputFormat          string
+	mcpConfig            string
+	permissionPromptTool string
+}
+
+// flagsWithValue lists claude flags that consume the following argument
+var flagsWithValue = map[string]bool{
+	"--resume": true, "--model": true, "--output-format": true, "--input-format": true,
+	"--mcp-config": true, "--permission-prompt-tool": true, "--max-turns": true,
+	"--system-prompt": true, "--append-system-prompt": true, "--allowedTools": true,
+	"--disallowedTools": true, "--add-dir": true, "--permission-mode": true,
+	"--settings": true, "--setting-sources": true, "--fallback-model": true, "--session-id": true,
+}
+
+func parseArgs(args []string) cliArgs {
+	var parsed cliArgs
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--print" || arg == "-p":
+			// --print may be followed by the query
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				i++
+				parsed.query = args[i]
+			}
+		case flagsWithValue[arg] && i+1 < len(args):
+			i++
+			switch arg {
+			case "--resume":
+				parsed.resumeID = args[i]
+			case "--input-format":
+				parsed.inputFormat = args[i]
+			case "--mcp-config":
+				parsed.mcpConfig = args[i]
+			case "--permission-prompt-tool":
+				parsed.permissionPromptTool = args[i]
+			}
+		case strings.HasPrefix(arg, "-"):
+			// Boolean flag
+		default:
+			parsed.query = arg
+		}
+	}
+	return parsed
+}
+
+// state is the per-invocation replay state
+type state struct {
+	vars       map[string]string
+	updated    json.RawMessage
+	lastPermit string
+	input      *bufio.Scanner
+}
+
+// Run replays the matching script and returns the exit code
+func (r *Runner) Run(args []string) int {
+	// Version probes are answered directly and not recorded as invocations
+	if len(args) == 1 && args[0] == "--version" {
+		version := r.Version
+		if version == "" {
+			version = DefaultVersion
+		}
+		fmt.Fprintf(r.Stdout, "%s (Claude Code)\n", version)
+		return 0
+	}
+
+	inv := Invocation{Args: args}
+	inv.WorkingDir, _ = os.Getwd()
+
+	code := r.run(args, &inv)
+	inv.ExitCode = code
+	r.record(inv)
+	return code
+}
+
+func (r *Runner) run(args []string, inv *Invocation) int {
+	if r.Fixtures == "" {
+		fmt.Fprintf(r.Stderr, "fake claude: %s is not set\n", EnvFixtures)
+		return 1
+	}
+	scripts, err := LoadScripts(r.Fixtures)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, "fake claude: %v\n", err)
+		return 1
+	}
+
+	parsed := parseArgs(args)
+	st := &state{vars: map[string]string{}}
+	if r.Stdin != nil {
+		st.input = bufio.NewScanner(r.Stdin)
+		st.input.Buffer(make([]byte, 0), 10*1024*1024)
+	}
+
+	// The query arrives on stdin with stream-json input, or when --add-dir is used
+	streamInput := parsed.inputFormat == string(claudecode.InputStreamJSON)
+	if streamInput {
+		text, ok := st.readMessage()
+		if !ok {
+			return 0
+		}
+		parsed.query = text
+	} else if parsed.query == "" && r.Stdin != nil {
+		data, _ := io.ReadAll(r.Stdin)
+		parsed.query = strings.TrimSpace(string(data))
+	}
+
+	inv.Query = parsed.query
+	inv.ResumeID = parsed.resumeID
+	inv.SessionID = uuid.New().String()
+	st.vars["session_id"] = inv.SessionID
+	st.vars["resume_id"] = parsed.resumeID
+	st.vars["query"] = parsed.query
+	st.vars["input"] = parsed.query
+
+	var script *Script
+	for i := range scripts {
+		if scripts[i].matches(parsed.query, parsed.resumeID != "") {
+			script = &scripts[i]
+			break
+		}
+	}
+	if script == nil {
+		fmt.Fprintf(r.Stderr, "fake claude: no script matches query %q\n", parsed.query)
+		return 1
+	}
+	inv.Script = script.Name
+
+	for _, step := range script.Steps {
+		if step.If != "" && step.If != st.lastPermit {
+			continue
+		}
+
+		switch {
+		case step.Exit != nil:
+			return *step.Exit
+		case step.Emit != nil:
+			line, err := st.expand(step.Emit)
+			if err != nil {
+				fmt.Fprintf(r.Stderr, "fake claude: %s: %v\n", script.Name, err)
+				return 1
+			}
+			fmt.Fprintf(r.Stdout, "%s\n", line)
+		case step.Stderr != "":
+			fmt.Fprint(r.Stderr, step.Stderr)
+		case step.SleepMS > 0:
+			time.Sleep(time.Duration(step.SleepMS) * time.Millisecond)
+		case step.Permission != nil:
+			if err := r.requestPermission(parsed, step.Permission, st); err != nil {
+				fmt.Fprintf(r.Stderr, "fake claude: permission prompt failed: %v\n", err)
+				return 1
+			}
+		case step.ReadInput:
+			text, ok := st.readMessage()
+			if !ok {
+				return 0
+			}
+			st.vars["input"] = text
+		}
+	}
+
+	// Like the real CLI, a stream-json session stays alive until its input closes
+	if streamInput {
+		for {
+			if _, ok := st.readMessage(); !ok {
+				break
+			}
+		}
+	}
+	return 0
+}
+
+// readMessage reads the next stream-json user message and returns its text
+func (st *state) readMessage() (string, bool) {
+	if st.input == nil {
+		return "", false
+	}
+	for st.input.Scan() {
+		var msg struct {
+			Message struct {
+				Content []struct {
+					Type string `json:"type"`
+					Text string `json:"text"`
+				} `json:"content"`
+			} `json:"message"`
+		}
+		if err := json.Unmarshal(st.input.Bytes(), &msg); err != nil {
+			continue
+		}
+		var texts []string
+		for _, content := range msg.Message.Content {
+			if content.Type == "text" {
+				texts = append(texts, content.Text)
+			}
+		}
+		return strings.Join(texts, "\n"), true
+	}
+	return "", false
+}
+
+// expand substitutes placeholders in an emitted event
+func (st *state) expand(raw json.RawMessage) (string, error) {
+	line := string(raw)
+	if st.updated != nil {
+		line = strings.ReplaceAll(line, `"{{updated_input}}"`, string(st.updated))
+	}
+	for name, value := range st.vars {
+		escaped, _ := json.Marshal(value)
+		line = strings.ReplaceAll(line, "{{"+name+"}}", string(escaped[1:len(escaped)-1]))
+	}
+
+	// Re-encode to validate and emit on a single line
+	var event interface{}
+	if err := json.Unmarshal([]byte(line), &event); err != nil {
+		return "", fmt.Errorf("invalid emit after substitution: %w", err)
+	}
+	compact, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+	return string(compact), nil
+}
+
+// requestPermission calls the permission prompt tool the way claude does
+func (r *Runner) requestPermission(parsed cliArgs, perm *Permission, st *state) error {
+	parts := strings.SplitN(parsed.permissionPromptTool, "__", 3)
+	if len(parts) != 3 || parts[0] != "mcp" {
+		return fmt.Errorf("--permission-prompt-tool %q is not an MCP tool", parsed.permissionPromptTool)
+	}
+	serverName, toolName := parts[1], parts[2]
+
+	config, err := loadMCPConfig(parsed.mcpConfig)
+	if err != nil {
+		return err
+	}
+	server, ok := config.MCPServers[serverName]
+	if !ok {
+		return fmt.Errorf("MCP server %q not configured", serverName)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), permissionTimeout)
+	defer cancel()
+
+	var client *mcpclient.Client
+	if server.Type == "http" {
+		client, err = mcpclient.NewStreamableHttpClient(server.URL, transport.WithHTTPHeaders(server.Headers))
+	} else {
+		env := os.Environ()
+		for key, value := range server.Env {
+			env = append(env, key+"="+value)
+		}
+		client, err = mcpclient.NewStdioMCPClient(server.Command, env, server.Args...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create MCP client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if err := client.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start MCP client: %w", err)
+	}
+
+	initReq := mcp.InitializeRequest{}
+	initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initReq.Params.ClientInfo = mcp.Implementation{Name: "fake-claude", Version: "1.0.0"}
+	if _, err := client.Initialize(ctx, initReq); err != nil {
+		return fmt.Errorf("failed to initialize MCP client: %w", err)
+	}
+
+	callReq := mcp.CallToolRequest{}
+	callReq.Params.Name = toolName
+	callReq.Params.Arguments = map[string]interface{}{
+		"tool_name":   perm.ToolName,
+		"input":       perm.Input,
+		"tool_use_id": perm.ToolUseID,
+	}
+	result, err := client.CallTool(ctx, callReq)
+	if err != nil {
+		return fmt.Errorf("tool call failed: %w", err)
+	}
+
+	var decision struct {
+		Behavior     string          `json:"behavior"`
+		Message      string          `json:"message"`
+		UpdatedInput json.RawMessage `json:"updatedInput"`
+	}
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			if err := json.Unmarshal([]byte(text.Text), &decision); err != nil {
+				return fmt.Errorf("invalid permission response %q: %w", text.Text, err)
+			}
+			break
+		}
+	}
+
+	switch decision.Behavior {
+	case "allow":
+		st.lastPermit = "allow"
+	case "deny":
+		st.lastPermit = "deny"
+	default:
+		return fmt.Errorf("unexpected permission behavior %q", decision.Behavior)
+	}
+	st.vars["permission_message"] = decision.Message
+	st.updated = decision.UpdatedInput
+	if st.updated == nil {
+		st.updated, _ = json.Marshal(perm.Input)
+	}
+	st.vars["updated_input"] = string(st.updated)
+	return nil
+}
+
+// loadMCPConfig reads --mcp-config, which is either a file path or inline JSON
+func loadMCPConfig(value string) (*claudecode.MCPConfig, error) {
+	if value == "" {
+		return nil, fmt.Errorf("--mcp-config not provided")
+	}
+
+	data := []byte(value)
+	if !strings.HasPrefix(strings.TrimSpace(value), "{") {
+		var err error
+		if data, err = os.ReadFile(value); err != nil {
+			return nil, fmt.Errorf("failed to read MCP config: %w", err)
+		}
+	}
+
+	var config claudecode.MCPConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid MCP config: %w", err)
+	}
+	return &config, nil
+}
+
+// record appends the invocation to the log file, if configured
+func (r *Runner) record(inv Invocation) {
+	if r.LogPath == "" {
+		return
+	}
+	line, err := json.Marshal(inv)
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(r.LogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+	_, _ = f.Write(append(line, '\n'))
+}
@@ -0,0 +1,209 @@
+package fakeclaude
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// run replays fixtures in-process and returns the decoded stdout events
+func run(t *testing.T, fixtures string, stdin io.Reader, args ...string) ([]claudecode.Event, string, int, Invocation) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	r := &Runner{
+		Fixtures: fixtures,
+		LogPath:  filepath.Join(t.TempDir(), "invocations.jsonl"),
+		Stdin:    stdin,
+		Stdout:   &stdout,
+		Stderr:   &stderr,
+	}
+	code := r.Run(args)
+
+	var events []claudecode.Event
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		event, err := claudecode.Decode([]byte(line))
+		require.NoError(t, err, "fake emitted invalid event: %s", line)
+		events = append(events, event)
+	}
+
+	fake := &Fake{LogPath: r.LogPath}
+	invocations := fake.Invocations(t)
+	require.Len(t, invocations, 1)
+
+	return events, stderr.String(), code, invocations[0]
+}
+
+func TestRunner_ReplaysMatchingScript(t *testing.T) {
+	t.Run("launch", func(t *testing.T) {
+		events, stderr, code, inv := run(t, "testdata/session", nil,
+			"--print", "hello", "--output-format", "stream-json", "--verbose", "hello")
+
+		assert.Equal(t, 0, code)
+		assert.Empty(t, stderr)
+		assert.Equal(t, "02_launch.jsonl", inv.Script)
+		assert.Equal(t, "hello", inv.Query)
+		require.Len(t, events, 3)
+
+		init, ok := events[0].(*claudecode.SystemInit)
+		require.True(t, ok)
+		assert.Equal(t, inv.SessionID, init.SessionID)
+
+		msg, ok := events[1].(*claudecode.AssistantMessage)
+		require.True(t, ok)
+		assert.Equal(t, "You said: hello", msg.Message.Content[0].Text)
+
+		_, ok = events[2].(*claudecode.ResultEvent)
+		assert.True(t, ok)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		events, _, code, inv := run(t, "testdata/session", nil,
+			"--print", "again", "--resume", "parent-session", "--output-format", "stream-json")
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "01_resume.jsonl", inv.Script)
+		assert.Equal(t, "parent-session", inv.ResumeID)
+		require.Len(t, events, 3)
+
+		msg, ok := events[1].(*claudecode.AssistantMessage)
+		require.True(t, ok)
+		assert.Equal(t, "Resumed parent-session: again", msg.Message.Content[0].Text)
+	})
+}
+
+func TestRunner_ExitCodeAndStderr(t *testing.T) {
+	events, stderr, code, inv := run(t, "testdata/failure.jsonl", nil, "--print", "fail")
+
+	assert.Equal(t, 2, code)
+	assert.Equal(t, 2, inv.ExitCode)
+	assert.Equal(t, "API Error: overloaded", stderr)
+	assert.Len(t, events, 1)
+}
+
+func TestRunner_Version(t *testing.T) {
+	var stdout bytes.Buffer
+	logPath := filepath.Join(t.TempDir(), "invocations.jsonl")
+	r := &Runner{Fixtures: "testdata", LogPath: logPath, Version: "1.0.10", Stdout: &stdout, Stderr: io.Discard}
+
+	assert.Equal(t, 0, r.Run([]string{"--version"}))
+	assert.Equal(t, "1.0.10 (Claude Code)\n", stdout.String())
+
+	_, err := os.Stat(logPath)
+	assert.True(t, os.IsNotExist(err), "version probes should not be recorded")
+}
+
+func TestRunner_StreamInput(t *testing.T) {
+	stdin := strings.NewReader(
+		`{"type":"user","message":{"role":"user","content":[{"type":"text","text":"one"}]}}` + "\n" +
+			`{"type":"user","message":{"role":"user","content":[{"type":"text","text":"two"}]}}` + "\n")
+
+	events, _, code, inv := run(t, "testdata/stream_input.jsonl", stdin,
+		"--print", "--input-format", "stream-json", "--output-format", "stream-json")
+
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "one", inv.Query)
+	require.Len(t, events, 4)
+	assert.Equal(t, "first: one", events[0].(*claudecode.AssistantMessage).Message.Content[0].Text)
+	assert.Equal(t, "second: two", events[2].(*claudecode.AssistantMessage).Message.Content[0].Text)
+}
+
+func TestRunner_Permission(t *testing.T) {
+	// A stand-in for the daemon's MCP approval endpoint
+	newApprovalServer := func(t *testing.T, response map[string]interface{}) (string, *string) {
+		var gotSessionHeader string
+		s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+		s.AddTool(mcp.NewTool("request_approval"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			assert.Equal(t, "Bash", req.GetString("tool_name", ""))
+			assert.Equal(t, "toolu_01", req.GetString("tool_use_id", ""))
+			data, _ := json.Marshal(response)
+			return mcp.NewToolResultText(string(data)), nil
+		})
+		httpServer := server.NewStreamableHTTPServer(s, server.WithStateLess(true))
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := r.Header.Get("X-Session-ID"); id != "" {
+				gotSessionHeader = id
+			}
+			httpServer.ServeHTTP(w, r)
+		}))
+		t.Cleanup(ts.Close)
+
+		return ts.URL, &gotSessionHeader
+	}
+
+	writeMCPConfig := func(t *testing.T, url string) string {
+		config := claudecode.MCPConfig{MCPServers: map[string]claudecode.MCPServer{
+			"approvals": {Type: "http", URL: url, Headers: map[string]string{"X-Session-ID": "sess-123"}},
+		}}
+		data, err := json.Marshal(config)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "mcp.json")
+		require.NoError(t, os.WriteFile(path, data, 0644))
+		return path
+	}
+
+	tests := []struct {
+		name     string
+		response map[string]interface{}
+		want     string
+	}{
+		{
+			name:     "allow with edited input",
+			response: map[string]interface{}{"behavior": "allow", "updatedInput": map[string]interface{}{"command": "ls -la"}},
+			want:     `ran with {"command":"ls -la"}`,
+		},
+		{
+			name:     "deny",
+			response: map[string]interface{}{"behavior": "deny", "message": "not today"},
+			want:     "denied: not today",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, sessionHeader := newApprovalServer(t, tt.response)
+
+			events, stderr, code, _ := run(t, "testdata/approval.jsonl", nil,
+				"--print", "list files",
+				"--mcp-config", writeMCPConfig(t, url),
+				"--permission-prompt-tool", "mcp__approvals__request_approval")
+
+			require.Equal(t, 0, code, "stderr: %s", stderr)
+			require.Len(t, events, 4)
+
+			user, ok := events[2].(*claudecode.UserToolResult)
+			require.True(t, ok)
+			results := user.ToolResults()
+			require.Len(t, results, 1)
+			assert.Equal(t, tt.want, results[0].Content.Value)
+			assert.Equal(t, "sess-123", *sessionHeader)
+		})
+	}
+
+	t.Run("missing MCP server", func(t *testing.T) {
+		_, stderr, code, _ := run(t, "testdata/approval.jsonl", nil,
+			"--print", "list files",
+			"--mcp-config", `{"mcpServers": {}}`,
+			"--permission-prompt-tool", "mcp__approvals__request_approval")
+
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, fmt.Sprintf("MCP server %q not configured", "approvals"))
+	})
+}
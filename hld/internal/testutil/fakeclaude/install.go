@@ -0,0 +1,89 @@
+package fakeclaude
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+const cmdPackage = "github.com/humanlayer/humanlayer/hld/internal/testutil/fakeclaude/cmd/claude"
+
+// build caches the fake executable for the lifetime of the test binary
+var build struct {
+	once sync.Once
+	dir  string
+	err  error
+	out  []byte
+}
+
+// Fake is an installed fake claude executable
+type Fake struct {
+	Path    string // Path to the executable
+	LogPath string // JSONL file of recorded invocations
+}
+
+// Install builds the fake claude executable (once per test binary), puts it first
+// on PATH and points it at the given fixture directory or script file.
+//
+// Call Install before creating a claudecode.Client or session.Manager, since they
+// resolve the claude binary when constructed.
+func Install(t *testing.T, fixtures string) *Fake {
+	t.Helper()
+
+	build.once.Do(func() {
+		build.dir, build.err = os.MkdirTemp("", "fakeclaude-")
+		if build.err != nil {
+			return
+		}
+		cmd := exec.Command("go", "build", "-o", filepath.Join(build.dir, "claude"), cmdPackage)
+		build.out, build.err = cmd.CombinedOutput()
+	})
+	if build.err != nil {
+		t.Fatalf("failed to build fake claude: %v\n%s", build.err, build.out)
+	}
+
+	absFixtures, err := filepath.Abs(fixtures)
+	if err != nil {
+		t.Fatalf("failed to resolve fixtures path: %v", err)
+	}
+
+	fake := &Fake{
+		Path:    filepath.Join(build.dir, "claude"),
+		LogPath: filepath.Join(t.TempDir(), "invocations.jsonl"),
+	}
+
+	t.Setenv("PATH", build.dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv(EnvFixtures, absFixtures)
+	t.Setenv(EnvLog, fake.LogPath)
+
+	return fake
+}
+
+// Invocations returns the recorded invocations of the fake, in order
+func (f *Fake) Invocations(t *testing.T) []Invocation {
+	t.Helper()
+
+	file, err := os.Open(f.LogPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("failed to open invocation log: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var invocations []Invocation
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var inv Invocation
+		if err := json.Unmarshal(scanner.Bytes(), &inv); err != nil {
+			t.Fatalf("invalid invocation log line: %v", err)
+		}
+		invocations = append(invocations, inv)
+	}
+	return invocations
+}
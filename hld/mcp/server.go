@@ -11,6 +11,7 @@ import (
 
 	"github.com/humanlayer/humanlayer/hld/approval"
 	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -25,8 +26,9 @@ const (
 
 // ApprovalDecision represents the outcome of an approval request
 type ApprovalDecision struct {
-	Approved bool
-	Comment  string
+	Approved     bool
+	Comment      string
+	UpdatedInput json.RawMessage // Tool input edited by the approver, nil if unchanged
 }
 
 // MCPServer wraps the mark3labs MCP server
@@ -163,12 +165,31 @@ func (s *MCPServer) handleRequestApproval(ctx context.Context, request mcp.CallT
 		}, nil
 	}
 
+	// Check if the approval was denied by an approval policy
+	if approval.Status == store.ApprovalStatusLocalDenied {
+		responseData := map[string]interface{}{
+			"behavior": "deny",
+			"message":  approval.Comment,
+		}
+		responseJSON, _ := json.Marshal(responseData)
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: string(responseJSON),
+				},
+			},
+		}, nil
+	}
+
 	// Register for event-driven approval resolution
 	decisionChan := make(chan ApprovalDecision, 1)
 	s.pendingApprovals.Store(toolUseID, decisionChan)
 	defer s.pendingApprovals.Delete(toolUseID)
 
-	// Wait for approval decision
+	// Wait for approval decision. Approvals with a policy timeout are resolved
+	// by the approval expiry monitor when nobody answers in time.
 	select {
 	case decision := <-decisionChan:
 		responseData := map[string]interface{}{
@@ -180,6 +201,9 @@ func (s *MCPServer) handleRequestApproval(ctx context.Context, request mcp.CallT
 				"behavior":     "allow",
 				"updatedInput": input,
 			}
+			if decision.UpdatedInput != nil {
+				responseData["updatedInput"] = decision.UpdatedInput
+			}
 		}
 		responseJSON, _ := json.Marshal(responseData)
 
@@ -192,10 +216,6 @@ func (s *MCPServer) handleRequestApproval(ctx context.Context, request mcp.CallT
 			},
 		}, nil
 
-	// For the moment, we don't timeout approvals, but in the future
-	// may choose to add a timeout or determine otherwise for resumed sessions
-	// case <-time.After(5 * time.Minute):
-	// 	return nil, fmt.Errorf("approval timeout")
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	}
@@ -238,6 +258,7 @@ func (s *MCPServer) listenForApprovalDecisions(ctx context.Context) {
 			toolUseID, _ := event.Data["tool_use_id"].(string)
 			approved, _ := event.Data["approved"].(bool)
 			comment, _ := event.Data["response_text"].(string)
+			updatedInput, _ := event.Data["updated_input"].(json.RawMessage)
 
 			if toolUseID == "" {
 				continue
@@ -247,8 +268,9 @@ func (s *MCPServer) listenForApprovalDecisions(ctx context.Context) {
 			if ch, ok := s.pendingApprovals.Load(toolUseID); ok {
 				select {
 				case ch.(chan ApprovalDecision) <- ApprovalDecision{
-					Approved: approved,
-					Comment:  comment,
+					Approved:     approved,
+					Comment:      comment,
+					UpdatedInput: updatedInput,
 				}:
 					slog.Info("Sent approval decision", "tool_use_id", toolUseID, "approved", approved)
 				default:
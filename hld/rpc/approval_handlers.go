@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/humanlayer/humanlayer/hld/approval"
 	"github.com/humanlayer/humanlayer/hld/session"
@@ -117,15 +118,25 @@ func (h *ApprovalHandlers) HandleFetchApprovals(ctx context.Context, params json
 
 // SendDecisionRequest is the request for sending a decision
 type SendDecisionRequest struct {
-	ApprovalID string `json:"approval_id"`
-	Decision   string `json:"decision"`
-	Comment    string `json:"comment,omitempty"`
+	ApprovalID   string          `json:"approval_id"`
+	Decision     string          `json:"decision"`
+	Comment      string          `json:"comment,omitempty"`
+	UpdatedInput json.RawMessage `json:"updated_input,omitempty"` // Edited tool input, only when approving
+	Remember     *RememberRule   `json:"remember,omitempty"`      // Rule to create for approve_always
+}
+
+// RememberRule describes the "always allow" rule created by an approve_always decision
+type RememberRule struct {
+	Scope  string `json:"scope,omitempty"`  // session (default) or project
+	Match  string `json:"match,omitempty"`  // exact (default), tool or prefix
+	Prefix string `json:"prefix,omitempty"` // Command prefix of prefix rules, defaults to the approved command
 }
 
 // SendDecisionResponse is the response for sending a decision
 type SendDecisionResponse struct {
-	Success bool   `json:"success"`
-	Error   string `json:"error,omitempty"`
+	Success bool                `json:"success"`
+	Error   string              `json:"error,omitempty"`
+	Rule    *store.ApprovalRule `json:"rule,omitempty"` // Rule created by approve_always
 }
 
 // HandleSendDecision handles the SendDecision RPC method
@@ -144,17 +155,34 @@ func (h *ApprovalHandlers) HandleSendDecision(ctx context.Context, params json.R
 	}
 
 	var err error
+	var rule *store.ApprovalRule
 
 	switch req.Decision {
+	case "approve_always":
+		if len(req.UpdatedInput) > 0 {
+			return nil, fmt.Errorf("updated_input is not allowed with approve_always")
+		}
+		var opts approval.RememberOptions
+		if req.Remember != nil {
+			opts = approval.RememberOptions{Scope: req.Remember.Scope, Match: req.Remember.Match, Prefix: req.Remember.Prefix}
+		}
+		rule, err = h.approvals.ApproveAndRemember(ctx, req.ApprovalID, req.Comment, opts)
 	case "approve":
-		err = h.approvals.ApproveToolCall(ctx, req.ApprovalID, req.Comment)
+		if len(req.UpdatedInput) > 0 {
+			err = h.approvals.ApproveToolCallWithInput(ctx, req.ApprovalID, req.Comment, req.UpdatedInput, store.ApprovalDecidedByHuman)
+		} else {
+			err = h.approvals.ApproveToolCall(ctx, req.ApprovalID, req.Comment)
+		}
 	case "deny":
 		if req.Comment == "" {
 			return nil, fmt.Errorf("comment is required for denial")
 		}
-		err = h.approvals.DenyToolCall(ctx, req.ApprovalID, req.Comment)
+		if len(req.UpdatedInput) > 0 {
+			return nil, fmt.Errorf("updated_input is only allowed when approving")
+		}
+		err = h.approvals.DenyToolCall(ctx, req.ApprovalID, req.Comment, store.ApprovalDecidedByHuman)
 	default:
-		return nil, fmt.Errorf("invalid decision: %s (must be 'approve' or 'deny')", req.Decision)
+		return nil, fmt.Errorf("invalid decision: %s (must be 'approve', 'approve_always' or 'deny')", req.Decision)
 	}
 
 	if err != nil {
@@ -166,6 +194,7 @@ func (h *ApprovalHandlers) HandleSendDecision(ctx context.Context, params json.R
 
 	return &SendDecisionResponse{
 		Success: true,
+		Rule:    rule,
 	}, nil
 }
 
@@ -202,10 +231,160 @@ func (h *ApprovalHandlers) HandleGetApproval(ctx context.Context, params json.Ra
 	}, nil
 }
 
+// ListApprovalRulesRequest is the request for listing approval rules
+type ListApprovalRulesRequest struct {
+	SessionID string `json:"session_id"`
+}
+
+// ListApprovalRulesResponse is the response for listing approval rules
+type ListApprovalRulesResponse struct {
+	Rules []*store.ApprovalRule `json:"rules"`
+}
+
+// HandleListApprovalRules handles the ListApprovalRules RPC method
+func (h *ApprovalHandlers) HandleListApprovalRules(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var req ListApprovalRulesRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	// Validate required fields
+	if req.SessionID == "" {
+		return nil, fmt.Errorf("session_id is required")
+	}
+
+	rules, err := h.approvals.ListApprovalRules(ctx, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		rules = []*store.ApprovalRule{}
+	}
+
+	return &ListApprovalRulesResponse{
+		Rules: rules,
+	}, nil
+}
+
+// RevokeApprovalRuleRequest is the request for revoking an approval rule
+type RevokeApprovalRuleRequest struct {
+	RuleID string `json:"rule_id"`
+}
+
+// RevokeApprovalRuleResponse is the response for revoking an approval rule
+type RevokeApprovalRuleResponse struct {
+	Success bool `json:"success"`
+}
+
+// HandleRevokeApprovalRule handles the RevokeApprovalRule RPC method
+func (h *ApprovalHandlers) HandleRevokeApprovalRule(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var req RevokeApprovalRuleRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	// Validate required fields
+	if req.RuleID == "" {
+		return nil, fmt.Errorf("rule_id is required")
+	}
+
+	if err := h.approvals.RevokeApprovalRule(ctx, req.RuleID); err != nil {
+		return nil, err
+	}
+
+	return &RevokeApprovalRuleResponse{
+		Success: true,
+	}, nil
+}
+
+// ApprovalQuery filters the approval history. Empty fields don't filter.
+type ApprovalQuery struct {
+	Status     string     `json:"status,omitempty"`
+	ToolName   string     `json:"tool_name,omitempty"`
+	SessionID  string     `json:"session_id,omitempty"`
+	WorkingDir string     `json:"working_dir,omitempty"`
+	DecidedBy  string     `json:"decided_by,omitempty"` // human, auto_accept, policy, rule or timeout
+	Since      *time.Time `json:"since,omitempty"`      // Created at or after
+	Until      *time.Time `json:"until,omitempty"`      // Created before
+}
+
+// filter converts the query to a store filter
+func (q ApprovalQuery) filter() store.ApprovalFilter {
+	return store.ApprovalFilter{
+		Status:        store.ApprovalStatus(q.Status),
+		ToolName:      q.ToolName,
+		SessionID:     q.SessionID,
+		WorkingDir:    q.WorkingDir,
+		DecidedBy:     q.DecidedBy,
+		CreatedAfter:  q.Since,
+		CreatedBefore: q.Until,
+	}
+}
+
+// QueryApprovalsRequest is the request for querying the approval history
+type QueryApprovalsRequest struct {
+	ApprovalQuery
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// QueryApprovalsResponse is the response for querying the approval history
+type QueryApprovalsResponse struct {
+	Approvals  []*store.Approval `json:"approvals"`
+	NextOffset *int              `json:"next_offset,omitempty"` // Set when the page is full
+}
+
+// HandleQueryApprovals handles the QueryApprovals RPC method
+func (h *ApprovalHandlers) HandleQueryApprovals(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var req QueryApprovalsRequest
+	if params != nil {
+		if err := json.Unmarshal(params, &req); err != nil {
+			return nil, fmt.Errorf("invalid request: %w", err)
+		}
+	}
+
+	filter := req.filter()
+	filter.Limit = req.Limit
+	filter.Offset = req.Offset
+	if filter.Limit == 0 {
+		filter.Limit = approval.DefaultApprovalHistoryLimit
+	}
+
+	approvals, err := h.approvals.ListApprovals(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &QueryApprovalsResponse{
+		Approvals: approvals,
+	}
+	if len(approvals) == filter.Limit {
+		nextOffset := filter.Offset + len(approvals)
+		resp.NextOffset = &nextOffset
+	}
+	return resp, nil
+}
+
+// HandleGetApprovalStats handles the GetApprovalStats RPC method
+func (h *ApprovalHandlers) HandleGetApprovalStats(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var req ApprovalQuery
+	if params != nil {
+		if err := json.Unmarshal(params, &req); err != nil {
+			return nil, fmt.Errorf("invalid request: %w", err)
+		}
+	}
+
+	return h.approvals.GetApprovalStats(ctx, req.filter())
+}
+
 // Register registers all local approval handlers with the RPC server
 func (h *ApprovalHandlers) Register(server *Server) {
 	server.Register("createApproval", h.HandleCreateApproval)
 	server.Register("fetchApprovals", h.HandleFetchApprovals)
 	server.Register("getApproval", h.HandleGetApproval)
 	server.Register("sendDecision", h.HandleSendDecision)
+	server.Register("listApprovalRules", h.HandleListApprovalRules)
+	server.Register("revokeApprovalRule", h.HandleRevokeApprovalRule)
+	server.Register("queryApprovals", h.HandleQueryApprovals)
+	server.Register("getApprovalStats", h.HandleGetApprovalStats)
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sort"
+	"strings"
 	"time"
 
 	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
@@ -57,6 +58,16 @@ type LaunchSessionRequest struct {
 	Verbose                           bool                  `json:"verbose,omitempty"`
 	DangerouslySkipPermissions        bool                  `json:"dangerously_skip_permissions,omitempty"`
 	DangerouslySkipPermissionsTimeout *int64                `json:"dangerously_skip_permissions_timeout,omitempty"`
+	InputFormat                       string                `json:"input_format,omitempty"` // "stream-json" keeps the process open for sendMessage
+	ResourceLimits                    *ResourceLimits       `json:"resource_limits,omitempty"`
+	FallbackModel                     string                `json:"fallback_model,omitempty"`
+	PermissionMode                    string                `json:"permission_mode,omitempty"` // default, acceptEdits or plan
+	Settings                          string                `json:"settings,omitempty"`        // Settings file path or JSON
+	SettingSources                    []string              `json:"setting_sources,omitempty"` // user, project and/or local
+	ExtraArgs                         []string              `json:"extra_args,omitempty"`      // Additional claude CLI arguments
+	OutputSchema                      json.RawMessage       `json:"output_schema,omitempty"`   // JSON Schema the final result must match
+	OutputSchemaRepairTurns           int                   `json:"output_schema_repair_turns,omitempty"`
+	ApprovalPolicy                    json.RawMessage       `json:"approval_policy,omitempty"` // Session-level approval policy rules
 }
 
 // LaunchSessionResponse is the response for launching a new session
@@ -77,27 +88,47 @@ func (h *SessionHandlers) HandleLaunchSession(ctx context.Context, params json.R
 		return nil, fmt.Errorf("query is required")
 	}
 
+	inputFormat, err := parseInputFormat(req.InputFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := parseResourceLimits(req.ResourceLimits)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build session config with daemon-level settings
 	config := session.LaunchSessionConfig{
 		SessionConfig: claudecode.SessionConfig{
 			Query: req.Query,
 			// Title:                req.Title, // TODO: Title field not available in claudecode.SessionConfig
-			MCPConfig:             req.MCPConfig,
-			PermissionPromptTool:  req.PermissionPromptTool,
-			WorkingDir:            req.WorkingDir,
-			MaxTurns:              req.MaxTurns,
-			SystemPrompt:          req.SystemPrompt,
-			AppendSystemPrompt:    req.AppendSystemPrompt,
-			AllowedTools:          req.AllowedTools,
-			DisallowedTools:       req.DisallowedTools,
-			AdditionalDirectories: req.AdditionalDirectories,
-			CustomInstructions:    req.CustomInstructions,
-			Verbose:               req.Verbose,
-			OutputFormat:          claudecode.OutputStreamJSON, // Always use streaming JSON for monitoring
+			MCPConfig:               req.MCPConfig,
+			PermissionPromptTool:    req.PermissionPromptTool,
+			WorkingDir:              req.WorkingDir,
+			MaxTurns:                req.MaxTurns,
+			SystemPrompt:            req.SystemPrompt,
+			AppendSystemPrompt:      req.AppendSystemPrompt,
+			AllowedTools:            req.AllowedTools,
+			DisallowedTools:         req.DisallowedTools,
+			AdditionalDirectories:   req.AdditionalDirectories,
+			CustomInstructions:      req.CustomInstructions,
+			Verbose:                 req.Verbose,
+			OutputFormat:            claudecode.OutputStreamJSON, // Always use streaming JSON for monitoring
+			InputFormat:             inputFormat,
+			Limits:                  limits,
+			FallbackModel:           claudecode.Model(req.FallbackModel),
+			PermissionMode:          claudecode.PermissionMode(req.PermissionMode),
+			Settings:                req.Settings,
+			SettingSources:          parseSettingSources(req.SettingSources),
+			ExtraArgs:               req.ExtraArgs,
+			OutputSchema:            req.OutputSchema,
+			OutputSchemaRepairTurns: req.OutputSchemaRepairTurns,
 		},
 		// Daemon-level settings (not passed to Claude Code)
 		DangerouslySkipPermissions:        req.DangerouslySkipPermissions,
 		DangerouslySkipPermissionsTimeout: req.DangerouslySkipPermissionsTimeout,
+		ApprovalPolicy:                    req.ApprovalPolicy,
 	}
 
 	// Parse model if provided
@@ -107,8 +138,15 @@ func (h *SessionHandlers) HandleLaunchSession(ctx context.Context, params json.R
 			config.Model = claudecode.ModelOpus
 		case "sonnet":
 			config.Model = claudecode.ModelSonnet
+		case "haiku":
+			config.Model = claudecode.ModelHaiku
+		case "opusplan":
+			config.Model = claudecode.ModelOpusPlan
 		default:
-			// Let Claude decide the default
+			// Full model names are passed through; anything else lets Claude decide the default
+			if strings.HasPrefix(req.Model, "claude-") {
+				config.Model = claudecode.Model(req.Model)
+			}
 		}
 	}
 
@@ -268,6 +306,18 @@ func (h *SessionHandlers) HandleGetConversation(ctx context.Context, params json
 			IsCompleted:       event.IsCompleted,
 			ApprovalStatus:    event.ApprovalStatus,
 			ApprovalID:        event.ApprovalID,
+
+			UpdatedToolInputJSON: event.UpdatedToolInputJSON,
+		}
+		for _, attachment := range event.Attachments {
+			rpcEvents[i].Attachments = append(rpcEvents[i].Attachments, Attachment{
+				BlockType:  attachment.BlockType,
+				SourceType: attachment.SourceType,
+				MediaType:  attachment.MediaType,
+				Data:       attachment.Data,
+				URL:        attachment.URL,
+				Title:      attachment.Title,
+			})
 		}
 	}
 
@@ -360,6 +410,7 @@ func (h *SessionHandlers) HandleGetSessionState(ctx context.Context, params json
 		CreatedAt:                  session.CreatedAt.Format(time.RFC3339),
 		LastActivityAt:             session.LastActivityAt.Format(time.RFC3339),
 		ErrorMessage:               session.ErrorMessage,
+		FailureReason:              session.FailureReason,
 		AutoAcceptEdits:            session.AutoAcceptEdits,
 		DangerouslySkipPermissions: session.DangerouslySkipPermissions,
 		Archived:                   session.Archived,
@@ -372,6 +423,9 @@ func (h *SessionHandlers) HandleGetSessionState(ctx context.Context, params json
 	if session.CompletedAt != nil {
 		state.CompletedAt = session.CompletedAt.Format(time.RFC3339)
 	}
+	if session.StructuredOutput != "" {
+		state.StructuredOutput = json.RawMessage(session.StructuredOutput)
+	}
 	if session.CostUSD != nil {
 		state.CostUSD = *session.CostUSD
 	}
@@ -416,6 +470,16 @@ func (h *SessionHandlers) HandleContinueSession(ctx context.Context, params json
 		return nil, fmt.Errorf("query is required")
 	}
 
+	inputFormat, err := parseInputFormat(req.InputFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := parseResourceLimits(req.ResourceLimits)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build session config for manager
 	config := session.ContinueSessionConfig{
 		ParentSessionID:       req.SessionID,
@@ -432,6 +496,14 @@ func (h *SessionHandlers) HandleContinueSession(ctx context.Context, params json
 		ProxyBaseURL:          req.ProxyBaseURL,
 		ProxyModelOverride:    req.ProxyModelOverride,
 		ProxyAPIKey:           req.ProxyAPIKey,
+		InputFormat:           inputFormat,
+		Limits:                limits,
+		FallbackModel:         claudecode.Model(req.FallbackModel),
+		PermissionMode:        claudecode.PermissionMode(req.PermissionMode),
+		Settings:              req.Settings,
+		SettingSources:        parseSettingSources(req.SettingSources),
+		ExtraArgs:             req.ExtraArgs,
+		ForkSession:           req.ForkSession,
 	}
 
 	// Parse MCP config if provided as JSON string
@@ -559,12 +631,12 @@ func (h *SessionHandlers) HandleUpdateSessionSettings(ctx context.Context, param
 					// For dangerously skip permissions, approve ALL tools
 					// For edit mode, only approve edit tools
 					if req.DangerouslySkipPermissions != nil && *req.DangerouslySkipPermissions {
-						err := h.approvalManager.ApproveToolCall(ctx, approval.ID, autoApproveComment)
+						err := h.approvalManager.ApproveToolCallWithInput(ctx, approval.ID, autoApproveComment, nil, store.ApprovalDecidedByAutoAccept)
 						if err != nil {
 							slog.Error("failed to auto-approve pending approval", "approval_id", approval.ID, "error", err)
 						}
 					} else if req.AutoAcceptEdits != nil && *req.AutoAcceptEdits && isEditTool(approval.ToolName) {
-						err := h.approvalManager.ApproveToolCall(ctx, approval.ID, autoApproveComment)
+						err := h.approvalManager.ApproveToolCallWithInput(ctx, approval.ID, autoApproveComment, nil, store.ApprovalDecidedByAutoAccept)
 						if err != nil {
 							slog.Error("failed to auto-approve pending approval", "approval_id", approval.ID, "error", err)
 						}
@@ -753,6 +825,107 @@ func (h *SessionHandlers) HandleBulkArchiveSessions(ctx context.Context, params
 	}, nil
 }
 
+// HandleSendMessage handles the SendMessage RPC method
+func (h *SessionHandlers) HandleSendMessage(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var req SendMessageRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	// Validate required fields
+	if req.SessionID == "" {
+		return nil, fmt.Errorf("session_id is required")
+	}
+	if req.Message == "" {
+		return nil, fmt.Errorf("message is required")
+	}
+
+	if err := h.manager.SendMessage(ctx, req.SessionID, req.Message); err != nil {
+		return nil, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return &SendMessageResponse{
+		Success:   true,
+		SessionID: req.SessionID,
+	}, nil
+}
+
+// HandleImportTranscripts handles the ImportTranscripts RPC method
+func (h *SessionHandlers) HandleImportTranscripts(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var req ImportTranscriptsRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	// Validate required fields
+	if req.ProjectDir == "" {
+		return nil, fmt.Errorf("project_dir is required")
+	}
+
+	result, err := h.manager.ImportTranscripts(ctx, session.ImportTranscriptsConfig{
+		ProjectDir:       req.ProjectDir,
+		ClaudeDir:        req.ClaudeDir,
+		ClaudeSessionIDs: req.ClaudeSessionIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import transcripts: %w", err)
+	}
+
+	imported := make([]ImportedSession, len(result.Imported))
+	for i, sess := range result.Imported {
+		imported[i] = ImportedSession{
+			SessionID:       sess.SessionID,
+			ClaudeSessionID: sess.ClaudeSessionID,
+			EventCount:      sess.EventCount,
+		}
+	}
+
+	return &ImportTranscriptsResponse{
+		Imported: imported,
+		Skipped:  result.Skipped,
+	}, nil
+}
+
+// parseInputFormat validates an input format from a request
+func parseInputFormat(format string) (claudecode.InputFormat, error) {
+	if err := claudecode.InputFormat(format).Validate(); err != nil {
+		return "", err
+	}
+	return claudecode.InputFormat(format), nil
+}
+
+// parseSettingSources converts setting source names; claudecode validates them at launch
+func parseSettingSources(names []string) []claudecode.SettingSource {
+	if len(names) == 0 {
+		return nil
+	}
+	sources := make([]claudecode.SettingSource, len(names))
+	for i, name := range names {
+		sources[i] = claudecode.SettingSource(name)
+	}
+	return sources
+}
+
+// parseResourceLimits converts requested resource limits, returning nil when none are set
+func parseResourceLimits(req *ResourceLimits) (*claudecode.ResourceLimits, error) {
+	if req == nil {
+		return nil, nil
+	}
+	limits := &claudecode.ResourceLimits{
+		CPUTime:   time.Duration(req.CPUTimeSeconds) * time.Second,
+		Memory:    req.MemoryBytes,
+		OpenFiles: req.OpenFiles,
+		WallClock: time.Duration(req.WallClockSeconds) * time.Second,
+	}
+	if err := limits.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid resource_limits: %w", err)
+	}
+	if limits.IsZero() {
+		return nil, nil
+	}
+	return limits, nil
+}
+
 // Register registers all session handlers with the RPC server
 func (h *SessionHandlers) Register(server *Server) {
 	server.Register("launchSession", h.HandleLaunchSession)
@@ -762,6 +935,8 @@ func (h *SessionHandlers) Register(server *Server) {
 	server.Register("getSessionState", h.HandleGetSessionState)
 	server.Register("continueSession", h.HandleContinueSession)
 	server.Register("interruptSession", h.HandleInterruptSession)
+	server.Register("sendMessage", h.HandleSendMessage)
+	server.Register("importTranscripts", h.HandleImportTranscripts)
 	server.Register("getSessionSnapshots", h.HandleGetSessionSnapshots)
 	server.Register("updateSessionSettings", h.HandleUpdateSessionSettings)
 	server.Register("updateSessionTitle", h.HandleUpdateSessionTitle)
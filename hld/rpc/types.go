@@ -1,5 +1,7 @@
 package rpc
 
+import "encoding/json"
+
 // HealthCheckRequest is the request for health check RPC
 type HealthCheckRequest struct{}
 
@@ -42,6 +44,22 @@ type ConversationEvent struct {
 	IsCompleted    bool   `json:"is_completed"`
 	ApprovalStatus string `json:"approval_status,omitempty"` // NULL, 'pending', 'approved', 'denied'
 	ApprovalID     string `json:"approval_id,omitempty"`
+
+	// Tool input as edited by the approver, when approved with changes
+	UpdatedToolInputJSON string `json:"updated_tool_input_json,omitempty"`
+
+	// Non-text content such as images and documents
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment is a non-text content block of a conversation event
+type Attachment struct {
+	BlockType  string `json:"block_type"` // image, document, redacted_thinking, web_search_result
+	SourceType string `json:"source_type,omitempty"`
+	MediaType  string `json:"media_type,omitempty"`
+	Data       string `json:"data,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Title      string `json:"title,omitempty"`
 }
 
 // GetConversationResponse is the response for fetching conversation history
@@ -56,33 +74,35 @@ type GetSessionStateRequest struct {
 
 // SessionState represents the current state of a session
 type SessionState struct {
-	ID                                  string  `json:"id"`
-	RunID                               string  `json:"run_id"`
-	ClaudeSessionID                     string  `json:"claude_session_id,omitempty"`
-	ParentSessionID                     string  `json:"parent_session_id,omitempty"`
-	Status                              string  `json:"status"` // starting, running, completed, failed, waiting_input
-	Query                               string  `json:"query"`
-	Summary                             string  `json:"summary"`
-	Title                               string  `json:"title"`
-	Model                               string  `json:"model,omitempty"`
-	ModelID                             string  `json:"model_id,omitempty"`
-	WorkingDir                          string  `json:"working_dir,omitempty"`
-	CreatedAt                           string  `json:"created_at"`
-	LastActivityAt                      string  `json:"last_activity_at"`
-	CompletedAt                         string  `json:"completed_at,omitempty"`
-	ErrorMessage                        string  `json:"error_message,omitempty"`
-	CostUSD                             float64 `json:"cost_usd,omitempty"`
-	InputTokens                         int     `json:"input_tokens,omitempty"`
-	OutputTokens                        int     `json:"output_tokens,omitempty"`
-	CacheCreationInputTokens            int     `json:"cache_creation_input_tokens,omitempty"`
-	CacheReadInputTokens                int     `json:"cache_read_input_tokens,omitempty"`
-	EffectiveContextTokens              int     `json:"effective_context_tokens,omitempty"`
-	ContextLimit                        int     `json:"context_limit,omitempty"`
-	DurationMS                          int     `json:"duration_ms,omitempty"`
-	AutoAcceptEdits                     bool    `json:"auto_accept_edits"`
-	DangerouslySkipPermissions          bool    `json:"dangerously_skip_permissions"`
-	DangerouslySkipPermissionsExpiresAt string  `json:"dangerously_skip_permissions_expires_at,omitempty"`
-	Archived                            bool    `json:"archived"`
+	ID                                  string          `json:"id"`
+	RunID                               string          `json:"run_id"`
+	ClaudeSessionID                     string          `json:"claude_session_id,omitempty"`
+	ParentSessionID                     string          `json:"parent_session_id,omitempty"`
+	Status                              string          `json:"status"` // starting, running, completed, failed, waiting_input
+	Query                               string          `json:"query"`
+	Summary                             string          `json:"summary"`
+	Title                               string          `json:"title"`
+	Model                               string          `json:"model,omitempty"`
+	ModelID                             string          `json:"model_id,omitempty"`
+	WorkingDir                          string          `json:"working_dir,omitempty"`
+	CreatedAt                           string          `json:"created_at"`
+	LastActivityAt                      string          `json:"last_activity_at"`
+	CompletedAt                         string          `json:"completed_at,omitempty"`
+	ErrorMessage                        string          `json:"error_message,omitempty"`
+	FailureReason                       string          `json:"failure_reason,omitempty"` // e.g. "resource_limit"
+	StructuredOutput                    json.RawMessage `json:"structured_output,omitempty"`
+	CostUSD                             float64         `json:"cost_usd,omitempty"`
+	InputTokens                         int             `json:"input_tokens,omitempty"`
+	OutputTokens                        int             `json:"output_tokens,omitempty"`
+	CacheCreationInputTokens            int             `json:"cache_creation_input_tokens,omitempty"`
+	CacheReadInputTokens                int             `json:"cache_read_input_tokens,omitempty"`
+	EffectiveContextTokens              int             `json:"effective_context_tokens,omitempty"`
+	ContextLimit                        int             `json:"context_limit,omitempty"`
+	DurationMS                          int             `json:"duration_ms,omitempty"`
+	AutoAcceptEdits                     bool            `json:"auto_accept_edits"`
+	DangerouslySkipPermissions          bool            `json:"dangerously_skip_permissions"`
+	DangerouslySkipPermissionsExpiresAt string          `json:"dangerously_skip_permissions_expires_at,omitempty"`
+	Archived                            bool            `json:"archived"`
 }
 
 // GetSessionStateResponse is the response for fetching session state
@@ -110,21 +130,38 @@ type FileSnapshotInfo struct {
 
 // ContinueSessionRequest is the request for continuing an existing session
 type ContinueSessionRequest struct {
-	SessionID             string   `json:"session_id"`                       // The session to continue (required)
-	Query                 string   `json:"query"`                            // The new query/message to send (required)
-	SystemPrompt          string   `json:"system_prompt,omitempty"`          // Override system prompt
-	AppendSystemPrompt    string   `json:"append_system_prompt,omitempty"`   // Append to system prompt
-	MCPConfig             string   `json:"mcp_config,omitempty"`             // JSON string of MCP config (to avoid import cycle)
-	PermissionPromptTool  string   `json:"permission_prompt_tool,omitempty"` // MCP tool for permission prompts
-	AllowedTools          []string `json:"allowed_tools,omitempty"`          // Allowed tools list
-	DisallowedTools       []string `json:"disallowed_tools,omitempty"`       // Disallowed tools list
-	AdditionalDirectories []string `json:"additional_directories,omitempty"` // Additional directories list
-	CustomInstructions    string   `json:"custom_instructions,omitempty"`    // Custom instructions
-	MaxTurns              int      `json:"max_turns,omitempty"`              // Max conversation turns
-	ProxyEnabled          bool     `json:"proxy_enabled,omitempty"`          // Whether proxy is enabled
-	ProxyBaseURL          string   `json:"proxy_base_url,omitempty"`         // Proxy base URL
-	ProxyModelOverride    string   `json:"proxy_model_override,omitempty"`   // Model to use with proxy
-	ProxyAPIKey           string   `json:"proxy_api_key,omitempty"`          // API key for proxy service
+	SessionID             string          `json:"session_id"`                       // The session to continue (required)
+	Query                 string          `json:"query"`                            // The new query/message to send (required)
+	SystemPrompt          string          `json:"system_prompt,omitempty"`          // Override system prompt
+	AppendSystemPrompt    string          `json:"append_system_prompt,omitempty"`   // Append to system prompt
+	MCPConfig             string          `json:"mcp_config,omitempty"`             // JSON string of MCP config (to avoid import cycle)
+	PermissionPromptTool  string          `json:"permission_prompt_tool,omitempty"` // MCP tool for permission prompts
+	AllowedTools          []string        `json:"allowed_tools,omitempty"`          // Allowed tools list
+	DisallowedTools       []string        `json:"disallowed_tools,omitempty"`       // Disallowed tools list
+	AdditionalDirectories []string        `json:"additional_directories,omitempty"` // Additional directories list
+	CustomInstructions    string          `json:"custom_instructions,omitempty"`    // Custom instructions
+	MaxTurns              int             `json:"max_turns,omitempty"`              // Max conversation turns
+	ProxyEnabled          bool            `json:"proxy_enabled,omitempty"`          // Whether proxy is enabled
+	ProxyBaseURL          string          `json:"proxy_base_url,omitempty"`         // Proxy base URL
+	ProxyModelOverride    string          `json:"proxy_model_override,omitempty"`   // Model to use with proxy
+	ProxyAPIKey           string          `json:"proxy_api_key,omitempty"`          // API key for proxy service
+	InputFormat           string          `json:"input_format,omitempty"`           // "stream-json" keeps the process open for sendMessage
+	ResourceLimits        *ResourceLimits `json:"resource_limits,omitempty"`        // Override the parent session's resource limits
+	FallbackModel         string          `json:"fallback_model,omitempty"`         // Override fallback model
+	PermissionMode        string          `json:"permission_mode,omitempty"`        // Override permission mode
+	Settings              string          `json:"settings,omitempty"`               // Override settings file or JSON
+	SettingSources        []string        `json:"setting_sources,omitempty"`        // Override setting sources
+	ExtraArgs             []string        `json:"extra_args,omitempty"`             // Override extra CLI arguments
+	ForkSession           bool            `json:"fork_session,omitempty"`           // Resume under a new claude session ID
+}
+
+// ResourceLimits bounds the resources a session's processes may use.
+// Zero values mean unlimited.
+type ResourceLimits struct {
+	CPUTimeSeconds   int64  `json:"cpu_time_seconds,omitempty"`   // Total CPU time
+	MemoryBytes      int64  `json:"memory_bytes,omitempty"`       // Resident memory
+	OpenFiles        uint64 `json:"open_files,omitempty"`         // Open file descriptors per process
+	WallClockSeconds int64  `json:"wall_clock_seconds,omitempty"` // Time until the session is stopped
 }
 
 // ContinueSessionResponse is the response for continuing a session
@@ -147,6 +184,38 @@ type InterruptSessionResponse struct {
 	Status    string `json:"status"`
 }
 
+// SendMessageRequest is the request for sending a message to a running session
+type SendMessageRequest struct {
+	SessionID string `json:"session_id"`
+	Message   string `json:"message"`
+}
+
+// SendMessageResponse is the response for sending a message to a running session
+type SendMessageResponse struct {
+	Success   bool   `json:"success"`
+	SessionID string `json:"session_id"`
+}
+
+// ImportTranscriptsRequest is the request for importing Claude transcripts from disk
+type ImportTranscriptsRequest struct {
+	ProjectDir       string   `json:"project_dir"`
+	ClaudeDir        string   `json:"claude_dir,omitempty"`         // Defaults to $CLAUDE_CONFIG_DIR or ~/.claude
+	ClaudeSessionIDs []string `json:"claude_session_ids,omitempty"` // Only import these transcripts
+}
+
+// ImportTranscriptsResponse is the response for importing Claude transcripts
+type ImportTranscriptsResponse struct {
+	Imported []ImportedSession `json:"imported"`
+	Skipped  []string          `json:"skipped"` // Claude session IDs already in the store
+}
+
+// ImportedSession describes a session created from a Claude transcript
+type ImportedSession struct {
+	SessionID       string `json:"session_id"`
+	ClaudeSessionID string `json:"claude_session_id"`
+	EventCount      int    `json:"event_count"`
+}
+
 // UpdateSessionSettingsRequest is the request for updating session settings
 type UpdateSessionSettingsRequest struct {
 	SessionID                           string `json:"session_id"`
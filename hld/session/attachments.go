@@ -0,0 +1,55 @@
+package session
+
+import (
+	"strings"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// attachmentFromContent converts a non-text content block into a store attachment
+func attachmentFromContent(content claudecode.Content) store.Attachment {
+	attachment := store.Attachment{
+		BlockType: content.Type,
+		Title:     content.Title,
+		URL:       content.URL,
+		Data:      content.Data,
+	}
+	if content.Source != nil {
+		attachment.SourceType = content.Source.Type
+		attachment.MediaType = content.Source.MediaType
+		attachment.Data = content.Source.Data
+		if content.Source.URL != "" {
+			attachment.URL = content.Source.URL
+		}
+	}
+	return attachment
+}
+
+// attachmentsFromBlocks converts the non-text blocks of a tool result
+func attachmentsFromBlocks(blocks []claudecode.Content) []store.Attachment {
+	if len(blocks) == 0 {
+		return nil
+	}
+	attachments := make([]store.Attachment, len(blocks))
+	for i, block := range blocks {
+		attachments[i] = attachmentFromContent(block)
+	}
+	return attachments
+}
+
+// webSearchSummary renders web search results as text, one "title - url" line per result
+func webSearchSummary(blocks []claudecode.Content) string {
+	var lines []string
+	for _, block := range blocks {
+		switch {
+		case block.URL != "" && block.Title != "":
+			lines = append(lines, block.Title+" - "+block.URL)
+		case block.URL != "":
+			lines = append(lines, block.URL)
+		case block.Type != "":
+			lines = append(lines, block.Type)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,104 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// decodeStreamEvent parses a stream-json line the way the client does
+func decodeStreamEvent(t *testing.T, line string) claudecode.StreamEvent {
+	t.Helper()
+	var event claudecode.StreamEvent
+	if err := json.Unmarshal([]byte(line), &event); err != nil {
+		t.Fatalf("failed to unmarshal event: %v", err)
+	}
+	typed, err := claudecode.Decode([]byte(line))
+	if err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	event.Typed = typed
+	return event
+}
+
+func TestProcessStreamEvent_RichContentBlocks(t *testing.T) {
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = sqliteStore.Close() }()
+
+	manager, err := NewManager(nil, sqliteStore, "")
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := sqliteStore.CreateSession(ctx, &store.Session{
+		ID:              "sess-rich",
+		RunID:           "run-rich",
+		ClaudeSessionID: "claude-rich",
+		Query:           "take a screenshot",
+		Status:          store.SessionStatusRunning,
+	}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	lines := []string{
+		`{"type":"assistant","session_id":"claude-rich","message":{"id":"m1","role":"assistant","content":[
+			{"type":"redacted_thinking","data":"EmwKAhgB"},
+			{"type":"tool_use","id":"toolu_1","name":"mcp__browser__screenshot","input":{}},
+			{"type":"server_tool_use","id":"srvtoolu_1","name":"web_search","input":{"query":"hld"}},
+			{"type":"web_search_tool_result","tool_use_id":"srvtoolu_1","content":[
+				{"type":"web_search_result","title":"HumanLayer","url":"https://humanlayer.dev"}
+			]}
+		]}}`,
+		`{"type":"user","session_id":"claude-rich","message":{"role":"user","content":[
+			{"type":"tool_result","tool_use_id":"toolu_1","content":[
+				{"type":"text","text":"Captured page"},
+				{"type":"image","source":{"type":"base64","media_type":"image/png","data":"iVBORw0KGgo="}}
+			]}
+		]}}`,
+	}
+	for _, line := range lines {
+		if err := manager.processStreamEvent(ctx, "sess-rich", "claude-rich", decodeStreamEvent(t, line)); err != nil {
+			t.Fatalf("failed to process event: %v", err)
+		}
+	}
+
+	events, err := sqliteStore.GetSessionConversation(ctx, "sess-rich")
+	if err != nil {
+		t.Fatalf("failed to get conversation: %v", err)
+	}
+	if len(events) != 5 {
+		t.Fatalf("expected 5 events, got %d", len(events))
+	}
+
+	thinking := events[0]
+	if thinking.EventType != store.EventTypeThinking || len(thinking.Attachments) != 1 ||
+		thinking.Attachments[0].BlockType != claudecode.ContentTypeRedactedThinking || thinking.Attachments[0].Data != "EmwKAhgB" {
+		t.Errorf("unexpected redacted thinking event: %+v", thinking)
+	}
+
+	serverCall := events[2]
+	if serverCall.EventType != store.EventTypeToolCall || serverCall.ToolName != "web_search" || !serverCall.IsCompleted {
+		t.Errorf("expected completed web_search tool call, got %+v", serverCall)
+	}
+
+	searchResult := events[3]
+	if searchResult.ToolResultForID != "srvtoolu_1" || searchResult.ToolResultContent != "HumanLayer - https://humanlayer.dev" ||
+		len(searchResult.Attachments) != 1 || searchResult.Attachments[0].URL != "https://humanlayer.dev" {
+		t.Errorf("unexpected web search result event: %+v", searchResult)
+	}
+
+	screenshot := events[4]
+	if screenshot.EventType != store.EventTypeToolResult || screenshot.ToolResultContent != "Captured page" {
+		t.Errorf("unexpected tool result event: %+v", screenshot)
+	}
+	if len(screenshot.Attachments) != 1 || screenshot.Attachments[0].MediaType != "image/png" || screenshot.Attachments[0].Data != "iVBORw0KGgo=" {
+		t.Errorf("expected screenshot attachment, got %+v", screenshot.Attachments)
+	}
+}
@@ -1,6 +1,8 @@
 package session
 
 import (
+	"context"
+
 	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
 )
 
@@ -8,20 +10,34 @@ import (
 //
 //go:generate mockgen -source=claudecode_wrapper.go -destination=mock_claudecode.go -package=session ClaudeSession
 type ClaudeSession interface {
-	// Interrupt sends a SIGINT signal to the session process
+	// Interrupt sends a SIGINT signal to the session's process group
 	Interrupt() error
 
-	// Kill forcefully terminates the session process
+	// Kill forcefully terminates the session's process group
 	Kill() error
 
+	// Terminate escalates SIGINT, SIGTERM and SIGKILL to the session's process group
+	// (continuing from an earlier Interrupt) and returns once the process has exited
+	Terminate() error
+
 	// GetID returns the session ID
 	GetID() string
 
 	// Wait blocks until the session completes and returns the result
 	Wait() (*claudecode.Result, error)
 
+	// WaitContext blocks until the session completes or ctx ends.
+	// If ctx ends first, the process is interrupted, then killed after a grace period.
+	WaitContext(ctx context.Context) (*claudecode.Result, error)
+
 	// GetEvents returns the events channel for streaming
 	GetEvents() <-chan claudecode.StreamEvent
+
+	// SendMessage writes an additional user turn (stream-json input only)
+	SendMessage(ctx context.Context, text string) error
+
+	// CloseInput closes stdin so claude exits after the current turn (stream-json input only)
+	CloseInput() error
 }
 
 // ClaudeSessionWrapper wraps a real claudecode.Session
@@ -44,6 +60,11 @@ func (w *ClaudeSessionWrapper) Kill() error {
 	return w.session.Kill()
 }
 
+// Terminate implements the ClaudeSession interface
+func (w *ClaudeSessionWrapper) Terminate() error {
+	return w.session.Terminate()
+}
+
 // GetID implements the ClaudeSession interface
 func (w *ClaudeSessionWrapper) GetID() string {
 	return w.session.ID
@@ -54,10 +75,25 @@ func (w *ClaudeSessionWrapper) Wait() (*claudecode.Result, error) {
 	return w.session.Wait()
 }
 
+// WaitContext implements the ClaudeSession interface
+func (w *ClaudeSessionWrapper) WaitContext(ctx context.Context) (*claudecode.Result, error) {
+	return w.session.WaitContext(ctx)
+}
+
 // GetEvents implements the ClaudeSession interface
 func (w *ClaudeSessionWrapper) GetEvents() <-chan claudecode.StreamEvent {
 	return w.session.Events
 }
 
+// SendMessage implements the ClaudeSession interface
+func (w *ClaudeSessionWrapper) SendMessage(ctx context.Context, text string) error {
+	return w.session.SendMessage(ctx, text)
+}
+
+// CloseInput implements the ClaudeSession interface
+func (w *ClaudeSessionWrapper) CloseInput() error {
+	return w.session.CloseInput()
+}
+
 // Ensure ClaudeSessionWrapper implements ClaudeSession
 var _ ClaudeSession = (*ClaudeSessionWrapper)(nil)
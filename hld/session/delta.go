@@ -0,0 +1,197 @@
+package session
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/bus"
+)
+
+// deltaFlushInterval bounds how often conversation_delta events are published
+// per session; deltas arriving in between are merged
+const deltaFlushInterval = 50 * time.Millisecond
+
+// deltaBlock identifies a content block of a message being generated
+type deltaBlock struct {
+	messageID string
+	index     int
+}
+
+// deltaBlockInfo describes a content block from its content_block_start event
+type deltaBlockInfo struct {
+	blockType string // text, thinking or tool_use
+	toolID    string
+	toolName  string
+}
+
+// pendingDelta is content received for a block but not yet published
+type pendingDelta struct {
+	block           deltaBlock
+	info            deltaBlockInfo
+	deltaType       string
+	parentToolUseID string
+	content         strings.Builder
+}
+
+// deltaCoalescer turns partial message events into conversation_delta bus
+// events, merging deltas that arrive within deltaFlushInterval of each other
+type deltaCoalescer struct {
+	eventBus        bus.EventBus
+	sessionID       string
+	runID           string
+	claudeSessionID string
+
+	mu        sync.Mutex
+	messageID string
+	blocks    map[deltaBlock]deltaBlockInfo
+	pending   []*pendingDelta
+	lastFlush time.Time
+	timer     *time.Timer
+	closed    bool
+}
+
+func newDeltaCoalescer(eventBus bus.EventBus, sessionID, runID string) *deltaCoalescer {
+	return &deltaCoalescer{
+		eventBus:  eventBus,
+		sessionID: sessionID,
+		runID:     runID,
+		blocks:    make(map[deltaBlock]deltaBlockInfo),
+	}
+}
+
+// add records a partial message event, publishing deltas when they are due
+func (c *deltaCoalescer) add(claudeSessionID string, msg *claudecode.PartialMessage) {
+	if c.eventBus == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	if claudeSessionID != "" {
+		c.claudeSessionID = claudeSessionID
+	}
+
+	event := msg.Event
+	switch event.Type {
+	case claudecode.PartialMessageStart:
+		if event.Message != nil {
+			c.messageID = event.Message.ID
+		}
+
+	case claudecode.PartialContentBlockStart:
+		if event.ContentBlock != nil {
+			c.blocks[deltaBlock{c.messageID, event.Index}] = deltaBlockInfo{
+				blockType: event.ContentBlock.Type,
+				toolID:    event.ContentBlock.ID,
+				toolName:  event.ContentBlock.Name,
+			}
+		}
+
+	case claudecode.PartialContentBlockDelta:
+		if event.Delta == nil || event.Delta.Content() == "" {
+			return
+		}
+		block := deltaBlock{c.messageID, event.Index}
+		pending := c.pendingFor(block, event.Delta.Type, msg.ParentToolUseID)
+		pending.content.WriteString(event.Delta.Content())
+		c.schedule()
+
+	case claudecode.PartialContentBlockStop:
+		// Publish what is left before the complete block is stored
+		c.flushLocked()
+		delete(c.blocks, deltaBlock{c.messageID, event.Index})
+
+	case claudecode.PartialMessageStop:
+		c.flushLocked()
+	}
+}
+
+// pendingFor returns the pending delta for block, starting a new one when the
+// most recent pending delta belongs to another block
+func (c *deltaCoalescer) pendingFor(block deltaBlock, deltaType, parentToolUseID string) *pendingDelta {
+	if n := len(c.pending); n > 0 {
+		if last := c.pending[n-1]; last.block == block && last.deltaType == deltaType {
+			return last
+		}
+	}
+	pending := &pendingDelta{
+		block:           block,
+		info:            c.blocks[block],
+		deltaType:       deltaType,
+		parentToolUseID: parentToolUseID,
+	}
+	c.pending = append(c.pending, pending)
+	return pending
+}
+
+// schedule publishes pending deltas now if the interval has passed, or arms
+// a timer to publish them once it does
+func (c *deltaCoalescer) schedule() {
+	wait := deltaFlushInterval - time.Since(c.lastFlush)
+	if wait <= 0 {
+		c.flushLocked()
+		return
+	}
+	if c.timer == nil {
+		c.timer = time.AfterFunc(wait, c.flush)
+	}
+}
+
+func (c *deltaCoalescer) flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.timer = nil
+	if !c.closed {
+		c.flushLocked()
+	}
+}
+
+// flushLocked publishes all pending deltas in order. c.mu must be held.
+func (c *deltaCoalescer) flushLocked() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	if len(c.pending) == 0 {
+		return
+	}
+
+	for _, pending := range c.pending {
+		c.eventBus.Publish(bus.Event{
+			Type: bus.EventConversationDelta,
+			Data: map[string]interface{}{
+				"session_id":         c.sessionID,
+				"run_id":             c.runID,
+				"claude_session_id":  c.claudeSessionID,
+				"message_id":         pending.block.messageID,
+				"index":              pending.block.index,
+				"block_type":         pending.info.blockType,
+				"delta_type":         pending.deltaType,
+				"content":            pending.content.String(),
+				"tool_id":            pending.info.toolID,
+				"tool_name":          pending.info.toolName,
+				"parent_tool_use_id": pending.parentToolUseID,
+			},
+		})
+	}
+	c.pending = nil
+	c.lastFlush = time.Now()
+}
+
+// close publishes any remaining deltas and stops further publishing
+func (c *deltaCoalescer) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	if c.eventBus != nil {
+		c.flushLocked()
+	}
+	c.closed = true
+}
@@ -0,0 +1,66 @@
+package session
+
+import (
+	"context"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+)
+
+// maxEventBatch caps how many events are stored in one transaction
+const maxEventBatch = 256
+
+// maxQueuedEvents bounds the events waiting to be stored. Once the store falls
+// this far behind, push blocks, the session's Events channel fills up and the
+// Claude client's overflow policy decides what happens next.
+const maxQueuedEvents = 4096
+
+// eventQueue is a bounded FIFO between the goroutine reading a Claude
+// session's events and the goroutine writing them to the store, so database
+// latency does not back up into the claude process until the queue is full
+type eventQueue struct {
+	events chan claudecode.StreamEvent
+}
+
+func newEventQueue() *eventQueue {
+	return &eventQueue{events: make(chan claudecode.StreamEvent, maxQueuedEvents)}
+}
+
+// push appends an event, blocking while the queue is full. It returns false
+// if ctx is cancelled first.
+func (q *eventQueue) push(ctx context.Context, event claudecode.StreamEvent) bool {
+	select {
+	case q.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// close marks the end of the stream; queued events are still returned by next.
+// It must not be called concurrently with push.
+func (q *eventQueue) close() {
+	close(q.events)
+}
+
+// next blocks until events are queued and returns up to maxEventBatch of them.
+// It returns nil once the queue is closed and drained.
+func (q *eventQueue) next() []claudecode.StreamEvent {
+	event, ok := <-q.events
+	if !ok {
+		return nil
+	}
+
+	batch := []claudecode.StreamEvent{event}
+	for len(batch) < maxEventBatch {
+		select {
+		case event, ok := <-q.events:
+			if !ok {
+				return batch
+			}
+			batch = append(batch, event)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
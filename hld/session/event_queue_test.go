@@ -0,0 +1,160 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/store"
+	"go.uber.org/mock/gomock"
+)
+
+// slowRawEventStore delays every raw event batch to simulate a busy database
+type slowRawEventStore struct {
+	store.ConversationStore
+	delay   time.Duration
+	batches atomic.Int32
+	events  atomic.Int32
+}
+
+func (s *slowRawEventStore) StoreRawEvents(ctx context.Context, sessionID string, eventJSON []string) error {
+	time.Sleep(s.delay)
+	s.batches.Add(1)
+	s.events.Add(int32(len(eventJSON)))
+	return s.ConversationStore.StoreRawEvents(ctx, sessionID, eventJSON)
+}
+
+func TestEventQueue_BatchesInOrder(t *testing.T) {
+	q := newEventQueue()
+	for i := 0; i < maxEventBatch+10; i++ {
+		q.push(context.Background(), claudecode.StreamEvent{Type: "assistant", SessionID: fmt.Sprint(i)})
+	}
+	q.close()
+
+	var seen int
+	for batch := q.next(); batch != nil; batch = q.next() {
+		if len(batch) > maxEventBatch {
+			t.Fatalf("batch of %d exceeds limit %d", len(batch), maxEventBatch)
+		}
+		for _, event := range batch {
+			if event.SessionID != fmt.Sprint(seen) {
+				t.Fatalf("expected event %d, got %s", seen, event.SessionID)
+			}
+			seen++
+		}
+	}
+	if seen != maxEventBatch+10 {
+		t.Errorf("expected %d events, got %d", maxEventBatch+10, seen)
+	}
+}
+
+func TestMonitorSession_SlowStoreDoesNotBlockEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = sqliteStore.Close() }()
+	slow := &slowRawEventStore{ConversationStore: sqliteStore, delay: 50 * time.Millisecond}
+
+	manager, err := NewManager(bus.NewEventBus(), slow, "")
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := sqliteStore.CreateSession(ctx, &store.Session{
+		ID:             "sess-slow",
+		RunID:          "run-slow",
+		Query:          "flood",
+		Status:         store.SessionStatusRunning,
+		CreatedAt:      time.Now(),
+		LastActivityAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	// Unbuffered, so every send waits for monitorSession to take the event
+	events := make(chan claudecode.StreamEvent)
+	claudeSession := NewMockClaudeSession(ctrl)
+	claudeSession.EXPECT().GetEvents().Return(events).AnyTimes()
+	claudeSession.EXPECT().WaitContext(gomock.Any()).Return(&claudecode.Result{NumTurns: 1}, nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		manager.monitorSession(ctx, "sess-slow", "run-slow", claudeSession, time.Now(), claudecode.SessionConfig{})
+	}()
+
+	const count = 500
+	start := time.Now()
+	for i := 0; i < count; i++ {
+		events <- claudecode.StreamEvent{
+			Type:      "assistant",
+			SessionID: "claude-slow",
+			Message: &claudecode.Message{
+				ID:      fmt.Sprintf("msg-%d", i),
+				Role:    "assistant",
+				Content: []claudecode.Content{{Type: "text", Text: "ok"}},
+			},
+		}
+	}
+	close(events)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("delivering events took %v; the store is throttling the reader", elapsed)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("monitorSession did not finish")
+	}
+
+	if got := slow.events.Load(); got != count {
+		t.Errorf("expected %d raw events stored, got %d", count, got)
+	}
+	if batches := slow.batches.Load(); batches >= count/2 {
+		t.Errorf("expected raw events to be batched, got %d batches for %d events", batches, count)
+	}
+
+	sess, err := sqliteStore.GetSession(ctx, "sess-slow")
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	if sess.Status != store.SessionStatusCompleted {
+		t.Errorf("expected completed status, got %s", sess.Status)
+	}
+	if sess.ClaudeSessionID != "claude-slow" {
+		t.Errorf("expected claude session ID to be captured, got %q", sess.ClaudeSessionID)
+	}
+}
+
+func TestEventQueue_BlocksWhenFull(t *testing.T) {
+	q := newEventQueue()
+	for i := 0; i < maxQueuedEvents; i++ {
+		if !q.push(context.Background(), claudecode.StreamEvent{Type: "assistant"}) {
+			t.Fatalf("push %d failed below the bound", i)
+		}
+	}
+
+	// A full queue blocks until the context ends
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if q.push(ctx, claudecode.StreamEvent{Type: "assistant"}) {
+		t.Fatal("expected push to a full queue to block")
+	}
+
+	// Taking a batch makes room again
+	if batch := q.next(); len(batch) != maxEventBatch {
+		t.Fatalf("expected a batch of %d, got %d", maxEventBatch, len(batch))
+	}
+	if !q.push(context.Background(), claudecode.StreamEvent{Type: "assistant"}) {
+		t.Fatal("expected push to succeed after draining")
+	}
+}
@@ -0,0 +1,302 @@
+package session
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// transcriptEntry is one line of a Claude transcript (~/.claude/projects/<project>/<session>.jsonl)
+type transcriptEntry struct {
+	Type        string          `json:"type"` // user, assistant, summary, system
+	SessionID   string          `json:"sessionId"`
+	UUID        string          `json:"uuid"`
+	CWD         string          `json:"cwd"`
+	Timestamp   time.Time       `json:"timestamp"`
+	IsSidechain bool            `json:"isSidechain"` // Subagent turns
+	IsMeta      bool            `json:"isMeta"`      // Injected context, not typed by the user
+	Summary     string          `json:"summary"`
+	Message     json.RawMessage `json:"message"`
+}
+
+// transcript is a parsed transcript ready to import
+type transcript struct {
+	claudeSessionID string
+	cwd             string
+	query           string
+	summary         string
+	modelID         string
+	startedAt       time.Time
+	lastActivityAt  time.Time
+	events          []claudecode.StreamEvent
+}
+
+// projectDirPattern matches the characters Claude replaces when naming a project's transcript directory
+var projectDirPattern = regexp.MustCompile(`[^a-zA-Z0-9]`)
+
+// transcriptDir returns the directory holding Claude's transcripts for projectDir
+func transcriptDir(claudeDir, projectDir string) string {
+	return filepath.Join(claudeDir, "projects", projectDirPattern.ReplaceAllString(projectDir, "-"))
+}
+
+// defaultClaudeDir returns Claude's config directory
+func defaultClaudeDir() (string, error) {
+	if dir := os.Getenv("CLAUDE_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, ".claude"), nil
+}
+
+// ImportTranscripts imports sessions from Claude's on-disk transcripts for a
+// project. Each transcript becomes a completed session whose events go through
+// the same mapping as live sessions, so it can be continued like any other.
+// Transcripts whose Claude session is already in the store are skipped.
+func (m *Manager) ImportTranscripts(ctx context.Context, config ImportTranscriptsConfig) (*ImportResult, error) {
+	if config.ProjectDir == "" {
+		return nil, fmt.Errorf("project directory is required")
+	}
+	projectDir, err := filepath.Abs(config.ProjectDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project directory: %w", err)
+	}
+
+	claudeDir := config.ClaudeDir
+	if claudeDir == "" {
+		if claudeDir, err = defaultClaudeDir(); err != nil {
+			return nil, err
+		}
+	}
+
+	dir := transcriptDir(claudeDir, projectDir)
+	files, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transcripts: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Claude transcripts found for %s in %s", projectDir, dir)
+	}
+	sort.Strings(files)
+
+	wanted := make(map[string]bool, len(config.ClaudeSessionIDs))
+	for _, id := range config.ClaudeSessionIDs {
+		wanted[id] = true
+	}
+
+	sessions, err := m.store.ListSessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	existing := make(map[string]bool, len(sessions))
+	for _, sess := range sessions {
+		if sess.ClaudeSessionID != "" {
+			existing[sess.ClaudeSessionID] = true
+		}
+	}
+
+	result := &ImportResult{Imported: []ImportedSession{}, Skipped: []string{}}
+	for _, file := range files {
+		claudeSessionID := strings.TrimSuffix(filepath.Base(file), ".jsonl")
+		if len(wanted) > 0 && !wanted[claudeSessionID] {
+			continue
+		}
+		if existing[claudeSessionID] {
+			result.Skipped = append(result.Skipped, claudeSessionID)
+			continue
+		}
+
+		t, err := readTranscript(file, claudeSessionID)
+		if err != nil {
+			return result, err
+		}
+		if len(t.events) == 0 {
+			slog.Debug("skipping empty transcript", "path", file)
+			continue
+		}
+		if t.cwd == "" {
+			t.cwd = projectDir
+		}
+
+		imported, err := m.importTranscript(ctx, t)
+		if err != nil {
+			return result, fmt.Errorf("failed to import %s: %w", file, err)
+		}
+		result.Imported = append(result.Imported, *imported)
+	}
+
+	slog.Info("imported Claude transcripts",
+		"project_dir", projectDir,
+		"imported", len(result.Imported),
+		"skipped", len(result.Skipped))
+	return result, nil
+}
+
+// importTranscript stores a parsed transcript as a completed session
+func (m *Manager) importTranscript(ctx context.Context, t *transcript) (*ImportedSession, error) {
+	sessionID := uuid.New().String()
+	dbSession := &store.Session{
+		ID:              sessionID,
+		RunID:           uuid.New().String(),
+		ClaudeSessionID: t.claudeSessionID,
+		Query:           t.query,
+		Summary:         t.summary,
+		Model:           simpleModelName(t.modelID),
+		ModelID:         t.modelID,
+		WorkingDir:      t.cwd,
+		Status:          store.SessionStatusCompleted,
+		CreatedAt:       t.startedAt,
+		LastActivityAt:  t.lastActivityAt,
+	}
+	if dbSession.Summary == "" {
+		dbSession.Summary = CalculateSummary(t.query)
+	}
+	if err := m.store.CreateSession(ctx, dbSession); err != nil {
+		return nil, err
+	}
+
+	for _, event := range t.events {
+		if err := m.processStreamEvent(ctx, sessionID, t.claudeSessionID, event); err != nil {
+			slog.Warn("failed to import transcript event",
+				"session_id", sessionID,
+				"claude_session_id", t.claudeSessionID,
+				"error", err)
+		}
+	}
+
+	// Processing events records the import time as activity; restore the transcript's
+	status := store.SessionStatusCompleted
+	update := store.SessionUpdate{
+		Status:         &status,
+		LastActivityAt: &t.lastActivityAt,
+		CompletedAt:    &t.lastActivityAt,
+	}
+	if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
+		return nil, err
+	}
+
+	return &ImportedSession{
+		SessionID:       sessionID,
+		ClaudeSessionID: t.claudeSessionID,
+		EventCount:      len(t.events),
+	}, nil
+}
+
+// readTranscript parses a transcript file into stream events. Subagent and
+// meta entries are left out, as are lines that fail to parse.
+func readTranscript(path, claudeSessionID string) (*transcript, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	t := &transcript{claudeSessionID: claudeSessionID}
+	reader := bufio.NewReader(f)
+	for lineNum := 1; ; lineNum++ {
+		line, readErr := reader.ReadBytes('\n')
+		if len(strings.TrimSpace(string(line))) > 0 {
+			if err := t.add(line); err != nil {
+				slog.Warn("skipping transcript line", "path", path, "line", lineNum, "error", err)
+			}
+		}
+		if readErr != nil {
+			if errors.Is(readErr, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to read transcript: %w", readErr)
+		}
+	}
+	return t, nil
+}
+
+// add appends one transcript line to t
+func (t *transcript) add(line []byte) error {
+	var entry transcriptEntry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return err
+	}
+
+	if entry.Type == "summary" {
+		t.summary = entry.Summary
+		return nil
+	}
+	if (entry.Type != "user" && entry.Type != "assistant") || entry.IsSidechain || entry.IsMeta {
+		return nil
+	}
+
+	message, err := parseTranscriptMessage(entry.Message)
+	if err != nil {
+		return err
+	}
+
+	if !entry.Timestamp.IsZero() {
+		if t.startedAt.IsZero() {
+			t.startedAt = entry.Timestamp
+		}
+		t.lastActivityAt = entry.Timestamp
+	}
+	if t.cwd == "" {
+		t.cwd = entry.CWD
+	}
+	if t.modelID == "" && message.Role == "assistant" && message.Model != "" && message.Model != "<synthetic>" {
+		t.modelID = message.Model
+	}
+	if t.query == "" && message.Role == "user" {
+		for _, content := range message.Content {
+			if content.Type == "text" && content.Text != "" {
+				t.query = content.Text
+				break
+			}
+		}
+	}
+
+	t.events = append(t.events, claudecode.StreamEvent{
+		Type:      entry.Type,
+		SessionID: t.claudeSessionID,
+		UUID:      entry.UUID,
+		Message:   message,
+	})
+	return nil
+}
+
+// parseTranscriptMessage decodes a transcript message, whose content may be a
+// plain string for prompts typed by the user
+func parseTranscriptMessage(raw json.RawMessage) (*claudecode.Message, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("entry has no message")
+	}
+
+	var message claudecode.Message
+	if err := json.Unmarshal(raw, &message); err == nil {
+		return &message, nil
+	}
+
+	var prompt struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(raw, &prompt); err != nil {
+		return nil, fmt.Errorf("failed to decode message: %w", err)
+	}
+	return &claudecode.Message{
+		Role:    prompt.Role,
+		Content: []claudecode.Content{{Type: "text", Text: prompt.Content}},
+	}, nil
+}
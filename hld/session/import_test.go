@@ -0,0 +1,157 @@
+package session
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+const testTranscript = `{"type":"summary","summary":"Greeting the user","leafUuid":"a2"}
+{"type":"user","sessionId":"0d6c5b8e-1111-4a1b-9c2d-3e4f5a6b7c8d","uuid":"u1","cwd":"CWD","timestamp":"2025-09-01T10:00:00Z","isSidechain":false,"message":{"role":"user","content":"say hi"}}
+{"type":"user","sessionId":"0d6c5b8e-1111-4a1b-9c2d-3e4f5a6b7c8d","uuid":"m1","cwd":"CWD","timestamp":"2025-09-01T10:00:00Z","isMeta":true,"message":{"role":"user","content":"<local-command-stdout></local-command-stdout>"}}
+{"type":"assistant","sessionId":"0d6c5b8e-1111-4a1b-9c2d-3e4f5a6b7c8d","uuid":"a1","cwd":"CWD","timestamp":"2025-09-01T10:00:05Z","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-5-20250929","content":[{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"command":"echo hi"}}],"usage":{"input_tokens":10,"output_tokens":5}}}
+{"type":"user","sessionId":"0d6c5b8e-1111-4a1b-9c2d-3e4f5a6b7c8d","uuid":"u2","cwd":"CWD","timestamp":"2025-09-01T10:00:06Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"hi"}]}}
+{"type":"assistant","sessionId":"0d6c5b8e-1111-4a1b-9c2d-3e4f5a6b7c8d","uuid":"s1","cwd":"CWD","timestamp":"2025-09-01T10:00:07Z","isSidechain":true,"message":{"role":"assistant","content":[{"type":"text","text":"subagent"}]}}
+{"type":"assistant","sessionId":"0d6c5b8e-1111-4a1b-9c2d-3e4f5a6b7c8d","uuid":"a2","cwd":"CWD","timestamp":"2025-09-01T10:00:09Z","message":{"id":"msg_2","type":"message","role":"assistant","model":"claude-sonnet-4-5-20250929","content":[{"type":"text","text":"hi"}]}}
+`
+
+const testTranscriptSessionID = "0d6c5b8e-1111-4a1b-9c2d-3e4f5a6b7c8d"
+
+// writeTranscript lays out a Claude config directory holding one transcript for projectDir
+func writeTranscript(t *testing.T, projectDir string) string {
+	t.Helper()
+
+	claudeDir := t.TempDir()
+	dir := transcriptDir(claudeDir, projectDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("failed to create transcript dir: %v", err)
+	}
+	content := []byte(strings.ReplaceAll(testTranscript, "CWD", projectDir))
+	if err := os.WriteFile(filepath.Join(dir, testTranscriptSessionID+".jsonl"), content, 0o644); err != nil {
+		t.Fatalf("failed to write transcript: %v", err)
+	}
+	return claudeDir
+}
+
+func TestTranscriptDir(t *testing.T) {
+	got := transcriptDir("/home/u/.claude", "/Users/me/src/my_app.v2")
+	want := "/home/u/.claude/projects/-Users-me-src-my-app-v2"
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestImportTranscripts(t *testing.T) {
+	manager, s, fake := newFakeClaudeManager(t)
+	ctx := context.Background()
+
+	projectDir := t.TempDir()
+	claudeDir := writeTranscript(t, projectDir)
+
+	result, err := manager.ImportTranscripts(ctx, ImportTranscriptsConfig{
+		ProjectDir: projectDir,
+		ClaudeDir:  claudeDir,
+	})
+	if err != nil {
+		t.Fatalf("ImportTranscripts failed: %v", err)
+	}
+	if len(result.Imported) != 1 || len(result.Skipped) != 0 {
+		t.Fatalf("expected 1 imported and 0 skipped, got %+v", result)
+	}
+	imported := result.Imported[0]
+	if imported.ClaudeSessionID != testTranscriptSessionID {
+		t.Errorf("unexpected claude session ID %s", imported.ClaudeSessionID)
+	}
+	if imported.EventCount != 4 {
+		t.Errorf("expected sidechain and meta entries to be skipped, got %d events", imported.EventCount)
+	}
+
+	sess, err := s.GetSession(ctx, imported.SessionID)
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	if sess.Status != store.SessionStatusCompleted {
+		t.Errorf("expected completed, got %s", sess.Status)
+	}
+	if sess.Query != "say hi" || sess.Summary != "Greeting the user" {
+		t.Errorf("unexpected query/summary: %q / %q", sess.Query, sess.Summary)
+	}
+	if sess.Model != "sonnet" || sess.ModelID != "claude-sonnet-4-5-20250929" {
+		t.Errorf("unexpected model %q (%q)", sess.Model, sess.ModelID)
+	}
+	if sess.WorkingDir != projectDir {
+		t.Errorf("expected working dir %s, got %s", projectDir, sess.WorkingDir)
+	}
+	if got := sess.CreatedAt.UTC().Format("15:04:05"); got != "10:00:00" {
+		t.Errorf("expected created at from transcript, got %s", got)
+	}
+	if got := sess.LastActivityAt.UTC().Format("15:04:05"); got != "10:00:09" {
+		t.Errorf("expected last activity from transcript, got %s", got)
+	}
+
+	events, err := s.GetConversation(ctx, testTranscriptSessionID)
+	if err != nil {
+		t.Fatalf("failed to get conversation: %v", err)
+	}
+	var types []string
+	for _, event := range events {
+		types = append(types, event.EventType)
+	}
+	want := []string{"message", "tool_call", "tool_result", "message"}
+	if len(types) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, types)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("expected events %v, got %v", want, types)
+		}
+	}
+	if !events[1].IsCompleted {
+		t.Error("expected tool call to be marked completed by its result")
+	}
+
+	// Importing again skips the transcript
+	again, err := manager.ImportTranscripts(ctx, ImportTranscriptsConfig{
+		ProjectDir: projectDir,
+		ClaudeDir:  claudeDir,
+	})
+	if err != nil {
+		t.Fatalf("second ImportTranscripts failed: %v", err)
+	}
+	if len(again.Imported) != 0 || len(again.Skipped) != 1 || again.Skipped[0] != testTranscriptSessionID {
+		t.Errorf("expected transcript to be skipped, got %+v", again)
+	}
+
+	// Imported sessions continue like daemon-launched ones
+	continued, err := manager.ContinueSession(ctx, ContinueSessionConfig{
+		ParentSessionID: imported.SessionID,
+		Query:           "again",
+	})
+	if err != nil {
+		t.Fatalf("ContinueSession failed: %v", err)
+	}
+	child := waitForStatus(t, s, continued.ID)
+	if child.Status != store.SessionStatusCompleted {
+		t.Fatalf("expected completed, got %s (error: %s)", child.Status, child.ErrorMessage)
+	}
+	invocations := fake.Invocations(t)
+	if len(invocations) != 1 || invocations[0].ResumeID != testTranscriptSessionID {
+		t.Errorf("expected resume of imported claude session, got %+v", invocations)
+	}
+}
+
+func TestImportTranscripts_NoTranscripts(t *testing.T) {
+	manager, _, _ := newFakeClaudeManager(t)
+
+	_, err := manager.ImportTranscripts(context.Background(), ImportTranscriptsConfig{
+		ProjectDir: t.TempDir(),
+		ClaudeDir:  t.TempDir(),
+	})
+	if err == nil {
+		t.Fatal("expected error when no transcripts exist")
+	}
+}
@@ -3,6 +3,7 @@ package session
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -13,11 +14,36 @@ import (
 
 	"github.com/google/uuid"
 	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/approval"
 	"github.com/humanlayer/humanlayer/hld/bus"
 	hldconfig "github.com/humanlayer/humanlayer/hld/config"
 	"github.com/humanlayer/humanlayer/hld/store"
 )
 
+// interruptWaitTimeout bounds how long ContinueSession waits for an interrupted
+// parent session to exit before it is killed
+const interruptWaitTimeout = 30 * time.Second
+
+// MaxOutputSchemaRepairTurns bounds the repair turns a session may request
+// for a result that does not match its output schema
+const MaxOutputSchemaRepairTurns = 3
+
+// ValidatePermissions rejects claude options that skip permission checks.
+// Sessions may only do so through dangerously_skip_permissions, which is
+// recorded on the session and can expire.
+func ValidatePermissions(mode claudecode.PermissionMode, extraArgs []string) error {
+	if mode == claudecode.PermissionModeBypassPermissions {
+		return fmt.Errorf("permission_mode %q is not allowed; use dangerously_skip_permissions", mode)
+	}
+	for _, arg := range extraArgs {
+		switch flag, _, _ := strings.Cut(arg, "="); flag {
+		case "--dangerously-skip-permissions", "--allow-dangerously-skip-permissions":
+			return fmt.Errorf("extra argument %q is not allowed; use dangerously_skip_permissions", flag)
+		}
+	}
+	return nil
+}
+
 // Manager handles the lifecycle of Claude Code sessions
 type Manager struct {
 	activeProcesses    map[string]ClaudeSession // Maps session ID to active Claude process
@@ -27,20 +53,24 @@ type Manager struct {
 	store              store.ConversationStore
 	approvalReconciler ApprovalReconciler
 	pendingQueries     sync.Map // map[sessionID]query - stores queries waiting for Claude session ID
+	schemaSessions     sync.Map // map[sessionID]struct{} - sessions whose result must match an output schema
 	socketPath         string   // Daemon socket path for MCP servers
 	httpPort           int      // HTTP server port for proxy endpoint
+	cgroupParent       string   // cgroup v2 directory for session resource limits
 }
 
 // Compile-time check that Manager implements SessionManager
 var _ SessionManager = (*Manager)(nil)
 
-// NewManager creates a new session manager with required store
-func NewManager(eventBus bus.EventBus, store store.ConversationStore, socketPath string) (*Manager, error) {
+// NewManager creates a new session manager with required store. clientOpts
+// configure the Claude client, e.g. its event overflow policy.
+func NewManager(eventBus bus.EventBus, store store.ConversationStore, socketPath string, clientOpts ...claudecode.ClientOption) (*Manager, error) {
 	if store == nil {
 		return nil, fmt.Errorf("store is required")
 	}
 
-	client, err := claudecode.NewClient()
+	opts := append([]claudecode.ClientOption{claudecode.WithLogger(slog.Default().With("component", "claudecode"))}, clientOpts...)
+	client, err := claudecode.NewClient(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Claude client: %w", err)
 	}
@@ -69,8 +99,60 @@ func (m *Manager) SetHTTPPort(port int) {
 	slog.Debug("HTTP port set for proxy endpoint", "port", port)
 }
 
+// SetCgroupParent sets the cgroup v2 directory under which sessions with
+// resource limits get their own cgroup
+func (m *Manager) SetCgroupParent(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cgroupParent = path
+	slog.Debug("cgroup parent set for session resource limits", "cgroup_parent", path)
+}
+
+// ClaudeVersion returns the claude CLI version detected at startup, or "" if it is unknown
+func (m *Manager) ClaudeVersion() string {
+	if m.client == nil {
+		return ""
+	}
+	version := m.client.Capabilities().Version
+	if version.IsZero() {
+		return ""
+	}
+	return version.String()
+}
+
+// supportsPartialMessages reports whether the claude CLI can stream partial
+// messages; older versions reject --include-partial-messages
+func (m *Manager) supportsPartialMessages() bool {
+	return m.client != nil && m.client.Capabilities().IncludePartialMessages
+}
+
+// sessionLimits returns a copy of limits using the daemon's cgroup parent,
+// or nil when no limits are set
+func (m *Manager) sessionLimits(limits *claudecode.ResourceLimits) *claudecode.ResourceLimits {
+	if limits.IsZero() {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	copied := *limits
+	copied.CgroupParent = m.cgroupParent
+	return &copied
+}
+
 // LaunchSession starts a new Claude Code session
 func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig) (*Session, error) {
+	if config.OutputSchemaRepairTurns > MaxOutputSchemaRepairTurns {
+		return nil, fmt.Errorf("output_schema_repair_turns must be at most %d", MaxOutputSchemaRepairTurns)
+	}
+	if err := ValidatePermissions(config.PermissionMode, config.ExtraArgs); err != nil {
+		return nil, err
+	}
+	if len(config.ApprovalPolicy) > 0 {
+		if _, err := approval.ParsePolicy(config.ApprovalPolicy, approval.PolicySourceSession); err != nil {
+			return nil, err
+		}
+	}
+
 	// Generate unique IDs
 	sessionID := uuid.New().String()
 	runID := uuid.New().String()
@@ -98,6 +180,11 @@ func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig)
 		"session_id", sessionID,
 		"socket_path", m.socketPath)
 
+	// Stream partial messages so subscribers see text as it is generated
+	if claudeConfig.OutputFormat == claudecode.OutputStreamJSON {
+		claudeConfig.IncludePartialMessages = m.supportsPartialMessages()
+	}
+
 	// Add HUMANLAYER_RUN_ID and HUMANLAYER_DAEMON_SOCKET to MCP server environment
 	// For HTTP servers, inject session ID header
 	if claudeConfig.MCPConfig != nil {
@@ -151,6 +238,8 @@ func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig)
 		}
 	}
 
+	claudeConfig.Limits = m.sessionLimits(claudeConfig.Limits)
+
 	// Create session record directly in database
 	startTime := time.Now()
 
@@ -173,6 +262,10 @@ func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig)
 		}
 	}
 
+	if len(config.ApprovalPolicy) > 0 {
+		dbSession.ApprovalPolicy = string(config.ApprovalPolicy)
+	}
+
 	// Handle proxy configuration from config
 	if config.ProxyEnabled {
 		dbSession.ProxyEnabled = config.ProxyEnabled
@@ -246,8 +339,9 @@ func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig)
 		"mcp_servers", mcpServerCount,
 		"mcp_servers_detail", mcpServersDetail)
 
-	// Launch Claude session (without daemon-level settings)
-	claudeSession, err := m.client.Launch(claudeConfig)
+	// Launch Claude session (without daemon-level settings).
+	// The process is bound to ctx, so daemon shutdown interrupts it and kills it after a grace period.
+	claudeSession, err := m.client.LaunchContext(ctx, claudeConfig)
 	if err != nil {
 		slog.Error("failed to launch Claude session",
 			"session_id", sessionID,
@@ -340,85 +434,66 @@ func (m *Manager) LaunchSession(ctx context.Context, config LaunchSessionConfig)
 	}, nil
 }
 
-// monitorSession tracks the lifecycle of a Claude session
-func (m *Manager) monitorSession(ctx context.Context, sessionID, runID string, claudeSession ClaudeSession, startTime time.Time, config claudecode.SessionConfig) {
+// processEvents stores and processes queued events until the queue is closed
+// and drained or ctx is cancelled. Raw events are stored a batch at a time;
+// conversation events and session updates are written per event, since later
+// events read what earlier ones stored.
+func (m *Manager) processEvents(ctx context.Context, sessionID, runID string, queue *eventQueue) {
 	// Get the session ID from the Claude session once available
 	var claudeSessionID string
 
-eventLoop:
-	for {
-		select {
-		case <-ctx.Done():
-			// Context cancelled, stop processing
-			slog.Debug("monitorSession context cancelled, stopping event processing",
+	deltas := newDeltaCoalescer(m.eventBus, sessionID, runID)
+	defer deltas.close()
+
+	for batch := queue.next(); batch != nil; batch = queue.next() {
+		// Check context before each database operation
+		if ctx.Err() != nil {
+			slog.Debug("context cancelled during event processing",
 				"session_id", sessionID)
 			return
-		case event, ok := <-claudeSession.GetEvents():
-			if !ok {
-				// Channel closed, exit loop
-				break eventLoop
-			}
+		}
 
-			// Check context before each database operation
-			if ctx.Err() != nil {
-				slog.Debug("context cancelled during event processing",
-					"session_id", sessionID)
-				return
+		// Store raw events for debugging, preserving fields we don't model.
+		// Partial messages are skipped since the complete message is stored.
+		rawEvents := make([]string, 0, len(batch))
+		for _, event := range batch {
+			if event.Type == "stream_event" {
+				continue
+			}
+			if event.Typed != nil {
+				rawEvents = append(rawEvents, string(event.Typed.Raw()))
+				continue
 			}
-
-			// Store raw event for debugging
 			eventJSON, err := json.Marshal(event)
 			if err != nil {
 				slog.Error("failed to marshal event", "error", err)
-			} else {
-				if err := m.store.StoreRawEvent(ctx, sessionID, string(eventJSON)); err != nil {
-					slog.Debug("failed to store raw event", "error", err)
-				}
+				continue
 			}
+			rawEvents = append(rawEvents, string(eventJSON))
+		}
+		if err := m.store.StoreRawEvents(ctx, sessionID, rawEvents); err != nil {
+			slog.Debug("failed to store raw events", "error", err, "count", len(rawEvents))
+		}
 
-			// Capture Claude session ID from either top-level or message session_id
-			if claudeSessionID == "" {
-				if event.SessionID != "" {
-					claudeSessionID = event.SessionID
-				} else if event.Message != nil && event.Message.ID != "" {
-					// For assistant/user messages, we can use the message itself as proof of session
-					// The actual claude session ID might be in the raw JSON
-					if eventJSON, err := json.Marshal(event); err == nil {
-						var rawEvent map[string]interface{}
-						if err := json.Unmarshal(eventJSON, &rawEvent); err == nil {
-							if sid, ok := rawEvent["session_id"].(string); ok && sid != "" {
-								claudeSessionID = sid
-							}
-						}
-					}
-				}
+		for _, event := range batch {
+			if ctx.Err() != nil {
+				return
 			}
 
-			if claudeSessionID != "" {
-				// Note: Claude session ID captured for resume capability
-				slog.Debug("captured Claude session ID",
-					"session_id", sessionID,
-					"claude_session_id", claudeSessionID)
-
-				// Update database
-				update := store.SessionUpdate{
-					ClaudeSessionID: &claudeSessionID,
-				}
-				if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
-					slog.Error("failed to update session in database", "error", err)
-				}
+			// Capture Claude session ID from the event header
+			if claudeSessionID == "" && event.SessionID != "" {
+				claudeSessionID = event.SessionID
+				m.captureClaudeSessionID(ctx, sessionID, claudeSessionID)
+			}
 
-				// Inject the pending query now that we have Claude session ID
-				if queryVal, ok := m.pendingQueries.LoadAndDelete(sessionID); ok {
-					if query, ok := queryVal.(string); ok && query != "" {
-						if err := m.injectQueryAsFirstEvent(ctx, sessionID, claudeSessionID, query); err != nil {
-							slog.Error("failed to inject query as first event",
-								"sessionID", sessionID,
-								"claudeSessionID", claudeSessionID,
-								"error", err)
-						}
+			// Partial messages are only published; the complete message is stored when it arrives
+			if event.Type == "stream_event" {
+				if typed, err := event.AsEvent(); err == nil {
+					if partial, ok := typed.(*claudecode.PartialMessage); ok {
+						deltas.add(claudeSessionID, partial)
 					}
 				}
+				continue
 			}
 
 			// Process and store event
@@ -427,19 +502,124 @@ eventLoop:
 			}
 		}
 	}
+}
+
+// captureClaudeSessionID records the Claude session ID for resume and injects
+// the pending query now that it is known
+func (m *Manager) captureClaudeSessionID(ctx context.Context, sessionID, claudeSessionID string) {
+	// Note: Claude session ID captured for resume capability
+	slog.Debug("captured Claude session ID",
+		"session_id", sessionID,
+		"claude_session_id", claudeSessionID)
+
+	// Update database
+	update := store.SessionUpdate{
+		ClaudeSessionID: &claudeSessionID,
+	}
+	if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
+		slog.Error("failed to update session in database", "error", err)
+	}
+
+	// Inject the pending query now that we have Claude session ID
+	if queryVal, ok := m.pendingQueries.LoadAndDelete(sessionID); ok {
+		if query, ok := queryVal.(string); ok && query != "" {
+			if err := m.injectQueryAsFirstEvent(ctx, sessionID, claudeSessionID, query); err != nil {
+				slog.Error("failed to inject query as first event",
+					"sessionID", sessionID,
+					"claudeSessionID", claudeSessionID,
+					"error", err)
+			}
+		}
+	}
+}
 
-	// Wait for session to complete
-	result, err := claudeSession.Wait()
+// awaitSession stores the events of a Claude process until it exits and
+// returns its result. The caller checks ctx.Err() before using the result.
+func (m *Manager) awaitSession(ctx context.Context, sessionID, runID string, claudeSession ClaudeSession) (*claudecode.Result, error) {
+	// Events are handed to a separate goroutine for storage so that database
+	// latency never stalls reading claude's output
+	queue := newEventQueue()
+	processed := make(chan struct{})
+	go func() {
+		defer close(processed)
+		m.processEvents(ctx, sessionID, runID, queue)
+	}()
 
-	// Check if context was cancelled before updating database
-	if ctx.Err() != nil {
-		slog.Debug("context cancelled, skipping final session updates",
+eventLoop:
+	for {
+		select {
+		case <-ctx.Done():
+			// Context cancelled, stop processing
+			slog.Debug("monitorSession context cancelled, stopping event processing",
+				"session_id", sessionID)
+			queue.close()
+			return nil, ctx.Err()
+		case event, ok := <-claudeSession.GetEvents():
+			if !ok {
+				// Channel closed, exit loop
+				break eventLoop
+			}
+			queue.push(ctx, event)
+		}
+	}
+
+	// Finish storing events before recording the final status
+	queue.close()
+	select {
+	case <-processed:
+	case <-ctx.Done():
+		slog.Debug("monitorSession context cancelled while storing events",
 			"session_id", sessionID)
-		return
+		return nil, ctx.Err()
+	}
+
+	// Wait for session to complete (interrupted and killed if ctx ends first)
+	return claudeSession.WaitContext(ctx)
+}
+
+// monitorSession tracks the lifecycle of a Claude session. A result that does
+// not match the output schema is repaired with further turns, and the session
+// stays running until the result matches or no repair turn is left.
+func (m *Manager) monitorSession(ctx context.Context, sessionID, runID string, claudeSession ClaudeSession, startTime time.Time, config claudecode.SessionConfig) {
+	if len(config.OutputSchema) > 0 {
+		m.schemaSessions.Store(sessionID, struct{}{})
+		defer m.schemaSessions.Delete(sessionID)
+	}
+
+	var result *claudecode.Result
+	var err error
+	var structuredOutput json.RawMessage
+	var schemaErr error
+	for {
+		result, err = m.awaitSession(ctx, sessionID, runID, claudeSession)
+
+		// Check if context was cancelled before updating database
+		if ctx.Err() != nil {
+			slog.Debug("context cancelled, skipping final session updates",
+				"session_id", sessionID)
+			return
+		}
+
+		// Check a successful result against the launch's output schema
+		structuredOutput, schemaErr = nil, nil
+		if err == nil && result != nil && !result.IsError && len(config.OutputSchema) > 0 {
+			structuredOutput, schemaErr = claudecode.ValidateOutput(config.OutputSchema, result.Result)
+		}
+		if schemaErr == nil || m.sessionInterrupting(ctx, sessionID) {
+			break
+		}
+
+		repairSession, repairConfig, ok := m.startRepairTurn(ctx, sessionID, result, config, schemaErr)
+		if !ok {
+			break
+		}
+		claudeSession, config = repairSession, repairConfig
 	}
 
 	endTime := time.Now()
 
+	var limitErr *claudecode.LimitError
+
 	// First check if this was an intentional interrupt (regardless of error)
 	session, dbErr := m.store.GetSession(ctx, sessionID)
 	if dbErr == nil && session != nil && session.Status == string(StatusInterrupting) {
@@ -468,6 +648,13 @@ eventLoop:
 				},
 			})
 		}
+	} else if errors.As(err, &limitErr) {
+		slog.Warn("claude process exceeded resource limit",
+			"session_id", sessionID,
+			"limit", limitErr.Limit,
+			"error", limitErr.Error(),
+			"duration", endTime.Sub(startTime))
+		m.failSessionForLimit(ctx, sessionID, runID, limitErr)
 	} else if err != nil {
 		slog.Error("claude process failed",
 			"session_id", sessionID,
@@ -480,6 +667,20 @@ eventLoop:
 			"error", result.Error,
 			"duration", endTime.Sub(startTime))
 		m.updateSessionStatus(ctx, sessionID, StatusFailed, result.Error)
+	} else if schemaErr != nil {
+		slog.Warn("session result does not match output schema",
+			"session_id", sessionID,
+			"error", schemaErr.Error(),
+			"duration", endTime.Sub(startTime))
+		reason := store.FailureReasonOutputSchema
+		update := store.SessionUpdate{FailureReason: &reason}
+		if result.Result != "" {
+			update.ResultContent = &result.Result
+		}
+		if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
+			slog.Error("failed to record session failure reason", "error", err)
+		}
+		m.updateSessionStatus(ctx, sessionID, StatusFailed, schemaErr.Error())
 	} else {
 		// No longer updating in-memory session
 
@@ -502,6 +703,10 @@ eventLoop:
 				update.ResultContent = &result.Result
 			}
 		}
+		if structuredOutput != nil {
+			structured := string(structuredOutput)
+			update.StructuredOutput = &structured
+		}
 		if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
 			slog.Error("failed to update session completion in database", "error", err)
 		}
@@ -529,7 +734,7 @@ eventLoop:
 
 	// Determine final status for logging
 	finalStatus := StatusCompleted
-	if err != nil || (result != nil && result.IsError) {
+	if err != nil || (result != nil && result.IsError) || schemaErr != nil {
 		finalStatus = StatusFailed
 	} else if dbErr == nil && session != nil && session.Status == string(StatusInterrupting) {
 		finalStatus = StatusInterrupted
@@ -552,6 +757,61 @@ eventLoop:
 	m.pendingQueries.Delete(sessionID)
 }
 
+// sessionInterrupting reports whether the session is being interrupted
+func (m *Manager) sessionInterrupting(ctx context.Context, sessionID string) bool {
+	session, err := m.store.GetSession(ctx, sessionID)
+	return err == nil && session != nil && session.Status == string(StatusInterrupting)
+}
+
+// startRepairTurn resumes the Claude session with a turn asking for a result
+// that matches the output schema. It returns false when no repair turn is left
+// or it cannot start.
+func (m *Manager) startRepairTurn(ctx context.Context, sessionID string, result *claudecode.Result, config claudecode.SessionConfig, schemaErr error) (ClaudeSession, claudecode.SessionConfig, bool) {
+	var validationErr *claudecode.OutputValidationError
+	if !errors.As(schemaErr, &validationErr) || config.OutputSchemaRepairTurns == 0 || result.SessionID == "" {
+		return nil, config, false
+	}
+
+	repairConfig := claudecode.RepairConfig(config, result.SessionID, validationErr)
+	// Pass the repair prompt as an argument so the process exits after one turn
+	repairConfig.InputFormat = ""
+
+	claudeSession, err := m.client.LaunchContext(ctx, repairConfig)
+	if err != nil {
+		slog.Error("failed to launch output repair turn",
+			"session_id", sessionID,
+			"error", err)
+		return nil, config, false
+	}
+	wrappedSession := NewClaudeSessionWrapper(claudeSession)
+
+	m.mu.Lock()
+	m.activeProcesses[sessionID] = wrappedSession
+	m.mu.Unlock()
+
+	slog.Info("result does not match output schema, requesting repair",
+		"session_id", sessionID,
+		"problems", validationErr.Problems,
+		"repair_turns_left", repairConfig.OutputSchemaRepairTurns)
+
+	// Record the repair prompt as a user turn, as SendMessage does
+	event := &store.ConversationEvent{
+		SessionID:       sessionID,
+		ClaudeSessionID: result.SessionID,
+		EventType:       store.EventTypeMessage,
+		CreatedAt:       time.Now(),
+		Role:            "user",
+		Content:         repairConfig.Query,
+	}
+	if err := m.store.AddConversationEvent(ctx, event); err != nil {
+		slog.Error("failed to store output repair prompt",
+			"session_id", sessionID,
+			"error", err)
+	}
+
+	return wrappedSession, repairConfig, true
+}
+
 // updateSessionStatus updates the status of a session in the database
 func (m *Manager) updateSessionStatus(ctx context.Context, sessionID string, status Status, errorMsg string) {
 	// Update database
@@ -582,6 +842,28 @@ func (m *Manager) updateSessionStatus(ctx context.Context, sessionID string, sta
 	// This would require a database read. For now, we'll skip the event.
 }
 
+// failSessionForLimit marks a session failed because it exceeded a resource
+// limit and publishes the breach
+func (m *Manager) failSessionForLimit(ctx context.Context, sessionID, runID string, limitErr *claudecode.LimitError) {
+	reason := store.FailureReasonResourceLimit
+	if err := m.store.UpdateSession(ctx, sessionID, store.SessionUpdate{FailureReason: &reason}); err != nil {
+		slog.Error("failed to record session failure reason", "error", err)
+	}
+	m.updateSessionStatus(ctx, sessionID, StatusFailed, limitErr.Error())
+
+	if m.eventBus != nil {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventSessionResourceLimitExceeded,
+			Data: map[string]interface{}{
+				"session_id": sessionID,
+				"run_id":     runID,
+				"limit":      string(limitErr.Limit),
+				"error":      limitErr.Error(),
+			},
+		})
+	}
+}
+
 // GetSessionInfo returns session info from the database by ID
 func (m *Manager) GetSessionInfo(sessionID string) (*Info, error) {
 	ctx := context.Background()
@@ -599,6 +881,7 @@ func (m *Manager) GetSessionInfo(sessionID string) (*Info, error) {
 		StartTime:       dbSession.CreatedAt,
 		LastActivityAt:  dbSession.LastActivityAt,
 		Error:           dbSession.ErrorMessage,
+		FailureReason:   dbSession.FailureReason,
 		Query:           dbSession.Query,
 		Summary:         dbSession.Summary,
 		Title:           dbSession.Title,
@@ -612,6 +895,9 @@ func (m *Manager) GetSessionInfo(sessionID string) (*Info, error) {
 	if dbSession.CompletedAt != nil {
 		info.EndTime = dbSession.CompletedAt
 	}
+	if dbSession.StructuredOutput != "" {
+		info.StructuredOutput = json.RawMessage(dbSession.StructuredOutput)
+	}
 
 	// Populate Result field if we have result data
 	if dbSession.ResultContent != "" || dbSession.NumTurns != nil || dbSession.CostUSD != nil || dbSession.DurationMS != nil {
@@ -663,6 +949,7 @@ func (m *Manager) ListSessions() []Info {
 			StartTime:                           dbSession.CreatedAt,
 			LastActivityAt:                      dbSession.LastActivityAt,
 			Error:                               dbSession.ErrorMessage,
+			FailureReason:                       dbSession.FailureReason,
 			Query:                               dbSession.Query,
 			Summary:                             dbSession.Summary,
 			Title:                               dbSession.Title,
@@ -680,6 +967,9 @@ func (m *Manager) ListSessions() []Info {
 		if dbSession.CompletedAt != nil {
 			info.EndTime = dbSession.CompletedAt
 		}
+		if dbSession.StructuredOutput != "" {
+			info.StructuredOutput = json.RawMessage(dbSession.StructuredOutput)
+		}
 
 		// Populate Result field if we have result data
 		if dbSession.ResultContent != "" || dbSession.NumTurns != nil || dbSession.CostUSD != nil || dbSession.DurationMS != nil {
@@ -735,18 +1025,23 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 			"raw_event_json", string(eventJSON))
 	}
 
+	typed, err := event.AsEvent()
+	if err != nil {
+		return fmt.Errorf("failed to decode stream event: %w", err)
+	}
+
 	// Process token updates from assistant messages even without claudeSessionID
-	if event.Type == "assistant" && event.Message != nil && event.Message.Role == "assistant" && event.Message.Usage != nil {
+	if msg, ok := typed.(*claudecode.AssistantMessage); ok && msg.Message.Role == "assistant" && msg.Message.Usage != nil {
 		// QUICK FIX: Skip token updates for subagent events
 		// Subagents have parent_tool_use_id set at the event level
-		if event.ParentToolUseID != "" {
+		if msg.ParentToolUseID != "" {
 			slog.Debug("skipping token update for subagent event",
 				"session_id", sessionID,
-				"parent_tool_use_id", event.ParentToolUseID)
+				"parent_tool_use_id", msg.ParentToolUseID)
 			// Continue processing the rest of the event, just skip token updates
 		} else {
 			// Original token update logic for root-level events
-			usage := event.Message.Usage
+			usage := msg.Message.Usage
 			// Compute effective context tokens (what's actually in the context window)
 			// This includes ALL tokens that count toward the context limit
 			effective := usage.InputTokens + usage.OutputTokens + usage.CacheReadInputTokens + usage.CacheCreationInputTokens
@@ -800,10 +1095,10 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 		return nil
 	}
 
-	switch event.Type {
-	case "system":
-		// System events (session created, tools available, etc)
-		switch event.Subtype {
+	switch ev := typed.(type) {
+	case *claudecode.SystemEvent:
+		// System events (session created, etc)
+		switch ev.Subtype {
 		case "session_created":
 			// Store system event
 			convEvent := &store.ConversationEvent{
@@ -811,7 +1106,7 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 				ClaudeSessionID: claudeSessionID,
 				EventType:       store.EventTypeSystem,
 				Role:            "system",
-				Content:         fmt.Sprintf("Session created with ID: %s", event.SessionID),
+				Content:         fmt.Sprintf("Session created with ID: %s", ev.SessionID),
 			}
 			if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
 				return err
@@ -825,242 +1120,80 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 						"session_id":        sessionID,
 						"claude_session_id": claudeSessionID,
 						"event_type":        "system",
-						"subtype":           event.Subtype,
-						"content":           fmt.Sprintf("Session created with ID: %s", event.SessionID),
+						"subtype":           ev.Subtype,
+						"content":           fmt.Sprintf("Session created with ID: %s", ev.SessionID),
 						"content_type":      "system",
 					},
 				})
 			}
-		case "init":
-			// Check if we need to populate the model
-			session, err := m.store.GetSession(ctx, sessionID)
-			if err != nil {
-				slog.Error("failed to get session for model update", "error", err)
-				return nil // Non-fatal, continue processing
-			}
-
-			// Only update if model is empty and init event has a model
-			if session != nil && session.Model == "" && event.Model != "" {
-				// Store the full model ID
-				modelID := event.Model
-
-				// Extract simple model name from API format (case-insensitive)
-				var modelName string
-				lowerModel := strings.ToLower(event.Model)
-				if strings.Contains(lowerModel, "opus") {
-					modelName = "opus"
-				} else if strings.Contains(lowerModel, "sonnet") {
-					modelName = "sonnet"
-				}
-
-				// Update session with both model ID and simplified name
-				if modelName != "" {
-					update := store.SessionUpdate{
-						Model:   &modelName,
-						ModelID: &modelID,
-					}
-					if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
-						slog.Error("failed to update session model from init event",
-							"session_id", sessionID,
-							"model", modelName,
-							"model_id", modelID,
-							"error", err)
-					} else {
-						slog.Info("populated session model from init event",
-							"session_id", sessionID,
-							"model", modelName,
-							"model_id", modelID)
-					}
-				} else {
-					// Still store the model ID even if we don't recognize the format
-					update := store.SessionUpdate{
-						ModelID: &modelID,
-					}
-					if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
-						slog.Error("failed to update session model_id from init event",
-							"session_id", sessionID,
-							"model_id", modelID,
-							"error", err)
-					} else {
-						slog.Debug("stored unrecognized model format in init event",
-							"session_id", sessionID,
-							"model_id", modelID)
-					}
-				}
-			}
-			// Don't store init event in conversation history - we only extract the model
 		}
 		// Other system events can be added as needed
 
-	case "assistant", "user":
-		// Messages contain the actual content
-		if event.Message != nil {
-			// Token usage is already processed at the top of this function
-			// Process each content block
-			for _, content := range event.Message.Content {
-				switch content.Type {
-				case "text":
-					// Text message
-					convEvent := &store.ConversationEvent{
-						SessionID:       sessionID,
-						ClaudeSessionID: claudeSessionID,
-						EventType:       store.EventTypeMessage,
-						Role:            event.Message.Role,
-						Content:         content.Text,
-					}
-					if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
-						return err
-					}
-
-					// Update session activity timestamp for text messages
-					m.updateSessionActivity(ctx, sessionID)
-
-					// Publish conversation updated event
-					if m.eventBus != nil {
-						m.eventBus.Publish(bus.Event{
-							Type: bus.EventConversationUpdated,
-							Data: map[string]interface{}{
-								"session_id":        sessionID,
-								"claude_session_id": claudeSessionID,
-								"event_type":        "message",
-								"role":              event.Message.Role,
-								"content":           content.Text,
-								"content_type":      "text",
-							},
-						})
-					}
-
-				case "tool_use":
-					// Tool call
-					inputJSON, err := json.Marshal(content.Input)
-					if err != nil {
-						return fmt.Errorf("failed to marshal tool input: %w", err)
-					}
-
-					convEvent := &store.ConversationEvent{
-						SessionID:       sessionID,
-						ClaudeSessionID: claudeSessionID,
-						EventType:       store.EventTypeToolCall,
-						ToolID:          content.ID,
-						ToolName:        content.Name,
-						ToolInputJSON:   string(inputJSON),
-						ParentToolUseID: event.ParentToolUseID, // Capture from event level
-						// We don't know yet if this needs approval - that comes from HumanLayer API
-					}
-					if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
-						return err
-					}
-
-					// Update session activity timestamp for tool calls
-					m.updateSessionActivity(ctx, sessionID)
-
-					// Publish conversation updated event
-					if m.eventBus != nil {
-						// Parse tool input for event data
-						var toolInput map[string]interface{}
-						if err := json.Unmarshal([]byte(string(inputJSON)), &toolInput); err != nil {
-							toolInput = nil // Don't include invalid JSON
-						}
-
-						m.eventBus.Publish(bus.Event{
-							Type: bus.EventConversationUpdated,
-							Data: map[string]interface{}{
-								"session_id":         sessionID,
-								"claude_session_id":  claudeSessionID,
-								"event_type":         "tool_call",
-								"tool_id":            content.ID,
-								"tool_name":          content.Name,
-								"tool_input":         toolInput,
-								"parent_tool_use_id": event.ParentToolUseID,
-								"content_type":       "tool_use",
-							},
-						})
-					}
-
-				case "tool_result":
-					// Tool result (in user message)
-					convEvent := &store.ConversationEvent{
-						SessionID:         sessionID,
-						ClaudeSessionID:   claudeSessionID,
-						EventType:         store.EventTypeToolResult,
-						Role:              "user",
-						ToolResultForID:   content.ToolUseID,
-						ToolResultContent: content.Content.Value,
-					}
-					if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
-						return err
-					}
-
-					// Asynchronously capture file snapshot for Read tool results
-					if toolCall, err := m.store.GetToolCallByID(ctx, content.ToolUseID); err == nil && toolCall != nil && toolCall.ToolName == "Read" {
-						go m.captureFileSnapshot(ctx, sessionID, content.ToolUseID, toolCall.ToolInputJSON, content.Content.Value)
-					}
-
-					// Update session activity timestamp for tool results
-					m.updateSessionActivity(ctx, sessionID)
-
-					// Publish conversation updated event
-					if m.eventBus != nil {
-						m.eventBus.Publish(bus.Event{
-							Type: bus.EventConversationUpdated,
-							Data: map[string]interface{}{
-								"session_id":          sessionID,
-								"claude_session_id":   claudeSessionID,
-								"event_type":          "tool_result",
-								"tool_result_for_id":  content.ToolUseID,
-								"tool_result_content": content.Content.Value,
-								"content_type":        "tool_result",
-							},
-						})
-					}
+	case *claudecode.SystemInit:
+		// Check if we need to populate the model
+		session, err := m.store.GetSession(ctx, sessionID)
+		if err != nil {
+			slog.Error("failed to get session for model update", "error", err)
+			return nil // Non-fatal, continue processing
+		}
 
-					// Mark the corresponding tool call as completed
-					if err := m.store.MarkToolCallCompleted(ctx, content.ToolUseID, sessionID); err != nil {
-						slog.Error("failed to mark tool call as completed",
-							"tool_id", content.ToolUseID,
-							"session_id", sessionID,
-							"error", err)
-						// Continue anyway - this is not fatal
-					}
+		// Only update if model is empty and init event has a model
+		if session != nil && session.Model == "" && ev.Model != "" {
+			// Store the full model ID
+			modelID := ev.Model
 
-				case "thinking":
-					// Thinking message
-					convEvent := &store.ConversationEvent{
-						SessionID:       sessionID,
-						ClaudeSessionID: claudeSessionID,
-						EventType:       store.EventTypeThinking,
-						Role:            event.Message.Role,
-						Content:         content.Thinking,
-					}
-					if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
-						return err
-					}
+			// Extract simple model name from API format
+			modelName := simpleModelName(ev.Model)
 
-					// Update session activity timestamp for thinking messages
-					m.updateSessionActivity(ctx, sessionID)
-
-					// Publish conversation updated event
-					if m.eventBus != nil {
-						m.eventBus.Publish(bus.Event{
-							Type: bus.EventConversationUpdated,
-							Data: map[string]interface{}{
-								"session_id":        sessionID,
-								"claude_session_id": claudeSessionID,
-								"event_type":        "thinking",
-								"role":              event.Message.Role,
-								"content":           content.Thinking,
-								"content_type":      "thinking",
-							},
-						})
-					}
+			// Update session with both model ID and simplified name
+			if modelName != "" {
+				update := store.SessionUpdate{
+					Model:   &modelName,
+					ModelID: &modelID,
+				}
+				if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
+					slog.Error("failed to update session model from init event",
+						"session_id", sessionID,
+						"model", modelName,
+						"model_id", modelID,
+						"error", err)
+				} else {
+					slog.Info("populated session model from init event",
+						"session_id", sessionID,
+						"model", modelName,
+						"model_id", modelID)
+				}
+			} else {
+				// Still store the model ID even if we don't recognize the format
+				update := store.SessionUpdate{
+					ModelID: &modelID,
+				}
+				if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
+					slog.Error("failed to update session model_id from init event",
+						"session_id", sessionID,
+						"model_id", modelID,
+						"error", err)
+				} else {
+					slog.Debug("stored unrecognized model format in init event",
+						"session_id", sessionID,
+						"model_id", modelID)
 				}
 			}
 		}
+		// Don't store init event in conversation history - we only extract the model
 
-	case "result":
+	case *claudecode.AssistantMessage:
+		// Messages contain the actual content
+		// Token usage is already processed at the top of this function
+		return m.processMessageContent(ctx, sessionID, claudeSessionID, ev.ParentToolUseID, &ev.Message)
+
+	case *claudecode.UserToolResult:
+		return m.processMessageContent(ctx, sessionID, claudeSessionID, ev.ParentToolUseID, &ev.Message)
+
+	case *claudecode.ResultEvent:
 		// Session completion
 		status := store.SessionStatusCompleted
-		if event.IsError {
+		if ev.IsError {
 			status = store.SessionStatusFailed
 		}
 
@@ -1069,13 +1202,13 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 			Status:         &status,
 			CompletedAt:    &now,
 			LastActivityAt: &now,
-			CostUSD:        &event.CostUSD,
-			DurationMS:     &event.DurationMS,
+			CostUSD:        &ev.CostUSD,
+			DurationMS:     &ev.DurationMS,
 		}
 
 		// Process usage data from result event
-		if event.Usage != nil {
-			usage := event.Usage
+		if ev.Usage != nil {
+			usage := ev.Usage
 			// Skip updating token counts from result events - they appear to accumulate incorrectly
 			// Result events show cumulative cache reads across the entire session (bug)
 			// We only trust token counts from individual assistant messages
@@ -1085,8 +1218,15 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 				"reason", "result events report cumulative cache reads")
 		}
 
-		if event.Error != "" {
-			update.ErrorMessage = &event.Error
+		if ev.Error != "" {
+			update.ErrorMessage = &ev.Error
+		}
+
+		// A result that must match an output schema completes the session
+		// once the monitor has validated it
+		if _, ok := m.schemaSessions.Load(sessionID); ok && !ev.IsError {
+			update.Status = nil
+			update.CompletedAt = nil
 		}
 
 		return m.store.UpdateSession(ctx, sessionID, update)
@@ -1095,6 +1235,322 @@ func (m *Manager) processStreamEvent(ctx context.Context, sessionID string, clau
 	return nil
 }
 
+// simpleModelName extracts the short model name (opus, sonnet) from a full
+// model ID, case-insensitively. It returns "" for unrecognized formats.
+func simpleModelName(modelID string) string {
+	lowerModel := strings.ToLower(modelID)
+	if strings.Contains(lowerModel, "opus") {
+		return "opus"
+	} else if strings.Contains(lowerModel, "sonnet") {
+		return "sonnet"
+	}
+	return ""
+}
+
+// processMessageContent stores each content block of an assistant or user message
+func (m *Manager) processMessageContent(ctx context.Context, sessionID, claudeSessionID, parentToolUseID string, message *claudecode.Message) error {
+	for _, content := range message.Content {
+		switch content.Type {
+		case "text":
+			// Text message
+			convEvent := &store.ConversationEvent{
+				SessionID:       sessionID,
+				ClaudeSessionID: claudeSessionID,
+				EventType:       store.EventTypeMessage,
+				Role:            message.Role,
+				Content:         content.Text,
+			}
+			if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
+				return err
+			}
+
+			// Update session activity timestamp for text messages
+			m.updateSessionActivity(ctx, sessionID)
+
+			// Publish conversation updated event
+			if m.eventBus != nil {
+				m.eventBus.Publish(bus.Event{
+					Type: bus.EventConversationUpdated,
+					Data: map[string]interface{}{
+						"session_id":        sessionID,
+						"claude_session_id": claudeSessionID,
+						"event_type":        "message",
+						"role":              message.Role,
+						"content":           content.Text,
+						"content_type":      "text",
+					},
+				})
+			}
+
+		case "tool_use":
+			// Tool call
+			inputJSON, err := json.Marshal(content.Input)
+			if err != nil {
+				return fmt.Errorf("failed to marshal tool input: %w", err)
+			}
+
+			convEvent := &store.ConversationEvent{
+				SessionID:       sessionID,
+				ClaudeSessionID: claudeSessionID,
+				EventType:       store.EventTypeToolCall,
+				ToolID:          content.ID,
+				ToolName:        content.Name,
+				ToolInputJSON:   string(inputJSON),
+				ParentToolUseID: parentToolUseID, // Capture from event level
+				// We don't know yet if this needs approval - that comes from HumanLayer API
+			}
+			if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
+				return err
+			}
+
+			// Update session activity timestamp for tool calls
+			m.updateSessionActivity(ctx, sessionID)
+
+			// Publish conversation updated event
+			if m.eventBus != nil {
+				// Parse tool input for event data
+				var toolInput map[string]interface{}
+				if err := json.Unmarshal([]byte(string(inputJSON)), &toolInput); err != nil {
+					toolInput = nil // Don't include invalid JSON
+				}
+
+				m.eventBus.Publish(bus.Event{
+					Type: bus.EventConversationUpdated,
+					Data: map[string]interface{}{
+						"session_id":         sessionID,
+						"claude_session_id":  claudeSessionID,
+						"event_type":         "tool_call",
+						"tool_id":            content.ID,
+						"tool_name":          content.Name,
+						"tool_input":         toolInput,
+						"parent_tool_use_id": parentToolUseID,
+						"content_type":       "tool_use",
+					},
+				})
+			}
+
+		case "tool_result":
+			// Tool result (in user message)
+			convEvent := &store.ConversationEvent{
+				SessionID:         sessionID,
+				ClaudeSessionID:   claudeSessionID,
+				EventType:         store.EventTypeToolResult,
+				Role:              "user",
+				ToolResultForID:   content.ToolUseID,
+				ToolResultContent: content.Content.Value,
+				Attachments:       attachmentsFromBlocks(content.Content.Blocks),
+			}
+			if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
+				return err
+			}
+
+			// Asynchronously capture file snapshot for Read tool results
+			if toolCall, err := m.store.GetToolCallByID(ctx, content.ToolUseID); err == nil && toolCall != nil && toolCall.ToolName == "Read" {
+				go m.captureFileSnapshot(ctx, sessionID, content.ToolUseID, toolCall.ToolInputJSON, content.Content.Value)
+			}
+
+			// Update session activity timestamp for tool results
+			m.updateSessionActivity(ctx, sessionID)
+
+			// Publish conversation updated event
+			if m.eventBus != nil {
+				m.eventBus.Publish(bus.Event{
+					Type: bus.EventConversationUpdated,
+					Data: map[string]interface{}{
+						"session_id":          sessionID,
+						"claude_session_id":   claudeSessionID,
+						"event_type":          "tool_result",
+						"tool_result_for_id":  content.ToolUseID,
+						"tool_result_content": content.Content.Value,
+						"content_type":        "tool_result",
+						"attachment_count":    len(convEvent.Attachments),
+					},
+				})
+			}
+
+			// Mark the corresponding tool call as completed
+			if err := m.store.MarkToolCallCompleted(ctx, content.ToolUseID, sessionID); err != nil {
+				slog.Error("failed to mark tool call as completed",
+					"tool_id", content.ToolUseID,
+					"session_id", sessionID,
+					"error", err)
+				// Continue anyway - this is not fatal
+			}
+
+		case "thinking":
+			// Thinking message
+			convEvent := &store.ConversationEvent{
+				SessionID:       sessionID,
+				ClaudeSessionID: claudeSessionID,
+				EventType:       store.EventTypeThinking,
+				Role:            message.Role,
+				Content:         content.Thinking,
+			}
+			if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
+				return err
+			}
+
+			// Update session activity timestamp for thinking messages
+			m.updateSessionActivity(ctx, sessionID)
+
+			// Publish conversation updated event
+			if m.eventBus != nil {
+				m.eventBus.Publish(bus.Event{
+					Type: bus.EventConversationUpdated,
+					Data: map[string]interface{}{
+						"session_id":        sessionID,
+						"claude_session_id": claudeSessionID,
+						"event_type":        "thinking",
+						"role":              message.Role,
+						"content":           content.Thinking,
+						"content_type":      "thinking",
+					},
+				})
+			}
+
+		case claudecode.ContentTypeRedactedThinking:
+			// Encrypted thinking is kept as an attachment so it survives into history
+			convEvent := &store.ConversationEvent{
+				SessionID:       sessionID,
+				ClaudeSessionID: claudeSessionID,
+				EventType:       store.EventTypeThinking,
+				Role:            message.Role,
+				Attachments:     []store.Attachment{attachmentFromContent(content)},
+			}
+			if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
+				return err
+			}
+
+			m.updateSessionActivity(ctx, sessionID)
+
+			if m.eventBus != nil {
+				m.eventBus.Publish(bus.Event{
+					Type: bus.EventConversationUpdated,
+					Data: map[string]interface{}{
+						"session_id":        sessionID,
+						"claude_session_id": claudeSessionID,
+						"event_type":        "thinking",
+						"role":              message.Role,
+						"content_type":      content.Type,
+						"attachment_count":  1,
+					},
+				})
+			}
+
+		case claudecode.ContentTypeImage, claudecode.ContentTypeDocument:
+			// Media sent by the user or produced in a message
+			convEvent := &store.ConversationEvent{
+				SessionID:       sessionID,
+				ClaudeSessionID: claudeSessionID,
+				EventType:       store.EventTypeMessage,
+				Role:            message.Role,
+				Content:         content.Title,
+				Attachments:     []store.Attachment{attachmentFromContent(content)},
+			}
+			if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
+				return err
+			}
+
+			m.updateSessionActivity(ctx, sessionID)
+
+			if m.eventBus != nil {
+				m.eventBus.Publish(bus.Event{
+					Type: bus.EventConversationUpdated,
+					Data: map[string]interface{}{
+						"session_id":        sessionID,
+						"claude_session_id": claudeSessionID,
+						"event_type":        "message",
+						"role":              message.Role,
+						"content":           content.Title,
+						"content_type":      content.Type,
+						"attachment_count":  1,
+					},
+				})
+			}
+
+		case claudecode.ContentTypeServerToolUse:
+			// Server-side tool call (e.g. web search); runs without approval
+			inputJSON, err := json.Marshal(content.Input)
+			if err != nil {
+				return fmt.Errorf("failed to marshal server tool input: %w", err)
+			}
+
+			convEvent := &store.ConversationEvent{
+				SessionID:       sessionID,
+				ClaudeSessionID: claudeSessionID,
+				EventType:       store.EventTypeToolCall,
+				ToolID:          content.ID,
+				ToolName:        content.Name,
+				ToolInputJSON:   string(inputJSON),
+				ParentToolUseID: parentToolUseID,
+			}
+			if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
+				return err
+			}
+
+			m.updateSessionActivity(ctx, sessionID)
+
+			if m.eventBus != nil {
+				m.eventBus.Publish(bus.Event{
+					Type: bus.EventConversationUpdated,
+					Data: map[string]interface{}{
+						"session_id":         sessionID,
+						"claude_session_id":  claudeSessionID,
+						"event_type":         "tool_call",
+						"tool_id":            content.ID,
+						"tool_name":          content.Name,
+						"tool_input":         content.Input,
+						"parent_tool_use_id": parentToolUseID,
+						"content_type":       content.Type,
+					},
+				})
+			}
+
+		case claudecode.ContentTypeWebSearchToolResult:
+			// Server tool result, delivered in the same assistant message as the call
+			summary := webSearchSummary(content.Content.Blocks)
+			convEvent := &store.ConversationEvent{
+				SessionID:         sessionID,
+				ClaudeSessionID:   claudeSessionID,
+				EventType:         store.EventTypeToolResult,
+				Role:              message.Role,
+				ToolResultForID:   content.ToolUseID,
+				ToolResultContent: summary,
+				Attachments:       attachmentsFromBlocks(content.Content.Blocks),
+			}
+			if err := m.store.AddConversationEvent(ctx, convEvent); err != nil {
+				return err
+			}
+
+			m.updateSessionActivity(ctx, sessionID)
+
+			if m.eventBus != nil {
+				m.eventBus.Publish(bus.Event{
+					Type: bus.EventConversationUpdated,
+					Data: map[string]interface{}{
+						"session_id":          sessionID,
+						"claude_session_id":   claudeSessionID,
+						"event_type":          "tool_result",
+						"tool_result_for_id":  content.ToolUseID,
+						"tool_result_content": summary,
+						"content_type":        content.Type,
+						"attachment_count":    len(convEvent.Attachments),
+					},
+				})
+			}
+
+			if err := m.store.MarkToolCallCompleted(ctx, content.ToolUseID, sessionID); err != nil {
+				slog.Error("failed to mark server tool call as completed",
+					"tool_id", content.ToolUseID,
+					"session_id", sessionID,
+					"error", err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // captureFileSnapshot captures full file content for Read tool results
 func (m *Manager) captureFileSnapshot(ctx context.Context, sessionID, toolID, toolInputJSON, toolResultContent string) {
 	// Parse tool input to get file path
@@ -1245,8 +1701,17 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 		m.mu.RUnlock()
 
 		if exists {
-			_, err := claudeSession.Wait()
-			if err != nil {
+			waitCtx, cancel := context.WithTimeout(ctx, interruptWaitTimeout)
+			_, err := claudeSession.WaitContext(waitCtx)
+			cancel()
+
+			var ctxErr *claudecode.ContextError
+			if errors.As(err, &ctxErr) {
+				slog.Warn("interrupted session did not exit in time, process was killed",
+					"parent_session_id", req.ParentSessionID,
+					"timeout", interruptWaitTimeout,
+					"error", err)
+			} else if err != nil {
 				slog.Debug("interrupted session exited",
 					"parent_session_id", req.ParentSessionID,
 					"error", err)
@@ -1262,6 +1727,35 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 		slog.Info("session interrupted and completed, proceeding with resume",
 			"parent_session_id", req.ParentSessionID,
 			"final_status", parentSession.Status)
+	} else {
+		// A session launched with stream-json input keeps its process alive between turns.
+		// Close its input so claude exits before the session is resumed elsewhere.
+		m.mu.RLock()
+		claudeSession, exists := m.activeProcesses[req.ParentSessionID]
+		m.mu.RUnlock()
+
+		if exists {
+			slog.Info("closing input of idle session before resume",
+				"parent_session_id", req.ParentSessionID)
+
+			if err := claudeSession.CloseInput(); err != nil {
+				slog.Warn("failed to close session input",
+					"parent_session_id", req.ParentSessionID,
+					"error", err)
+			}
+
+			waitCtx, cancel := context.WithTimeout(ctx, interruptWaitTimeout)
+			_, err := claudeSession.WaitContext(waitCtx)
+			cancel()
+
+			var ctxErr *claudecode.ContextError
+			if errors.As(err, &ctxErr) {
+				slog.Warn("idle session did not exit in time, process was killed",
+					"parent_session_id", req.ParentSessionID,
+					"timeout", interruptWaitTimeout,
+					"error", err)
+			}
+		}
 	}
 
 	// Build config for resumed session
@@ -1278,6 +1772,7 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 		PermissionPromptTool: parentSession.PermissionPromptTool,
 		// MaxTurns intentionally NOT inherited - let it default or be specified
 	}
+	config.IncludePartialMessages = m.supportsPartialMessages() // Stream text to subscribers as it is generated
 
 	// Deserialize JSON arrays for tools
 	if parentSession.AllowedTools != "" {
@@ -1307,6 +1802,43 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 		}
 	}
 
+	// Inherit claude CLI options
+	config.PermissionMode = claudecode.PermissionMode(parentSession.PermissionMode)
+	config.FallbackModel = claudecode.Model(parentSession.FallbackModel)
+	config.Settings = parentSession.Settings
+	if parentSession.SettingSources != "" {
+		var sources []claudecode.SettingSource
+		if err := json.Unmarshal([]byte(parentSession.SettingSources), &sources); err == nil {
+			config.SettingSources = sources
+		} else {
+			slog.Error("Failed to unmarshal setting sources",
+				"error", err,
+				"raw", parentSession.SettingSources)
+		}
+	}
+	if parentSession.ExtraArgs != "" {
+		var extraArgs []string
+		if err := json.Unmarshal([]byte(parentSession.ExtraArgs), &extraArgs); err == nil {
+			config.ExtraArgs = extraArgs
+		} else {
+			slog.Error("Failed to unmarshal extra args",
+				"error", err,
+				"raw", parentSession.ExtraArgs)
+		}
+	}
+
+	// Deserialize and inherit resource limits
+	if parentSession.ResourceLimits != "" {
+		var limits claudecode.ResourceLimits
+		if err := json.Unmarshal([]byte(parentSession.ResourceLimits), &limits); err == nil {
+			config.Limits = &limits
+		} else {
+			slog.Error("Failed to unmarshal resource limits",
+				"error", err,
+				"raw", parentSession.ResourceLimits)
+		}
+	}
+
 	// Retrieve and inherit MCP configuration from parent session
 	mcpServers, err := m.store.GetMCPServers(ctx, req.ParentSessionID)
 	if err == nil && len(mcpServers) > 0 {
@@ -1378,6 +1910,33 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 	if req.MaxTurns > 0 {
 		config.MaxTurns = req.MaxTurns
 	}
+	if req.InputFormat != "" {
+		config.InputFormat = req.InputFormat
+	}
+	if req.Limits != nil {
+		config.Limits = req.Limits
+	}
+	if req.PermissionMode != "" {
+		config.PermissionMode = req.PermissionMode
+	}
+	if req.FallbackModel != "" {
+		config.FallbackModel = req.FallbackModel
+	}
+	if req.Settings != "" {
+		config.Settings = req.Settings
+	}
+	if len(req.SettingSources) > 0 {
+		config.SettingSources = req.SettingSources
+	}
+	if len(req.ExtraArgs) > 0 {
+		config.ExtraArgs = req.ExtraArgs
+	}
+	config.ForkSession = req.ForkSession
+	config.Limits = m.sessionLimits(config.Limits)
+	// Checked after merging so that options inherited from the parent are covered too
+	if err := ValidatePermissions(config.PermissionMode, config.ExtraArgs); err != nil {
+		return nil, err
+	}
 
 	// Create new session with parent reference
 	sessionID := uuid.New().String()
@@ -1389,6 +1948,8 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 	dbSession.Summary = CalculateSummary(req.Query)
 	// Inherit auto-accept setting from parent
 	dbSession.AutoAcceptEdits = parentSession.AutoAcceptEdits
+	// Inherit approval policy from parent
+	dbSession.ApprovalPolicy = parentSession.ApprovalPolicy
 	// Inherit dangerously skip permissions from parent
 	dbSession.DangerouslySkipPermissions = parentSession.DangerouslySkipPermissions
 	dbSession.DangerouslySkipPermissionsExpiresAt = parentSession.DangerouslySkipPermissionsExpiresAt
@@ -1536,7 +2097,7 @@ func (m *Manager) ContinueSession(ctx context.Context, req ContinueSessionConfig
 		"proxy_base_url", dbSession.ProxyBaseURL,
 		"proxy_model", dbSession.ProxyModelOverride)
 
-	claudeSession, err := m.client.Launch(config)
+	claudeSession, err := m.client.LaunchContext(ctx, config)
 	if err != nil {
 		slog.Error("failed to resume Claude session from failed parent",
 			"session_id", sessionID,
@@ -1674,6 +2235,87 @@ func (m *Manager) InterruptSession(ctx context.Context, sessionID string) error
 	return nil
 }
 
+// SendMessage sends a follow-up user message to a session whose claude process was
+// launched with stream-json input and is still running, without creating a child session
+func (m *Manager) SendMessage(ctx context.Context, sessionID string, message string) error {
+	m.mu.RLock()
+	claudeSession, exists := m.activeProcesses[sessionID]
+	m.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf("session not found or not active")
+	}
+
+	session, err := m.store.GetSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session.Status == store.SessionStatusInterrupting || session.Status == store.SessionStatusInterrupted {
+		return fmt.Errorf("cannot send message to session with status %s", session.Status)
+	}
+
+	if err := claudeSession.SendMessage(ctx, message); err != nil {
+		return fmt.Errorf("failed to send message to Claude session: %w", err)
+	}
+
+	// Claude does not echo stream-json input, so record the user turn ourselves
+	event := &store.ConversationEvent{
+		SessionID:       sessionID,
+		ClaudeSessionID: session.ClaudeSessionID,
+		EventType:       store.EventTypeMessage,
+		CreatedAt:       time.Now(),
+		Role:            "user",
+		Content:         message,
+	}
+	if err := m.store.AddConversationEvent(ctx, event); err != nil {
+		slog.Error("failed to store sent message",
+			"session_id", sessionID,
+			"error", err)
+	} else if m.eventBus != nil {
+		m.eventBus.Publish(bus.Event{
+			Type: bus.EventConversationUpdated,
+			Data: map[string]interface{}{
+				"session_id":        sessionID,
+				"claude_session_id": session.ClaudeSessionID,
+				"event_type":        "message",
+				"role":              "user",
+				"content":           message,
+				"content_type":      "text",
+			},
+		})
+	}
+
+	// Each finished turn marks the session completed; the new turn makes it running again
+	if session.Status != store.SessionStatusRunning {
+		status := string(StatusRunning)
+		now := time.Now()
+		update := store.SessionUpdate{
+			Status:         &status,
+			LastActivityAt: &now,
+		}
+		if err := m.store.UpdateSession(ctx, sessionID, update); err != nil {
+			slog.Error("failed to update session status after sending message",
+				"session_id", sessionID,
+				"error", err)
+		}
+
+		if m.eventBus != nil {
+			m.eventBus.Publish(bus.Event{
+				Type: bus.EventSessionStatusChanged,
+				Data: map[string]interface{}{
+					"session_id": sessionID,
+					"run_id":     session.RunID,
+					"old_status": session.Status,
+					"new_status": string(StatusRunning),
+				},
+			})
+		}
+	} else {
+		m.updateSessionActivity(ctx, sessionID)
+	}
+
+	return nil
+}
+
 // injectQueryAsFirstEvent adds the user's query as the first conversation event
 func (m *Manager) injectQueryAsFirstEvent(ctx context.Context, sessionID, claudeSessionID, query string) error {
 	// Check if we already have a user message as the first event (deduplication)
@@ -1788,19 +2430,32 @@ func (m *Manager) StopAllSessions(timeout time.Duration) error {
 	}
 }
 
-// forceKillRemaining forcefully terminates any remaining sessions
+// forceKillRemaining terminates any remaining sessions, escalating from the earlier
+// interrupt to SIGTERM and then SIGKILL across each session's process group
 func (m *Manager) forceKillRemaining() {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
+	// Snapshot without holding the lock, since terminating waits for each
+	// process to exit and monitorSession needs the lock to clean up
+	m.mu.RLock()
+	remaining := make(map[string]ClaudeSession, len(m.activeProcesses))
 	for id, session := range m.activeProcesses {
-		slog.Warn("force killing session", "session_id", id)
-		if err := session.Kill(); err != nil {
-			slog.Error("failed to force kill session",
-				"session_id", id,
-				"error", err)
-		}
+		remaining[id] = session
 	}
+	m.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for id, session := range remaining {
+		wg.Add(1)
+		go func(id string, session ClaudeSession) {
+			defer wg.Done()
+			slog.Warn("force terminating session", "session_id", id)
+			if err := session.Terminate(); err != nil {
+				slog.Error("failed to force terminate session",
+					"session_id", id,
+					"error", err)
+			}
+		}(id, session)
+	}
+	wg.Wait()
 }
 
 // UpdateSessionSettings updates session settings and publishes appropriate events
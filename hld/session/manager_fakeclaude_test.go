@@ -0,0 +1,503 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
+	"github.com/humanlayer/humanlayer/hld/bus"
+	"github.com/humanlayer/humanlayer/hld/internal/testutil/fakeclaude"
+	"github.com/humanlayer/humanlayer/hld/store"
+)
+
+// newFakeClaudeManager creates a manager backed by an in-memory store that
+// launches the fake claude executable with the fixtures in testdata/fakeclaude
+func newFakeClaudeManager(t *testing.T) (*Manager, store.ConversationStore, *fakeclaude.Fake) {
+	t.Helper()
+
+	fake := fakeclaude.Install(t, "testdata/fakeclaude")
+
+	sqliteStore, err := store.NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = sqliteStore.Close() })
+
+	manager, err := NewManager(bus.NewEventBus(), sqliteStore, "")
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	return manager, sqliteStore, fake
+}
+
+// waitForStatus polls the store until the session reaches a terminal status
+func waitForStatus(t *testing.T, s store.ConversationStore, sessionID string) *store.Session {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		sess, err := s.GetSession(context.Background(), sessionID)
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		switch sess.Status {
+		case store.SessionStatusCompleted, store.SessionStatusFailed, store.SessionStatusInterrupted:
+			return sess
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("session %s did not finish", sessionID)
+	return nil
+}
+
+func TestFakeClaude_LaunchAndContinue(t *testing.T) {
+	manager, s, fake := newFakeClaudeManager(t)
+	ctx := context.Background()
+
+	launched, err := manager.LaunchSession(ctx, LaunchSessionConfig{
+		SessionConfig: claudecode.SessionConfig{
+			Query:        "hello",
+			OutputFormat: claudecode.OutputStreamJSON,
+			WorkingDir:   t.TempDir(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("LaunchSession failed: %v", err)
+	}
+
+	sess := waitForStatus(t, s, launched.ID)
+	if sess.Status != store.SessionStatusCompleted {
+		t.Fatalf("expected completed, got %s (error: %s)", sess.Status, sess.ErrorMessage)
+	}
+	if sess.ResultContent != "You said: hello" {
+		t.Errorf("unexpected result content: %q", sess.ResultContent)
+	}
+	if sess.Model != "sonnet" || sess.ModelID != "claude-sonnet-4-5-20250929" {
+		t.Errorf("expected model from init event, got %q (%q)", sess.Model, sess.ModelID)
+	}
+
+	invocations := fake.Invocations(t)
+	if len(invocations) != 1 {
+		t.Fatalf("expected 1 invocation, got %d", len(invocations))
+	}
+	if sess.ClaudeSessionID != invocations[0].SessionID {
+		t.Errorf("expected claude session ID %s, got %s", invocations[0].SessionID, sess.ClaudeSessionID)
+	}
+
+	events, err := s.GetConversation(ctx, sess.ClaudeSessionID)
+	if err != nil {
+		t.Fatalf("failed to get conversation: %v", err)
+	}
+	var assistantText string
+	for _, event := range events {
+		if event.Role == "assistant" && event.EventType == store.EventTypeMessage {
+			assistantText = event.Content
+		}
+	}
+	if assistantText != "You said: hello" {
+		t.Errorf("expected assistant message in conversation, got %q", assistantText)
+	}
+
+	// Continuing resumes the parent's claude session
+	continued, err := manager.ContinueSession(ctx, ContinueSessionConfig{
+		ParentSessionID: launched.ID,
+		Query:           "again",
+	})
+	if err != nil {
+		t.Fatalf("ContinueSession failed: %v", err)
+	}
+
+	child := waitForStatus(t, s, continued.ID)
+	if child.Status != store.SessionStatusCompleted {
+		t.Fatalf("expected completed, got %s (error: %s)", child.Status, child.ErrorMessage)
+	}
+	if child.ResultContent != "Resumed: again" {
+		t.Errorf("unexpected result content: %q", child.ResultContent)
+	}
+
+	invocations = fake.Invocations(t)
+	if len(invocations) != 2 {
+		t.Fatalf("expected 2 invocations, got %d", len(invocations))
+	}
+	if invocations[1].ResumeID != sess.ClaudeSessionID {
+		t.Errorf("expected --resume %s, got %q", sess.ClaudeSessionID, invocations[1].ResumeID)
+	}
+}
+
+func TestFakeClaude_ProcessFailure(t *testing.T) {
+	manager, s, fake := newFakeClaudeManager(t)
+
+	launched, err := manager.LaunchSession(context.Background(), LaunchSessionConfig{
+		SessionConfig: claudecode.SessionConfig{
+			Query:        "please fail",
+			OutputFormat: claudecode.OutputStreamJSON,
+			WorkingDir:   t.TempDir(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("LaunchSession failed: %v", err)
+	}
+
+	sess := waitForStatus(t, s, launched.ID)
+	if sess.Status != store.SessionStatusFailed {
+		t.Fatalf("expected failed, got %s", sess.Status)
+	}
+	if sess.ErrorMessage == "" {
+		t.Error("expected error message from stderr/exit code")
+	}
+
+	invocations := fake.Invocations(t)
+	if len(invocations) != 1 || invocations[0].ExitCode != 1 {
+		t.Errorf("expected a single invocation exiting with 1, got %+v", invocations)
+	}
+}
+
+func TestFakeClaude_ResourceLimitExceeded(t *testing.T) {
+	manager, s, _ := newFakeClaudeManager(t)
+	ctx := context.Background()
+
+	sub := manager.eventBus.Subscribe(ctx, bus.EventFilter{
+		Types: []bus.EventType{bus.EventSessionResourceLimitExceeded},
+	})
+
+	launched, err := manager.LaunchSession(ctx, LaunchSessionConfig{
+		SessionConfig: claudecode.SessionConfig{
+			Query:        "hang",
+			OutputFormat: claudecode.OutputStreamJSON,
+			WorkingDir:   t.TempDir(),
+			Limits:       &claudecode.ResourceLimits{WallClock: time.Second},
+		},
+	})
+	if err != nil {
+		t.Fatalf("LaunchSession failed: %v", err)
+	}
+
+	sess := waitForStatus(t, s, launched.ID)
+	if sess.Status != store.SessionStatusFailed {
+		t.Fatalf("expected failed, got %s", sess.Status)
+	}
+	if sess.FailureReason != store.FailureReasonResourceLimit {
+		t.Errorf("expected failure reason %q, got %q", store.FailureReasonResourceLimit, sess.FailureReason)
+	}
+	if sess.ResourceLimits == "" {
+		t.Error("expected resource limits to be stored")
+	}
+
+	select {
+	case event := <-sub.Channel:
+		if event.Data["session_id"] != launched.ID || event.Data["limit"] != string(claudecode.LimitWallClock) {
+			t.Errorf("unexpected event data: %v", event.Data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected resource limit event")
+	}
+
+	// Continuing inherits the parent's limits
+	continued, err := manager.ContinueSession(ctx, ContinueSessionConfig{
+		ParentSessionID: launched.ID,
+		Query:           "again",
+	})
+	if err != nil {
+		t.Fatalf("ContinueSession failed: %v", err)
+	}
+
+	child := waitForStatus(t, s, continued.ID)
+	if child.Status != store.SessionStatusCompleted {
+		t.Fatalf("expected completed, got %s (error: %s)", child.Status, child.ErrorMessage)
+	}
+	if child.ResourceLimits != sess.ResourceLimits {
+		t.Errorf("expected inherited limits %s, got %s", sess.ResourceLimits, child.ResourceLimits)
+	}
+	if child.FailureReason != "" {
+		t.Errorf("expected no failure reason, got %q", child.FailureReason)
+	}
+}
+
+func TestFakeClaude_CLIOptionsInherited(t *testing.T) {
+	manager, s, fake := newFakeClaudeManager(t)
+	ctx := context.Background()
+
+	launched, err := manager.LaunchSession(ctx, LaunchSessionConfig{
+		SessionConfig: claudecode.SessionConfig{
+			Query:          "hello",
+			OutputFormat:   claudecode.OutputStreamJSON,
+			WorkingDir:     t.TempDir(),
+			Model:          claudecode.ModelOpus,
+			FallbackModel:  claudecode.ModelSonnet,
+			PermissionMode: claudecode.PermissionModePlan,
+			Settings:       `{"includeCoAuthoredBy":false}`,
+			SettingSources: []claudecode.SettingSource{claudecode.SettingSourceProject},
+			ExtraArgs:      []string{"--strict-mcp-config"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("LaunchSession failed: %v", err)
+	}
+	sess := waitForStatus(t, s, launched.ID)
+	if sess.PermissionMode != "plan" || sess.FallbackModel != "sonnet" || sess.SettingSources != `["project"]` {
+		t.Errorf("unexpected stored options: %+v", sess)
+	}
+
+	continued, err := manager.ContinueSession(ctx, ContinueSessionConfig{
+		ParentSessionID: launched.ID,
+		Query:           "again",
+		PermissionMode:  claudecode.PermissionModeAcceptEdits,
+		ForkSession:     true,
+	})
+	if err != nil {
+		t.Fatalf("ContinueSession failed: %v", err)
+	}
+	child := waitForStatus(t, s, continued.ID)
+	if child.PermissionMode != "acceptEdits" || child.ExtraArgs != sess.ExtraArgs || child.Settings != sess.Settings {
+		t.Errorf("expected inherited options with overridden permission mode, got %+v", child)
+	}
+
+	invocations := fake.Invocations(t)
+	if len(invocations) != 2 {
+		t.Fatalf("expected 2 invocations, got %d", len(invocations))
+	}
+	args := strings.Join(invocations[1].Args, " ")
+	for _, want := range []string{
+		"--fork-session",
+		"--fallback-model sonnet",
+		"--permission-mode acceptEdits",
+		"--setting-sources project",
+		"--strict-mcp-config",
+	} {
+		if !strings.Contains(args, want) {
+			t.Errorf("expected %q in continued args: %s", want, args)
+		}
+	}
+}
+
+func TestFakeClaude_PartialMessageDeltas(t *testing.T) {
+	manager, s, fake := newFakeClaudeManager(t)
+	ctx := context.Background()
+
+	sub := manager.eventBus.Subscribe(ctx, bus.EventFilter{
+		Types: []bus.EventType{bus.EventConversationDelta, bus.EventConversationUpdated},
+	})
+
+	launched, err := manager.LaunchSession(ctx, LaunchSessionConfig{
+		SessionConfig: claudecode.SessionConfig{
+			Query:        "stream please",
+			OutputFormat: claudecode.OutputStreamJSON,
+			WorkingDir:   t.TempDir(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("LaunchSession failed: %v", err)
+	}
+
+	sess := waitForStatus(t, s, launched.ID)
+	if sess.Status != store.SessionStatusCompleted {
+		t.Fatalf("expected completed, got %s (error: %s)", sess.Status, sess.ErrorMessage)
+	}
+	if !strings.Contains(strings.Join(fake.Invocations(t)[0].Args, " "), "--include-partial-messages") {
+		t.Error("expected --include-partial-messages to be passed")
+	}
+
+	var deltas []string
+	var messageUpdated bool
+	timeout := time.After(5 * time.Second)
+	for !messageUpdated {
+		select {
+		case event := <-sub.Channel:
+			switch event.Type {
+			case bus.EventConversationDelta:
+				if messageUpdated {
+					t.Error("delta published after the complete message")
+				}
+				if event.Data["session_id"] != launched.ID || event.Data["message_id"] != "msg_partial" || event.Data["block_type"] != "text" {
+					t.Errorf("unexpected delta data: %v", event.Data)
+				}
+				deltas = append(deltas, event.Data["content"].(string))
+			case bus.EventConversationUpdated:
+				messageUpdated = event.Data["content"] == "Hello, world"
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for conversation events")
+		}
+	}
+
+	if strings.Join(deltas, "") != "Hello, world" {
+		t.Errorf("expected deltas to add up to the message, got %q", deltas)
+	}
+	if len(deltas) == 0 || len(deltas) >= 5 {
+		t.Errorf("expected 5 deltas to be coalesced, got %d events", len(deltas))
+	}
+
+	// Only the complete message is stored
+	events, err := s.GetConversation(ctx, sess.ClaudeSessionID)
+	if err != nil {
+		t.Fatalf("failed to get conversation: %v", err)
+	}
+	var messages []string
+	for _, event := range events {
+		if event.EventType == store.EventTypeMessage && event.Role == "assistant" {
+			messages = append(messages, event.Content)
+		}
+	}
+	if len(messages) != 1 || messages[0] != "Hello, world" {
+		t.Errorf("expected only the complete message to be stored, got %q", messages)
+	}
+}
+
+func TestFakeClaude_PartialMessagesOldCLI(t *testing.T) {
+	// --include-partial-messages is only passed to claude versions supporting it
+	t.Setenv(fakeclaude.EnvVersion, "1.0.50")
+	manager, s, fake := newFakeClaudeManager(t)
+	ctx := context.Background()
+
+	launched, err := manager.LaunchSession(ctx, LaunchSessionConfig{
+		SessionConfig: claudecode.SessionConfig{
+			Query:        "stream please",
+			OutputFormat: claudecode.OutputStreamJSON,
+			WorkingDir:   t.TempDir(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("LaunchSession failed: %v", err)
+	}
+
+	sess := waitForStatus(t, s, launched.ID)
+	if sess.Status != store.SessionStatusCompleted {
+		t.Fatalf("expected completed, got %s (error: %s)", sess.Status, sess.ErrorMessage)
+	}
+	if strings.Contains(strings.Join(fake.Invocations(t)[0].Args, " "), "--include-partial-messages") {
+		t.Error("expected --include-partial-messages not to be passed to claude 1.0.50")
+	}
+}
+
+// waitForOutput polls the store until the session has finished validating its
+// result: a completed session with structured output, or a failed one
+func waitForOutput(t *testing.T, s store.ConversationStore, sessionID string) *store.Session {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		sess, err := s.GetSession(context.Background(), sessionID)
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		if sess.StructuredOutput != "" || sess.Status == store.SessionStatusFailed {
+			return sess
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("session %s did not produce structured output", sessionID)
+	return nil
+}
+
+func TestFakeClaude_OutputSchemaRepair(t *testing.T) {
+	schema := json.RawMessage(`{"type":"object","required":["status","tests"],"properties":{"status":{"enum":["passed","failed"]},"tests":{"type":"integer"}}}`)
+
+	t.Run("repairs an invalid result", func(t *testing.T) {
+		manager, s, fake := newFakeClaudeManager(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sub := manager.eventBus.Subscribe(ctx, bus.EventFilter{
+			Types: []bus.EventType{bus.EventSessionStatusChanged},
+		})
+
+		launched, err := manager.LaunchSession(ctx, LaunchSessionConfig{
+			SessionConfig: claudecode.SessionConfig{
+				Query:                   "report the build",
+				OutputFormat:            claudecode.OutputStreamJSON,
+				WorkingDir:              t.TempDir(),
+				OutputSchema:            schema,
+				OutputSchemaRepairTurns: 1,
+			},
+		})
+		if err != nil {
+			t.Fatalf("LaunchSession failed: %v", err)
+		}
+
+		// The session stays running through the repair turn
+		sess := waitForStatus(t, s, launched.ID)
+		if sess.Status != store.SessionStatusCompleted {
+			t.Fatalf("expected completed, got %s (error: %s)", sess.Status, sess.ErrorMessage)
+		}
+		for completed := false; !completed; {
+			select {
+			case event := <-sub.Channel:
+				if event.Data["old_status"] == string(StatusCompleted) {
+					t.Errorf("unexpected status change: %v", event.Data)
+				}
+				completed = event.Data["new_status"] == string(StatusCompleted)
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected session completion event")
+			}
+		}
+		select {
+		case event := <-sub.Channel:
+			t.Errorf("unexpected status change after completion: %v", event.Data)
+		case <-time.After(100 * time.Millisecond):
+		}
+		if sess.StructuredOutput != `{"status": "passed", "tests": 12}` {
+			t.Errorf("unexpected structured output: %s", sess.StructuredOutput)
+		}
+
+		invocations := fake.Invocations(t)
+		if len(invocations) != 2 {
+			t.Fatalf("expected launch and repair invocations, got %d", len(invocations))
+		}
+		if invocations[1].ResumeID != invocations[0].SessionID {
+			t.Errorf("expected repair turn to resume %s, got %q", invocations[0].SessionID, invocations[1].ResumeID)
+		}
+
+		info, err := manager.GetSessionInfo(launched.ID)
+		if err != nil {
+			t.Fatalf("GetSessionInfo failed: %v", err)
+		}
+		if string(info.StructuredOutput) != sess.StructuredOutput {
+			t.Errorf("expected structured output in session info, got %s", info.StructuredOutput)
+		}
+	})
+
+	t.Run("fails without repair turns", func(t *testing.T) {
+		manager, s, fake := newFakeClaudeManager(t)
+
+		launched, err := manager.LaunchSession(context.Background(), LaunchSessionConfig{
+			SessionConfig: claudecode.SessionConfig{
+				Query:        "report the build",
+				OutputFormat: claudecode.OutputStreamJSON,
+				WorkingDir:   t.TempDir(),
+				OutputSchema: schema,
+			},
+		})
+		if err != nil {
+			t.Fatalf("LaunchSession failed: %v", err)
+		}
+
+		sess := waitForOutput(t, s, launched.ID)
+		if sess.Status != store.SessionStatusFailed {
+			t.Fatalf("expected failed, got %s", sess.Status)
+		}
+		if sess.FailureReason != store.FailureReasonOutputSchema {
+			t.Errorf("expected failure reason %q, got %q", store.FailureReasonOutputSchema, sess.FailureReason)
+		}
+		if len(fake.Invocations(t)) != 1 {
+			t.Errorf("expected no repair turn")
+		}
+	})
+
+	t.Run("bounds repair turns", func(t *testing.T) {
+		manager, _, _ := newFakeClaudeManager(t)
+
+		_, err := manager.LaunchSession(context.Background(), LaunchSessionConfig{
+			SessionConfig: claudecode.SessionConfig{
+				Query:                   "report the build",
+				OutputSchema:            schema,
+				OutputSchemaRepairTurns: MaxOutputSchemaRepairTurns + 1,
+			},
+		})
+		if err == nil {
+			t.Fatal("expected an error for too many repair turns")
+		}
+	})
+}
@@ -87,7 +87,7 @@ func TestStopAllSessions_Timeout(t *testing.T) {
 
 	mockSession.EXPECT().GetID().Return(stubbornID).AnyTimes()
 	mockSession.EXPECT().Interrupt().Return(nil).Times(1)
-	mockSession.EXPECT().Kill().Return(nil).Times(1) // Force kill should be attempted
+	mockSession.EXPECT().Terminate().Return(nil).Times(1) // Force termination should be attempted
 
 	manager.activeProcesses[stubbornID] = mockSession
 
@@ -129,7 +129,7 @@ func TestStopAllSessions_ConcurrentOperations(t *testing.T) {
 			atomic.AddInt32(&interruptCount, 1)
 			return nil
 		}).MaxTimes(1)
-		mockSession.EXPECT().Kill().Return(nil).MaxTimes(1) // May be force killed on timeout
+		mockSession.EXPECT().Terminate().Return(nil).MaxTimes(1) // May be force terminated on timeout
 
 		manager.activeProcesses[sessionID] = mockSession
 
@@ -174,7 +174,7 @@ func TestStopAllSessions_ConcurrentOperations(t *testing.T) {
 				atomic.AddInt32(&interruptCount, 1)
 				return nil
 			}).MaxTimes(1)
-			mockSession.EXPECT().Kill().Return(nil).MaxTimes(1) // May be force killed on timeout
+			mockSession.EXPECT().Terminate().Return(nil).MaxTimes(1) // May be force terminated on timeout
 
 			manager.mu.Lock()
 			manager.activeProcesses[sessionID] = mockSession
@@ -233,7 +233,7 @@ func TestStopAllSessions_InterruptError(t *testing.T) {
 	mockErrorSession := NewMockClaudeSession(ctrl)
 	mockErrorSession.EXPECT().GetID().Return(errorID).AnyTimes()
 	mockErrorSession.EXPECT().Interrupt().Return(fmt.Errorf("interrupt failed"))
-	mockErrorSession.EXPECT().Kill().Return(nil) // Should attempt force kill on timeout
+	mockErrorSession.EXPECT().Terminate().Return(nil) // Should attempt force kill on timeout
 	manager.activeProcesses[errorID] = mockErrorSession
 
 	// Mock store expectations
@@ -300,7 +300,7 @@ func TestForceKillRemaining(t *testing.T) {
 		mockSession := NewMockClaudeSession(ctrl)
 
 		mockSession.EXPECT().GetID().Return(sessionID).AnyTimes()
-		mockSession.EXPECT().Kill().DoAndReturn(func() error {
+		mockSession.EXPECT().Terminate().DoAndReturn(func() error {
 			mu.Lock()
 			killCalled[sessionID] = true
 			mu.Unlock()
@@ -346,14 +346,14 @@ func TestStopAllSessions_MixedStatuses(t *testing.T) {
 	mockCompletedSession := NewMockClaudeSession(ctrl)
 	mockCompletedSession.EXPECT().GetID().Return(completedID).AnyTimes()
 	// No Interrupt() expectation
-	mockCompletedSession.EXPECT().Kill().Return(nil).MaxTimes(1) // May be force killed on timeout
+	mockCompletedSession.EXPECT().Terminate().Return(nil).MaxTimes(1) // May be force killed on timeout
 	manager.activeProcesses[completedID] = mockCompletedSession
 
 	// Interrupted session - should NOT be interrupted again
 	mockInterruptedSession := NewMockClaudeSession(ctrl)
 	mockInterruptedSession.EXPECT().GetID().Return(interruptedID).AnyTimes()
 	// No Interrupt() expectation
-	mockInterruptedSession.EXPECT().Kill().Return(nil).MaxTimes(1) // May be force killed on timeout
+	mockInterruptedSession.EXPECT().Terminate().Return(nil).MaxTimes(1) // May be force killed on timeout
 	manager.activeProcesses[interruptedID] = mockInterruptedSession
 
 	// Mock store expectations
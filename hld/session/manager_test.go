@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -294,6 +295,58 @@ func TestContinueSession_ValidatesClaudeSessionID(t *testing.T) {
 	}
 }
 
+func TestLaunchSession_RejectsPermissionBypass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No store expectations: rejected sessions must not be stored
+	mockStore := store.NewMockConversationStore(ctrl)
+	manager, _ := NewManager(nil, mockStore, "")
+
+	configs := map[string]claudecode.SessionConfig{
+		"permission mode": {Query: "q", PermissionMode: claudecode.PermissionModeBypassPermissions},
+		"extra argument":  {Query: "q", ExtraArgs: []string{"--dangerously-skip-permissions"}},
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			_, err := manager.LaunchSession(context.Background(), LaunchSessionConfig{SessionConfig: config})
+			if err == nil || !strings.Contains(err.Error(), "use dangerously_skip_permissions") {
+				t.Errorf("expected permission bypass to be rejected, got %v", err)
+			}
+		})
+	}
+}
+
+func TestContinueSession_RejectsInheritedPermissionBypass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := store.NewMockConversationStore(ctrl)
+	manager, _ := NewManager(nil, mockStore, "")
+
+	// Parent stored before bypass was rejected
+	parentSession := &store.Session{
+		ID:              "parent-1",
+		RunID:           "run-1",
+		ClaudeSessionID: "claude-1",
+		Status:          store.SessionStatusCompleted,
+		Query:           "original query",
+		WorkingDir:      "/tmp",
+		ExtraArgs:       `["--verbose","--dangerously-skip-permissions"]`,
+		CreatedAt:       time.Now(),
+	}
+	mockStore.EXPECT().GetSession(gomock.Any(), "parent-1").Return(parentSession, nil)
+	mockStore.EXPECT().GetMCPServers(gomock.Any(), "parent-1").Return(nil, nil).AnyTimes()
+
+	_, err := manager.ContinueSession(context.Background(), ContinueSessionConfig{
+		ParentSessionID: "parent-1",
+		Query:           "continue this",
+	})
+	if err == nil || !strings.Contains(err.Error(), "--dangerously-skip-permissions") {
+		t.Errorf("expected inherited permission bypass to be rejected, got %v", err)
+	}
+}
+
 func TestLaunchSession_SetsMCPEnvironment(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -626,6 +679,80 @@ func TestInterruptSession(t *testing.T) {
 	}
 }
 
+func TestSendMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := store.NewMockConversationStore(ctrl)
+	manager, _ := NewManager(nil, mockStore, "")
+
+	t.Run("session without active process", func(t *testing.T) {
+		err := manager.SendMessage(context.Background(), "not-found", "hello")
+		if err == nil || err.Error() != "session not found or not active" {
+			t.Errorf("Expected 'session not found or not active' error, got: %v", err)
+		}
+	})
+
+	t.Run("idle stream-json session", func(t *testing.T) {
+		mockClaude := NewMockClaudeSession(ctrl)
+		manager.mu.Lock()
+		manager.activeProcesses["sess-idle"] = mockClaude
+		manager.mu.Unlock()
+		defer func() {
+			manager.mu.Lock()
+			delete(manager.activeProcesses, "sess-idle")
+			manager.mu.Unlock()
+		}()
+
+		mockStore.EXPECT().GetSession(gomock.Any(), "sess-idle").Return(&store.Session{
+			ID:              "sess-idle",
+			RunID:           "run-idle",
+			ClaudeSessionID: "claude-idle",
+			Status:          store.SessionStatusCompleted,
+		}, nil)
+		mockClaude.EXPECT().SendMessage(gomock.Any(), "follow up").Return(nil)
+		mockStore.EXPECT().AddConversationEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, event *store.ConversationEvent) error {
+				if event.Role != "user" || event.Content != "follow up" || event.ClaudeSessionID != "claude-idle" {
+					t.Errorf("Unexpected conversation event: %+v", event)
+				}
+				return nil
+			})
+		mockStore.EXPECT().UpdateSession(gomock.Any(), "sess-idle", gomock.Any()).DoAndReturn(
+			func(ctx context.Context, sessionID string, update store.SessionUpdate) error {
+				if update.Status == nil || *update.Status != store.SessionStatusRunning {
+					t.Errorf("Expected status to be set to running, got: %v", update.Status)
+				}
+				return nil
+			})
+
+		if err := manager.SendMessage(context.Background(), "sess-idle", "follow up"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("interrupting session", func(t *testing.T) {
+		mockClaude := NewMockClaudeSession(ctrl)
+		manager.mu.Lock()
+		manager.activeProcesses["sess-stopping"] = mockClaude
+		manager.mu.Unlock()
+		defer func() {
+			manager.mu.Lock()
+			delete(manager.activeProcesses, "sess-stopping")
+			manager.mu.Unlock()
+		}()
+
+		mockStore.EXPECT().GetSession(gomock.Any(), "sess-stopping").Return(&store.Session{
+			ID:     "sess-stopping",
+			Status: store.SessionStatusInterrupting,
+		}, nil)
+
+		if err := manager.SendMessage(context.Background(), "sess-stopping", "too late"); err == nil {
+			t.Error("Expected error for interrupting session")
+		}
+	})
+}
+
 func TestContinueSession_InterruptsRunningSession(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -725,8 +852,8 @@ func TestStopAllSessions_FiltersSessionsByStatus(t *testing.T) {
 	// Set up expectations for the waiting session (should be interrupted)
 	mockClaudeSession3.EXPECT().Interrupt().Return(nil).Times(1)
 
-	// Add Kill() expectations for sessions that might timeout
-	mockClaudeSession2.EXPECT().Kill().Return(nil).MaxTimes(1) // Completed session might be force killed
+	// Add Terminate() expectations for sessions that might timeout
+	mockClaudeSession2.EXPECT().Terminate().Return(nil).MaxTimes(1) // Completed session might be force killed
 
 	// Manually populate activeProcesses for testing
 	manager.activeProcesses["session-running"] = mockClaudeSession1
@@ -842,7 +969,7 @@ func TestStopAllSessions_TimeoutBehavior(t *testing.T) {
 	mockClaudeSession := NewMockClaudeSession(ctrl)
 	// Set up expectations for forced kill since it won't stop gracefully
 	mockClaudeSession.EXPECT().Interrupt().Return(nil).Times(1)
-	mockClaudeSession.EXPECT().Kill().Return(nil).Times(1)
+	mockClaudeSession.EXPECT().Terminate().Return(nil).Times(1)
 	manager.activeProcesses["stuck-session"] = mockClaudeSession
 
 	// Mock GetSessionInfo
@@ -883,7 +1010,7 @@ func TestStopAllSessions_RaceConditions(t *testing.T) {
 		sessionID := fmt.Sprintf("session-%d", i)
 		mockSession := NewMockClaudeSession(ctrl)
 		mockSession.EXPECT().Interrupt().Return(nil).Times(1)
-		mockSession.EXPECT().Kill().Return(nil).MaxTimes(1) // Might be force killed if timeout
+		mockSession.EXPECT().Terminate().Return(nil).MaxTimes(1) // Might be force killed if timeout
 		manager.activeProcesses[sessionID] = mockSession
 
 		// Mock GetSessionInfo for each session
@@ -932,7 +1059,7 @@ func TestStopAllSessions_RaceConditions(t *testing.T) {
 			newSessionID := fmt.Sprintf("new-session-%d", i)
 			mockNewSession := NewMockClaudeSession(ctrl)
 			mockNewSession.EXPECT().Interrupt().Return(nil).MaxTimes(1)
-			mockNewSession.EXPECT().Kill().Return(nil).MaxTimes(1) // Might be force killed
+			mockNewSession.EXPECT().Terminate().Return(nil).MaxTimes(1) // Might be force killed
 			manager.activeProcesses[newSessionID] = mockNewSession
 			manager.mu.Unlock()
 
@@ -980,7 +1107,7 @@ func TestStopAllSessions_ForceKillBehavior(t *testing.T) {
 	// Session should be interrupted multiple times as we retry
 	mockClaudeSession.EXPECT().Interrupt().Return(nil).MinTimes(1)
 	// Eventually it should be force killed
-	mockClaudeSession.EXPECT().Kill().Return(nil).Times(1)
+	mockClaudeSession.EXPECT().Terminate().Return(nil).Times(1)
 
 	manager.activeProcesses["stubborn-session"] = mockClaudeSession
 
@@ -10,6 +10,7 @@
 package session
 
 import (
+	context "context"
 	reflect "reflect"
 
 	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
@@ -40,6 +41,20 @@ func (m *MockClaudeSession) EXPECT() *MockClaudeSessionMockRecorder {
 	return m.recorder
 }
 
+// CloseInput mocks base method.
+func (m *MockClaudeSession) CloseInput() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseInput")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseInput indicates an expected call of CloseInput.
+func (mr *MockClaudeSessionMockRecorder) CloseInput() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseInput", reflect.TypeOf((*MockClaudeSession)(nil).CloseInput))
+}
+
 // GetEvents mocks base method.
 func (m *MockClaudeSession) GetEvents() <-chan claudecode.StreamEvent {
 	m.ctrl.T.Helper()
@@ -96,6 +111,34 @@ func (mr *MockClaudeSessionMockRecorder) Kill() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kill", reflect.TypeOf((*MockClaudeSession)(nil).Kill))
 }
 
+// SendMessage mocks base method.
+func (m *MockClaudeSession) SendMessage(ctx context.Context, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", ctx, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockClaudeSessionMockRecorder) SendMessage(ctx, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockClaudeSession)(nil).SendMessage), ctx, text)
+}
+
+// Terminate mocks base method.
+func (m *MockClaudeSession) Terminate() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Terminate")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Terminate indicates an expected call of Terminate.
+func (mr *MockClaudeSessionMockRecorder) Terminate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Terminate", reflect.TypeOf((*MockClaudeSession)(nil).Terminate))
+}
+
 // Wait mocks base method.
 func (m *MockClaudeSession) Wait() (*claudecode.Result, error) {
 	m.ctrl.T.Helper()
@@ -110,3 +153,18 @@ func (mr *MockClaudeSessionMockRecorder) Wait() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockClaudeSession)(nil).Wait))
 }
+
+// WaitContext mocks base method.
+func (m *MockClaudeSession) WaitContext(ctx context.Context) (*claudecode.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitContext", ctx)
+	ret0, _ := ret[0].(*claudecode.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitContext indicates an expected call of WaitContext.
+func (mr *MockClaudeSessionMockRecorder) WaitContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitContext", reflect.TypeOf((*MockClaudeSession)(nil).WaitContext), ctx)
+}
@@ -2,6 +2,7 @@ package session
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	claudecode "github.com/humanlayer/humanlayer/claudecode-go"
@@ -49,6 +50,7 @@ type Info struct {
 	EndTime                             *time.Time         `json:"end_time,omitempty"`
 	LastActivityAt                      time.Time          `json:"last_activity_at"`
 	Error                               string             `json:"error,omitempty"`
+	FailureReason                       string             `json:"failure_reason,omitempty"`
 	Query                               string             `json:"query"`
 	Summary                             string             `json:"summary"`
 	Title                               string             `json:"title"`
@@ -56,6 +58,7 @@ type Info struct {
 	ModelID                             string             `json:"model_id,omitempty"`
 	WorkingDir                          string             `json:"working_dir,omitempty"`
 	Result                              *claudecode.Result `json:"result,omitempty"`
+	StructuredOutput                    json.RawMessage    `json:"structured_output,omitempty"`
 	AutoAcceptEdits                     bool               `json:"auto_accept_edits"`
 	DangerouslySkipPermissions          bool               `json:"dangerously_skip_permissions"`
 	DangerouslySkipPermissionsExpiresAt *time.Time         `json:"dangerously_skip_permissions_expires_at,omitempty"`
@@ -66,9 +69,10 @@ type Info struct {
 type LaunchSessionConfig struct {
 	claudecode.SessionConfig
 	// Daemon-level settings that don't get passed to Claude Code
-	Title                             string // Session title (optional)
-	DangerouslySkipPermissions        bool   // Whether to auto-approve all tools
-	DangerouslySkipPermissionsTimeout *int64 // Optional timeout in milliseconds
+	Title                             string          // Session title (optional)
+	DangerouslySkipPermissions        bool            // Whether to auto-approve all tools
+	DangerouslySkipPermissionsTimeout *int64          // Optional timeout in milliseconds
+	ApprovalPolicy                    json.RawMessage // Session-level approval policy (see approval.ParsePolicy)
 	// Proxy configuration
 	ProxyEnabled       bool   // Whether proxy is enabled
 	ProxyBaseURL       string // Proxy base URL
@@ -79,21 +83,49 @@ type LaunchSessionConfig struct {
 
 // ContinueSessionConfig contains the configuration for continuing a session
 type ContinueSessionConfig struct {
-	ParentSessionID       string                // The parent session to resume from
-	Query                 string                // The new query
-	SystemPrompt          string                // Optional system prompt override
-	AppendSystemPrompt    string                // Optional append to system prompt
-	MCPConfig             *claudecode.MCPConfig // Optional MCP config override
-	PermissionPromptTool  string                // Optional permission prompt tool
-	AllowedTools          []string              // Optional allowed tools override
-	DisallowedTools       []string              // Optional disallowed tools override
-	AdditionalDirectories []string              // Optional additional directories override
-	CustomInstructions    string                // Optional custom instructions
-	MaxTurns              int                   // Optional max turns override
-	ProxyEnabled          bool                  // Whether proxy is enabled
-	ProxyBaseURL          string                // Proxy base URL
-	ProxyModelOverride    string                // Model to use with proxy
-	ProxyAPIKey           string                // API key for proxy service
+	ParentSessionID       string                     // The parent session to resume from
+	Query                 string                     // The new query
+	SystemPrompt          string                     // Optional system prompt override
+	AppendSystemPrompt    string                     // Optional append to system prompt
+	MCPConfig             *claudecode.MCPConfig      // Optional MCP config override
+	PermissionPromptTool  string                     // Optional permission prompt tool
+	AllowedTools          []string                   // Optional allowed tools override
+	DisallowedTools       []string                   // Optional disallowed tools override
+	AdditionalDirectories []string                   // Optional additional directories override
+	CustomInstructions    string                     // Optional custom instructions
+	MaxTurns              int                        // Optional max turns override
+	InputFormat           claudecode.InputFormat     // Optional input format (stream-json enables SendMessage)
+	Limits                *claudecode.ResourceLimits // Optional resource limits override
+	PermissionMode        claudecode.PermissionMode  // Optional permission mode override
+	FallbackModel         claudecode.Model           // Optional fallback model override
+	Settings              string                     // Optional settings file or JSON override
+	SettingSources        []claudecode.SettingSource // Optional setting sources override
+	ExtraArgs             []string                   // Optional extra CLI arguments override
+	ForkSession           bool                       // Resume under a new claude session ID, leaving the parent's untouched
+	ProxyEnabled          bool                       // Whether proxy is enabled
+	ProxyBaseURL          string                     // Proxy base URL
+	ProxyModelOverride    string                     // Model to use with proxy
+	ProxyAPIKey           string                     // API key for proxy service
+}
+
+// ImportTranscriptsConfig selects the Claude transcripts to import
+type ImportTranscriptsConfig struct {
+	ProjectDir       string   // Directory the sessions were started in
+	ClaudeDir        string   // Claude config directory (default $CLAUDE_CONFIG_DIR or ~/.claude)
+	ClaudeSessionIDs []string // Only import these sessions (all when empty)
+}
+
+// ImportedSession describes a transcript imported into the store
+type ImportedSession struct {
+	SessionID       string `json:"session_id"`
+	ClaudeSessionID string `json:"claude_session_id"`
+	EventCount      int    `json:"event_count"`
+}
+
+// ImportResult is the outcome of importing transcripts
+type ImportResult struct {
+	Imported []ImportedSession `json:"imported"`
+	Skipped  []string          `json:"skipped"` // Claude session IDs already in the store
 }
 
 // SessionManager defines the interface for managing Claude Code sessions
@@ -113,6 +145,12 @@ type SessionManager interface {
 	// InterruptSession interrupts a running session
 	InterruptSession(ctx context.Context, sessionID string) error
 
+	// SendMessage sends a follow-up message to a session launched with stream-json input
+	SendMessage(ctx context.Context, sessionID string, message string) error
+
+	// ImportTranscripts imports sessions from Claude's on-disk transcripts for a project
+	ImportTranscripts(ctx context.Context, config ImportTranscriptsConfig) (*ImportResult, error)
+
 	// StopAllSessions gracefully stops all active sessions with a timeout
 	StopAllSessions(timeout time.Duration) error
 
@@ -121,6 +159,9 @@ type SessionManager interface {
 
 	// SetHTTPPort sets the HTTP port for the proxy endpoint
 	SetHTTPPort(port int)
+
+	// ClaudeVersion returns the detected claude CLI version, or "" if it is unknown
+	ClaudeVersion() string
 }
 
 // ReadToolResult represents the JSON structure of a Read tool result
@@ -146,6 +187,7 @@ func SessionToInfo(s store.Session) Info {
 		StartTime:                           s.CreatedAt,
 		LastActivityAt:                      s.LastActivityAt,
 		Error:                               s.ErrorMessage,
+		FailureReason:                       s.FailureReason,
 		Query:                               s.Query,
 		Summary:                             s.Summary,
 		Title:                               s.Title,
@@ -161,6 +203,9 @@ func SessionToInfo(s store.Session) Info {
 	if s.CompletedAt != nil {
 		info.EndTime = s.CompletedAt
 	}
+	if s.StructuredOutput != "" {
+		info.StructuredOutput = json.RawMessage(s.StructuredOutput)
+	}
 
 	// Populate Result field if we have result data
 	if s.ResultContent != "" || s.NumTurns != nil || s.CostUSD != nil || s.DurationMS != nil {
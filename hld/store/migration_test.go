@@ -2,7 +2,11 @@ package store_test
 
 import (
 	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/humanlayer/humanlayer/hld/store"
 	"github.com/stretchr/testify/assert"
@@ -81,3 +85,470 @@ func TestMigration14_ToolUseID(t *testing.T) {
 	require.NoError(t, err)
 	assert.Nil(t, retrieved2.ToolUseID, "ToolUseID should be nil when not provided")
 }
+
+func TestMigration18_ResourceLimits(t *testing.T) {
+	s, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+	ctx := context.Background()
+
+	session := &store.Session{
+		ID:             "test-session-1",
+		RunID:          "test-run-1",
+		Query:          "test query",
+		Status:         store.SessionStatusRunning,
+		ResourceLimits: `{"wall_clock":60000000000}`,
+	}
+	require.NoError(t, s.CreateSession(ctx, session))
+
+	reason := store.FailureReasonResourceLimit
+	require.NoError(t, s.UpdateSession(ctx, session.ID, store.SessionUpdate{FailureReason: &reason}))
+
+	retrieved, err := s.GetSession(ctx, session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, session.ResourceLimits, retrieved.ResourceLimits)
+	assert.Equal(t, store.FailureReasonResourceLimit, retrieved.FailureReason)
+
+	sessions, err := s.ListSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, store.FailureReasonResourceLimit, sessions[0].FailureReason)
+}
+
+func TestMigration20_ConversationAttachments(t *testing.T) {
+	s, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+	ctx := context.Background()
+
+	require.NoError(t, s.CreateSession(ctx, &store.Session{
+		ID:              "test-session-1",
+		RunID:           "test-run-1",
+		ClaudeSessionID: "claude-1",
+		Query:           "test query",
+		Status:          store.SessionStatusRunning,
+	}))
+
+	event := &store.ConversationEvent{
+		SessionID:         "test-session-1",
+		ClaudeSessionID:   "claude-1",
+		EventType:         store.EventTypeToolResult,
+		Role:              "user",
+		ToolResultForID:   "tool-1",
+		ToolResultContent: "Took a screenshot",
+		Attachments: []store.Attachment{
+			{BlockType: "image", SourceType: "base64", MediaType: "image/png", Data: "iVBORw0KGgo="},
+			{BlockType: "document", SourceType: "url", URL: "https://example.com/spec.pdf", Title: "spec"},
+		},
+	}
+	require.NoError(t, s.AddConversationEvent(ctx, event))
+	require.NoError(t, s.AddConversationEvent(ctx, &store.ConversationEvent{
+		SessionID:       "test-session-1",
+		ClaudeSessionID: "claude-1",
+		EventType:       store.EventTypeMessage,
+		Role:            "assistant",
+		Content:         "no attachments",
+	}))
+
+	events, err := s.GetSessionConversation(ctx, "test-session-1")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	attachments := events[0].Attachments
+	require.Len(t, attachments, 2)
+	assert.Equal(t, event.ID, attachments[0].EventID)
+	assert.Equal(t, "image/png", attachments[0].MediaType)
+	assert.Equal(t, "iVBORw0KGgo=", attachments[0].Data)
+	assert.Equal(t, 1, attachments[1].Position)
+	assert.Equal(t, "https://example.com/spec.pdf", attachments[1].URL)
+	assert.Empty(t, events[1].Attachments)
+
+	byClaudeSession, err := s.GetConversation(ctx, "claude-1")
+	require.NoError(t, err)
+	assert.Len(t, byClaudeSession[0].Attachments, 2)
+}
+
+func TestMigration21_StructuredOutput(t *testing.T) {
+	s, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+	ctx := context.Background()
+
+	require.NoError(t, s.CreateSession(ctx, &store.Session{
+		ID:     "test-session-1",
+		RunID:  "test-run-1",
+		Query:  "test query",
+		Status: store.SessionStatusRunning,
+	}))
+
+	sess, err := s.GetSession(ctx, "test-session-1")
+	require.NoError(t, err)
+	assert.Empty(t, sess.StructuredOutput)
+
+	output := `{"status":"passed"}`
+	require.NoError(t, s.UpdateSession(ctx, "test-session-1", store.SessionUpdate{
+		StructuredOutput: &output,
+	}))
+
+	sess, err = s.GetSession(ctx, "test-session-1")
+	require.NoError(t, err)
+	assert.Equal(t, output, sess.StructuredOutput)
+
+	sessions, err := s.ListSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, output, sessions[0].StructuredOutput)
+}
+
+func TestMigration22_ApprovalPolicy(t *testing.T) {
+	s, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+	ctx := context.Background()
+
+	policy := `{"rules":[{"tool":"Read","action":"allow"}]}`
+	require.NoError(t, s.CreateSession(ctx, &store.Session{
+		ID:             "test-session-1",
+		RunID:          "test-run-1",
+		Query:          "test query",
+		Status:         store.SessionStatusRunning,
+		ApprovalPolicy: policy,
+	}))
+
+	sess, err := s.GetSessionByRunID(ctx, "test-run-1")
+	require.NoError(t, err)
+	assert.Equal(t, policy, sess.ApprovalPolicy)
+
+	require.NoError(t, s.CreateApproval(ctx, &store.Approval{
+		ID:         "approval-1",
+		RunID:      "test-run-1",
+		SessionID:  "test-session-1",
+		Status:     store.ApprovalStatusLocalApproved,
+		ToolName:   "Read",
+		ToolInput:  []byte(`{}`),
+		PolicyRule: "session rule 1",
+	}))
+
+	approval, err := s.GetApproval(ctx, "approval-1")
+	require.NoError(t, err)
+	assert.Equal(t, "session rule 1", approval.PolicyRule)
+}
+
+func TestMigration23_ApprovalTimeouts(t *testing.T) {
+	s, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+	ctx := context.Background()
+
+	require.NoError(t, s.CreateSession(ctx, &store.Session{
+		ID:     "test-session-1",
+		RunID:  "test-run-1",
+		Query:  "test query",
+		Status: store.SessionStatusRunning,
+	}))
+
+	now := time.Now()
+	expired := now.Add(-time.Minute)
+	notExpired := now.Add(time.Hour)
+	for _, approval := range []*store.Approval{
+		{ID: "expired", ExpiresAt: &expired, OnTimeout: "interrupt", TimeoutMessage: "Nobody answered"},
+		{ID: "not-expired", ExpiresAt: &notExpired, OnTimeout: "deny"},
+		{ID: "no-timeout"},
+	} {
+		approval.RunID = "test-run-1"
+		approval.SessionID = "test-session-1"
+		approval.Status = store.ApprovalStatusLocalPending
+		approval.CreatedAt = now.Add(-time.Hour)
+		approval.ToolName = "Bash"
+		approval.ToolInput = []byte(`{}`)
+		require.NoError(t, s.CreateApproval(ctx, approval))
+	}
+
+	approvals, err := s.GetExpiredApprovals(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, approvals, 1)
+	assert.Equal(t, "expired", approvals[0].ID)
+	assert.Equal(t, "interrupt", approvals[0].OnTimeout)
+	assert.Equal(t, "Nobody answered", approvals[0].TimeoutMessage)
+	require.NotNil(t, approvals[0].ExpiresAt)
+	assert.WithinDuration(t, expired, *approvals[0].ExpiresAt, time.Second)
+
+	// The expired status is allowed and expired approvals are no longer pending
+	require.NoError(t, s.UpdateApprovalResponse(ctx, "expired", store.ApprovalStatusLocalExpired, store.ApprovalDecidedByTimeout, "Nobody answered"))
+	approval, err := s.GetApproval(ctx, "expired")
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalStatusLocalExpired, approval.Status)
+
+	approvals, err = s.GetExpiredApprovals(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, approvals)
+
+	pending, err := s.GetPendingApprovals(ctx, "test-session-1")
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+}
+
+func TestMigration25_ApprovalRules(t *testing.T) {
+	s, err := store.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+	ctx := context.Background()
+
+	for _, session := range []*store.Session{
+		{ID: "parent", RunID: "run-1", WorkingDir: "/work/repo"},
+		{ID: "child", RunID: "run-2", ParentSessionID: "parent", WorkingDir: "/work/repo"},
+		{ID: "other", RunID: "run-3", WorkingDir: "/work/other"},
+	} {
+		session.Query = "test query"
+		session.Status = store.SessionStatusRunning
+		require.NoError(t, s.CreateSession(ctx, session))
+	}
+
+	now := time.Now()
+	require.NoError(t, s.CreateApprovalRule(ctx, &store.ApprovalRule{
+		ID: "rule-1", Scope: store.ApprovalRuleScopeSession, SessionID: "parent",
+		ToolName: "Bash", Match: store.ApprovalRuleMatchPrefix, CommandPrefix: "go test", CreatedAt: now,
+	}))
+	require.NoError(t, s.CreateApprovalRule(ctx, &store.ApprovalRule{
+		ID: "rule-2", Scope: store.ApprovalRuleScopeProject, SessionID: "child", WorkingDir: "/work/repo",
+		ToolName: "Read", Match: store.ApprovalRuleMatchTool, CreatedAt: now.Add(time.Second),
+	}))
+
+	rules, err := s.ListApprovalRules(ctx, "child")
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "rule-1", rules[0].ID)
+	assert.Equal(t, "go test", rules[0].CommandPrefix)
+	assert.Equal(t, "/work/repo", rules[1].WorkingDir)
+
+	// The parent's own session rule and the project rule
+	rules, err = s.ListApprovalRules(ctx, "parent")
+	require.NoError(t, err)
+	assert.Len(t, rules, 2)
+
+	rules, err = s.ListApprovalRules(ctx, "other")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	require.NoError(t, s.DeleteApprovalRule(ctx, "rule-1"))
+	assert.ErrorIs(t, s.DeleteApprovalRule(ctx, "rule-1"), store.ErrNotFound)
+}
+
+func TestMigration26_ApprovalHistory(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	s, err := store.NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for _, session := range []*store.Session{
+		{ID: "sess-1", RunID: "run-1", WorkingDir: "/work/repo"},
+		{ID: "sess-2", RunID: "run-2", WorkingDir: "/work/other"},
+	} {
+		session.Query = "test query"
+		session.Status = store.SessionStatusRunning
+		require.NoError(t, s.CreateSession(ctx, session))
+	}
+
+	now := time.Now()
+	for i, approval := range []*store.Approval{
+		{ID: "human-approved", SessionID: "sess-1", ToolName: "Bash", Status: store.ApprovalStatusLocalPending},
+		{ID: "human-denied", SessionID: "sess-1", ToolName: "Bash", Status: store.ApprovalStatusLocalPending},
+		{ID: "policy", SessionID: "sess-1", ToolName: "Edit", Status: store.ApprovalStatusLocalApproved, PolicyRule: "docs", DecidedBy: store.ApprovalDecidedByPolicy},
+		{ID: "rule", SessionID: "sess-2", ToolName: "Bash", Status: store.ApprovalStatusLocalApproved, ApprovalRuleID: "rule-1", DecidedBy: store.ApprovalDecidedByRule},
+		{ID: "auto-accept", SessionID: "sess-2", ToolName: "Edit", Status: store.ApprovalStatusLocalApproved, Comment: "Auto-accepted (auto-accept mode enabled)", DecidedBy: store.ApprovalDecidedByAutoAccept},
+		{ID: "expired", SessionID: "sess-2", ToolName: "Bash", Status: store.ApprovalStatusLocalPending},
+		{ID: "pending", SessionID: "sess-2", ToolName: "Bash", Status: store.ApprovalStatusLocalPending},
+	} {
+		approval.RunID = "run-" + approval.SessionID[len("sess-"):]
+		approval.CreatedAt = now.Add(-time.Hour + time.Duration(i)*time.Minute)
+		approval.ToolInput = []byte(`{}`)
+		require.NoError(t, s.CreateApproval(ctx, approval))
+	}
+	require.NoError(t, s.UpdateApprovalResponse(ctx, "human-approved", store.ApprovalStatusLocalApproved, store.ApprovalDecidedByHuman, ""))
+	require.NoError(t, s.UpdateApprovalResponse(ctx, "human-denied", store.ApprovalStatusLocalDenied, store.ApprovalDecidedByHuman, "No"))
+	require.NoError(t, s.UpdateApprovalResponse(ctx, "expired", store.ApprovalStatusLocalExpired, store.ApprovalDecidedByTimeout, "No response"))
+
+	decidedBy := func(s store.ConversationStore) map[string]string {
+		approvals, err := s.ListApprovals(ctx, store.ApprovalFilter{})
+		require.NoError(t, err)
+		result := map[string]string{}
+		for _, approval := range approvals {
+			result[approval.ID] = approval.DecidedBy
+		}
+		return result
+	}
+	want := map[string]string{
+		"human-approved": store.ApprovalDecidedByHuman,
+		"human-denied":   store.ApprovalDecidedByHuman,
+		"policy":         store.ApprovalDecidedByPolicy,
+		"rule":           store.ApprovalDecidedByRule,
+		"auto-accept":    store.ApprovalDecidedByAutoAccept,
+		"expired":        store.ApprovalDecidedByTimeout,
+		"pending":        "",
+	}
+	assert.Equal(t, want, decidedBy(s))
+
+	// Approvals recorded before the migration are backfilled
+	db, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE approvals SET decided_by = NULL`)
+	require.NoError(t, err)
+	_, err = db.Exec(`DELETE FROM schema_version WHERE version >= 26`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	require.NoError(t, s.Close())
+
+	s, err = store.NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+	assert.Equal(t, want, decidedBy(s))
+
+	t.Run("filters", func(t *testing.T) {
+		since := now.Add(-time.Hour + 2*time.Minute)
+		until := now.Add(-time.Hour + 6*time.Minute)
+		tests := []struct {
+			name   string
+			filter store.ApprovalFilter
+			want   []string
+		}{
+			{"newest first", store.ApprovalFilter{}, []string{"pending", "expired", "auto-accept", "rule", "policy", "human-denied", "human-approved"}},
+			{"status", store.ApprovalFilter{Status: store.ApprovalStatusLocalApproved}, []string{"auto-accept", "rule", "policy", "human-approved"}},
+			{"tool and session", store.ApprovalFilter{ToolName: "Bash", SessionID: "sess-2"}, []string{"pending", "expired", "rule"}},
+			{"working directory", store.ApprovalFilter{WorkingDir: "/work/repo"}, []string{"policy", "human-denied", "human-approved"}},
+			{"decided by", store.ApprovalFilter{DecidedBy: store.ApprovalDecidedByHuman}, []string{"human-denied", "human-approved"}},
+			{"time range", store.ApprovalFilter{CreatedAfter: &since, CreatedBefore: &until}, []string{"expired", "auto-accept", "rule", "policy"}},
+			{"page", store.ApprovalFilter{Limit: 2, Offset: 1}, []string{"expired", "auto-accept"}},
+			{"offset without limit", store.ApprovalFilter{Offset: 5}, []string{"human-denied", "human-approved"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				approvals, err := s.ListApprovals(ctx, tt.filter)
+				require.NoError(t, err)
+				ids := make([]string, len(approvals))
+				for i, approval := range approvals {
+					ids[i] = approval.ID
+				}
+				assert.Equal(t, tt.want, ids)
+			})
+		}
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		stats, err := s.GetApprovalStats(ctx, store.ApprovalFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, 7, stats.Total)
+		assert.Equal(t, map[store.ApprovalStatus]int{"approved": 4, "denied": 1, "approval_expired": 1, "pending": 1}, stats.ByStatus)
+		assert.Equal(t, map[string]int{"human": 2, "policy": 1, "rule": 1, "auto_accept": 1, "timeout": 1}, stats.ByDecidedBy)
+		assert.InDelta(t, 4.0/6, stats.ApprovalRate, 0.001)
+		require.NotNil(t, stats.MedianResponseMS)
+		// Human decisions were made about an hour after the approvals were created
+		assert.InDelta(t, time.Hour.Milliseconds(), *stats.MedianResponseMS, float64(2*time.Minute.Milliseconds()))
+
+		require.Len(t, stats.Tools, 2)
+		bash := stats.Tools[0]
+		assert.Equal(t, "Bash", bash.ToolName)
+		assert.Equal(t, store.ToolApprovalStats{
+			ToolName: "Bash", Total: 5, Approved: 2, Denied: 1, Expired: 1, Pending: 1,
+			ApprovalRate: 0.5, MedianResponseMS: bash.MedianResponseMS,
+		}, bash)
+		assert.NotNil(t, bash.MedianResponseMS)
+		assert.Equal(t, "Edit", stats.Tools[1].ToolName)
+		assert.Equal(t, 1.0, stats.Tools[1].ApprovalRate)
+		assert.Nil(t, stats.Tools[1].MedianResponseMS, "no human decisions for Edit")
+
+		stats, err = s.GetApprovalStats(ctx, store.ApprovalFilter{WorkingDir: "/nowhere"})
+		require.NoError(t, err)
+		assert.Zero(t, stats.Total)
+		assert.Empty(t, stats.Tools)
+		assert.Nil(t, stats.MedianResponseMS)
+	})
+}
+
+func TestMigrations_UpgradeFromVersion17(t *testing.T) {
+	dump, err := os.ReadFile(filepath.Join("testdata", "baseline_v17.sql"))
+	require.NoError(t, err)
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(string(dump))
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	s, err := store.NewSQLiteStore(dbPath)
+	require.NoError(t, err, "an existing database must upgrade")
+	defer func() { _ = s.Close() }()
+	ctx := context.Background()
+
+	session, err := s.GetSession(ctx, "sess-1")
+	require.NoError(t, err)
+	assert.Equal(t, "claude-1", session.ClaudeSessionID)
+	assert.Equal(t, "/work/repo", session.WorkingDir)
+
+	approvals, err := s.ListApprovals(ctx, store.ApprovalFilter{})
+	require.NoError(t, err)
+	statuses := map[string]store.ApprovalStatus{}
+	decidedBy := map[string]string{}
+	for _, approval := range approvals {
+		statuses[approval.ID] = approval.Status
+		decidedBy[approval.ID] = approval.DecidedBy
+	}
+	assert.Equal(t, map[string]store.ApprovalStatus{
+		"approval-approved": store.ApprovalStatusLocalApproved,
+		"approval-denied":   store.ApprovalStatusLocalDenied,
+		"approval-pending":  store.ApprovalStatusLocalPending,
+	}, statuses)
+	assert.Equal(t, map[string]string{
+		"approval-approved": store.ApprovalDecidedByHuman,
+		"approval-denied":   store.ApprovalDecidedByHuman,
+		"approval-pending":  "",
+	}, decidedBy)
+
+	// Approvals created before the upgrade can expire
+	expired := time.Now().Add(-time.Minute)
+	require.NoError(t, s.CreateApproval(ctx, &store.Approval{
+		ID:        "approval-timeout",
+		RunID:     "run-2",
+		SessionID: "sess-2",
+		Status:    store.ApprovalStatusLocalPending,
+		ToolName:  "Bash",
+		ToolInput: []byte(`{}`),
+		ExpiresAt: &expired,
+	}))
+	due, err := s.GetExpiredApprovals(ctx, time.Now())
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.NoError(t, s.UpdateApprovalResponse(ctx, "approval-pending", store.ApprovalStatusLocalExpired, store.ApprovalDecidedByTimeout, "No response"))
+	approval, err := s.GetApproval(ctx, "approval-pending")
+	require.NoError(t, err)
+	assert.Equal(t, store.ApprovalStatusLocalExpired, approval.Status)
+
+	// The upgraded approvals table matches a fresh one
+	freshPath := filepath.Join(t.TempDir(), "fresh.db")
+	fresh, err := store.NewSQLiteStore(freshPath)
+	require.NoError(t, err)
+	require.NoError(t, fresh.Close())
+	approvalsSchema := func(path string) (string, []string) {
+		db, err := sql.Open("sqlite3", path)
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+		var schema string
+		require.NoError(t, db.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'table' AND name = 'approvals'`).Scan(&schema))
+		rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = 'approvals' AND sql IS NOT NULL ORDER BY name`)
+		require.NoError(t, err)
+		defer func() { _ = rows.Close() }()
+		var indexes []string
+		for rows.Next() {
+			var name string
+			require.NoError(t, rows.Scan(&name))
+			indexes = append(indexes, name)
+		}
+		require.NoError(t, rows.Err())
+		return schema, indexes
+	}
+	upgradedSchema, upgradedIndexes := approvalsSchema(dbPath)
+	freshSchema, freshIndexes := approvalsSchema(freshPath)
+	assert.Contains(t, upgradedSchema, "'approval_expired'")
+	assert.Contains(t, freshSchema, "'approval_expired'")
+	assert.Contains(t, upgradedIndexes, "idx_approvals_expires_at")
+	assert.Equal(t, freshIndexes, upgradedIndexes)
+}
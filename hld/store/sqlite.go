@@ -110,7 +110,24 @@ func (s *SQLiteStore) initSchema() error {
 		archived BOOLEAN DEFAULT FALSE,
 
 		-- Additional directories for --add-dir support
-		additional_directories TEXT
+		additional_directories TEXT,
+
+		-- Resource limits (JSON) and why the session failed, if it did
+		resource_limits TEXT,
+		failure_reason TEXT,
+
+		-- Claude CLI options
+		permission_mode TEXT,
+		fallback_model TEXT,
+		settings TEXT,
+		setting_sources TEXT,
+		extra_args TEXT,
+
+		-- Final result parsed against the launch's output schema (JSON)
+		structured_output TEXT,
+
+		-- Session-level approval policy rules (JSON)
+		approval_policy TEXT
 	);
 	CREATE INDEX IF NOT EXISTS idx_sessions_claude ON sessions(claude_session_id);
 	CREATE INDEX IF NOT EXISTS idx_sessions_status ON sessions(status);
@@ -191,16 +208,26 @@ func (s *SQLiteStore) initSchema() error {
 		id TEXT PRIMARY KEY,
 		run_id TEXT NOT NULL,
 		session_id TEXT NOT NULL,
-		status TEXT NOT NULL CHECK (status IN ('pending', 'approved', 'denied')),
+		status TEXT NOT NULL CHECK (status IN ('pending', 'approved', 'denied', 'approval_expired')),
 		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		responded_at DATETIME,
 
 		-- Tool approval fields
 		tool_name TEXT NOT NULL,
 		tool_input TEXT NOT NULL, -- JSON
+		tool_use_id TEXT,
 
 		-- Response fields
 		comment TEXT, -- For denial reasons or approval notes
+		policy_rule TEXT,
+		approval_rule_id TEXT, -- Always allow rule that approved the tool call
+		updated_input TEXT, -- JSON, tool input as edited by the approver
+		decided_by TEXT,
+
+		-- Timeout fields
+		expires_at DATETIME,
+		on_timeout TEXT,
+		timeout_message TEXT,
 
 		FOREIGN KEY (session_id) REFERENCES sessions(id)
 	);
@@ -891,6 +918,403 @@ func (s *SQLiteStore) applyMigrations() error {
 		slog.Info("Migration 17 applied successfully")
 	}
 
+	// Migration 18: Add resource_limits and failure_reason columns
+	if currentVersion < 18 {
+		slog.Info("Applying migration 18: Add resource_limits and failure_reason columns")
+
+		for _, column := range []string{"resource_limits", "failure_reason"} {
+			// Check if column already exists for idempotency
+			var columnExists int
+			err = s.db.QueryRow(`
+				SELECT COUNT(*) FROM pragma_table_info('sessions')
+				WHERE name = ?
+			`, column).Scan(&columnExists)
+			if err != nil {
+				return fmt.Errorf("failed to check %s column: %w", column, err)
+			}
+
+			if columnExists == 0 {
+				_, err = s.db.Exec(fmt.Sprintf(`ALTER TABLE sessions ADD COLUMN %s TEXT`, column))
+				if err != nil {
+					return fmt.Errorf("failed to add %s column: %w", column, err)
+				}
+			}
+		}
+
+		// Record migration
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (18, 'Add resource_limits and failure_reason columns for per-session resource limits')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 18: %w", err)
+		}
+
+		slog.Info("Migration 18 applied successfully")
+	}
+
+	// Migration 19: Add columns for the remaining claude CLI options
+	if currentVersion < 19 {
+		slog.Info("Applying migration 19: Add claude CLI option columns")
+
+		for _, column := range []string{"permission_mode", "fallback_model", "settings", "setting_sources", "extra_args"} {
+			// Check if column already exists for idempotency
+			var columnExists int
+			err = s.db.QueryRow(`
+				SELECT COUNT(*) FROM pragma_table_info('sessions')
+				WHERE name = ?
+			`, column).Scan(&columnExists)
+			if err != nil {
+				return fmt.Errorf("failed to check %s column: %w", column, err)
+			}
+
+			if columnExists == 0 {
+				_, err = s.db.Exec(fmt.Sprintf(`ALTER TABLE sessions ADD COLUMN %s TEXT`, column))
+				if err != nil {
+					return fmt.Errorf("failed to add %s column: %w", column, err)
+				}
+			}
+		}
+
+		// Record migration
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (19, 'Add permission_mode, fallback_model, settings, setting_sources and extra_args columns')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 19: %w", err)
+		}
+
+		slog.Info("Migration 19 applied successfully")
+	}
+
+	// Migration 20: Add conversation_attachments table for image, document and other rich blocks
+	if currentVersion < 20 {
+		slog.Info("Applying migration 20: Add conversation_attachments table")
+
+		_, err = s.db.Exec(`
+			CREATE TABLE IF NOT EXISTS conversation_attachments (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				event_id INTEGER NOT NULL,
+				position INTEGER NOT NULL, -- Order within the event
+				block_type TEXT NOT NULL,  -- image, document, redacted_thinking, web_search_result
+				source_type TEXT,          -- base64, url, text
+				media_type TEXT,
+				data TEXT,
+				url TEXT,
+				title TEXT,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+				FOREIGN KEY (event_id) REFERENCES conversation_events(id) ON DELETE CASCADE
+			);
+			CREATE INDEX IF NOT EXISTS idx_attachments_event
+				ON conversation_attachments(event_id, position);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create conversation_attachments table: %w", err)
+		}
+
+		// Record migration
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (20, 'Add conversation_attachments table for rich content blocks')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 20: %w", err)
+		}
+
+		slog.Info("Migration 20 applied successfully")
+	}
+
+	// Migration 21: Add structured_output column for results validated against an output schema
+	if currentVersion < 21 {
+		slog.Info("Applying migration 21: Add structured_output column")
+
+		// Check if column already exists for idempotency
+		var columnExists int
+		err = s.db.QueryRow(`
+			SELECT COUNT(*) FROM pragma_table_info('sessions')
+			WHERE name = 'structured_output'
+		`).Scan(&columnExists)
+		if err != nil {
+			return fmt.Errorf("failed to check structured_output column: %w", err)
+		}
+
+		if columnExists == 0 {
+			_, err = s.db.Exec(`ALTER TABLE sessions ADD COLUMN structured_output TEXT`)
+			if err != nil {
+				return fmt.Errorf("failed to add structured_output column: %w", err)
+			}
+		}
+
+		// Record migration
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (21, 'Add structured_output column for output schema results')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 21: %w", err)
+		}
+
+		slog.Info("Migration 21 applied successfully")
+	}
+
+	// Migration 22: Add approval policy columns
+	if currentVersion < 22 {
+		slog.Info("Applying migration 22: Add approval policy columns")
+
+		for _, column := range []struct{ table, name string }{
+			{"sessions", "approval_policy"},
+			{"approvals", "policy_rule"},
+		} {
+			// Check if column already exists for idempotency
+			var columnExists int
+			err = s.db.QueryRow(`
+				SELECT COUNT(*) FROM pragma_table_info(?)
+				WHERE name = ?
+			`, column.table, column.name).Scan(&columnExists)
+			if err != nil {
+				return fmt.Errorf("failed to check %s.%s column: %w", column.table, column.name, err)
+			}
+
+			if columnExists == 0 {
+				_, err = s.db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s TEXT`, column.table, column.name))
+				if err != nil {
+					return fmt.Errorf("failed to add %s.%s column: %w", column.table, column.name, err)
+				}
+			}
+		}
+
+		// Record migration
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (22, 'Add approval_policy to sessions and policy_rule to approvals')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 22: %w", err)
+		}
+
+		slog.Info("Migration 22 applied successfully")
+	}
+
+	// Migration 23: Approval timeouts
+	if currentVersion < 23 {
+		slog.Info("Applying migration 23: Add approval timeouts and expired status")
+
+		// SQLite cannot alter a CHECK constraint, so rebuild the approvals table
+		// to allow the expired status
+		tx, err := s.db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin migration 23: %w", err)
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		_, err = tx.Exec(`
+			CREATE TABLE approvals_new (
+				id TEXT PRIMARY KEY,
+				run_id TEXT NOT NULL,
+				session_id TEXT NOT NULL,
+				status TEXT NOT NULL CHECK (status IN ('pending', 'approved', 'denied', 'approval_expired')),
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				responded_at DATETIME,
+
+				-- Tool approval fields
+				tool_name TEXT NOT NULL,
+				tool_input TEXT NOT NULL, -- JSON
+				tool_use_id TEXT,
+
+				-- Response fields
+				comment TEXT, -- For denial reasons or approval notes
+				policy_rule TEXT,
+
+				-- Timeout fields
+				expires_at DATETIME,
+				on_timeout TEXT,
+				timeout_message TEXT,
+
+				FOREIGN KEY (session_id) REFERENCES sessions(id)
+			)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create new approvals table: %w", err)
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO approvals_new (
+				id, run_id, session_id, status, created_at, responded_at,
+				tool_name, tool_input, tool_use_id, comment, policy_rule
+			)
+			SELECT id, run_id, session_id, status, created_at, responded_at,
+				tool_name, tool_input, tool_use_id, comment, policy_rule
+			FROM approvals
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to copy approvals: %w", err)
+		}
+
+		_, err = tx.Exec(`
+			DROP TABLE approvals;
+			ALTER TABLE approvals_new RENAME TO approvals;
+			CREATE INDEX IF NOT EXISTS idx_approvals_pending ON approvals(status) WHERE status = 'pending';
+			CREATE INDEX IF NOT EXISTS idx_approvals_session ON approvals(session_id);
+			CREATE INDEX IF NOT EXISTS idx_approvals_run_id ON approvals(run_id);
+			CREATE INDEX IF NOT EXISTS idx_approvals_tool_use_id ON approvals(tool_use_id) WHERE tool_use_id IS NOT NULL;
+			CREATE INDEX IF NOT EXISTS idx_approvals_expires_at ON approvals(expires_at) WHERE status = 'pending' AND expires_at IS NOT NULL;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to replace approvals table: %w", err)
+		}
+
+		// Record migration
+		_, err = tx.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (23, 'Add approval timeouts and expired approval status')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 23: %w", err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration 23: %w", err)
+		}
+
+		slog.Info("Migration 23 applied successfully")
+	}
+
+	// Migration 24: Add updated_input to approvals
+	if currentVersion < 24 {
+		slog.Info("Applying migration 24: Add updated_input to approvals")
+
+		// Check if column already exists for idempotency
+		var columnExists int
+		err = s.db.QueryRow(`
+			SELECT COUNT(*) FROM pragma_table_info('approvals')
+			WHERE name = 'updated_input'
+		`).Scan(&columnExists)
+		if err != nil {
+			return fmt.Errorf("failed to check updated_input column: %w", err)
+		}
+
+		if columnExists == 0 {
+			_, err = s.db.Exec(`
+				ALTER TABLE approvals
+				ADD COLUMN updated_input TEXT
+			`)
+			if err != nil {
+				return fmt.Errorf("failed to add updated_input column: %w", err)
+			}
+		}
+
+		// Record migration
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (24, 'Add updated_input to approvals for approvals with edited tool input')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 24: %w", err)
+		}
+
+		slog.Info("Migration 24 applied successfully")
+	}
+
+	// Migration 25: Add approval_rules table for remembered "always allow" decisions
+	if currentVersion < 25 {
+		slog.Info("Applying migration 25: Add approval_rules table")
+
+		_, err = s.db.Exec(`
+			CREATE TABLE IF NOT EXISTS approval_rules (
+				id TEXT PRIMARY KEY,
+				scope TEXT NOT NULL CHECK (scope IN ('session', 'project')),
+				session_id TEXT NOT NULL,
+				working_dir TEXT,
+				tool_name TEXT NOT NULL,
+				match_type TEXT NOT NULL CHECK (match_type IN ('exact', 'tool', 'prefix')),
+				tool_input TEXT,
+				command_prefix TEXT,
+				approval_id TEXT,
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+				FOREIGN KEY (session_id) REFERENCES sessions(id)
+			);
+			CREATE INDEX IF NOT EXISTS idx_approval_rules_session
+				ON approval_rules(session_id);
+			CREATE INDEX IF NOT EXISTS idx_approval_rules_project
+				ON approval_rules(working_dir) WHERE scope = 'project';
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create approval_rules table: %w", err)
+		}
+
+		// Record migration
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (25, 'Add approval_rules table for always allow decisions')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 25: %w", err)
+		}
+
+		slog.Info("Migration 25 applied successfully")
+	}
+
+	// Migration 26: Record who or what decided each approval
+	if currentVersion < 26 {
+		slog.Info("Applying migration 26: Add decided_by and approval_rule_id to approvals")
+
+		// Check if columns already exist for idempotency
+		columns := []string{"decided_by", "approval_rule_id"}
+		for _, column := range columns {
+			var columnExists int
+			err = s.db.QueryRow(`
+				SELECT COUNT(*) FROM pragma_table_info('approvals')
+				WHERE name = ?
+			`, column).Scan(&columnExists)
+			if err != nil {
+				return fmt.Errorf("failed to check %s column: %w", column, err)
+			}
+
+			if columnExists == 0 {
+				_, err = s.db.Exec(fmt.Sprintf(`
+					ALTER TABLE approvals
+					ADD COLUMN %s TEXT
+				`, column))
+				if err != nil {
+					return fmt.Errorf("failed to add %s column: %w", column, err)
+				}
+			}
+		}
+
+		// Backfill from what earlier versions recorded: the always allow rule,
+		// the policy rule name, and the comment of auto-accepted approvals,
+		// which were never responded to
+		_, err = s.db.Exec(`
+			UPDATE approvals SET decided_by = CASE
+				WHEN status = 'approval_expired' THEN 'timeout'
+				WHEN approval_rule_id IS NOT NULL THEN 'rule'
+				WHEN policy_rule IS NOT NULL AND policy_rule != '' THEN 'policy'
+				WHEN responded_at IS NULL AND comment LIKE 'Auto-accepted%' THEN 'auto_accept'
+				ELSE 'human'
+			END
+			WHERE status != 'pending' AND decided_by IS NULL;
+			CREATE INDEX IF NOT EXISTS idx_approvals_created_at
+				ON approvals(created_at);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to backfill decided_by: %w", err)
+		}
+
+		// Record migration
+		_, err = s.db.Exec(`
+			INSERT INTO schema_version (version, description)
+			VALUES (26, 'Add decided_by and approval_rule_id to approvals for approval history queries')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to record migration 26: %w", err)
+		}
+
+		slog.Info("Migration 26 applied successfully")
+	}
+
 	return nil
 }
 
@@ -905,17 +1329,19 @@ func (s *SQLiteStore) CreateSession(ctx context.Context, session *Session) error
 		INSERT INTO sessions (
 			id, run_id, claude_session_id, parent_session_id,
 			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
-			permission_prompt_tool, allowed_tools, disallowed_tools, additional_directories,
+			permission_prompt_tool, allowed_tools, disallowed_tools, additional_directories, resource_limits, approval_policy,
+			permission_mode, fallback_model, settings, setting_sources, extra_args,
 			status, created_at, last_activity_at, auto_accept_edits, archived, dangerously_skip_permissions, dangerously_skip_permissions_expires_at,
 			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := s.db.ExecContext(ctx, query,
 		session.ID, session.RunID, session.ClaudeSessionID, session.ParentSessionID,
 		session.Query, session.Summary, session.Title, session.Model, session.ModelID, session.WorkingDir, session.MaxTurns,
 		session.SystemPrompt, session.AppendSystemPrompt, session.CustomInstructions,
-		session.PermissionPromptTool, session.AllowedTools, session.DisallowedTools, session.AdditionalDirectories,
+		session.PermissionPromptTool, session.AllowedTools, session.DisallowedTools, session.AdditionalDirectories, session.ResourceLimits, session.ApprovalPolicy,
+		session.PermissionMode, session.FallbackModel, session.Settings, session.SettingSources, session.ExtraArgs,
 		session.Status, session.CreatedAt, session.LastActivityAt, session.AutoAcceptEdits, session.Archived,
 		session.DangerouslySkipPermissions, session.DangerouslySkipPermissionsExpiresAt,
 		session.ProxyEnabled, session.ProxyBaseURL, session.ProxyModelOverride, session.ProxyAPIKey,
@@ -988,6 +1414,14 @@ func (s *SQLiteStore) UpdateSession(ctx context.Context, sessionID string, updat
 		setParts = append(setParts, "error_message = ?")
 		args = append(args, *updates.ErrorMessage)
 	}
+	if updates.FailureReason != nil {
+		setParts = append(setParts, "failure_reason = ?")
+		args = append(args, *updates.FailureReason)
+	}
+	if updates.StructuredOutput != nil {
+		setParts = append(setParts, "structured_output = ?")
+		args = append(args, *updates.StructuredOutput)
+	}
 	if updates.Summary != nil {
 		setParts = append(setParts, "summary = ?")
 		args = append(args, *updates.Summary)
@@ -1078,10 +1512,11 @@ func (s *SQLiteStore) GetSession(ctx context.Context, sessionID string) (*Sessio
 	query := `
 		SELECT id, run_id, claude_session_id, parent_session_id,
 			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
-			permission_prompt_tool, allowed_tools, disallowed_tools, additional_directories,
+			permission_prompt_tool, allowed_tools, disallowed_tools, additional_directories, resource_limits, approval_policy,
+			permission_mode, fallback_model, settings, setting_sources, extra_args,
 			status, created_at, last_activity_at, completed_at,
 			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
-			duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
+			duration_ms, num_turns, result_content, structured_output, error_message, failure_reason, auto_accept_edits, archived,
 			dangerously_skip_permissions, dangerously_skip_permissions_expires_at,
 			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key
 		FROM sessions WHERE id = ?
@@ -1089,12 +1524,13 @@ func (s *SQLiteStore) GetSession(ctx context.Context, sessionID string) (*Sessio
 
 	var session Session
 	var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
-	var permissionPromptTool, allowedTools, disallowedTools, additionalDirectories sql.NullString
+	var permissionPromptTool, allowedTools, disallowedTools, additionalDirectories, resourceLimits, approvalPolicy sql.NullString
+	var permissionMode, fallbackModel, settings, settingSources, extraArgs sql.NullString
 	var completedAt sql.NullTime
 	var costUSD sql.NullFloat64
 	var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
 	var durationMS, numTurns sql.NullInt64
-	var resultContent, errorMessage sql.NullString
+	var resultContent, structuredOutput, errorMessage, failureReason sql.NullString
 	var archived sql.NullBool
 	var dangerouslySkipPermissionsExpiresAt sql.NullTime
 	var proxyEnabled sql.NullBool
@@ -1104,10 +1540,11 @@ func (s *SQLiteStore) GetSession(ctx context.Context, sessionID string) (*Sessio
 		&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
 		&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
 		&systemPrompt, &appendSystemPrompt, &customInstructions,
-		&permissionPromptTool, &allowedTools, &disallowedTools, &additionalDirectories,
+		&permissionPromptTool, &allowedTools, &disallowedTools, &additionalDirectories, &resourceLimits, &approvalPolicy,
+		&permissionMode, &fallbackModel, &settings, &settingSources, &extraArgs,
 		&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
 		&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
-		&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
+		&durationMS, &numTurns, &resultContent, &structuredOutput, &errorMessage, &failureReason, &session.AutoAcceptEdits,
 		&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt,
 		&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey,
 	)
@@ -1133,7 +1570,16 @@ func (s *SQLiteStore) GetSession(ctx context.Context, sessionID string) (*Sessio
 	session.AllowedTools = allowedTools.String
 	session.DisallowedTools = disallowedTools.String
 	session.AdditionalDirectories = additionalDirectories.String
+	session.ResourceLimits = resourceLimits.String
+	session.ApprovalPolicy = approvalPolicy.String
+	session.PermissionMode = permissionMode.String
+	session.FallbackModel = fallbackModel.String
+	session.Settings = settings.String
+	session.SettingSources = settingSources.String
+	session.ExtraArgs = extraArgs.String
+	session.FailureReason = failureReason.String
 	session.ResultContent = resultContent.String
+	session.StructuredOutput = structuredOutput.String
 	session.ErrorMessage = errorMessage.String
 	if completedAt.Valid {
 		session.CompletedAt = &completedAt.Time
@@ -1192,10 +1638,11 @@ func (s *SQLiteStore) GetSessionByRunID(ctx context.Context, runID string) (*Ses
 	query := `
 		SELECT id, run_id, claude_session_id, parent_session_id,
 			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
-			permission_prompt_tool, allowed_tools, disallowed_tools, additional_directories,
+			permission_prompt_tool, allowed_tools, disallowed_tools, additional_directories, resource_limits, approval_policy,
+			permission_mode, fallback_model, settings, setting_sources, extra_args,
 			status, created_at, last_activity_at, completed_at,
 			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
-			duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
+			duration_ms, num_turns, result_content, structured_output, error_message, failure_reason, auto_accept_edits, archived,
 			dangerously_skip_permissions, dangerously_skip_permissions_expires_at,
 			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key
 		FROM sessions
@@ -1204,12 +1651,13 @@ func (s *SQLiteStore) GetSessionByRunID(ctx context.Context, runID string) (*Ses
 
 	var session Session
 	var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
-	var permissionPromptTool, allowedTools, disallowedTools, additionalDirectories sql.NullString
+	var permissionPromptTool, allowedTools, disallowedTools, additionalDirectories, resourceLimits, approvalPolicy sql.NullString
+	var permissionMode, fallbackModel, settings, settingSources, extraArgs sql.NullString
 	var completedAt sql.NullTime
 	var costUSD sql.NullFloat64
 	var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
 	var durationMS, numTurns sql.NullInt64
-	var resultContent, errorMessage sql.NullString
+	var resultContent, structuredOutput, errorMessage, failureReason sql.NullString
 	var archived sql.NullBool
 	var dangerouslySkipPermissionsExpiresAt sql.NullTime
 	var proxyEnabled sql.NullBool
@@ -1219,10 +1667,11 @@ func (s *SQLiteStore) GetSessionByRunID(ctx context.Context, runID string) (*Ses
 		&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
 		&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
 		&systemPrompt, &appendSystemPrompt, &customInstructions,
-		&permissionPromptTool, &allowedTools, &disallowedTools, &additionalDirectories,
+		&permissionPromptTool, &allowedTools, &disallowedTools, &additionalDirectories, &resourceLimits, &approvalPolicy,
+		&permissionMode, &fallbackModel, &settings, &settingSources, &extraArgs,
 		&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
 		&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
-		&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
+		&durationMS, &numTurns, &resultContent, &structuredOutput, &errorMessage, &failureReason, &session.AutoAcceptEdits,
 		&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt,
 		&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey,
 	)
@@ -1248,7 +1697,16 @@ func (s *SQLiteStore) GetSessionByRunID(ctx context.Context, runID string) (*Ses
 	session.AllowedTools = allowedTools.String
 	session.DisallowedTools = disallowedTools.String
 	session.AdditionalDirectories = additionalDirectories.String
+	session.ResourceLimits = resourceLimits.String
+	session.ApprovalPolicy = approvalPolicy.String
+	session.PermissionMode = permissionMode.String
+	session.FallbackModel = fallbackModel.String
+	session.Settings = settings.String
+	session.SettingSources = settingSources.String
+	session.ExtraArgs = extraArgs.String
+	session.FailureReason = failureReason.String
 	session.ResultContent = resultContent.String
+	session.StructuredOutput = structuredOutput.String
 	session.ErrorMessage = errorMessage.String
 	if completedAt.Valid {
 		session.CompletedAt = &completedAt.Time
@@ -1307,10 +1765,11 @@ func (s *SQLiteStore) ListSessions(ctx context.Context) ([]*Session, error) {
 	query := `
 		SELECT id, run_id, claude_session_id, parent_session_id,
 			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
-			permission_prompt_tool, allowed_tools, disallowed_tools, additional_directories,
+			permission_prompt_tool, allowed_tools, disallowed_tools, additional_directories, resource_limits, approval_policy,
+			permission_mode, fallback_model, settings, setting_sources, extra_args,
 			status, created_at, last_activity_at, completed_at,
 			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
-		duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
+		duration_ms, num_turns, result_content, structured_output, error_message, failure_reason, auto_accept_edits, archived,
 			dangerously_skip_permissions, dangerously_skip_permissions_expires_at,
 			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key
 		FROM sessions
@@ -1327,12 +1786,13 @@ func (s *SQLiteStore) ListSessions(ctx context.Context) ([]*Session, error) {
 	for rows.Next() {
 		var session Session
 		var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
-		var permissionPromptTool, allowedTools, disallowedTools, additionalDirectories sql.NullString
+		var permissionPromptTool, allowedTools, disallowedTools, additionalDirectories, resourceLimits, approvalPolicy sql.NullString
+		var permissionMode, fallbackModel, settings, settingSources, extraArgs sql.NullString
 		var completedAt sql.NullTime
 		var costUSD sql.NullFloat64
 		var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
 		var durationMS, numTurns sql.NullInt64
-		var resultContent, errorMessage sql.NullString
+		var resultContent, structuredOutput, errorMessage, failureReason sql.NullString
 		var archived sql.NullBool
 		var dangerouslySkipPermissionsExpiresAt sql.NullTime
 		var proxyEnabled sql.NullBool
@@ -1342,10 +1802,11 @@ func (s *SQLiteStore) ListSessions(ctx context.Context) ([]*Session, error) {
 			&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
 			&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
 			&systemPrompt, &appendSystemPrompt, &customInstructions,
-			&permissionPromptTool, &allowedTools, &disallowedTools, &additionalDirectories,
+			&permissionPromptTool, &allowedTools, &disallowedTools, &additionalDirectories, &resourceLimits, &approvalPolicy,
+			&permissionMode, &fallbackModel, &settings, &settingSources, &extraArgs,
 			&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
 			&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
-			&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
+			&durationMS, &numTurns, &resultContent, &structuredOutput, &errorMessage, &failureReason, &session.AutoAcceptEdits,
 			&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt,
 			&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey,
 		)
@@ -1404,7 +1865,16 @@ func (s *SQLiteStore) ListSessions(ctx context.Context) ([]*Session, error) {
 			session.NumTurns = &turns
 		}
 		session.AdditionalDirectories = additionalDirectories.String
+		session.ResourceLimits = resourceLimits.String
+		session.ApprovalPolicy = approvalPolicy.String
+		session.PermissionMode = permissionMode.String
+		session.FallbackModel = fallbackModel.String
+		session.Settings = settings.String
+		session.SettingSources = settingSources.String
+		session.ExtraArgs = extraArgs.String
+		session.FailureReason = failureReason.String
 		session.ResultContent = resultContent.String
+		session.StructuredOutput = structuredOutput.String
 		session.ErrorMessage = errorMessage.String
 
 		// Handle archived field - default to false if NULL
@@ -1433,10 +1903,11 @@ func (s *SQLiteStore) GetExpiredDangerousPermissionsSessions(ctx context.Context
 	query := `
 		SELECT id, run_id, claude_session_id, parent_session_id,
 			query, summary, title, model, model_id, working_dir, max_turns, system_prompt, append_system_prompt, custom_instructions,
-			permission_prompt_tool, allowed_tools, disallowed_tools, additional_directories,
+			permission_prompt_tool, allowed_tools, disallowed_tools, additional_directories, resource_limits, approval_policy,
+			permission_mode, fallback_model, settings, setting_sources, extra_args,
 			status, created_at, last_activity_at, completed_at,
 			cost_usd, input_tokens, output_tokens, cache_creation_input_tokens, cache_read_input_tokens, effective_context_tokens,
-		duration_ms, num_turns, result_content, error_message, auto_accept_edits, archived,
+		duration_ms, num_turns, result_content, structured_output, error_message, failure_reason, auto_accept_edits, archived,
 			dangerously_skip_permissions, dangerously_skip_permissions_expires_at,
 			proxy_enabled, proxy_base_url, proxy_model_override, proxy_api_key
 		FROM sessions
@@ -1457,12 +1928,13 @@ func (s *SQLiteStore) GetExpiredDangerousPermissionsSessions(ctx context.Context
 	for rows.Next() {
 		var session Session
 		var claudeSessionID, parentSessionID, summary, title, model, modelID, workingDir, systemPrompt, appendSystemPrompt, customInstructions sql.NullString
-		var permissionPromptTool, allowedTools, disallowedTools, additionalDirectories sql.NullString
+		var permissionPromptTool, allowedTools, disallowedTools, additionalDirectories, resourceLimits, approvalPolicy sql.NullString
+		var permissionMode, fallbackModel, settings, settingSources, extraArgs sql.NullString
 		var completedAt sql.NullTime
 		var costUSD sql.NullFloat64
 		var inputTokens, outputTokens, cacheCreationInputTokens, cacheReadInputTokens, effectiveContextTokens sql.NullInt64
 		var durationMS, numTurns sql.NullInt64
-		var resultContent, errorMessage sql.NullString
+		var resultContent, structuredOutput, errorMessage, failureReason sql.NullString
 		var archived sql.NullBool
 		var dangerouslySkipPermissionsExpiresAt sql.NullTime
 		var proxyEnabled sql.NullBool
@@ -1472,10 +1944,11 @@ func (s *SQLiteStore) GetExpiredDangerousPermissionsSessions(ctx context.Context
 			&session.ID, &session.RunID, &claudeSessionID, &parentSessionID,
 			&session.Query, &summary, &title, &model, &modelID, &workingDir, &session.MaxTurns,
 			&systemPrompt, &appendSystemPrompt, &customInstructions,
-			&permissionPromptTool, &allowedTools, &disallowedTools, &additionalDirectories,
+			&permissionPromptTool, &allowedTools, &disallowedTools, &additionalDirectories, &resourceLimits, &approvalPolicy,
+			&permissionMode, &fallbackModel, &settings, &settingSources, &extraArgs,
 			&session.Status, &session.CreatedAt, &session.LastActivityAt, &completedAt,
 			&costUSD, &inputTokens, &outputTokens, &cacheCreationInputTokens, &cacheReadInputTokens, &effectiveContextTokens,
-			&durationMS, &numTurns, &resultContent, &errorMessage, &session.AutoAcceptEdits,
+			&durationMS, &numTurns, &resultContent, &structuredOutput, &errorMessage, &failureReason, &session.AutoAcceptEdits,
 			&archived, &session.DangerouslySkipPermissions, &dangerouslySkipPermissionsExpiresAt,
 			&proxyEnabled, &proxyBaseURL, &proxyModelOverride, &proxyAPIKey,
 		)
@@ -1534,7 +2007,16 @@ func (s *SQLiteStore) GetExpiredDangerousPermissionsSessions(ctx context.Context
 			session.NumTurns = &turns
 		}
 		session.AdditionalDirectories = additionalDirectories.String
+		session.ResourceLimits = resourceLimits.String
+		session.ApprovalPolicy = approvalPolicy.String
+		session.PermissionMode = permissionMode.String
+		session.FallbackModel = fallbackModel.String
+		session.Settings = settings.String
+		session.SettingSources = settingSources.String
+		session.ExtraArgs = extraArgs.String
+		session.FailureReason = failureReason.String
 		session.ResultContent = resultContent.String
+		session.StructuredOutput = structuredOutput.String
 		session.ErrorMessage = errorMessage.String
 
 		// Handle archived field - default to false if NULL
@@ -1680,9 +2162,127 @@ func (s *SQLiteStore) AddConversationEvent(ctx context.Context, event *Conversat
 		event.ID = id
 	}
 
+	if err := insertAttachments(ctx, tx, event); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
+// insertAttachments stores the attachments of a newly inserted event
+func insertAttachments(ctx context.Context, tx *sql.Tx, event *ConversationEvent) error {
+	for i := range event.Attachments {
+		attachment := &event.Attachments[i]
+		attachment.EventID = event.ID
+		attachment.Position = i
+
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO conversation_attachments (
+				event_id, position, block_type, source_type, media_type, data, url, title
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`,
+			attachment.EventID, attachment.Position, attachment.BlockType, attachment.SourceType,
+			attachment.MediaType, attachment.Data, attachment.URL, attachment.Title,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add attachment: %w", err)
+		}
+		if id, err := result.LastInsertId(); err == nil {
+			attachment.ID = id
+		}
+	}
+	return nil
+}
+
+// loadAttachments fills in the attachments of events
+func (s *SQLiteStore) loadAttachments(ctx context.Context, events []*ConversationEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*ConversationEvent, len(events))
+	placeholders := make([]string, len(events))
+	args := make([]interface{}, len(events))
+	for i, event := range events {
+		byID[event.ID] = event
+		placeholders[i] = "?"
+		args[i] = event.ID
+	}
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, event_id, position, block_type, source_type, media_type, data, url, title
+		FROM conversation_attachments
+		WHERE event_id IN (%s)
+		ORDER BY event_id, position
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return fmt.Errorf("failed to get attachments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var attachment Attachment
+		var sourceType, mediaType, data, url, title sql.NullString
+		err := rows.Scan(
+			&attachment.ID, &attachment.EventID, &attachment.Position, &attachment.BlockType,
+			&sourceType, &mediaType, &data, &url, &title,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachment.SourceType = sourceType.String
+		attachment.MediaType = mediaType.String
+		attachment.Data = data.String
+		attachment.URL = url.String
+		attachment.Title = title.String
+
+		if event, ok := byID[attachment.EventID]; ok {
+			event.Attachments = append(event.Attachments, attachment)
+		}
+	}
+	return rows.Err()
+}
+
+// loadUpdatedToolInputs sets the tool input edited by the approver on tool
+// calls whose approval was approved with changes
+func (s *SQLiteStore) loadUpdatedToolInputs(ctx context.Context, events []*ConversationEvent) error {
+	byApprovalID := make(map[string]*ConversationEvent)
+	var placeholders []string
+	var args []interface{}
+	for _, event := range events {
+		if event.ApprovalID == "" {
+			continue
+		}
+		byApprovalID[event.ApprovalID] = event
+		placeholders = append(placeholders, "?")
+		args = append(args, event.ApprovalID)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, updated_input
+		FROM approvals
+		WHERE id IN (%s) AND updated_input IS NOT NULL
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return fmt.Errorf("failed to get updated tool inputs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var approvalID, updatedInput string
+		if err := rows.Scan(&approvalID, &updatedInput); err != nil {
+			return fmt.Errorf("failed to scan updated tool input: %w", err)
+		}
+		if event, ok := byApprovalID[approvalID]; ok {
+			event.UpdatedToolInputJSON = updatedInput
+		}
+	}
+	return rows.Err()
+}
+
 // GetConversation retrieves all events for a Claude session
 func (s *SQLiteStore) GetConversation(ctx context.Context, claudeSessionID string) ([]*ConversationEvent, error) {
 	query := `
@@ -1719,6 +2319,13 @@ func (s *SQLiteStore) GetConversation(ctx context.Context, claudeSessionID strin
 		events = append(events, event)
 	}
 
+	if err := s.loadAttachments(ctx, events); err != nil {
+		return nil, err
+	}
+	if err := s.loadUpdatedToolInputs(ctx, events); err != nil {
+		return nil, err
+	}
+
 	return events, nil
 }
 
@@ -1820,6 +2427,13 @@ func (s *SQLiteStore) GetSessionConversation(ctx context.Context, sessionID stri
 		events = append(events, event)
 	}
 
+	if err := s.loadAttachments(ctx, events); err != nil {
+		return nil, err
+	}
+	if err := s.loadUpdatedToolInputs(ctx, events); err != nil {
+		return nil, err
+	}
+
 	return events, nil
 }
 
@@ -2183,6 +2797,36 @@ func (s *SQLiteStore) StoreRawEvent(ctx context.Context, sessionID string, event
 	return nil
 }
 
+// StoreRawEvents stores a batch of raw events in a single transaction
+func (s *SQLiteStore) StoreRawEvents(ctx context.Context, sessionID string, eventJSON []string) error {
+	if len(eventJSON) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO raw_events (session_id, event_json)
+		VALUES (?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare raw event insert: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, event := range eventJSON {
+		if _, err := stmt.ExecContext(ctx, sessionID, event); err != nil {
+			return fmt.Errorf("failed to store raw event: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
 // CreateApproval creates a new approval
 func (s *SQLiteStore) CreateApproval(ctx context.Context, approval *Approval) error {
 	// Validate status
@@ -2193,13 +2837,23 @@ func (s *SQLiteStore) CreateApproval(ctx context.Context, approval *Approval) er
 	query := `
 		INSERT INTO approvals (
 			id, run_id, session_id, tool_use_id, status, created_at,
-			tool_name, tool_input, comment
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			tool_name, tool_input, comment, policy_rule, approval_rule_id, decided_by,
+			expires_at, on_timeout, timeout_message
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
+	var approvalRuleID, decidedBy sql.NullString
+	if approval.ApprovalRuleID != "" {
+		approvalRuleID = sql.NullString{String: approval.ApprovalRuleID, Valid: true}
+	}
+	if approval.DecidedBy != "" {
+		decidedBy = sql.NullString{String: approval.DecidedBy, Valid: true}
+	}
+
 	_, err := s.db.ExecContext(ctx, query,
 		approval.ID, approval.RunID, approval.SessionID, approval.ToolUseID, approval.Status.String(), approval.CreatedAt,
-		approval.ToolName, string(approval.ToolInput), approval.Comment,
+		approval.ToolName, string(approval.ToolInput), approval.Comment, approval.PolicyRule, approvalRuleID, decidedBy,
+		approval.ExpiresAt, approval.OnTimeout, approval.TimeoutMessage,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create approval: %w", err)
@@ -2207,31 +2861,29 @@ func (s *SQLiteStore) CreateApproval(ctx context.Context, approval *Approval) er
 	return nil
 }
 
-// GetApproval retrieves an approval by ID
-func (s *SQLiteStore) GetApproval(ctx context.Context, id string) (*Approval, error) {
-	query := `
-		SELECT id, run_id, session_id, tool_use_id, status, created_at, responded_at,
-			tool_name, tool_input, comment
-		FROM approvals WHERE id = ?
-	`
+// approvalColumns are the columns scanned by scanApproval
+const approvalColumns = `id, run_id, session_id, tool_use_id, status, created_at, responded_at,
+	tool_name, tool_input, comment, policy_rule, expires_at, on_timeout, timeout_message, updated_input,
+	decided_by, approval_rule_id`
 
+// scanApproval scans a row selected with approvalColumns
+func scanApproval(row interface{ Scan(dest ...any) error }) (*Approval, error) {
 	var approval Approval
 	var toolUseID sql.NullString
-	var respondedAt sql.NullTime
-	var comment sql.NullString
+	var respondedAt, expiresAt sql.NullTime
+	var comment, policyRule, onTimeout, timeoutMessage, updatedInput, decidedBy, approvalRuleID sql.NullString
 	var statusStr string
 	var toolInputStr string
 
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
+	err := row.Scan(
 		&approval.ID, &approval.RunID, &approval.SessionID, &toolUseID, &statusStr,
 		&approval.CreatedAt, &respondedAt,
-		&approval.ToolName, &toolInputStr, &comment,
+		&approval.ToolName, &toolInputStr, &comment, &policyRule,
+		&expiresAt, &onTimeout, &timeoutMessage, &updatedInput,
+		&decidedBy, &approvalRuleID,
 	)
-	if err == sql.ErrNoRows {
-		return nil, &NotFoundError{Type: "approval", ID: id}
-	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get approval: %w", err)
+		return nil, err
 	}
 
 	// Convert status string to ApprovalStatus
@@ -2247,17 +2899,41 @@ func (s *SQLiteStore) GetApproval(ctx context.Context, id string) (*Approval, er
 	if respondedAt.Valid {
 		approval.RespondedAt = &respondedAt.Time
 	}
+	if expiresAt.Valid {
+		approval.ExpiresAt = &expiresAt.Time
+	}
 	approval.Comment = comment.String
+	approval.PolicyRule = policyRule.String
+	approval.DecidedBy = decidedBy.String
+	approval.ApprovalRuleID = approvalRuleID.String
+	approval.OnTimeout = onTimeout.String
+	approval.TimeoutMessage = timeoutMessage.String
 	approval.ToolInput = json.RawMessage(toolInputStr)
+	if updatedInput.Valid {
+		approval.UpdatedInput = json.RawMessage(updatedInput.String)
+	}
 
 	return &approval, nil
 }
 
+// GetApproval retrieves an approval by ID
+func (s *SQLiteStore) GetApproval(ctx context.Context, id string) (*Approval, error) {
+	query := `SELECT ` + approvalColumns + ` FROM approvals WHERE id = ?`
+
+	approval, err := scanApproval(s.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, &NotFoundError{Type: "approval", ID: id}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval: %w", err)
+	}
+	return approval, nil
+}
+
 // GetPendingApprovals retrieves all pending approvals for a session
 func (s *SQLiteStore) GetPendingApprovals(ctx context.Context, sessionID string) ([]*Approval, error) {
 	query := `
-		SELECT id, run_id, session_id, tool_use_id, status, created_at, responded_at,
-			tool_name, tool_input, comment
+		SELECT ` + approvalColumns + `
 		FROM approvals
 		WHERE session_id = ? AND status = ?
 		ORDER BY created_at ASC
@@ -2271,46 +2947,229 @@ func (s *SQLiteStore) GetPendingApprovals(ctx context.Context, sessionID string)
 
 	var approvals []*Approval
 	for rows.Next() {
-		var approval Approval
-		var toolUseID sql.NullString
-		var respondedAt sql.NullTime
-		var comment sql.NullString
-		var statusStr string
-		var toolInputStr string
+		approval, err := scanApproval(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan approval: %w", err)
+		}
+		approvals = append(approvals, approval)
+	}
 
-		err := rows.Scan(
-			&approval.ID, &approval.RunID, &approval.SessionID, &toolUseID, &statusStr,
-			&approval.CreatedAt, &respondedAt,
-			&approval.ToolName, &toolInputStr, &comment,
-		)
+	return approvals, nil
+}
+
+// GetExpiredApprovals retrieves pending approvals whose expiry is at or before now
+func (s *SQLiteStore) GetExpiredApprovals(ctx context.Context, now time.Time) ([]*Approval, error) {
+	query := `
+		SELECT ` + approvalColumns + `
+		FROM approvals
+		WHERE status = ? AND expires_at IS NOT NULL AND expires_at <= ?
+		ORDER BY expires_at ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, ApprovalStatusLocalPending.String(), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired approvals: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var approvals []*Approval
+	for rows.Next() {
+		approval, err := scanApproval(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan approval: %w", err)
 		}
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, nil
+}
+
+// approvalFilterWhere builds the WHERE clause selecting approvals matching filter
+func approvalFilterWhere(filter ApprovalFilter) (string, []interface{}) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
 
-		// Convert status string to ApprovalStatus
-		approval.Status = ApprovalStatus(statusStr)
-		if !approval.Status.IsValid() {
-			return nil, fmt.Errorf("invalid approval status in database: %s", statusStr)
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status.String())
+	}
+	if filter.ToolName != "" {
+		conditions = append(conditions, "tool_name = ?")
+		args = append(args, filter.ToolName)
+	}
+	if filter.SessionID != "" {
+		conditions = append(conditions, "session_id = ?")
+		args = append(args, filter.SessionID)
+	}
+	if filter.WorkingDir != "" {
+		conditions = append(conditions, "session_id IN (SELECT id FROM sessions WHERE working_dir = ?)")
+		args = append(args, filter.WorkingDir)
+	}
+	if filter.DecidedBy != "" {
+		conditions = append(conditions, "decided_by = ?")
+		args = append(args, filter.DecidedBy)
+	}
+	// Compare as julian days, since timestamps may be stored with different offsets
+	if filter.CreatedAfter != nil {
+		conditions = append(conditions, "julianday(created_at) >= julianday(?)")
+		args = append(args, *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		conditions = append(conditions, "julianday(created_at) < julianday(?)")
+		args = append(args, *filter.CreatedBefore)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// ListApprovals retrieves approvals across sessions matching filter, newest first
+func (s *SQLiteStore) ListApprovals(ctx context.Context, filter ApprovalFilter) ([]*Approval, error) {
+	where, args := approvalFilterWhere(filter)
+	query := `
+		SELECT ` + approvalColumns + `
+		FROM approvals
+		WHERE ` + where + `
+		ORDER BY julianday(created_at) DESC, id DESC
+	`
+	if filter.Limit > 0 || filter.Offset > 0 {
+		// A negative LIMIT means no limit in SQLite
+		limit := filter.Limit
+		if limit <= 0 {
+			limit = -1
 		}
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, filter.Offset)
+	}
 
-		// Handle nullable fields
-		if toolUseID.Valid {
-			approval.ToolUseID = &toolUseID.String
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approvals: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	approvals := []*Approval{}
+	for rows.Next() {
+		approval, err := scanApproval(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan approval: %w", err)
 		}
-		if respondedAt.Valid {
-			approval.RespondedAt = &respondedAt.Time
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, rows.Err()
+}
+
+// GetApprovalStats aggregates the approvals matching filter
+func (s *SQLiteStore) GetApprovalStats(ctx context.Context, filter ApprovalFilter) (*ApprovalStats, error) {
+	where, args := approvalFilterWhere(filter)
+	query := `
+		SELECT tool_name, status, decided_by,
+			CAST((julianday(responded_at) - julianday(created_at)) * 86400000 AS INTEGER)
+		FROM approvals
+		WHERE ` + where
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	stats := &ApprovalStats{
+		ByStatus:    map[ApprovalStatus]int{},
+		ByDecidedBy: map[string]int{},
+		Tools:       []ToolApprovalStats{},
+	}
+	var responseTimes []int64
+	tools := map[string]*ToolApprovalStats{}
+	toolResponseTimes := map[string][]int64{}
+
+	for rows.Next() {
+		var toolName, status string
+		var decidedBy sql.NullString
+		var responseMS sql.NullInt64
+		if err := rows.Scan(&toolName, &status, &decidedBy, &responseMS); err != nil {
+			return nil, fmt.Errorf("failed to scan approval: %w", err)
+		}
+
+		tool, ok := tools[toolName]
+		if !ok {
+			tool = &ToolApprovalStats{ToolName: toolName}
+			tools[toolName] = tool
+		}
+
+		stats.Total++
+		tool.Total++
+		stats.ByStatus[ApprovalStatus(status)]++
+		if decidedBy.Valid {
+			stats.ByDecidedBy[decidedBy.String]++
+		}
+		switch ApprovalStatus(status) {
+		case ApprovalStatusLocalApproved:
+			tool.Approved++
+		case ApprovalStatusLocalDenied:
+			tool.Denied++
+		case ApprovalStatusLocalExpired:
+			tool.Expired++
+		case ApprovalStatusLocalPending:
+			tool.Pending++
 		}
-		approval.Comment = comment.String
-		approval.ToolInput = json.RawMessage(toolInputStr)
 
-		approvals = append(approvals, &approval)
+		humanDecided := decidedBy.String == ApprovalDecidedByHuman || decidedBy.String == ApprovalDecidedByHumanLayer
+		if humanDecided && responseMS.Valid {
+			responseTimes = append(responseTimes, responseMS.Int64)
+			toolResponseTimes[toolName] = append(toolResponseTimes[toolName], responseMS.Int64)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get approval stats: %w", err)
 	}
 
-	return approvals, nil
+	stats.ApprovalRate = approvalRate(stats.ByStatus[ApprovalStatusLocalApproved], stats.Total-stats.ByStatus[ApprovalStatusLocalPending])
+	stats.MedianResponseMS = median(responseTimes)
+	for _, tool := range tools {
+		tool.ApprovalRate = approvalRate(tool.Approved, tool.Total-tool.Pending)
+		tool.MedianResponseMS = median(toolResponseTimes[tool.ToolName])
+		stats.Tools = append(stats.Tools, *tool)
+	}
+	sort.Slice(stats.Tools, func(i, j int) bool {
+		return stats.Tools[i].ToolName < stats.Tools[j].ToolName
+	})
+
+	return stats, nil
+}
+
+// approvalRate returns the share of decided approvals that were approved
+func approvalRate(approved, decided int) float64 {
+	if decided == 0 {
+		return 0
+	}
+	return float64(approved) / float64(decided)
 }
 
-// UpdateApprovalResponse updates the status and comment of an approval
-func (s *SQLiteStore) UpdateApprovalResponse(ctx context.Context, id string, status ApprovalStatus, comment string) error {
+// median returns the median of values, or nil if there are none
+func median(values []int64) *int64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	mid := len(values) / 2
+	m := values[mid]
+	if len(values)%2 == 0 {
+		m = (values[mid-1] + values[mid]) / 2
+	}
+	return &m
+}
+
+// UpdateApprovalResponse updates the status and comment of an approval and
+// records who or what decided it
+func (s *SQLiteStore) UpdateApprovalResponse(ctx context.Context, id string, status ApprovalStatus, decidedBy string, comment string) error {
+	return s.UpdateApprovalResponseWithInput(ctx, id, status, decidedBy, comment, nil)
+}
+
+// UpdateApprovalResponseWithInput updates the status and comment of an
+// approval, recording updatedInput as the tool input edited by the approver
+// unless it is nil
+func (s *SQLiteStore) UpdateApprovalResponseWithInput(ctx context.Context, id string, status ApprovalStatus, decidedBy string, comment string, updatedInput json.RawMessage) error {
 	// Validate status
 	if !status.IsValid() {
 		return fmt.Errorf("invalid approval status: %s", status)
@@ -2327,13 +3186,18 @@ func (s *SQLiteStore) UpdateApprovalResponse(ctx context.Context, id string, sta
 		return &AlreadyDecidedError{ID: id, Status: approval.Status.String()}
 	}
 
+	var updatedInputStr sql.NullString
+	if updatedInput != nil {
+		updatedInputStr = sql.NullString{String: string(updatedInput), Valid: true}
+	}
+
 	query := `
 		UPDATE approvals
-		SET status = ?, comment = ?, responded_at = CURRENT_TIMESTAMP
+		SET status = ?, comment = ?, updated_input = ?, decided_by = ?, responded_at = CURRENT_TIMESTAMP
 		WHERE id = ? AND status = ?
 	`
 
-	result, err := s.db.ExecContext(ctx, query, status.String(), comment, id, ApprovalStatusLocalPending.String())
+	result, err := s.db.ExecContext(ctx, query, status.String(), comment, updatedInputStr, decidedBy, id, ApprovalStatusLocalPending.String())
 	if err != nil {
 		return fmt.Errorf("failed to update approval response: %w", err)
 	}
@@ -2410,6 +3274,80 @@ func MCPServersFromConfig(sessionID string, config map[string]claudecode.MCPServ
 	return servers, nil
 }
 
+// CreateApprovalRule stores a new approval rule
+func (s *SQLiteStore) CreateApprovalRule(ctx context.Context, rule *ApprovalRule) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO approval_rules (
+			id, scope, session_id, working_dir, tool_name, match_type,
+			tool_input, command_prefix, approval_id, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, rule.ID, rule.Scope, rule.SessionID, rule.WorkingDir, rule.ToolName, rule.Match,
+		rule.ToolInput, rule.CommandPrefix, rule.ApprovalID, rule.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create approval rule: %w", err)
+	}
+	return nil
+}
+
+// ListApprovalRules retrieves the rules that apply to a session, oldest first
+func (s *SQLiteStore) ListApprovalRules(ctx context.Context, sessionID string) ([]*ApprovalRule, error) {
+	// Session rules follow continued sessions, so walk up the parent chain
+	rows, err := s.db.QueryContext(ctx, `
+		WITH RECURSIVE lineage(id, parent_id, working_dir, depth) AS (
+			SELECT id, parent_session_id, working_dir, 0 FROM sessions WHERE id = ?
+			UNION ALL
+			SELECT s.id, s.parent_session_id, s.working_dir, l.depth + 1
+			FROM sessions s JOIN lineage l ON s.id = l.parent_id
+		)
+		SELECT id, scope, session_id, working_dir, tool_name, match_type,
+			tool_input, command_prefix, approval_id, created_at
+		FROM approval_rules
+		WHERE (scope = 'session' AND session_id IN (SELECT id FROM lineage))
+			OR (scope = 'project' AND working_dir != '' AND working_dir = (SELECT working_dir FROM lineage WHERE depth = 0))
+		ORDER BY created_at ASC
+	`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approval rules: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var rules []*ApprovalRule
+	for rows.Next() {
+		var rule ApprovalRule
+		var workingDir, toolInput, commandPrefix, approvalID sql.NullString
+		err := rows.Scan(
+			&rule.ID, &rule.Scope, &rule.SessionID, &workingDir, &rule.ToolName, &rule.Match,
+			&toolInput, &commandPrefix, &approvalID, &rule.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan approval rule: %w", err)
+		}
+		rule.WorkingDir = workingDir.String
+		rule.ToolInput = toolInput.String
+		rule.CommandPrefix = commandPrefix.String
+		rule.ApprovalID = approvalID.String
+		rules = append(rules, &rule)
+	}
+	return rules, rows.Err()
+}
+
+// DeleteApprovalRule deletes an approval rule
+func (s *SQLiteStore) DeleteApprovalRule(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM approval_rules WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete approval rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return &NotFoundError{Type: "approval rule", ID: id}
+	}
+	return nil
+}
+
 // CreateFileSnapshot stores a new file snapshot
 func (s *SQLiteStore) CreateFileSnapshot(ctx context.Context, snapshot *FileSnapshot) error {
 	_, err := s.db.ExecContext(ctx, `
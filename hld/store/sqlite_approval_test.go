@@ -65,11 +65,11 @@ func TestApprovalErrors(t *testing.T) {
 		require.NoError(t, err)
 
 		// Approve it first
-		err = store.UpdateApprovalResponse(ctx, approval.ID, ApprovalStatusLocalApproved, "Looks safe")
+		err = store.UpdateApprovalResponse(ctx, approval.ID, ApprovalStatusLocalApproved, ApprovalDecidedByHuman, "Looks safe")
 		require.NoError(t, err)
 
 		// Try to approve it again - should fail with AlreadyDecidedError
-		err = store.UpdateApprovalResponse(ctx, approval.ID, ApprovalStatusLocalApproved, "Approving again")
+		err = store.UpdateApprovalResponse(ctx, approval.ID, ApprovalStatusLocalApproved, ApprovalDecidedByHuman, "Approving again")
 		assert.Error(t, err)
 
 		// Check that the error is of the correct type
@@ -82,13 +82,13 @@ func TestApprovalErrors(t *testing.T) {
 		assert.True(t, errors.Is(err, ErrAlreadyDecided))
 
 		// Try to deny it - should also fail
-		err = store.UpdateApprovalResponse(ctx, approval.ID, ApprovalStatusLocalDenied, "Actually, deny it")
+		err = store.UpdateApprovalResponse(ctx, approval.ID, ApprovalStatusLocalDenied, ApprovalDecidedByHuman, "Actually, deny it")
 		assert.Error(t, err)
 		assert.True(t, errors.Is(err, ErrAlreadyDecided))
 	})
 
 	t.Run("UpdateApprovalResponse_NotFound", func(t *testing.T) {
-		err := store.UpdateApprovalResponse(ctx, "non-existent", ApprovalStatusLocalApproved, "")
+		err := store.UpdateApprovalResponse(ctx, "non-existent", ApprovalStatusLocalApproved, ApprovalDecidedByHuman, "")
 		assert.Error(t, err)
 
 		// Should get NotFoundError from GetApproval call
@@ -110,11 +110,11 @@ func TestApprovalErrors(t *testing.T) {
 		require.NoError(t, err)
 
 		// Deny it
-		err = store.UpdateApprovalResponse(ctx, approval.ID, ApprovalStatusLocalDenied, "Not allowed")
+		err = store.UpdateApprovalResponse(ctx, approval.ID, ApprovalStatusLocalDenied, ApprovalDecidedByHuman, "Not allowed")
 		require.NoError(t, err)
 
 		// Try to approve it now - should fail
-		err = store.UpdateApprovalResponse(ctx, approval.ID, ApprovalStatusLocalApproved, "Changed my mind")
+		err = store.UpdateApprovalResponse(ctx, approval.ID, ApprovalStatusLocalApproved, ApprovalDecidedByHuman, "Changed my mind")
 		assert.Error(t, err)
 
 		var alreadyDecidedErr *AlreadyDecidedError
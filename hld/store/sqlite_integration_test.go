@@ -366,6 +366,15 @@ func TestSQLiteStoreIntegration(t *testing.T) {
 			t.Fatalf("failed to store raw event: %v", err)
 		}
 
+		// Store a batch in one transaction
+		err = store.StoreRawEvents(ctx, sessionID, []string{
+			`{"type": "user"}`,
+			`{"type": "result"}`,
+		})
+		if err != nil {
+			t.Fatalf("failed to store raw events: %v", err)
+		}
+
 		// Verify by checking database directly (since we don't have a getter)
 		db, err := sql.Open("sqlite3", dbPath)
 		if err != nil {
@@ -379,8 +388,8 @@ func TestSQLiteStoreIntegration(t *testing.T) {
 			t.Fatalf("failed to count raw events: %v", err)
 		}
 
-		if count != 1 {
-			t.Errorf("expected 1 raw event, got %d", count)
+		if count != 3 {
+			t.Errorf("expected 3 raw events, got %d", count)
 		}
 	})
 
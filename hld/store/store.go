@@ -40,12 +40,28 @@ type ConversationStore interface {
 
 	// Raw event storage (for debugging)
 	StoreRawEvent(ctx context.Context, sessionID string, eventJSON string) error
+	StoreRawEvents(ctx context.Context, sessionID string, eventJSON []string) error
 
 	// Approval operations for local approvals
 	CreateApproval(ctx context.Context, approval *Approval) error
 	GetApproval(ctx context.Context, id string) (*Approval, error)
 	GetPendingApprovals(ctx context.Context, sessionID string) ([]*Approval, error)
-	UpdateApprovalResponse(ctx context.Context, id string, status ApprovalStatus, comment string) error
+	// GetExpiredApprovals returns pending approvals, across sessions, whose timeout has passed
+	GetExpiredApprovals(ctx context.Context, now time.Time) ([]*Approval, error)
+	UpdateApprovalResponse(ctx context.Context, id string, status ApprovalStatus, decidedBy string, comment string) error
+	// UpdateApprovalResponseWithInput also records the tool input as edited by the approver
+	UpdateApprovalResponseWithInput(ctx context.Context, id string, status ApprovalStatus, decidedBy string, comment string, updatedInput json.RawMessage) error
+	// ListApprovals returns approvals across sessions matching filter, newest first
+	ListApprovals(ctx context.Context, filter ApprovalFilter) ([]*Approval, error)
+	// GetApprovalStats aggregates the approvals matching filter, ignoring its Limit and Offset
+	GetApprovalStats(ctx context.Context, filter ApprovalFilter) (*ApprovalStats, error)
+
+	// Approval rule operations
+	CreateApprovalRule(ctx context.Context, rule *ApprovalRule) error
+	// ListApprovalRules returns the rules that apply to a session: its own, those of
+	// the sessions it was continued from, and those of its working directory
+	ListApprovalRules(ctx context.Context, sessionID string) ([]*ApprovalRule, error)
+	DeleteApprovalRule(ctx context.Context, id string) error
 
 	// File snapshot operations
 	CreateFileSnapshot(ctx context.Context, snapshot *FileSnapshot) error
@@ -106,6 +122,15 @@ type Session struct {
 	DangerouslySkipPermissionsExpiresAt *time.Time `db:"dangerously_skip_permissions_expires_at"`
 	Archived                            bool       // New field for session archiving
 	AdditionalDirectories               string     // JSON array of additional directories
+	ResourceLimits                      string     // JSON-encoded claudecode.ResourceLimits, empty when unlimited
+	FailureReason                       string     // Why the session failed, e.g. FailureReasonResourceLimit
+	PermissionMode                      string     // Claude CLI --permission-mode
+	FallbackModel                       string     // Claude CLI --fallback-model
+	Settings                            string     // Claude CLI --settings (file path or JSON)
+	SettingSources                      string     // JSON array of setting sources
+	ExtraArgs                           string     // JSON array of extra CLI arguments
+	StructuredOutput                    string     // Final result as JSON, when launched with an output schema
+	ApprovalPolicy                      string     // JSON-encoded session-level approval policy, empty when unset
 
 	// Proxy configuration
 	ProxyEnabled       bool   `db:"proxy_enabled"`
@@ -139,6 +164,8 @@ type SessionUpdate struct {
 	ModelID                             *string // Full model identifier
 	Archived                            *bool   // New field for updating archived status
 	AdditionalDirectories               *string `db:"additional_directories"` // JSON array of additional directories
+	FailureReason                       *string `db:"failure_reason"`
+	StructuredOutput                    *string `db:"structured_output"`
 	// New proxy fields
 	ProxyEnabled       *bool   `db:"proxy_enabled"`
 	ProxyBaseURL       *string `db:"proxy_base_url"`
@@ -173,6 +200,25 @@ type ConversationEvent struct {
 	IsCompleted    bool   // TRUE when tool result received
 	ApprovalStatus string // NULL, 'pending', 'approved', 'denied'
 	ApprovalID     string // HumanLayer approval ID when correlated
+
+	// Tool input as edited by the approver, when the call was approved with changes
+	UpdatedToolInputJSON string
+
+	// Non-text content such as images, stored in conversation_attachments
+	Attachments []Attachment
+}
+
+// Attachment is a non-text content block of a conversation event
+type Attachment struct {
+	ID         int64
+	EventID    int64
+	Position   int    // Order within the event
+	BlockType  string // image, document, redacted_thinking, web_search_result
+	SourceType string // base64, url, text
+	MediaType  string
+	Data       string // Base64 payload, document text or encrypted thinking
+	URL        string
+	Title      string
 }
 
 // FileSnapshot represents a snapshot of file content at Read time
@@ -203,6 +249,7 @@ const (
 	ApprovalStatusLocalPending  ApprovalStatus = "pending"
 	ApprovalStatusLocalApproved ApprovalStatus = "approved"
 	ApprovalStatusLocalDenied   ApprovalStatus = "denied"
+	ApprovalStatusLocalExpired  ApprovalStatus = "approval_expired" // Nobody answered before the approval's timeout
 )
 
 // String returns the string representation of the status
@@ -213,7 +260,7 @@ func (s ApprovalStatus) String() string {
 // IsValid checks if the status is valid
 func (s ApprovalStatus) IsValid() bool {
 	switch s {
-	case ApprovalStatusLocalPending, ApprovalStatusLocalApproved, ApprovalStatusLocalDenied:
+	case ApprovalStatusLocalPending, ApprovalStatusLocalApproved, ApprovalStatusLocalDenied, ApprovalStatusLocalExpired:
 		return true
 	default:
 		return false
@@ -232,6 +279,94 @@ type Approval struct {
 	ToolName    string          `json:"tool_name"`
 	ToolInput   json.RawMessage `json:"tool_input"`
 	Comment     string          `json:"comment,omitempty"`
+	PolicyRule  string          `json:"policy_rule,omitempty"` // Approval policy rule that decided the approval, if any
+	DecidedBy   string          `json:"decided_by,omitempty"`  // Who or what decided the approval, empty while pending
+
+	// Always allow rule that approved the tool call, if any
+	ApprovalRuleID string `json:"approval_rule_id,omitempty"`
+
+	// Tool input as edited by the approver; the tool runs with it instead of ToolInput
+	UpdatedInput json.RawMessage `json:"updated_input,omitempty"`
+
+	// Timeout for pending approvals, and what happens when it passes
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	OnTimeout      string     `json:"on_timeout,omitempty"` // deny, approve or interrupt
+	TimeoutMessage string     `json:"timeout_message,omitempty"`
+}
+
+// Who or what decided an approval
+const (
+	ApprovalDecidedByHuman      = "human"       // Answered by someone through the daemon
+	ApprovalDecidedByHumanLayer = "humanlayer"  // Answered by someone through HumanLayer
+	ApprovalDecidedByAutoAccept = "auto_accept" // Dangerous skip permissions or auto-accept edits
+	ApprovalDecidedByPolicy     = "policy"      // An approval policy rule
+	ApprovalDecidedByRule       = "rule"        // An always allow rule
+	ApprovalDecidedByTimeout    = "timeout"     // Nobody answered before the approval expired
+)
+
+// ApprovalFilter selects approvals across sessions. Zero values don't filter.
+type ApprovalFilter struct {
+	Status        ApprovalStatus
+	ToolName      string
+	SessionID     string
+	WorkingDir    string // Working directory of the approval's session
+	DecidedBy     string
+	CreatedAfter  *time.Time // Inclusive
+	CreatedBefore *time.Time // Exclusive
+	Limit         int        // 0 for no limit
+	Offset        int
+}
+
+// ApprovalStats aggregates approvals. Rates are the approved share of
+// decided (not pending) approvals, and response times are measured from
+// creation to the answer of approvals decided by a human.
+type ApprovalStats struct {
+	Total            int                    `json:"total"`
+	ByStatus         map[ApprovalStatus]int `json:"by_status"`
+	ByDecidedBy      map[string]int         `json:"by_decided_by"`
+	ApprovalRate     float64                `json:"approval_rate"`
+	MedianResponseMS *int64                 `json:"median_response_ms,omitempty"` // nil without human decisions
+	Tools            []ToolApprovalStats    `json:"tools"`                        // Sorted by tool name
+}
+
+// ToolApprovalStats aggregates the approvals of one tool
+type ToolApprovalStats struct {
+	ToolName         string  `json:"tool_name"`
+	Total            int     `json:"total"`
+	Approved         int     `json:"approved"`
+	Denied           int     `json:"denied"`
+	Expired          int     `json:"expired"`
+	Pending          int     `json:"pending"`
+	ApprovalRate     float64 `json:"approval_rate"`
+	MedianResponseMS *int64  `json:"median_response_ms,omitempty"`
+}
+
+// Approval rule scopes
+const (
+	ApprovalRuleScopeSession = "session" // The session and sessions continued from it
+	ApprovalRuleScopeProject = "project" // All sessions in the working directory
+)
+
+// Approval rule match types
+const (
+	ApprovalRuleMatchExact  = "exact"  // Same tool and input
+	ApprovalRuleMatchTool   = "tool"   // Same tool, any input
+	ApprovalRuleMatchPrefix = "prefix" // Same tool, command starting with CommandPrefix
+)
+
+// ApprovalRule is a remembered "always allow" decision that auto-approves
+// matching tool calls
+type ApprovalRule struct {
+	ID            string    `json:"id"`
+	Scope         string    `json:"scope"`
+	SessionID     string    `json:"session_id"`            // Session the rule was created in
+	WorkingDir    string    `json:"working_dir,omitempty"` // Working directory of project rules
+	ToolName      string    `json:"tool_name"`
+	Match         string    `json:"match"`
+	ToolInput     string    `json:"tool_input,omitempty"`     // Canonical JSON input of exact rules
+	CommandPrefix string    `json:"command_prefix,omitempty"` // Command prefix of prefix rules
+	ApprovalID    string    `json:"approval_id,omitempty"`    // Approval the rule was created from
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 // EventType constants
@@ -269,6 +404,12 @@ const (
 	SessionStatusInterrupted  = "interrupted"  // Session was interrupted but can be resumed
 )
 
+// Session failure reasons
+const (
+	FailureReasonResourceLimit = "resource_limit" // Session was stopped for exceeding its resource limits
+	FailureReasonOutputSchema  = "output_schema"  // Final result did not match the output schema
+)
+
 // Helper functions for converting between store types and Claude types
 
 // NewSessionFromConfig creates a Session from Claude SessionConfig
@@ -293,11 +434,31 @@ func NewSessionFromConfig(id, runID string, config claudecode.SessionConfig) *Se
 		AllowedTools:          string(allowedToolsJSON),
 		DisallowedTools:       string(disallowedToolsJSON),
 		AdditionalDirectories: string(additionalDirJSON),
+		PermissionMode:        string(config.PermissionMode),
+		FallbackModel:         string(config.FallbackModel),
+		Settings:              config.Settings,
 		Status:                SessionStatusStarting,
 		CreatedAt:             time.Now(),
 		LastActivityAt:        time.Now(),
 	}
 
+	if len(config.SettingSources) > 0 {
+		settingSourcesJSON, _ := json.Marshal(config.SettingSources)
+		session.SettingSources = string(settingSourcesJSON)
+	}
+	if len(config.ExtraArgs) > 0 {
+		extraArgsJSON, _ := json.Marshal(config.ExtraArgs)
+		session.ExtraArgs = string(extraArgsJSON)
+	}
+
+	if !config.Limits.IsZero() {
+		// The cgroup parent is daemon configuration, not part of the session
+		limits := *config.Limits
+		limits.CgroupParent = ""
+		limitsJSON, _ := json.Marshal(limits)
+		session.ResourceLimits = string(limitsJSON)
+	}
+
 	// Note: Proxy configuration should be explicitly set by the user
 	// through the UI, not auto-detected from environment variables
 
@@ -0,0 +1,189 @@
+-- A database created by hld at schema version 17, before approval timeouts,
+-- policies and rules, with sessions, a conversation event and decided and
+-- pending approvals. Generated with sqlite3 .dump.
+PRAGMA foreign_keys=OFF;
+BEGIN TRANSACTION;
+CREATE TABLE sessions (
+		id TEXT PRIMARY KEY,
+		run_id TEXT NOT NULL UNIQUE,
+		claude_session_id TEXT,
+		parent_session_id TEXT,
+
+		-- Launch configuration
+		query TEXT NOT NULL,
+		summary TEXT,
+		model TEXT,
+		working_dir TEXT,
+		max_turns INTEGER,
+		system_prompt TEXT,
+		append_system_prompt TEXT,
+		custom_instructions TEXT,
+		permission_prompt_tool TEXT,
+		allowed_tools TEXT,
+		disallowed_tools TEXT,
+
+		-- Runtime status
+		status TEXT NOT NULL DEFAULT 'starting',
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		last_activity_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		completed_at TIMESTAMP,
+
+		-- Results
+		cost_usd REAL,
+		duration_ms INTEGER,
+		num_turns INTEGER,
+		result_content TEXT,
+		error_message TEXT,
+
+		-- Session settings
+		auto_accept_edits BOOLEAN DEFAULT 0,
+		dangerously_skip_permissions BOOLEAN DEFAULT 0,
+		dangerously_skip_permissions_expires_at TIMESTAMP,
+
+		-- Archival
+		archived BOOLEAN DEFAULT FALSE,
+
+		-- Additional directories for --add-dir support
+		additional_directories TEXT
+	, title TEXT DEFAULT '', model_id TEXT, input_tokens INTEGER, output_tokens INTEGER, cache_creation_input_tokens INTEGER, cache_read_input_tokens INTEGER, effective_context_tokens INTEGER, proxy_enabled BOOLEAN DEFAULT 0, proxy_base_url TEXT DEFAULT '', proxy_model_override TEXT DEFAULT '', proxy_api_key TEXT DEFAULT '');
+INSERT INTO sessions VALUES('sess-1','run-1','claude-1','','fix the tests','','sonnet','/work/repo',0,'','','','','','','completed','0001-01-01 00:00:00+00:00','0001-01-01 00:00:00+00:00',NULL,NULL,NULL,NULL,NULL,NULL,0,0,NULL,0,'','','',NULL,NULL,NULL,NULL,NULL,0,'','','');
+INSERT INTO sessions VALUES('sess-2','run-2','','','deploy','','','/work/repo',0,'','','','','','','waiting_input','0001-01-01 00:00:00+00:00','0001-01-01 00:00:00+00:00',NULL,NULL,NULL,NULL,NULL,NULL,0,0,NULL,0,'','','',NULL,NULL,NULL,NULL,NULL,0,'','','');
+CREATE TABLE conversation_events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		session_id TEXT NOT NULL,
+		claude_session_id TEXT,
+		sequence INTEGER NOT NULL,
+		event_type TEXT NOT NULL,
+
+		-- Common fields
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+		-- Message fields
+		role TEXT,
+		content TEXT,
+
+		-- Tool call fields
+		tool_id TEXT,
+		tool_name TEXT,
+		tool_input_json TEXT,
+
+		-- Tool result fields
+		tool_result_for_id TEXT,
+		tool_result_content TEXT,
+
+		-- Tool call completion and approval tracking
+		is_completed BOOLEAN DEFAULT FALSE,  -- TRUE when tool result received
+		approval_status TEXT,        -- NULL, 'pending', 'approved', 'denied'
+		approval_id TEXT, parent_tool_use_id TEXT,           -- HumanLayer approval ID when correlated
+
+		FOREIGN KEY (session_id) REFERENCES sessions(id)
+	);
+INSERT INTO conversation_events VALUES(1,'sess-1','claude-1',1,'message','2026-10-17 01:59:28','user','fix the tests','','','','','',0,'','','');
+CREATE TABLE mcp_servers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		session_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		command TEXT NOT NULL,
+		args_json TEXT,
+		env_json TEXT,
+
+		FOREIGN KEY (session_id) REFERENCES sessions(id)
+	);
+CREATE TABLE raw_events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		session_id TEXT NOT NULL,
+		event_json TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+		FOREIGN KEY (session_id) REFERENCES sessions(id)
+	);
+CREATE TABLE schema_version (
+		version INTEGER PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		description TEXT
+	);
+INSERT INTO schema_version VALUES(1,'2026-10-17 01:59:28','Initial schema with conversation events');
+INSERT INTO schema_version VALUES(3,'2026-10-17 01:59:28','Add permission_prompt_tool, append_system_prompt, allowed_tools, disallowed_tools fields');
+INSERT INTO schema_version VALUES(4,'2026-10-17 01:59:28','Add approvals table for local approvals');
+INSERT INTO schema_version VALUES(5,'2026-10-17 01:59:28','Add index on parent_session_id for efficient tree queries');
+INSERT INTO schema_version VALUES(6,'2026-10-17 01:59:28','Add parent_tool_use_id for sub-task tracking');
+INSERT INTO schema_version VALUES(7,'2026-10-17 01:59:28','Add file_snapshots table for Read operation tracking');
+INSERT INTO schema_version VALUES(8,'2026-10-17 01:59:28','Add auto_accept_edits for session-level edit auto-approval');
+INSERT INTO schema_version VALUES(9,'2026-10-17 01:59:28','Add archived field to sessions table for hiding old sessions');
+INSERT INTO schema_version VALUES(10,'2026-10-17 01:59:28','Add title column to sessions table');
+INSERT INTO schema_version VALUES(11,'2026-10-17 01:59:28','Add dangerously skip permissions with timeout support');
+INSERT INTO schema_version VALUES(12,'2026-10-17 01:59:28','Add model_id column for full model identifier');
+INSERT INTO schema_version VALUES(13,'2026-10-17 01:59:28','Add detailed token tracking fields (input, output, cache, effective context)');
+INSERT INTO schema_version VALUES(14,'2026-10-17 01:59:28','Add tool_use_id column to approvals table for direct correlation');
+INSERT INTO schema_version VALUES(15,'2026-10-17 01:59:28','Add proxy configuration columns for model customization');
+INSERT INTO schema_version VALUES(16,'2026-10-17 01:59:28','Add user settings table for advanced providers preference');
+INSERT INTO schema_version VALUES(17,'2026-10-17 01:59:28','Add additional_directories column for --add-dir functionality');
+CREATE TABLE approvals (
+		id TEXT PRIMARY KEY,
+		run_id TEXT NOT NULL,
+		session_id TEXT NOT NULL,
+		status TEXT NOT NULL CHECK (status IN ('pending', 'approved', 'denied')),
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		responded_at DATETIME,
+
+		-- Tool approval fields
+		tool_name TEXT NOT NULL,
+		tool_input TEXT NOT NULL, -- JSON
+
+		-- Response fields
+		comment TEXT, tool_use_id TEXT, -- For denial reasons or approval notes
+
+		FOREIGN KEY (session_id) REFERENCES sessions(id)
+	);
+INSERT INTO approvals VALUES('approval-approved','run-1','sess-1','approved','0001-01-01 00:00:00+00:00','2026-10-17 01:59:28','Bash','{"command": "go test ./..."}','looks good','toolu_1');
+INSERT INTO approvals VALUES('approval-denied','run-1','sess-1','denied','0001-01-01 00:00:00+00:00','2026-10-17 01:59:28','Write','{"file_path": "/etc/hosts"}','not that file',NULL);
+INSERT INTO approvals VALUES('approval-pending','run-2','sess-2','pending','0001-01-01 00:00:00+00:00',NULL,'Bash','{"command": "make deploy"}','',NULL);
+CREATE TABLE file_snapshots (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				tool_id TEXT NOT NULL,
+				session_id TEXT NOT NULL,
+				file_path TEXT NOT NULL, -- Relative path from tool call
+				content TEXT NOT NULL,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+				FOREIGN KEY (session_id) REFERENCES sessions(id)
+			);
+CREATE TABLE user_settings (
+				id INTEGER PRIMARY KEY CHECK (id = 1), -- Singleton row
+				advanced_providers BOOLEAN NOT NULL DEFAULT FALSE,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+INSERT INTO user_settings VALUES(1,0,'2026-10-17 01:59:28','2026-10-17 01:59:28');
+INSERT INTO sqlite_sequence VALUES('conversation_events',1);
+CREATE INDEX idx_sessions_claude ON sessions(claude_session_id);
+CREATE INDEX idx_sessions_status ON sessions(status);
+CREATE INDEX idx_sessions_run_id ON sessions(run_id);
+CREATE INDEX idx_sessions_parent ON sessions(parent_session_id);
+CREATE INDEX idx_conversation_claude_session ON conversation_events(claude_session_id, sequence);
+CREATE INDEX idx_conversation_session ON conversation_events(session_id, sequence);
+CREATE INDEX idx_conversation_approval ON conversation_events(approval_id);
+CREATE INDEX idx_conversation_pending_approvals
+		ON conversation_events(approval_status)
+		WHERE approval_status = 'pending';
+CREATE INDEX idx_mcp_servers_session ON mcp_servers(session_id);
+CREATE INDEX idx_raw_events_session ON raw_events(session_id, created_at);
+CREATE INDEX idx_approvals_pending ON approvals(status) WHERE status = 'pending';
+CREATE INDEX idx_approvals_session ON approvals(session_id);
+CREATE INDEX idx_approvals_run_id ON approvals(run_id);
+CREATE INDEX idx_conversation_parent_tool
+			ON conversation_events(parent_tool_use_id)
+			WHERE parent_tool_use_id IS NOT NULL
+		;
+CREATE INDEX idx_snapshots_session_path
+				ON file_snapshots(session_id, file_path);
+CREATE INDEX idx_snapshots_tool
+				ON file_snapshots(tool_id);
+CREATE INDEX idx_sessions_archived
+			ON sessions(archived)
+		;
+CREATE INDEX idx_approvals_tool_use_id
+			ON approvals(tool_use_id)
+			WHERE tool_use_id IS NOT NULL
+		;
+COMMIT;
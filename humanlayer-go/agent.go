@@ -0,0 +1,188 @@
+package humanlayer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Default polling intervals for WaitForDecision and WaitForHumanResponse
+const (
+	DefaultPollInterval    = time.Second
+	DefaultMaxPollInterval = 30 * time.Second
+)
+
+// createFunctionCallRequest is the body of POST /function_calls
+type createFunctionCallRequest struct {
+	RunID  string           `json:"run_id"`
+	CallID string           `json:"call_id"`
+	Spec   FunctionCallSpec `json:"spec"`
+}
+
+// createHumanContactRequest is the body of POST /contact_requests
+type createHumanContactRequest struct {
+	RunID  string           `json:"run_id"`
+	CallID string           `json:"call_id"`
+	Spec   HumanContactSpec `json:"spec"`
+}
+
+// CreateFunctionCall requests approval for a function call. A call ID is
+// generated when call.CallID is empty; call.Status is ignored.
+func (c *Client) CreateFunctionCall(ctx context.Context, call FunctionCall) (*FunctionCall, error) {
+	if call.CallID == "" {
+		id, err := generateCallID()
+		if err != nil {
+			return nil, err
+		}
+		call.CallID = id
+	}
+
+	body := createFunctionCallRequest{
+		RunID:  call.RunID,
+		CallID: call.CallID,
+		Spec:   call.Spec,
+	}
+	resp, err := c.doRequest(ctx, "POST", "/function_calls", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var created FunctionCall
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &created, nil
+}
+
+// GetFunctionCall fetches a function call and its current status
+func (c *Client) GetFunctionCall(ctx context.Context, callID string) (*FunctionCall, error) {
+	resp, err := c.doRequest(ctx, "GET", "/function_calls/"+url.PathEscape(callID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var call FunctionCall
+	if err := json.NewDecoder(resp.Body).Decode(&call); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &call, nil
+}
+
+// CreateHumanContact sends a message to a human. A call ID is generated when
+// contact.CallID is empty; contact.Status is ignored.
+func (c *Client) CreateHumanContact(ctx context.Context, contact HumanContact) (*HumanContact, error) {
+	if contact.CallID == "" {
+		id, err := generateCallID()
+		if err != nil {
+			return nil, err
+		}
+		contact.CallID = id
+	}
+
+	body := createHumanContactRequest{
+		RunID:  contact.RunID,
+		CallID: contact.CallID,
+		Spec:   contact.Spec,
+	}
+	resp, err := c.doRequest(ctx, "POST", "/contact_requests", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var created HumanContact
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &created, nil
+}
+
+// GetHumanContact fetches a human contact and its current status
+func (c *Client) GetHumanContact(ctx context.Context, callID string) (*HumanContact, error) {
+	resp, err := c.doRequest(ctx, "GET", "/contact_requests/"+url.PathEscape(callID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var contact HumanContact
+	if err := json.NewDecoder(resp.Body).Decode(&contact); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &contact, nil
+}
+
+// WaitForDecision polls a function call until it has been approved or denied,
+// or ctx is done. The interval starts at the client's poll interval and
+// doubles after each poll up to the maximum (see WithPollInterval).
+func (c *Client) WaitForDecision(ctx context.Context, callID string) (*FunctionCall, error) {
+	var call *FunctionCall
+	err := c.poll(ctx, func() (bool, error) {
+		var err error
+		call, err = c.GetFunctionCall(ctx, callID)
+		if err != nil {
+			return false, err
+		}
+		return call.Status != nil && call.Status.Approved != nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// WaitForHumanResponse polls a human contact until the human has responded,
+// or ctx is done, using the same backoff as WaitForDecision
+func (c *Client) WaitForHumanResponse(ctx context.Context, callID string) (*HumanContact, error) {
+	var contact *HumanContact
+	err := c.poll(ctx, func() (bool, error) {
+		var err error
+		contact, err = c.GetHumanContact(ctx, callID)
+		if err != nil {
+			return false, err
+		}
+		return contact.Status != nil && (contact.Status.RespondedAt != nil || contact.Status.Response != ""), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return contact, nil
+}
+
+// poll calls done until it reports true or fails, backing off between calls
+func (c *Client) poll(ctx context.Context, done func() (bool, error)) error {
+	interval := c.pollInterval
+	for {
+		finished, err := done()
+		if err != nil {
+			return err
+		}
+		if finished {
+			return nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+
+		interval *= 2
+		if interval > c.maxPollInterval {
+			interval = c.maxPollInterval
+		}
+	}
+}
+
+// generateCallID returns a random call ID
+func generateCallID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate call ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,135 @@
+package humanlayer_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	humanlayer "github.com/humanlayer/humanlayer-go"
+	"github.com/humanlayer/humanlayer-go/humanlayertest"
+)
+
+func TestWaitForDecision(t *testing.T) {
+	tests := []struct {
+		name         string
+		behavior     humanlayertest.FunctionCallBehavior
+		wantApproved bool
+		wantComment  string
+	}{
+		{"approved", humanlayertest.AutoApprove("ship it"), true, "ship it"},
+		{"denied", humanlayertest.DenyWithReason("not on a friday"), false, "not on a friday"},
+		{"decided while polling", humanlayertest.DelayDecision(30*time.Millisecond, humanlayertest.AutoApprove("later")), true, "later"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := humanlayertest.NewServer(humanlayertest.WithFunctionCallBehavior(tt.behavior))
+			defer server.Close()
+			client, err := server.Client(humanlayer.WithPollInterval(5*time.Millisecond, 20*time.Millisecond))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+
+			call, err := client.CreateFunctionCall(ctx, humanlayer.FunctionCall{RunID: "run-1", Spec: humanlayer.FunctionCallSpec{Fn: "deploy"}})
+			if err != nil {
+				t.Fatalf("CreateFunctionCall() error = %v", err)
+			}
+			call, err = client.WaitForDecision(ctx, call.CallID)
+			if err != nil {
+				t.Fatalf("WaitForDecision() error = %v", err)
+			}
+			if *call.Status.Approved != tt.wantApproved || call.Status.Comment != tt.wantComment {
+				t.Errorf("WaitForDecision() status = %+v, want approved %v with %q", call.Status, tt.wantApproved, tt.wantComment)
+			}
+		})
+	}
+}
+
+func TestWaitForDecisionBackoff(t *testing.T) {
+	const initial, maxInterval = 20 * time.Millisecond, 50 * time.Millisecond
+	const pendingPolls = 4
+
+	var mu sync.Mutex
+	var polls []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		polls = append(polls, time.Now())
+		n := len(polls)
+		mu.Unlock()
+		if n <= pendingPolls {
+			_, _ = w.Write([]byte(`{"run_id": "run-1", "call_id": "fc-1", "spec": {"fn": "deploy"}, "status": {}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"run_id": "run-1", "call_id": "fc-1", "spec": {"fn": "deploy"}, "status": {"approved": true}}`))
+	}))
+	defer server.Close()
+	client, err := humanlayer.NewClient(
+		humanlayer.WithAPIKey("key"),
+		humanlayer.WithBaseURL(server.URL),
+		humanlayer.WithPollInterval(initial, maxInterval),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.WaitForDecision(context.Background(), "fc-1"); err != nil {
+		t.Fatalf("WaitForDecision() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(polls) != pendingPolls+1 {
+		t.Fatalf("polls = %d, want %d", len(polls), pendingPolls+1)
+	}
+	// The interval doubles from initial and is capped at maxInterval
+	want := []time.Duration{initial, 2 * initial, maxInterval, maxInterval}
+	for i, atLeast := range want {
+		if gap := polls[i+1].Sub(polls[i]); gap < atLeast {
+			t.Errorf("interval before poll %d = %v, want at least %v", i+2, gap, atLeast)
+		}
+	}
+}
+
+func TestWaitForDecisionContextDone(t *testing.T) {
+	server := humanlayertest.NewServer()
+	defer server.Close()
+	client, err := server.Client(humanlayer.WithPollInterval(5*time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := server.AddFunctionCall(humanlayer.FunctionCall{RunID: "run-1"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.WaitForDecision(ctx, call.CallID); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitForDecision() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWaitForHumanResponse(t *testing.T) {
+	server := humanlayertest.NewServer(humanlayertest.WithHumanContactBehavior(
+		humanlayertest.DelayReply(20*time.Millisecond, humanlayertest.AutoRespond("use the staging db")),
+	))
+	defer server.Close()
+	client, err := server.Client(humanlayer.WithPollInterval(5*time.Millisecond, 20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	contact, err := client.CreateHumanContact(ctx, humanlayer.HumanContact{RunID: "run-1", Spec: humanlayer.HumanContactSpec{Msg: "which db?"}})
+	if err != nil {
+		t.Fatalf("CreateHumanContact() error = %v", err)
+	}
+	contact, err = client.WaitForHumanResponse(ctx, contact.CallID)
+	if err != nil {
+		t.Fatalf("WaitForHumanResponse() error = %v", err)
+	}
+	if contact.Status.Response != "use the staging db" {
+		t.Errorf("response = %q, want %q", contact.Status.Response, "use the staging db")
+	}
+}
@@ -0,0 +1,250 @@
+package humanlayer
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// ContactChannel represents how to contact the human. Setting more than one
+// channel contacts the human on each of them; the first response wins.
+type ContactChannel struct {
+	Slack    *SlackChannel    `json:"slack,omitempty"`
+	SMS      *SMSChannel      `json:"sms,omitempty"`
+	WhatsApp *WhatsAppChannel `json:"whatsapp,omitempty"`
+	Email    *EmailChannel    `json:"email,omitempty"`
+
+	// Extra holds fields this package does not model, so they survive a
+	// decode and re-encode
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Context returns the description of who the channel reaches, from the first
+// channel that has one
+func (c ContactChannel) Context() string {
+	switch {
+	case c.Slack != nil && c.Slack.ContextAboutChannelOrUser != "":
+		return c.Slack.ContextAboutChannelOrUser
+	case c.SMS != nil && c.SMS.ContextAboutUser != "":
+		return c.SMS.ContextAboutUser
+	case c.WhatsApp != nil && c.WhatsApp.ContextAboutUser != "":
+		return c.WhatsApp.ContextAboutUser
+	case c.Email != nil && c.Email.ContextAboutUser != "":
+		return c.Email.ContextAboutUser
+	}
+	return ""
+}
+
+// SlackChannel routes a request to a Slack channel or user
+type SlackChannel struct {
+	// A channel or user ID such as C123456 or U123456, not #channel or @user
+	ChannelOrUserID string `json:"channel_or_user_id"`
+	// Who the channel reaches, for the LLM, e.g. "a dm with the user you're assisting"
+	ContextAboutChannelOrUser string `json:"context_about_channel_or_user,omitempty"`
+	// Overrides the default bot. The app's Slack webhook must point at HumanLayer.
+	BotToken string `json:"bot_token,omitempty"`
+	// Only responses from these users are accepted; must not be empty if set
+	AllowedResponderIDs     []string `json:"allowed_responder_ids,omitempty"`
+	ExperimentalSlackBlocks *bool    `json:"experimental_slack_blocks,omitempty"`
+	// Posts into an existing thread
+	ThreadTS string `json:"thread_ts,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// SMSChannel routes a request to a phone number by SMS
+type SMSChannel struct {
+	PhoneNumber      string `json:"phone_number"`
+	ContextAboutUser string `json:"context_about_user,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// WhatsAppChannel routes a request to a phone number on WhatsApp
+type WhatsAppChannel struct {
+	PhoneNumber      string `json:"phone_number"`
+	ContextAboutUser string `json:"context_about_user,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// EmailChannel routes a request to an email address
+type EmailChannel struct {
+	Address              string           `json:"address"`
+	ContextAboutUser     string           `json:"context_about_user,omitempty"`
+	AdditionalRecipients []EmailRecipient `json:"additional_recipients,omitempty"`
+
+	// For replying on an existing thread
+	Subject             string `json:"subject,omitempty"`
+	ReferencesMessageID string `json:"references_message_id,omitempty"`
+	InReplyToMessageID  string `json:"in_reply_to_message_id,omitempty"`
+
+	// Deprecated: use Subject, ReferencesMessageID and InReplyToMessageID
+	ExperimentalSubjectLine         string `json:"experimental_subject_line,omitempty"`
+	ExperimentalReferencesMessageID string `json:"experimental_references_message_id,omitempty"`
+	ExperimentalInReplyToMessageID  string `json:"experimental_in_reply_to_message_id,omitempty"`
+
+	// Jinja2 template used to render the email body
+	Template string `json:"template,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// InReplyTo returns an email channel that replies on the thread of the
+// message messageID, sent by fromAddress with subject
+func InReplyTo(fromAddress, subject, messageID string) *EmailChannel {
+	return &EmailChannel{
+		Address:             fromAddress,
+		Subject:             "Re: " + subject,
+		InReplyToMessageID:  messageID,
+		ReferencesMessageID: messageID,
+	}
+}
+
+// Email recipient fields
+const (
+	RecipientTo  = "to"
+	RecipientCC  = "cc"
+	RecipientBCC = "bcc"
+)
+
+// EmailRecipient is an additional recipient of an email request
+type EmailRecipient struct {
+	Address          string `json:"address"`
+	ContextAboutUser string `json:"context_about_user,omitempty"`
+	Field            string `json:"field,omitempty"` // RecipientTo, RecipientCC or RecipientBCC
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Escalation re-sends a pending request with a new message, to additional
+// recipients or on a different channel
+type Escalation struct {
+	EscalationMsg        string           `json:"escalation_msg"`
+	AdditionalRecipients []EmailRecipient `json:"additional_recipients,omitempty"`
+	Channel              *ContactChannel  `json:"channel,omitempty"`
+}
+
+// The channel types implement json.Marshaler and json.Unmarshaler to keep
+// fields they do not model in Extra.
+
+// MarshalJSON implements json.Marshaler
+func (c ContactChannel) MarshalJSON() ([]byte, error) {
+	type plain ContactChannel
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+func (c *ContactChannel) UnmarshalJSON(data []byte) error {
+	type plain ContactChannel
+	return unmarshalWithExtra(data, (*plain)(c), &c.Extra)
+}
+
+func (c SlackChannel) MarshalJSON() ([]byte, error) {
+	type plain SlackChannel
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+func (c *SlackChannel) UnmarshalJSON(data []byte) error {
+	type plain SlackChannel
+	return unmarshalWithExtra(data, (*plain)(c), &c.Extra)
+}
+
+func (c SMSChannel) MarshalJSON() ([]byte, error) {
+	type plain SMSChannel
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+func (c *SMSChannel) UnmarshalJSON(data []byte) error {
+	type plain SMSChannel
+	return unmarshalWithExtra(data, (*plain)(c), &c.Extra)
+}
+
+func (c WhatsAppChannel) MarshalJSON() ([]byte, error) {
+	type plain WhatsAppChannel
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+func (c *WhatsAppChannel) UnmarshalJSON(data []byte) error {
+	type plain WhatsAppChannel
+	return unmarshalWithExtra(data, (*plain)(c), &c.Extra)
+}
+
+func (c EmailChannel) MarshalJSON() ([]byte, error) {
+	type plain EmailChannel
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+func (c *EmailChannel) UnmarshalJSON(data []byte) error {
+	type plain EmailChannel
+	return unmarshalWithExtra(data, (*plain)(c), &c.Extra)
+}
+
+func (r EmailRecipient) MarshalJSON() ([]byte, error) {
+	type plain EmailRecipient
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (r *EmailRecipient) UnmarshalJSON(data []byte) error {
+	type plain EmailRecipient
+	return unmarshalWithExtra(data, (*plain)(r), &r.Extra)
+}
+
+// marshalWithExtra encodes v, a struct without custom marshalling, and adds
+// the extra fields it does not already contain
+func marshalWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, exists := fields[key]; !exists {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// unmarshalWithExtra decodes data into v, a pointer to a struct without
+// custom unmarshalling, and stores the fields v has no tag for in extra
+func unmarshalWithExtra(data []byte, v interface{}, extra *map[string]json.RawMessage) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, name := range jsonFieldNames(reflect.TypeOf(v).Elem()) {
+		delete(fields, name)
+	}
+	if len(fields) == 0 {
+		*extra = nil
+		return nil
+	}
+	*extra = fields
+	return nil
+}
+
+// jsonFieldNames returns the JSON names of a struct type's encoded fields
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
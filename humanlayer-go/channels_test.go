@@ -0,0 +1,139 @@
+package humanlayer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// assertJSONEqual fails unless got and want encode the same JSON value
+func assertJSONEqual(t *testing.T, got, want []byte) {
+	t.Helper()
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		t.Fatalf("invalid JSON %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("JSON = %s, want %s", got, want)
+	}
+}
+
+// roundTrip decodes data into a T and encodes it again
+func roundTrip[T any](t *testing.T, data string) []byte {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return encoded
+}
+
+func TestChannelJSONRoundTrip(t *testing.T) {
+	slack := `{
+		"channel_or_user_id": "C123456",
+		"context_about_channel_or_user": "the deploys channel",
+		"bot_token": "xoxb-1",
+		"allowed_responder_ids": ["U1", "U2"],
+		"experimental_slack_blocks": false,
+		"thread_ts": "1700000000.000100",
+		"future_option": {"nested": [1, 2]}
+	}`
+	sms := `{"phone_number": "+15555550100", "context_about_user": "the on-call engineer", "carrier_hint": "x"}`
+	whatsapp := `{"phone_number": "+15555550101", "context_about_user": "the founder", "template_id": 7}`
+	recipient := `{"address": "lead@example.com", "context_about_user": "the team lead", "field": "cc", "display_name": "Lead"}`
+	email := `{
+		"address": "user@example.com",
+		"context_about_user": "the user",
+		"additional_recipients": [` + recipient + `],
+		"subject": "Re: deploy",
+		"references_message_id": "<a@example.com>",
+		"in_reply_to_message_id": "<a@example.com>",
+		"experimental_subject_line": "old subject",
+		"experimental_references_message_id": "<b@example.com>",
+		"experimental_in_reply_to_message_id": "<b@example.com>",
+		"template": "{{ event.spec.fn }}",
+		"reply_to": "noreply@example.com"
+	}`
+	contactChannel := `{"slack": ` + slack + `, "sms": ` + sms + `, "whatsapp": ` + whatsapp + `, "email": ` + email + `, "discord": {"channel_id": "1"}}`
+
+	tests := []struct {
+		name      string
+		data      string
+		roundTrip func(*testing.T, string) []byte
+	}{
+		{"slack", slack, roundTrip[SlackChannel]},
+		{"sms", sms, roundTrip[SMSChannel]},
+		{"whatsapp", whatsapp, roundTrip[WhatsAppChannel]},
+		{"email recipient", recipient, roundTrip[EmailRecipient]},
+		{"email", email, roundTrip[EmailChannel]},
+		{"contact channel", contactChannel, roundTrip[ContactChannel]},
+		{"escalation", `{"escalation_msg": "still waiting", "additional_recipients": [` + recipient + `], "channel": ` + contactChannel + `}`, roundTrip[Escalation]},
+		{"function call spec", `{"fn": "deploy", "kwargs": {"env": "prod"}, "channel": ` + contactChannel + `}`, roundTrip[FunctionCallSpec]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertJSONEqual(t, tt.roundTrip(t, tt.data), []byte(tt.data))
+		})
+	}
+}
+
+func TestChannelJSONDecode(t *testing.T) {
+	var channel ContactChannel
+	data := `{"slack": {"channel_or_user_id": "C1", "experimental_slack_blocks": true, "future_option": 1}, "discord": {"channel_id": "1"}}`
+	if err := json.Unmarshal([]byte(data), &channel); err != nil {
+		t.Fatal(err)
+	}
+
+	if channel.Slack == nil || channel.Slack.ChannelOrUserID != "C1" || channel.Slack.ExperimentalSlackBlocks == nil || !*channel.Slack.ExperimentalSlackBlocks {
+		t.Errorf("Slack = %+v", channel.Slack)
+	}
+	if got := string(channel.Slack.Extra["future_option"]); got != "1" {
+		t.Errorf("Slack.Extra[future_option] = %q, want 1", got)
+	}
+	if len(channel.Extra) != 1 || channel.Extra["discord"] == nil {
+		t.Errorf("Extra = %v, want only discord", channel.Extra)
+	}
+
+	// Decoding again replaces the extra fields
+	if err := json.Unmarshal([]byte(`{"sms": {"phone_number": "+15555550100"}}`), &channel); err != nil {
+		t.Fatal(err)
+	}
+	if channel.Extra != nil {
+		t.Errorf("Extra after decoding known fields = %v, want nil", channel.Extra)
+	}
+}
+
+func TestChannelJSONEncode(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel interface{}
+		want    string
+	}{
+		{"empty contact channel", ContactChannel{}, `{}`},
+		{"modelled fields only", ContactChannel{SMS: &SMSChannel{PhoneNumber: "+15555550100"}}, `{"sms": {"phone_number": "+15555550100"}}`},
+		{"extra fields", SMSChannel{PhoneNumber: "+15555550100", Extra: map[string]json.RawMessage{"carrier_hint": json.RawMessage(`"x"`)}}, `{"phone_number": "+15555550100", "carrier_hint": "x"}`},
+		{"modelled fields win over extra", SMSChannel{PhoneNumber: "+15555550100", Extra: map[string]json.RawMessage{"phone_number": json.RawMessage(`"+1"`)}}, `{"phone_number": "+15555550100"}`},
+		{"reply", InReplyTo("user@example.com", "deploy", "<a@example.com>"), `{
+			"address": "user@example.com",
+			"subject": "Re: deploy",
+			"references_message_id": "<a@example.com>",
+			"in_reply_to_message_id": "<a@example.com>"
+		}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.channel)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			assertJSONEqual(t, got, []byte(tt.want))
+		})
+	}
+}
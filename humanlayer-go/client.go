@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"time"
 )
@@ -15,6 +16,7 @@ import (
 type APIError struct {
 	StatusCode int
 	Body       string
+	RetryAfter time.Duration // From the Retry-After header, if any
 }
 
 // Error implements the error interface
@@ -27,11 +29,39 @@ func (e *APIError) IsConflict() bool {
 	return e.StatusCode == http.StatusConflict
 }
 
+// IsNotFound returns true if this is a 404 Not Found error
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized returns true if the API key was missing, invalid or lacks access
+func (e *APIError) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// IsRateLimited returns true if this is a 429 Too Many Requests error
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsServerError returns true for 5xx errors
+func (e *APIError) IsServerError() bool {
+	return e.StatusCode >= 500
+}
+
+// IsRetryable returns true if the request may succeed when retried later
+func (e *APIError) IsRetryable() bool {
+	return e.IsRateLimited() || e.IsServerError()
+}
+
 // Client is the HumanLayer API client
 type Client struct {
-	apiKey     string
-	baseURL    string
-	httpClient *http.Client
+	apiKey          string
+	baseURL         string
+	httpClient      *http.Client
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+	retryPolicy     RetryPolicy
 }
 
 // ClientOption is a functional option for configuring the client
@@ -44,6 +74,9 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		pollInterval:    DefaultPollInterval,
+		maxPollInterval: DefaultMaxPollInterval,
+		retryPolicy:     DefaultRetryPolicy,
 	}
 
 	// Check for API key in environment if not provided
@@ -88,16 +121,68 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
-// doRequest performs an HTTP request with auth and JSON handling
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
-	url := c.baseURL + path
+// WithPollInterval sets the initial and maximum intervals between polls in
+// WaitForDecision and WaitForHumanResponse
+func WithPollInterval(initial, maxInterval time.Duration) ClientOption {
+	return func(c *Client) error {
+		if initial <= 0 || maxInterval < initial {
+			return fmt.Errorf("invalid poll interval: initial %s, max %s", initial, maxInterval)
+		}
+		c.pollInterval = initial
+		c.maxPollInterval = maxInterval
+		return nil
+	}
+}
 
-	var bodyReader io.Reader
+// WithRetryPolicy sets how idempotent requests are retried
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MaxAttempts < 1 {
+			return fmt.Errorf("retry policy needs at least 1 attempt, got %d", policy.MaxAttempts)
+		}
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+// doRequest performs an HTTP request with auth and JSON handling, retrying
+// idempotent requests according to the client's retry policy
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
+	}
+
+	attempts := 1
+	if isIdempotent(method) {
+		attempts = c.retryPolicy.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, path, jsonBody)
+		if err == nil || attempt >= attempts || !isRetryable(ctx, err) {
+			return resp, err
+		}
+		wait, ok := c.retryPolicy.backoff(attempt, err)
+		if !ok {
+			return resp, err
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send performs a single HTTP request
+func (c *Client) send(ctx context.Context, method, path string, jsonBody []byte) (*http.Response, error) {
+	url := c.baseURL + path
+
+	var bodyReader io.Reader
+	if jsonBody != nil {
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
@@ -121,60 +206,23 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		return nil, &APIError{
 			StatusCode: resp.StatusCode,
 			Body:       string(body),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
 		}
 	}
 
 	return resp, nil
 }
 
-// GetPendingFunctionCalls fetches all pending function call approval requests
+// GetPendingFunctionCalls fetches all pending function call approval requests,
+// following pagination
 func (c *Client) GetPendingFunctionCalls(ctx context.Context) ([]FunctionCall, error) {
-	resp, err := c.doRequest(ctx, "GET", "/agent/function_calls/pending", nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// The API returns a paginated response with this structure:
-	// {"items": [...], "total": N, "limit": 100, "offset": 0, "has_more": false}
-	var paginatedResponse struct {
-		Items   []FunctionCall `json:"items"`
-		Total   int            `json:"total"`
-		Limit   int            `json:"limit"`
-		Offset  int            `json:"offset"`
-		HasMore bool           `json:"has_more"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&paginatedResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return paginatedResponse.Items, nil
+	return c.PendingFunctionCalls(ListOptions{}).All(ctx)
 }
 
-// GetPendingHumanContacts fetches all pending human contact requests
+// GetPendingHumanContacts fetches all pending human contact requests,
+// following pagination
 func (c *Client) GetPendingHumanContacts(ctx context.Context) ([]HumanContact, error) {
-	resp, err := c.doRequest(ctx, "GET", "/agent/human_contacts/pending", nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// The API returns a paginated response with this structure:
-	// {"items": [...], "total": N, "limit": 100, "offset": 0, "has_more": false}
-	var paginatedResponse struct {
-		Items   []HumanContact `json:"items"`
-		Total   int            `json:"total"`
-		Limit   int            `json:"limit"`
-		Offset  int            `json:"offset"`
-		HasMore bool           `json:"has_more"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&paginatedResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return paginatedResponse.Items, nil
+	return c.PendingHumanContacts(ListOptions{}).All(ctx)
 }
 
 // RespondToFunctionCall responds to a function call approval request
@@ -221,3 +269,37 @@ func (c *Client) RespondToHumanContact(ctx context.Context, callID string, respo
 	resp.Body.Close()
 	return nil
 }
+
+// EscalateFunctionCall re-sends a pending function call by email, to the
+// escalation's additional recipients or channel
+func (c *Client) EscalateFunctionCall(ctx context.Context, callID string, escalation Escalation) (*FunctionCall, error) {
+	path := fmt.Sprintf("/agent/function_calls/%s/escalate_email", url.PathEscape(callID))
+	resp, err := c.doRequest(ctx, "POST", path, escalation)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var call FunctionCall
+	if err := json.NewDecoder(resp.Body).Decode(&call); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &call, nil
+}
+
+// EscalateHumanContact re-sends a pending human contact by email, to the
+// escalation's additional recipients or channel
+func (c *Client) EscalateHumanContact(ctx context.Context, callID string, escalation Escalation) (*HumanContact, error) {
+	path := fmt.Sprintf("/agent/human_contacts/%s/escalate_email", url.PathEscape(callID))
+	resp, err := c.doRequest(ctx, "POST", path, escalation)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var contact HumanContact
+	if err := json.NewDecoder(resp.Body).Decode(&contact); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &contact, nil
+}
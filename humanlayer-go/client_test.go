@@ -0,0 +1,166 @@
+package humanlayer_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	humanlayer "github.com/humanlayer/humanlayer-go"
+	"github.com/humanlayer/humanlayer-go/humanlayertest"
+)
+
+// fastRetries keeps retry tests quick
+var fastRetries = humanlayer.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		failures   []int
+		wantStatus int // 0 when the request succeeds
+	}{
+		{"no failures", nil, 0},
+		{"server error then success", []int{http.StatusServiceUnavailable}, 0},
+		{"rate limited then success", []int{http.StatusTooManyRequests, http.StatusBadGateway}, 0},
+		{"attempts exhausted", []int{500, 502, 503}, http.StatusServiceUnavailable},
+		{"client errors are not retried", []int{http.StatusBadRequest}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := humanlayertest.NewServer()
+			defer server.Close()
+			client, err := server.Client(humanlayer.WithRetryPolicy(fastRetries))
+			if err != nil {
+				t.Fatal(err)
+			}
+			call := server.AddFunctionCall(humanlayer.FunctionCall{})
+			server.FailNext(tt.failures...)
+
+			_, err = client.GetFunctionCall(context.Background(), call.CallID)
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("GetFunctionCall() error = %v", err)
+				}
+				return
+			}
+			var apiErr *humanlayer.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+				t.Fatalf("GetFunctionCall() error = %v, want status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestClientDoesNotRetryPost(t *testing.T) {
+	server := humanlayertest.NewServer()
+	defer server.Close()
+	client, err := server.Client(humanlayer.WithRetryPolicy(fastRetries))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.FailNext(http.StatusServiceUnavailable)
+
+	_, err = client.CreateFunctionCall(context.Background(), humanlayer.FunctionCall{CallID: "fc-post"})
+	var apiErr *humanlayer.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsServerError() {
+		t.Fatalf("CreateFunctionCall() error = %v, want the 503", err)
+	}
+	if _, ok := server.FunctionCall("fc-post"); ok {
+		t.Error("POST was retried")
+	}
+}
+
+func TestClientRetryAfter(t *testing.T) {
+	tests := []struct {
+		name         string
+		retryAfter   string
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{"waits for retry after", "1", 2, false},
+		{"returns the error when retry after exceeds max backoff", "120", 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter)
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				_, _ = w.Write([]byte(`{"call_id": "fc-1"}`))
+			}))
+			defer server.Close()
+			client, err := humanlayer.NewClient(
+				humanlayer.WithAPIKey("key"),
+				humanlayer.WithBaseURL(server.URL),
+				humanlayer.WithRetryPolicy(humanlayer.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Second}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Now()
+			_, err = client.GetFunctionCall(context.Background(), "fc-1")
+			elapsed := time.Since(start)
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+			if tt.wantErr {
+				var apiErr *humanlayer.APIError
+				if !errors.As(err, &apiErr) || !apiErr.IsRateLimited() || apiErr.RetryAfter != 2*time.Minute {
+					t.Fatalf("GetFunctionCall() error = %v, want the 429 with its Retry-After", err)
+				}
+				if elapsed > time.Second {
+					t.Errorf("returned after %v, want no wait", elapsed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetFunctionCall() error = %v", err)
+			}
+			if elapsed < time.Second {
+				t.Errorf("retried after %v, want the 1s Retry-After", elapsed)
+			}
+		})
+	}
+}
+
+func TestEscalateEscapesCallID(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		_, _ = w.Write([]byte(`{"call_id": "a/b?c"}`))
+	}))
+	defer server.Close()
+	client, err := humanlayer.NewClient(humanlayer.WithAPIKey("key"), humanlayer.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.EscalateFunctionCall(ctx, "a/b?c", humanlayer.Escalation{}); err != nil {
+		t.Fatalf("EscalateFunctionCall() error = %v", err)
+	}
+	if _, err := client.EscalateHumanContact(ctx, "a/b?c", humanlayer.Escalation{}); err != nil {
+		t.Fatalf("EscalateHumanContact() error = %v", err)
+	}
+
+	want := []string{
+		"/agent/function_calls/a%2Fb%3Fc/escalate_email",
+		"/agent/human_contacts/a%2Fb%3Fc/escalate_email",
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if !strings.HasSuffix(paths[i], want[i]) {
+			t.Errorf("path = %q, want suffix %q", paths[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package humanlayertest
+
+import (
+	"time"
+
+	humanlayer "github.com/humanlayer/humanlayer-go"
+)
+
+// Decision is the fake human's response to a function call
+type Decision struct {
+	Approve          bool
+	Comment          string
+	RejectOptionName string
+	Delay            time.Duration // How long the human takes to respond
+}
+
+func (d Decision) status() humanlayer.FunctionCallStatus {
+	approved := d.Approve
+	return humanlayer.FunctionCallStatus{
+		Approved:         &approved,
+		Comment:          d.Comment,
+		RejectOptionName: d.RejectOptionName,
+	}
+}
+
+// Reply is the fake human's response to a human contact
+type Reply struct {
+	Response           string
+	ResponseOptionName string
+	Delay              time.Duration // How long the human takes to respond
+}
+
+func (r Reply) status() humanlayer.HumanContactStatus {
+	return humanlayer.HumanContactStatus{
+		Response:           r.Response,
+		ResponseOptionName: r.ResponseOptionName,
+	}
+}
+
+// FunctionCallBehavior decides how the fake human responds to a new function
+// call. Returning nil leaves the call pending for the test to answer.
+type FunctionCallBehavior func(call humanlayer.FunctionCall) *Decision
+
+// HumanContactBehavior decides how the fake human responds to a new human
+// contact. Returning nil leaves the contact pending for the test to answer.
+type HumanContactBehavior func(contact humanlayer.HumanContact) *Reply
+
+// AutoApprove approves every function call immediately
+func AutoApprove(comment string) FunctionCallBehavior {
+	return func(humanlayer.FunctionCall) *Decision {
+		return &Decision{Approve: true, Comment: comment}
+	}
+}
+
+// DenyWithReason denies every function call immediately
+func DenyWithReason(reason string) FunctionCallBehavior {
+	return func(humanlayer.FunctionCall) *Decision {
+		return &Decision{Comment: reason}
+	}
+}
+
+// AutoRespond answers every human contact immediately
+func AutoRespond(response string) HumanContactBehavior {
+	return func(humanlayer.HumanContact) *Reply {
+		return &Reply{Response: response}
+	}
+}
+
+// ByFunction picks the behavior by the called function's name, leaving calls
+// to other functions pending
+func ByFunction(behaviors map[string]FunctionCallBehavior) FunctionCallBehavior {
+	return func(call humanlayer.FunctionCall) *Decision {
+		if behavior, ok := behaviors[call.Spec.Fn]; ok {
+			return behavior(call)
+		}
+		return nil
+	}
+}
+
+// DelayDecision makes behavior's decisions arrive after delay
+func DelayDecision(delay time.Duration, behavior FunctionCallBehavior) FunctionCallBehavior {
+	return func(call humanlayer.FunctionCall) *Decision {
+		decision := behavior(call)
+		if decision != nil {
+			decision.Delay = delay
+		}
+		return decision
+	}
+}
+
+// DelayReply makes behavior's replies arrive after delay
+func DelayReply(delay time.Duration, behavior HumanContactBehavior) HumanContactBehavior {
+	return func(contact humanlayer.HumanContact) *Reply {
+		reply := behavior(contact)
+		if reply != nil {
+			reply.Delay = delay
+		}
+		return reply
+	}
+}
@@ -0,0 +1,615 @@
+// Package humanlayertest provides an in-process fake of the HumanLayer API for
+// testing code that uses humanlayer.Client.
+//
+//	server := humanlayertest.NewServer(humanlayertest.WithFunctionCallBehavior(humanlayertest.AutoApprove("ok")))
+//	defer server.Close()
+//
+//	client, err := server.Client()
+//	call, err := client.CreateFunctionCall(ctx, humanlayer.FunctionCall{Spec: spec})
+//	call, err = client.WaitForDecision(ctx, call.CallID)
+package humanlayertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	humanlayer "github.com/humanlayer/humanlayer-go"
+)
+
+// DefaultAPIKey is the API key the server accepts unless WithAPIKey is given
+const DefaultAPIKey = "humanlayertest-key"
+
+// defaultPageLimit matches the API's page size for pending lists
+const defaultPageLimit = 100
+
+// Server is a fake HumanLayer API backed by httptest.Server. It serves the
+// human side (/agent/function_calls, /agent/human_contacts) and the agent
+// side (/function_calls, /contact_requests) from the same in-memory state.
+type Server struct {
+	*httptest.Server
+
+	apiKey string
+
+	mu                   sync.Mutex
+	functionCalls        map[string]*humanlayer.FunctionCall
+	functionCallOrder    []string
+	humanContacts        map[string]*humanlayer.HumanContact
+	humanContactOrder    []string
+	functionCallBehavior FunctionCallBehavior
+	humanContactBehavior HumanContactBehavior
+	escalations          map[string][]humanlayer.Escalation
+	failures             []int
+	timers               []*time.Timer
+	nextID               int
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithAPIKey sets the API key requests must present
+func WithAPIKey(key string) Option {
+	return func(s *Server) {
+		s.apiKey = key
+	}
+}
+
+// WithFunctionCallBehavior sets how the fake human handles new function calls
+func WithFunctionCallBehavior(behavior FunctionCallBehavior) Option {
+	return func(s *Server) {
+		s.functionCallBehavior = behavior
+	}
+}
+
+// WithHumanContactBehavior sets how the fake human handles new human contacts
+func WithHumanContactBehavior(behavior HumanContactBehavior) Option {
+	return func(s *Server) {
+		s.humanContactBehavior = behavior
+	}
+}
+
+// NewServer starts a fake HumanLayer API. Calls stay pending until a behavior
+// or the test responds to them. Close the server when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		apiKey:        DefaultAPIKey,
+		functionCalls: make(map[string]*humanlayer.FunctionCall),
+		humanContacts: make(map[string]*humanlayer.HumanContact),
+		escalations:   make(map[string][]humanlayer.Escalation),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/agent/function_calls/pending", s.handlePendingFunctionCalls)
+	mux.HandleFunc("/agent/function_calls/", s.handleFunctionCallAction)
+	mux.HandleFunc("/agent/human_contacts/pending", s.handlePendingHumanContacts)
+	mux.HandleFunc("/agent/human_contacts/", s.handleHumanContactAction)
+	mux.HandleFunc("/function_calls", s.handleCreateFunctionCall)
+	mux.HandleFunc("/function_calls/", s.handleGetFunctionCall)
+	mux.HandleFunc("/contact_requests", s.handleCreateHumanContact)
+	mux.HandleFunc("/contact_requests/", s.handleGetHumanContact)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// Close stops pending delayed responses and shuts the server down
+func (s *Server) Close() {
+	s.mu.Lock()
+	for _, timer := range s.timers {
+		timer.Stop()
+	}
+	s.mu.Unlock()
+	s.Server.Close()
+}
+
+// Client returns a humanlayer.Client for the server. Later options override
+// the server's base URL and API key.
+func (s *Server) Client(opts ...humanlayer.ClientOption) (*humanlayer.Client, error) {
+	defaults := []humanlayer.ClientOption{
+		humanlayer.WithAPIKey(s.apiKey),
+		humanlayer.WithBaseURL(s.URL),
+		humanlayer.WithHTTPClient(s.Server.Client()),
+	}
+	return humanlayer.NewClient(append(defaults, opts...)...)
+}
+
+// SetFunctionCallBehavior changes how the fake human handles function calls
+// created from now on
+func (s *Server) SetFunctionCallBehavior(behavior FunctionCallBehavior) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.functionCallBehavior = behavior
+}
+
+// SetHumanContactBehavior changes how the fake human handles human contacts
+// created from now on
+func (s *Server) SetHumanContactBehavior(behavior HumanContactBehavior) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.humanContactBehavior = behavior
+}
+
+// FailNext makes the next requests fail with the given status codes, one per
+// request, e.g. FailNext(503, 429) to exercise retries
+func (s *Server) FailNext(statusCodes ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, statusCodes...)
+}
+
+// AddFunctionCall adds a function call as if an agent had created it and
+// returns it with its call ID set
+func (s *Server) AddFunctionCall(call humanlayer.FunctionCall) humanlayer.FunctionCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.addFunctionCall(call)
+}
+
+// AddHumanContact adds a human contact as if an agent had created it and
+// returns it with its call ID set
+func (s *Server) AddHumanContact(contact humanlayer.HumanContact) humanlayer.HumanContact {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.addHumanContact(contact)
+}
+
+// FunctionCall returns the current state of a function call
+func (s *Server) FunctionCall(callID string) (humanlayer.FunctionCall, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	call, ok := s.functionCalls[callID]
+	if !ok {
+		return humanlayer.FunctionCall{}, false
+	}
+	return *call, true
+}
+
+// HumanContact returns the current state of a human contact
+func (s *Server) HumanContact(callID string) (humanlayer.HumanContact, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	contact, ok := s.humanContacts[callID]
+	if !ok {
+		return humanlayer.HumanContact{}, false
+	}
+	return *contact, true
+}
+
+// Escalations returns the escalations received for a call, in order
+func (s *Server) Escalations(callID string) []humanlayer.Escalation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]humanlayer.Escalation(nil), s.escalations[callID]...)
+}
+
+// Approve approves a pending function call as the human
+func (s *Server) Approve(callID, comment string) error {
+	return s.Decide(callID, Decision{Approve: true, Comment: comment})
+}
+
+// Deny denies a pending function call as the human
+func (s *Server) Deny(callID, reason string) error {
+	return s.Decide(callID, Decision{Comment: reason})
+}
+
+// Decide responds to a pending function call as the human, ignoring the
+// decision's delay
+func (s *Server) Decide(callID string, decision Decision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.respondFunctionCall(callID, decision.status())
+	return err
+}
+
+// Respond responds to a pending human contact as the human
+func (s *Server) Respond(callID, response string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.respondHumanContact(callID, Reply{Response: response}.status())
+	return err
+}
+
+// addFunctionCall stores a new call and applies the behavior; s.mu must be held
+func (s *Server) addFunctionCall(call humanlayer.FunctionCall) *humanlayer.FunctionCall {
+	if call.CallID == "" {
+		s.nextID++
+		call.CallID = fmt.Sprintf("fc-%d", s.nextID)
+	}
+	call.Status = &humanlayer.FunctionCallStatus{RequestedAt: now()}
+	stored := &call
+	if _, exists := s.functionCalls[call.CallID]; !exists {
+		s.functionCallOrder = append(s.functionCallOrder, call.CallID)
+	}
+	s.functionCalls[call.CallID] = stored
+
+	if s.functionCallBehavior != nil {
+		if decision := s.functionCallBehavior(call); decision != nil {
+			callID, status := call.CallID, decision.status()
+			s.schedule(decision.Delay, func() {
+				_, _ = s.respondFunctionCall(callID, status)
+			})
+		}
+	}
+	copied := *stored
+	return &copied
+}
+
+// addHumanContact stores a new contact and applies the behavior; s.mu must be held
+func (s *Server) addHumanContact(contact humanlayer.HumanContact) *humanlayer.HumanContact {
+	if contact.CallID == "" {
+		s.nextID++
+		contact.CallID = fmt.Sprintf("hc-%d", s.nextID)
+	}
+	contact.Status = &humanlayer.HumanContactStatus{RequestedAt: now()}
+	stored := &contact
+	if _, exists := s.humanContacts[contact.CallID]; !exists {
+		s.humanContactOrder = append(s.humanContactOrder, contact.CallID)
+	}
+	s.humanContacts[contact.CallID] = stored
+
+	if s.humanContactBehavior != nil {
+		if reply := s.humanContactBehavior(contact); reply != nil {
+			callID, status := contact.CallID, reply.status()
+			s.schedule(reply.Delay, func() {
+				_, _ = s.respondHumanContact(callID, status)
+			})
+		}
+	}
+	copied := *stored
+	return &copied
+}
+
+// schedule runs respond now or after delay; s.mu must be held
+func (s *Server) schedule(delay time.Duration, respond func()) {
+	if delay <= 0 {
+		respond()
+		return
+	}
+	s.timers = append(s.timers, time.AfterFunc(delay, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		respond()
+	}))
+}
+
+// respondFunctionCall records a response; s.mu must be held
+func (s *Server) respondFunctionCall(callID string, status humanlayer.FunctionCallStatus) (*humanlayer.FunctionCall, error) {
+	call, ok := s.functionCalls[callID]
+	if !ok {
+		return nil, &humanlayer.APIError{StatusCode: http.StatusNotFound, Body: "function call not found"}
+	}
+	if call.Status != nil && call.Status.RespondedAt != nil {
+		return nil, &humanlayer.APIError{StatusCode: http.StatusConflict, Body: "function call already responded to"}
+	}
+	if status.Approved == nil {
+		return nil, &humanlayer.APIError{StatusCode: http.StatusBadRequest, Body: "approved is required"}
+	}
+	if call.Status != nil {
+		status.RequestedAt = call.Status.RequestedAt
+	}
+	status.RespondedAt = now()
+	call.Status = &status
+	copied := *call
+	return &copied, nil
+}
+
+// respondHumanContact records a response; s.mu must be held
+func (s *Server) respondHumanContact(callID string, status humanlayer.HumanContactStatus) (*humanlayer.HumanContact, error) {
+	contact, ok := s.humanContacts[callID]
+	if !ok {
+		return nil, &humanlayer.APIError{StatusCode: http.StatusNotFound, Body: "human contact not found"}
+	}
+	if contact.Status != nil && contact.Status.RespondedAt != nil {
+		return nil, &humanlayer.APIError{StatusCode: http.StatusConflict, Body: "human contact already responded to"}
+	}
+	if contact.Status != nil {
+		status.RequestedAt = contact.Status.RequestedAt
+	}
+	status.RespondedAt = now()
+	contact.Status = &status
+	copied := *contact
+	return &copied, nil
+}
+
+// middleware checks the API key and injects queued failures
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.apiKey {
+			writeError(w, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+
+		s.mu.Lock()
+		var failure int
+		if len(s.failures) > 0 {
+			failure, s.failures = s.failures[0], s.failures[1:]
+		}
+		s.mu.Unlock()
+		if failure != 0 {
+			if failure == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "0")
+			}
+			writeError(w, failure, http.StatusText(failure))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handlePendingFunctionCalls(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	s.mu.Lock()
+	var pending []humanlayer.FunctionCall
+	for _, id := range s.functionCallOrder {
+		if call := s.functionCalls[id]; call.Status == nil || call.Status.RespondedAt == nil {
+			pending = append(pending, *call)
+		}
+	}
+	s.mu.Unlock()
+	writePage(w, r, pending)
+}
+
+func (s *Server) handlePendingHumanContacts(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	s.mu.Lock()
+	var pending []humanlayer.HumanContact
+	for _, id := range s.humanContactOrder {
+		if contact := s.humanContacts[id]; contact.Status == nil || contact.Status.RespondedAt == nil {
+			pending = append(pending, *contact)
+		}
+	}
+	s.mu.Unlock()
+	writePage(w, r, pending)
+}
+
+// handleFunctionCallAction serves POST /agent/function_calls/{id}/respond
+// and /agent/function_calls/{id}/escalate_email
+func (s *Server) handleFunctionCallAction(w http.ResponseWriter, r *http.Request) {
+	callID, action, ok := callAction(r.URL.Path, "/agent/function_calls/")
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	switch action {
+	case "respond":
+		var status humanlayer.FunctionCallStatus
+		if err := json.NewDecoder(r.Body).Decode(&status); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+			return
+		}
+		s.mu.Lock()
+		call, err := s.respondFunctionCall(callID, status)
+		s.mu.Unlock()
+		writeResult(w, http.StatusOK, call, err)
+	case "escalate_email":
+		var escalation humanlayer.Escalation
+		if err := json.NewDecoder(r.Body).Decode(&escalation); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		call, ok := s.functionCalls[callID]
+		if !ok {
+			writeError(w, http.StatusNotFound, "function call not found")
+			return
+		}
+		if call.Status != nil && call.Status.RespondedAt != nil {
+			writeError(w, http.StatusConflict, "function call already responded to")
+			return
+		}
+		s.escalations[callID] = append(s.escalations[callID], escalation)
+		writeJSON(w, http.StatusOK, call)
+	}
+}
+
+// handleHumanContactAction serves POST /agent/human_contacts/{id}/respond
+// and /agent/human_contacts/{id}/escalate_email
+func (s *Server) handleHumanContactAction(w http.ResponseWriter, r *http.Request) {
+	callID, action, ok := callAction(r.URL.Path, "/agent/human_contacts/")
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	switch action {
+	case "respond":
+		var status humanlayer.HumanContactStatus
+		if err := json.NewDecoder(r.Body).Decode(&status); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+			return
+		}
+		s.mu.Lock()
+		contact, err := s.respondHumanContact(callID, status)
+		s.mu.Unlock()
+		writeResult(w, http.StatusOK, contact, err)
+	case "escalate_email":
+		var escalation humanlayer.Escalation
+		if err := json.NewDecoder(r.Body).Decode(&escalation); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		contact, ok := s.humanContacts[callID]
+		if !ok {
+			writeError(w, http.StatusNotFound, "human contact not found")
+			return
+		}
+		if contact.Status != nil && contact.Status.RespondedAt != nil {
+			writeError(w, http.StatusConflict, "human contact already responded to")
+			return
+		}
+		s.escalations[callID] = append(s.escalations[callID], escalation)
+		writeJSON(w, http.StatusOK, contact)
+	}
+}
+
+// handleCreateFunctionCall serves POST /function_calls
+func (s *Server) handleCreateFunctionCall(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+	var call humanlayer.FunctionCall
+	if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.functionCalls[call.CallID]; exists && call.CallID != "" {
+		writeError(w, http.StatusConflict, "function call already exists")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.addFunctionCall(call))
+}
+
+// handleGetFunctionCall serves GET /function_calls/{id}
+func (s *Server) handleGetFunctionCall(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	call, ok := s.FunctionCall(strings.TrimPrefix(r.URL.Path, "/function_calls/"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "function call not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, call)
+}
+
+// handleCreateHumanContact serves POST /contact_requests
+func (s *Server) handleCreateHumanContact(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+	var contact humanlayer.HumanContact
+	if err := json.NewDecoder(r.Body).Decode(&contact); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.humanContacts[contact.CallID]; exists && contact.CallID != "" {
+		writeError(w, http.StatusConflict, "human contact already exists")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.addHumanContact(contact))
+}
+
+// handleGetHumanContact serves GET /contact_requests/{id}
+func (s *Server) handleGetHumanContact(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	contact, ok := s.HumanContact(strings.TrimPrefix(r.URL.Path, "/contact_requests/"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "human contact not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, contact)
+}
+
+// callAction splits "<prefix><id>/<action>" for the respond and
+// escalate_email actions
+func callAction(path, prefix string) (string, string, bool) {
+	callID, action, found := strings.Cut(strings.TrimPrefix(path, prefix), "/")
+	if !found || callID == "" {
+		return "", "", false
+	}
+	switch action {
+	case "respond", "escalate_email":
+		return callID, action, true
+	}
+	return "", "", false
+}
+
+// writePage writes the slice of items selected by the limit and offset query
+// parameters in the API's paginated format
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	limit := defaultPageLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = parsed
+	}
+	offset := 0
+	if value := r.URL.Query().Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			writeError(w, http.StatusBadRequest, "invalid offset")
+			return
+		}
+		offset = parsed
+	}
+
+	start := min(offset, len(items))
+	end := min(start+limit, len(items))
+	page := items[start:end]
+	if page == nil {
+		page = []T{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"items":    page,
+		"total":    len(items),
+		"limit":    limit,
+		"offset":   offset,
+		"has_more": end < len(items),
+	})
+}
+
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return false
+	}
+	return true
+}
+
+// writeResult writes value, or err as an error response
+func writeResult(w http.ResponseWriter, status int, value interface{}, err error) {
+	if apiErr, ok := err.(*humanlayer.APIError); ok {
+		writeError(w, apiErr.StatusCode, apiErr.Body)
+		return
+	}
+	writeJSON(w, status, value)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"detail": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func now() *humanlayer.CustomTime {
+	return &humanlayer.CustomTime{Time: time.Now().UTC()}
+}
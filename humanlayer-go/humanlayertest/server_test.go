@@ -0,0 +1,222 @@
+package humanlayertest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	humanlayer "github.com/humanlayer/humanlayer-go"
+	"github.com/humanlayer/humanlayer-go/humanlayertest"
+)
+
+// newClient returns a client for server that does not retry
+func newClient(t *testing.T, server *humanlayertest.Server) *humanlayer.Client {
+	t.Helper()
+	client, err := server.Client(humanlayer.WithRetryPolicy(humanlayer.RetryPolicy{MaxAttempts: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// wantStatus fails unless err is an APIError with status
+func wantStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var apiErr *humanlayer.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != status {
+		t.Fatalf("error = %v, want status %d", err, status)
+	}
+}
+
+func TestServerBehaviors(t *testing.T) {
+	tests := []struct {
+		name        string
+		behavior    humanlayertest.FunctionCallBehavior
+		fn          string
+		wantPending bool
+		wantApprove bool
+		wantComment string
+	}{
+		{"no behavior", nil, "deploy", true, false, ""},
+		{"auto approve", humanlayertest.AutoApprove("ok"), "deploy", false, true, "ok"},
+		{"deny with reason", humanlayertest.DenyWithReason("no"), "deploy", false, false, "no"},
+		{"delayed", humanlayertest.DelayDecision(time.Hour, humanlayertest.AutoApprove("ok")), "deploy", true, false, ""},
+		{"by function", humanlayertest.ByFunction(map[string]humanlayertest.FunctionCallBehavior{"read": humanlayertest.AutoApprove("")}), "read", false, true, ""},
+		{"by function leaves others pending", humanlayertest.ByFunction(map[string]humanlayertest.FunctionCallBehavior{"read": humanlayertest.AutoApprove("")}), "deploy", true, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := humanlayertest.NewServer(humanlayertest.WithFunctionCallBehavior(tt.behavior))
+			defer server.Close()
+			client := newClient(t, server)
+
+			call, err := client.CreateFunctionCall(context.Background(), humanlayer.FunctionCall{RunID: "run-1", Spec: humanlayer.FunctionCallSpec{Fn: tt.fn}})
+			if err != nil {
+				t.Fatalf("CreateFunctionCall() error = %v", err)
+			}
+			stored, ok := server.FunctionCall(call.CallID)
+			if !ok {
+				t.Fatal("call not stored")
+			}
+			if pending := stored.Status.Approved == nil; pending != tt.wantPending {
+				t.Fatalf("pending = %v, want %v", pending, tt.wantPending)
+			}
+			if tt.wantPending {
+				return
+			}
+			if *stored.Status.Approved != tt.wantApprove || stored.Status.Comment != tt.wantComment {
+				t.Errorf("status = %+v, want approved %v with %q", stored.Status, tt.wantApprove, tt.wantComment)
+			}
+			if stored.Status.RequestedAt == nil || stored.Status.RespondedAt == nil {
+				t.Errorf("status = %+v, want requested and responded times", stored.Status)
+			}
+		})
+	}
+}
+
+func TestServerDelayedDecision(t *testing.T) {
+	server := humanlayertest.NewServer(humanlayertest.WithFunctionCallBehavior(
+		humanlayertest.DelayDecision(20*time.Millisecond, humanlayertest.AutoApprove("later")),
+	))
+	defer server.Close()
+	client := newClient(t, server)
+	ctx := context.Background()
+
+	call, err := client.CreateFunctionCall(ctx, humanlayer.FunctionCall{RunID: "run-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Status != nil && call.Status.Approved != nil {
+		t.Fatal("call decided before the delay")
+	}
+	time.Sleep(50 * time.Millisecond)
+	call, err = client.GetFunctionCall(ctx, call.CallID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Status.Approved == nil || !*call.Status.Approved {
+		t.Errorf("status = %+v, want approved after the delay", call.Status)
+	}
+}
+
+func TestServerHumanSide(t *testing.T) {
+	server := humanlayertest.NewServer()
+	defer server.Close()
+	client := newClient(t, server)
+	ctx := context.Background()
+
+	first := server.AddFunctionCall(humanlayer.FunctionCall{RunID: "run-1"})
+	second := server.AddFunctionCall(humanlayer.FunctionCall{RunID: "run-1"})
+	contact := server.AddHumanContact(humanlayer.HumanContact{RunID: "run-1"})
+
+	calls, err := client.PendingFunctionCalls(humanlayer.ListOptions{Limit: 1}).All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 || calls[0].CallID != first.CallID || calls[1].CallID != second.CallID {
+		t.Fatalf("pending calls = %+v, want %s and %s", calls, first.CallID, second.CallID)
+	}
+
+	if err := client.ApproveFunctionCall(ctx, first.CallID, "ok"); err != nil {
+		t.Fatalf("ApproveFunctionCall() error = %v", err)
+	}
+	wantStatus(t, client.DenyFunctionCall(ctx, first.CallID, "changed my mind"), http.StatusConflict)
+	wantStatus(t, client.ApproveFunctionCall(ctx, "fc-missing", ""), http.StatusNotFound)
+	wantStatus(t, client.RespondToFunctionCall(ctx, second.CallID, humanlayer.FunctionCallStatus{Comment: "?"}), http.StatusBadRequest)
+
+	calls, err = client.GetPendingFunctionCalls(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 || calls[0].CallID != second.CallID {
+		t.Errorf("pending calls after approving = %+v, want %s", calls, second.CallID)
+	}
+
+	escalation := humanlayer.Escalation{EscalationMsg: "still waiting"}
+	if _, err := client.EscalateFunctionCall(ctx, second.CallID, escalation); err != nil {
+		t.Fatalf("EscalateFunctionCall() error = %v", err)
+	}
+	_, err = client.EscalateFunctionCall(ctx, first.CallID, escalation)
+	wantStatus(t, err, http.StatusConflict)
+	if got := server.Escalations(second.CallID); len(got) != 1 || got[0].EscalationMsg != "still waiting" {
+		t.Errorf("escalations = %+v", got)
+	}
+
+	if err := client.RespondToHumanContact(ctx, contact.CallID, "yes"); err != nil {
+		t.Fatalf("RespondToHumanContact() error = %v", err)
+	}
+	wantStatus(t, client.RespondToHumanContact(ctx, contact.CallID, "no"), http.StatusConflict)
+	if stored, _ := server.HumanContact(contact.CallID); stored.Status.Response != "yes" {
+		t.Errorf("response = %q, want yes", stored.Status.Response)
+	}
+	contacts, err := client.GetPendingHumanContacts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contacts) != 0 {
+		t.Errorf("pending contacts = %+v, want none", contacts)
+	}
+}
+
+func TestServerAgentSide(t *testing.T) {
+	server := humanlayertest.NewServer()
+	defer server.Close()
+	client := newClient(t, server)
+	ctx := context.Background()
+
+	call, err := client.CreateFunctionCall(ctx, humanlayer.FunctionCall{RunID: "run-1", CallID: "fc-fixed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.CreateFunctionCall(ctx, humanlayer.FunctionCall{RunID: "run-1", CallID: call.CallID})
+	wantStatus(t, err, http.StatusConflict)
+	_, err = client.GetFunctionCall(ctx, "fc-missing")
+	wantStatus(t, err, http.StatusNotFound)
+
+	if err := server.Deny(call.CallID, "no"); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Approve(call.CallID, ""); err == nil {
+		t.Error("Approve() after Deny() error = nil, want a conflict")
+	}
+
+	contact, err := client.CreateHumanContact(ctx, humanlayer.HumanContact{RunID: "run-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Respond(contact.CallID, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	contact, err = client.GetHumanContact(ctx, contact.CallID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contact.Status.Response != "hello" {
+		t.Errorf("response = %q, want hello", contact.Status.Response)
+	}
+}
+
+func TestServerAuthAndFailures(t *testing.T) {
+	server := humanlayertest.NewServer(humanlayertest.WithAPIKey("secret"))
+	defer server.Close()
+	ctx := context.Background()
+
+	wrongKey, err := server.Client(humanlayer.WithAPIKey("wrong"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = wrongKey.GetPendingFunctionCalls(ctx)
+	wantStatus(t, err, http.StatusUnauthorized)
+
+	client := newClient(t, server)
+	server.FailNext(http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	_, err = client.GetPendingFunctionCalls(ctx)
+	wantStatus(t, err, http.StatusServiceUnavailable)
+	_, err = client.GetPendingFunctionCalls(ctx)
+	wantStatus(t, err, http.StatusTooManyRequests)
+	if _, err := client.GetPendingFunctionCalls(ctx); err != nil {
+		t.Errorf("GetPendingFunctionCalls() after the failures error = %v", err)
+	}
+}
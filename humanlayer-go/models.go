@@ -102,21 +102,3 @@ type ResponseOption struct {
 	PromptFill  string `json:"prompt_fill,omitempty"`
 	Interactive bool   `json:"interactive"`
 }
-
-// ContactChannel represents how to contact the human
-type ContactChannel struct {
-	Slack *SlackChannel `json:"slack,omitempty"`
-	Email *EmailChannel `json:"email,omitempty"`
-}
-
-// SlackChannel configuration
-type SlackChannel struct {
-	ChannelOrUserID string `json:"channel_or_user_id"`
-	// Add other fields as needed
-}
-
-// EmailChannel configuration
-type EmailChannel struct {
-	Address string `json:"address"`
-	// Add other fields as needed
-}
@@ -0,0 +1,130 @@
+package humanlayer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ListOptions configures paginated list requests
+type ListOptions struct {
+	Limit int // Page size; 0 uses the API default
+}
+
+// page is the paginated response body of list endpoints:
+// {"items": [...], "total": N, "limit": 100, "offset": 0, "has_more": false}
+type page[T any] struct {
+	Items   []T  `json:"items"`
+	Total   int  `json:"total"`
+	Limit   int  `json:"limit"`
+	Offset  int  `json:"offset"`
+	HasMore bool `json:"has_more"`
+}
+
+// Iterator walks a paginated list, fetching pages by offset as needed:
+//
+//	it := client.PendingFunctionCalls(humanlayer.ListOptions{})
+//	for it.Next(ctx) {
+//		call := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	client *Client
+	path   string
+	limit  int
+
+	items   []T
+	index   int
+	offset  int
+	hasMore bool
+	current T
+	err     error
+}
+
+func newIterator[T any](c *Client, path string, opts ListOptions) *Iterator[T] {
+	return &Iterator[T]{client: c, path: path, limit: opts.Limit, hasMore: true}
+}
+
+// Next advances to the next item, fetching the next page when the current
+// one is used up. It returns false at the end of the list or on error.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for it.index >= len(it.items) {
+		if it.err != nil || !it.hasMore {
+			return false
+		}
+		if err := it.fetch(ctx); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	it.current = it.items[it.index]
+	it.index++
+	return true
+}
+
+// Item returns the item Next advanced to
+func (it *Iterator[T]) Item() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All returns the remaining items across all pages
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// fetch loads the page at the current offset
+func (it *Iterator[T]) fetch(ctx context.Context) error {
+	query := url.Values{}
+	if it.offset > 0 {
+		query.Set("offset", strconv.Itoa(it.offset))
+	}
+	if it.limit > 0 {
+		query.Set("limit", strconv.Itoa(it.limit))
+	}
+	path := it.path
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := it.client.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var p page[T]
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	it.items = p.Items
+	it.index = 0
+	it.offset += len(p.Items)
+	// An empty page ends the list even if the API claims there is more
+	it.hasMore = p.HasMore && len(p.Items) > 0
+	return nil
+}
+
+// PendingFunctionCalls returns an iterator over all pending function call
+// approval requests
+func (c *Client) PendingFunctionCalls(opts ListOptions) *Iterator[FunctionCall] {
+	return newIterator[FunctionCall](c, "/agent/function_calls/pending", opts)
+}
+
+// PendingHumanContacts returns an iterator over all pending human contact requests
+func (c *Client) PendingHumanContacts(opts ListOptions) *Iterator[HumanContact] {
+	return newIterator[HumanContact](c, "/agent/human_contacts/pending", opts)
+}
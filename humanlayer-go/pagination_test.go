@@ -0,0 +1,107 @@
+package humanlayer_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	humanlayer "github.com/humanlayer/humanlayer-go"
+	"github.com/humanlayer/humanlayer-go/humanlayertest"
+)
+
+func TestPendingFunctionCallsIterator(t *testing.T) {
+	tests := []struct {
+		name  string
+		calls int
+		limit int
+	}{
+		{"empty", 0, 0},
+		{"single page", 3, 0},
+		{"exact pages", 4, 2},
+		{"partial last page", 5, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := humanlayertest.NewServer()
+			defer server.Close()
+			client, err := server.Client()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want []string
+			for i := 0; i < tt.calls; i++ {
+				want = append(want, server.AddFunctionCall(humanlayer.FunctionCall{}).CallID)
+			}
+			// Responded calls are no longer pending
+			answered := server.AddFunctionCall(humanlayer.FunctionCall{})
+			if err := server.Approve(answered.CallID, ""); err != nil {
+				t.Fatal(err)
+			}
+
+			calls, err := client.PendingFunctionCalls(humanlayer.ListOptions{Limit: tt.limit}).All(context.Background())
+			if err != nil {
+				t.Fatalf("All() error = %v", err)
+			}
+			var got []string
+			for _, call := range calls {
+				got = append(got, call.CallID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("All() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestIteratorStopsOnEmptyPage(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// A server that keeps claiming there is more
+		if r.URL.Query().Get("offset") == "" {
+			_, _ = w.Write([]byte(`{"items": [{"run_id": "r", "call_id": "hc-1", "spec": {}}], "total": 5, "has_more": true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": [], "total": 5, "has_more": true}`))
+	}))
+	defer server.Close()
+	client, err := humanlayer.NewClient(humanlayer.WithAPIKey("key"), humanlayer.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contacts, err := client.PendingHumanContacts(humanlayer.ListOptions{}).All(context.Background())
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(contacts) != 1 || contacts[0].CallID != "hc-1" {
+		t.Errorf("All() = %+v, want hc-1", contacts)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	server := humanlayertest.NewServer()
+	defer server.Close()
+	client, err := server.Client(humanlayer.WithRetryPolicy(humanlayer.RetryPolicy{MaxAttempts: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.AddHumanContact(humanlayer.HumanContact{})
+	server.FailNext(http.StatusInternalServerError)
+
+	it := client.PendingHumanContacts(humanlayer.ListOptions{})
+	if it.Next(context.Background()) {
+		t.Fatal("Next() = true, want false on error")
+	}
+	if it.Err() == nil {
+		t.Fatal("Err() = nil, want the 500")
+	}
+	if it.Next(context.Background()) {
+		t.Error("Next() after an error = true, want false")
+	}
+}
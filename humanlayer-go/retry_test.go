@@ -0,0 +1,59 @@
+package humanlayer
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		name     string
+		attempt  int
+		err      error
+		min, max time.Duration
+		retry    bool
+	}{
+		{"first retry", 1, errors.New("network"), 50 * time.Millisecond, 100 * time.Millisecond, true},
+		{"doubles per attempt", 3, errors.New("network"), 200 * time.Millisecond, 400 * time.Millisecond, true},
+		{"capped at max backoff", 10, errors.New("network"), 500 * time.Millisecond, time.Second, true},
+		{"retry after", 1, &APIError{StatusCode: 429, RetryAfter: 700 * time.Millisecond}, 700 * time.Millisecond, 700 * time.Millisecond, true},
+		{"retry after equal to max backoff", 1, &APIError{StatusCode: 503, RetryAfter: time.Second}, time.Second, time.Second, true},
+		{"retry after beyond max backoff", 1, &APIError{StatusCode: 429, RetryAfter: time.Minute}, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, retry := policy.backoff(tt.attempt, tt.err)
+			if retry != tt.retry {
+				t.Fatalf("backoff() retry = %v, want %v", retry, tt.retry)
+			}
+			if wait < tt.min || wait > tt.max {
+				t.Errorf("backoff() = %v, want between %v and %v", wait, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"-1", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,392 @@
+package humanlayer
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Webhook event types
+const (
+	WebhookFunctionCallCompleted = "function_call.completed"
+	WebhookHumanContactCompleted = "human_contact.completed"
+)
+
+// Defaults for WebhookHandler
+const (
+	DefaultWebhookTolerance   = 5 * time.Minute
+	DefaultWebhookDedupWindow = 24 * time.Hour
+	maxWebhookBodySize        = 1 << 20
+)
+
+// ErrInvalidSignature is returned by VerifyWebhook when no signature matches
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// WebhookPayload is the body HumanLayer posts to webhooks
+type WebhookPayload struct {
+	Type   string          `json:"type"`
+	IsTest bool            `json:"is_test,omitempty"`
+	Event  json.RawMessage `json:"event"`
+}
+
+// WebhookHandler is an http.Handler that receives HumanLayer webhooks. It
+// verifies each delivery's signature and timestamp, decodes completed function
+// calls and human contacts, and passes them to the registered callbacks.
+// Repeated deliveries of the same call are acknowledged without calling the
+// callback again. Test deliveries are acknowledged and otherwise ignored.
+//
+// A callback error or panic responds with 500, and a delivery arriving while the same
+// call is still being handled with 409, so HumanLayer retries it. Error details
+// are logged rather than returned to the sender.
+type WebhookHandler struct {
+	secret         []byte
+	tolerance      time.Duration
+	onFunctionCall func(context.Context, FunctionCall) error
+	onHumanContact func(context.Context, HumanContact) error
+	deliveries     *deliveryCache
+	logger         *slog.Logger // slog.Default() when nil
+	now            func() time.Time
+}
+
+// WebhookOption is a functional option for configuring a WebhookHandler
+type WebhookOption func(*WebhookHandler) error
+
+// NewWebhookHandler creates a webhook handler verifying deliveries with
+// secret, the signing secret shown when the webhook was created
+// ("whsec_..." followed by base64)
+func NewWebhookHandler(secret string, opts ...WebhookOption) (*WebhookHandler, error) {
+	key, err := decodeWebhookSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &WebhookHandler{
+		secret:     key,
+		tolerance:  DefaultWebhookTolerance,
+		deliveries: newDeliveryCache(DefaultWebhookDedupWindow),
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		if err := opt(h); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// OnFunctionCall sets the callback for completed function calls
+func OnFunctionCall(fn func(ctx context.Context, call FunctionCall) error) WebhookOption {
+	return func(h *WebhookHandler) error {
+		h.onFunctionCall = fn
+		return nil
+	}
+}
+
+// OnHumanContact sets the callback for completed human contacts
+func OnHumanContact(fn func(ctx context.Context, contact HumanContact) error) WebhookOption {
+	return func(h *WebhookHandler) error {
+		h.onHumanContact = fn
+		return nil
+	}
+}
+
+// WithTimestampTolerance sets how far a delivery's timestamp may be from the
+// current time before it is rejected as a replay
+func WithTimestampTolerance(d time.Duration) WebhookOption {
+	return func(h *WebhookHandler) error {
+		if d <= 0 {
+			return fmt.Errorf("timestamp tolerance must be positive, got %s", d)
+		}
+		h.tolerance = d
+		return nil
+	}
+}
+
+// WithDedupWindow sets how long a handled call ID is remembered
+func WithDedupWindow(d time.Duration) WebhookOption {
+	return func(h *WebhookHandler) error {
+		if d <= 0 {
+			return fmt.Errorf("dedup window must be positive, got %s", d)
+		}
+		h.deliveries = newDeliveryCache(d)
+		return nil
+	}
+}
+
+// WithWebhookLogger sets the logger for rejected and failed deliveries
+// (default: slog.Default())
+func WithWebhookLogger(logger *slog.Logger) WebhookOption {
+	return func(h *WebhookHandler) error {
+		if logger == nil {
+			return fmt.Errorf("logger must not be nil")
+		}
+		h.logger = logger
+		return nil
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if err := h.verify(r.Header, body); err != nil {
+		h.log().Warn("rejected HumanLayer webhook delivery",
+			"webhook_id", webhookHeader(r.Header, "id"),
+			"error", err)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if payload.IsTest {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	status, err := h.dispatch(r.Context(), payload)
+	if err != nil {
+		level := slog.LevelWarn
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		h.log().Log(r.Context(), level, "failed to handle HumanLayer webhook delivery",
+			"webhook_id", webhookHeader(r.Header, "id"),
+			"type", payload.Type,
+			"status", status,
+			"error", err)
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	w.WriteHeader(status)
+}
+
+// log returns the handler's logger
+func (h *WebhookHandler) log() *slog.Logger {
+	if h.logger != nil {
+		return h.logger
+	}
+	return slog.Default()
+}
+
+// dispatch decodes the event and runs its callback once per call ID
+func (h *WebhookHandler) dispatch(ctx context.Context, payload WebhookPayload) (int, error) {
+	var callID string
+	var handle func() error
+
+	switch payload.Type {
+	case WebhookFunctionCallCompleted:
+		var call FunctionCall
+		if err := json.Unmarshal(payload.Event, &call); err != nil {
+			return http.StatusBadRequest, fmt.Errorf("invalid function call: %w", err)
+		}
+		if h.onFunctionCall == nil {
+			return http.StatusOK, nil
+		}
+		callID = call.CallID
+		handle = func() error { return h.onFunctionCall(ctx, call) }
+	case WebhookHumanContactCompleted:
+		var contact HumanContact
+		if err := json.Unmarshal(payload.Event, &contact); err != nil {
+			return http.StatusBadRequest, fmt.Errorf("invalid human contact: %w", err)
+		}
+		if h.onHumanContact == nil {
+			return http.StatusOK, nil
+		}
+		callID = contact.CallID
+		handle = func() error { return h.onHumanContact(ctx, contact) }
+	default:
+		// Acknowledge event types this handler does not know about
+		return http.StatusOK, nil
+	}
+
+	if callID == "" {
+		return http.StatusBadRequest, fmt.Errorf("missing call_id")
+	}
+
+	key := payload.Type + ":" + callID
+	switch h.deliveries.begin(key, h.now()) {
+	case deliveryHandled:
+		return http.StatusOK, nil
+	case deliveryInFlight:
+		// Have HumanLayer retry in case the other delivery fails
+		return http.StatusConflict, fmt.Errorf("delivery for %s is already being handled", callID)
+	}
+	if err := runCallback(handle); err != nil {
+		h.deliveries.abort(key)
+		return http.StatusInternalServerError, err
+	}
+	h.deliveries.finish(key, h.now())
+	return http.StatusOK, nil
+}
+
+// runCallback runs handle, turning a panic into an error so the delivery is
+// released and retried instead of staying in flight
+func runCallback(handle func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("webhook callback panicked: %v", r)
+		}
+	}()
+	return handle()
+}
+
+// verify checks the delivery's timestamp and signature headers
+func (h *WebhookHandler) verify(header http.Header, body []byte) error {
+	return verifyWebhook(h.secret, header, body, h.tolerance, h.now())
+}
+
+// VerifyWebhook checks the signature and timestamp of a webhook delivery,
+// for services that route webhooks themselves instead of using WebhookHandler
+func VerifyWebhook(secret string, header http.Header, body []byte, tolerance time.Duration) error {
+	key, err := decodeWebhookSecret(secret)
+	if err != nil {
+		return err
+	}
+	return verifyWebhook(key, header, body, tolerance, time.Now())
+}
+
+// verifyWebhook implements the Standard Webhooks scheme: the v1 signature is
+// the base64 HMAC-SHA256 of "<id>.<timestamp>.<body>"
+func verifyWebhook(key []byte, header http.Header, body []byte, tolerance time.Duration, now time.Time) error {
+	id := webhookHeader(header, "id")
+	timestamp := webhookHeader(header, "timestamp")
+	signatures := webhookHeader(header, "signature")
+	if id == "" || timestamp == "" || signatures == "" {
+		return fmt.Errorf("missing webhook signature headers")
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook timestamp %q", timestamp)
+	}
+	sent := time.Unix(seconds, 0)
+	if sent.Before(now.Add(-tolerance)) || sent.After(now.Add(tolerance)) {
+		return fmt.Errorf("webhook timestamp outside tolerance")
+	}
+
+	expected := signWebhook(key, id, timestamp, body)
+	for _, candidate := range strings.Fields(signatures) {
+		version, signature, found := strings.Cut(candidate, ",")
+		if !found || version != "v1" {
+			continue
+		}
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// signWebhook returns the v1 signature of a delivery
+func signWebhook(key []byte, id, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// webhookHeader reads a webhook-* header, falling back to svix-*
+func webhookHeader(header http.Header, name string) string {
+	if value := header.Get("webhook-" + name); value != "" {
+		return value
+	}
+	return header.Get("svix-" + name)
+}
+
+// decodeWebhookSecret returns the signing key for a "whsec_" secret
+func decodeWebhookSecret(secret string) ([]byte, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("webhook secret is required")
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook secret: %w", err)
+	}
+	return key, nil
+}
+
+// deliveryCache remembers which calls have been handled, and which are being
+// handled, so concurrent and repeated deliveries run the callback once
+type deliveryCache struct {
+	mu       sync.Mutex
+	window   time.Duration
+	handled  map[string]time.Time
+	inFlight map[string]bool
+}
+
+func newDeliveryCache(window time.Duration) *deliveryCache {
+	return &deliveryCache{
+		window:   window,
+		handled:  make(map[string]time.Time),
+		inFlight: make(map[string]bool),
+	}
+}
+
+// deliveryState is the outcome of deliveryCache.begin
+type deliveryState int
+
+const (
+	deliveryNew deliveryState = iota
+	deliveryHandled
+	deliveryInFlight
+)
+
+// begin returns whether key was already handled or is being handled, and
+// otherwise marks it in flight
+func (d *deliveryCache) begin(key string, now time.Time) deliveryState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for k, at := range d.handled {
+		if now.Sub(at) > d.window {
+			delete(d.handled, k)
+		}
+	}
+	if _, done := d.handled[key]; done {
+		return deliveryHandled
+	}
+	if d.inFlight[key] {
+		return deliveryInFlight
+	}
+	d.inFlight[key] = true
+	return deliveryNew
+}
+
+// finish records key as handled
+func (d *deliveryCache) finish(key string, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.inFlight, key)
+	d.handled[key] = now
+}
+
+// abort forgets key so a retried delivery is handled again
+func (d *deliveryCache) abort(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.inFlight, key)
+}
@@ -0,0 +1,312 @@
+package humanlayer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	testWebhookKey    = []byte("humanlayer-webhook-test-key")
+	testWebhookSecret = "whsec_" + base64.StdEncoding.EncodeToString(testWebhookKey)
+	testWebhookNow    = time.Unix(1700000000, 0)
+)
+
+const testFunctionCallBody = `{"type": "function_call.completed", "event": {"run_id": "run-1", "call_id": "fc-1", "spec": {"fn": "deploy"}, "status": {"approved": true}}}`
+
+// signedHeader returns the headers of a delivery signed with key at sent
+func signedHeader(key []byte, id string, sent time.Time, body string) http.Header {
+	timestamp := strconv.FormatInt(sent.Unix(), 10)
+	header := http.Header{}
+	header.Set("webhook-id", id)
+	header.Set("webhook-timestamp", timestamp)
+	header.Set("webhook-signature", "v1,"+signWebhook(key, id, timestamp, []byte(body)))
+	return header
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	body := testFunctionCallBody
+	valid := signedHeader(testWebhookKey, "msg_1", testWebhookNow, body)
+
+	tests := []struct {
+		name    string
+		header  func() http.Header
+		body    string
+		wantErr string
+	}{
+		{"valid", func() http.Header { return valid }, body, ""},
+		{"svix headers", func() http.Header {
+			header := http.Header{}
+			for name, values := range valid {
+				header[strings.Replace(name, "Webhook-", "Svix-", 1)] = values
+			}
+			return header
+		}, body, ""},
+		{"one of several signatures", func() http.Header {
+			header := valid.Clone()
+			header.Set("webhook-signature", "v1,bm9wZQ== v2,ignored "+valid.Get("webhook-signature"))
+			return header
+		}, body, ""},
+		{"tampered body", func() http.Header { return valid }, strings.Replace(body, "true", "false", 1), ErrInvalidSignature.Error()},
+		{"other key", func() http.Header { return signedHeader([]byte("other"), "msg_1", testWebhookNow, body) }, body, ErrInvalidSignature.Error()},
+		{"other id", func() http.Header {
+			header := valid.Clone()
+			header.Set("webhook-id", "msg_2")
+			return header
+		}, body, ErrInvalidSignature.Error()},
+		{"missing signature", func() http.Header {
+			header := valid.Clone()
+			header.Del("webhook-signature")
+			return header
+		}, body, "missing webhook signature headers"},
+		{"invalid timestamp", func() http.Header {
+			header := valid.Clone()
+			header.Set("webhook-timestamp", "yesterday")
+			return header
+		}, body, "invalid webhook timestamp"},
+		{"at the tolerance", func() http.Header {
+			return signedHeader(testWebhookKey, "msg_1", testWebhookNow.Add(-5*time.Minute), body)
+		}, body, ""},
+		{"too old", func() http.Header {
+			return signedHeader(testWebhookKey, "msg_1", testWebhookNow.Add(-6*time.Minute), body)
+		}, body, "outside tolerance"},
+		{"too new", func() http.Header {
+			return signedHeader(testWebhookKey, "msg_1", testWebhookNow.Add(6*time.Minute), body)
+		}, body, "outside tolerance"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyWebhook(testWebhookKey, tt.header(), []byte(tt.body), DefaultWebhookTolerance, testWebhookNow)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("verifyWebhook() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("verifyWebhook() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// newTestWebhookHandler returns a handler whose clock is testWebhookNow
+func newTestWebhookHandler(t *testing.T, opts ...WebhookOption) *WebhookHandler {
+	t.Helper()
+	h, err := NewWebhookHandler(testWebhookSecret, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.now = func() time.Time { return testWebhookNow }
+	return h
+}
+
+// deliver posts a signed delivery to h and returns the response status
+func deliver(h http.Handler, id, body string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header = signedHeader(testWebhookKey, id, testWebhookNow, body)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestWebhookHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		callback  func(context.Context, FunctionCall) error
+		want      int
+		wantCalls int
+	}{
+		{"function call", testFunctionCallBody, nil, http.StatusOK, 1},
+		{"callback error", testFunctionCallBody, func(context.Context, FunctionCall) error { return errors.New("db down") }, http.StatusInternalServerError, 1},
+		{"callback panic", testFunctionCallBody, func(context.Context, FunctionCall) error { panic("nil map") }, http.StatusInternalServerError, 1},
+		{"test delivery", `{"type": "function_call.completed", "is_test": true, "event": {}}`, nil, http.StatusOK, 0},
+		{"unknown type", `{"type": "agent.created", "event": {}}`, nil, http.StatusOK, 0},
+		{"missing call id", `{"type": "function_call.completed", "event": {"run_id": "run-1"}}`, nil, http.StatusBadRequest, 0},
+		{"invalid payload", `{"type": `, nil, http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			h := newTestWebhookHandler(t, OnFunctionCall(func(ctx context.Context, call FunctionCall) error {
+				calls++
+				if call.Spec.Fn != "deploy" || call.Status == nil || call.Status.Approved == nil || !*call.Status.Approved {
+					t.Errorf("callback got %+v", call)
+				}
+				if tt.callback != nil {
+					return tt.callback(ctx, call)
+				}
+				return nil
+			}))
+
+			if got := deliver(h, "msg_1", tt.body); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("callback calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWebhookHandlerRejectsUnsigned(t *testing.T) {
+	h := newTestWebhookHandler(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(testFunctionCallBody))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("unsigned status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestWebhookHandlerLogsErrorDetails(t *testing.T) {
+	var logs bytes.Buffer
+	h := newTestWebhookHandler(t,
+		WithWebhookLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		OnFunctionCall(func(context.Context, FunctionCall) error { return errors.New("connect to db.internal failed") }),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(testFunctionCallBody))
+	req.Header = signedHeader(testWebhookKey, "msg_1", testWebhookNow, testFunctionCallBody)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusInternalServerError || strings.Contains(rec.Body.String(), "db.internal") {
+		t.Errorf("callback error response = %d %q, want 500 without details", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(testFunctionCallBody))
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized || strings.Contains(rec.Body.String(), "signature") {
+		t.Errorf("unsigned response = %d %q, want 401 without details", rec.Code, rec.Body.String())
+	}
+
+	for _, want := range []string{"db.internal", "missing webhook signature headers", "webhook_id=msg_1"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("logs missing %q:\n%s", want, logs.String())
+		}
+	}
+}
+
+func TestWebhookHandlerDedup(t *testing.T) {
+	calls := 0
+	h := newTestWebhookHandler(t, WithDedupWindow(time.Hour), OnFunctionCall(func(context.Context, FunctionCall) error {
+		calls++
+		return nil
+	}))
+
+	// Redeliveries use new message IDs but the same call
+	for _, id := range []string{"msg_1", "msg_2"} {
+		if got := deliver(h, id, testFunctionCallBody); got != http.StatusOK {
+			t.Fatalf("delivery %s status = %d, want %d", id, got, http.StatusOK)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("callback calls = %d, want 1", calls)
+	}
+
+	// Calls are forgotten after the window
+	h.now = func() time.Time { return testWebhookNow.Add(2 * time.Hour) }
+	h.tolerance = 3 * time.Hour
+	deliver(h, "msg_3", testFunctionCallBody)
+	if calls != 2 {
+		t.Errorf("callback calls after the dedup window = %d, want 2", calls)
+	}
+}
+
+func TestWebhookHandlerRetriesFailedDeliveries(t *testing.T) {
+	for _, fail := range []func() error{
+		func() error { return errors.New("db down") },
+		func() error { panic("nil map") },
+	} {
+		calls := 0
+		h := newTestWebhookHandler(t, OnFunctionCall(func(context.Context, FunctionCall) error {
+			calls++
+			if calls == 1 {
+				return fail()
+			}
+			return nil
+		}))
+
+		if got := deliver(h, "msg_1", testFunctionCallBody); got != http.StatusInternalServerError {
+			t.Fatalf("failed delivery status = %d, want %d", got, http.StatusInternalServerError)
+		}
+		if got := deliver(h, "msg_2", testFunctionCallBody); got != http.StatusOK {
+			t.Errorf("retried delivery status = %d, want %d", got, http.StatusOK)
+		}
+		if calls != 2 {
+			t.Errorf("callback calls = %d, want 2", calls)
+		}
+	}
+}
+
+func TestWebhookHandlerInFlight(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	h := newTestWebhookHandler(t, OnHumanContact(func(context.Context, HumanContact) error {
+		close(started)
+		<-release
+		return nil
+	}))
+	body := `{"type": "human_contact.completed", "event": {"run_id": "run-1", "call_id": "hc-1", "spec": {"msg": "hi"}}}`
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var first int
+	go func() {
+		defer wg.Done()
+		first = deliver(h, "msg_1", body)
+	}()
+	<-started
+
+	if got := deliver(h, "msg_2", body); got != http.StatusConflict {
+		t.Errorf("concurrent delivery status = %d, want %d", got, http.StatusConflict)
+	}
+	close(release)
+	wg.Wait()
+	if first != http.StatusOK {
+		t.Errorf("first delivery status = %d, want %d", first, http.StatusOK)
+	}
+	if got := deliver(h, "msg_3", body); got != http.StatusOK {
+		t.Errorf("later delivery status = %d, want %d", got, http.StatusOK)
+	}
+}
+
+func TestNewWebhookHandlerOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+		opts   []WebhookOption
+	}{
+		{"missing secret", "", nil},
+		{"invalid secret", "whsec_not base64!", nil},
+		{"zero tolerance", testWebhookSecret, []WebhookOption{WithTimestampTolerance(0)}},
+		{"negative dedup window", testWebhookSecret, []WebhookOption{WithDedupWindow(-time.Second)}},
+		{"nil logger", testWebhookSecret, []WebhookOption{WithWebhookLogger(nil)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewWebhookHandler(tt.secret, tt.opts...); err == nil {
+				t.Error("NewWebhookHandler() error = nil, want an error")
+			}
+		})
+	}
+}